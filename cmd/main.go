@@ -10,18 +10,63 @@ import (
 	"github.com/gofiber/fiber/v2/middleware/logger"
 
 	"AvitoTask/internal/config"
+	"AvitoTask/internal/handlers/allowance"
 	"AvitoTask/internal/handlers/auth"
+	"AvitoTask/internal/handlers/bundle"
 	"AvitoTask/internal/handlers/buy_item"
+	"AvitoTask/internal/handlers/cart"
+	"AvitoTask/internal/handlers/catalog"
+	"AvitoTask/internal/handlers/coupon"
 	"AvitoTask/internal/handlers/info"
+	"AvitoTask/internal/handlers/ledger"
+	"AvitoTask/internal/handlers/order"
+	"AvitoTask/internal/handlers/payment_request"
+	"AvitoTask/internal/handlers/promotion"
+	"AvitoTask/internal/handlers/schedule"
 	"AvitoTask/internal/handlers/send_coin"
+	"AvitoTask/internal/handlers/send_item"
+	"AvitoTask/internal/handlers/voucher"
+	"AvitoTask/internal/handlers/wishlist"
+	"AvitoTask/internal/middleware/idempotency"
 	"AvitoTask/internal/middleware/jwt"
+	"AvitoTask/internal/middleware/role"
+	"AvitoTask/internal/models"
+	allowanceRepository "AvitoTask/internal/repository/allowance"
 	authRepository "AvitoTask/internal/repository/auth"
+	bundleRepository "AvitoTask/internal/repository/bundle"
+	cartRepository "AvitoTask/internal/repository/cart"
+	catalogRepository "AvitoTask/internal/repository/catalog"
+	couponRepository "AvitoTask/internal/repository/coupon"
+	idempotencyRepository "AvitoTask/internal/repository/idempotency"
 	"AvitoTask/internal/repository/inventory"
+	"AvitoTask/internal/repository/item_transfer"
+	ledgerRepository "AvitoTask/internal/repository/ledger"
+	notificationRepository "AvitoTask/internal/repository/notification"
+	orderRepository "AvitoTask/internal/repository/order"
+	paymentRequestRepository "AvitoTask/internal/repository/payment_request"
+	promotionRepository "AvitoTask/internal/repository/promotion"
+	scheduleRepository "AvitoTask/internal/repository/schedule"
 	"AvitoTask/internal/repository/transaction"
+	transferLimitRepository "AvitoTask/internal/repository/transfer_limit"
+	voucherRepository "AvitoTask/internal/repository/voucher"
+	wishlistRepository "AvitoTask/internal/repository/wishlist"
+	allowanceUsecase "AvitoTask/internal/usecase/allowance"
 	authUsecase "AvitoTask/internal/usecase/auth"
+	bundleUsecase "AvitoTask/internal/usecase/bundle"
 	buyItemUsecase "AvitoTask/internal/usecase/buy_item"
+	cartUsecase "AvitoTask/internal/usecase/cart"
+	catalogUsecase "AvitoTask/internal/usecase/catalog"
+	couponUsecase "AvitoTask/internal/usecase/coupon"
 	infoUsecase "AvitoTask/internal/usecase/info"
+	ledgerUsecase "AvitoTask/internal/usecase/ledger"
+	orderUsecase "AvitoTask/internal/usecase/order"
+	paymentRequestUsecase "AvitoTask/internal/usecase/payment_request"
+	promotionUsecase "AvitoTask/internal/usecase/promotion"
+	scheduleUsecase "AvitoTask/internal/usecase/schedule"
 	sendCoinUseCase "AvitoTask/internal/usecase/send_coin"
+	sendItemUseCase "AvitoTask/internal/usecase/send_item"
+	voucherUsecase "AvitoTask/internal/usecase/voucher"
+	wishlistUsecase "AvitoTask/internal/usecase/wishlist"
 )
 
 func main() {
@@ -44,7 +89,7 @@ func main() {
 				}, ","),
 				AllowCredentials: false,
 				MaxAge:           0,
-				AllowHeaders:     "Authorization, Reset",
+				AllowHeaders:     "Authorization, Reset, Idempotency-Key",
 				ExposeHeaders:    "Authorization, Reset",
 			},
 		),
@@ -63,27 +108,153 @@ func main() {
 	authPool := authRepository.NewInsertRepo(pool)
 	transactionPool := transaction.NewRepository(pool)
 	buyItemPool := inventory.NewInsertRepo(pool)
+	catalogPool := catalogRepository.NewRepository(pool)
+	cartPool := cartRepository.NewRepository(pool)
+	orderPool := orderRepository.NewRepository(pool)
+	itemTransferPool := item_transfer.NewRepository(pool)
+	promotionPool := promotionRepository.NewRepository(pool)
+	couponPool := couponRepository.NewRepository(pool)
+	voucherPool := voucherRepository.NewRepository(pool)
+	bundlePool := bundleRepository.NewRepository(pool)
+	wishlistPool := wishlistRepository.NewRepository(pool)
+	notificationPool := notificationRepository.NewRepository(pool)
+	ledgerPool := ledgerRepository.NewRepository(pool)
+	idempotencyPool := idempotencyRepository.NewRepository(pool)
+	schedulePool := scheduleRepository.NewRepository(pool)
+	paymentRequestPool := paymentRequestRepository.NewRepository(pool)
+	allowancePool := allowanceRepository.NewRepository(pool)
+	transferLimitPool := transferLimitRepository.NewRepository(pool)
+
+	// middleware group
+	jwtToken := jwt.NewMiddleware(cfg.JWT.Secret)
+	roleCheck := role.NewMiddleware(authPool)
+	idempotent := idempotency.New()
 
 	// usecase group
 	authUC := authUsecase.New(authPool)
-	sendCoinUC := sendCoinUseCase.NewUsecase(authPool, transactionPool)
-	buyItemUC := buyItemUsecase.NewUsecase(authPool, buyItemPool)
-	infoUC := infoUsecase.New(authPool, buyItemPool, transactionPool)
+	transferLimits := models.TransferLimits{
+		Daily:   models.LimitPolicy(cfg.Limits.Daily),
+		Monthly: models.LimitPolicy(cfg.Limits.Monthly),
+	}
+	sendCoinUC := sendCoinUseCase.NewUsecase(authPool, transactionPool, ledgerPool, idempotencyPool, transferLimitPool,
+		cfg.Transfers.PendingTimeout, transferLimits)
+	sendItemUC := sendItemUseCase.NewUsecase(authPool, buyItemPool, itemTransferPool, orderPool)
+	buyItemUC := buyItemUsecase.NewUsecase(authPool, buyItemPool, catalogPool, cartPool, orderPool, promotionPool, couponPool, bundlePool, ledgerPool, idempotencyPool)
+	catalogUC := catalogUsecase.NewUsecase(catalogPool, authPool, orderPool, buyItemPool, notificationPool)
+	cartUC := cartUsecase.NewUsecase(cartPool, catalogPool)
+	orderUC := orderUsecase.NewUsecase(orderPool, authPool, buyItemPool, catalogPool, bundlePool, ledgerPool, couponPool, cfg.Shop.RefundWindow)
+	promotionUC := promotionUsecase.NewUsecase(promotionPool, catalogPool, notificationPool)
+	couponUC := couponUsecase.NewUsecase(couponPool, catalogPool)
+	bundleUC := bundleUsecase.NewUsecase(bundlePool, catalogPool)
+	voucherUC := voucherUsecase.NewUsecase(voucherPool, orderPool, jwtToken)
+	wishlistUC := wishlistUsecase.NewUsecase(wishlistPool, catalogPool, authPool, notificationPool)
+	ledgerUC := ledgerUsecase.NewUsecase(ledgerPool)
+	scheduleUC := scheduleUsecase.NewUsecase(schedulePool, authPool, sendCoinUC, cfg.Schedule.RetryDelay, cfg.Schedule.MaxAttempts)
+	paymentRequestUC := paymentRequestUsecase.NewUsecase(paymentRequestPool, authPool, sendCoinUC, cfg.Transfers.RequestTimeout)
+	allowances := make([]models.Allowance, 0, len(cfg.Allowance.Grants))
+	for _, g := range cfg.Allowance.Grants {
+		a := models.Allowance(g)
+		if err := a.Validate(); err != nil {
+			panic(err)
+		}
+		allowances = append(allowances, a)
+	}
+	allowanceUC := allowanceUsecase.NewUsecase(allowancePool, authPool, ledgerPool, allowances)
+	infoUC := infoUsecase.New(authPool, buyItemPool, transactionPool, orderPool, itemTransferPool, wishlistPool)
 
 	// handlers group
 	authHandler := auth.NewHandler(authUC)
 	sendCoinHandler := send_coin.NewHandler(sendCoinUC)
+	sendItemHandler := send_item.NewHandler(sendItemUC)
 	buyItemHandler := buy_item.NewHandler(buyItemUC)
 	infoHandler := info.NewHandler(infoUC)
-
-	// middleware group
-	jwtToken := jwt.NewMiddleware(cfg.JWT.Secret)
+	catalogHandler := catalog.NewHandler(catalogUC)
+	cartHandler := cart.NewHandler(cartUC, buyItemUC)
+	orderHandler := order.NewHandler(orderUC)
+	promotionHandler := promotion.NewHandler(promotionUC)
+	couponHandler := coupon.NewHandler(couponUC)
+	bundleHandler := bundle.NewHandler(bundleUC)
+	voucherHandler := voucher.NewHandler(voucherUC, jwtToken.VoucherPublicKey())
+	wishlistHandler := wishlist.NewHandler(wishlistUC)
+	ledgerHandler := ledger.NewHandler(ledgerUC)
+	scheduleHandler := schedule.NewHandler(scheduleUC)
+	paymentRequestHandler := payment_request.NewHandler(paymentRequestUC)
+	allowanceHandler := allowance.NewHandler(allowanceUC)
 
 	api := app.Group("/api")
 	api.Post("/auth", authHandler.Handle, jwtToken.SignedToken)
-	api.Post("/sendCoin", jwtToken.CompareToken, sendCoinHandler.Handle)
-	api.Get("/buy/:item", jwtToken.CompareToken, buyItemHandler.Handle)
+	api.Post("/sendCoin", jwtToken.CompareToken, idempotent, sendCoinHandler.Handle)
+	api.Post("/sendCoin/batch", jwtToken.CompareToken, idempotent, sendCoinHandler.Batch)
+	api.Get("/sendCoin/limits", jwtToken.CompareToken, sendCoinHandler.Limits)
+	api.Get("/transfers/pending", jwtToken.CompareToken, sendCoinHandler.Pending)
+	api.Post("/transfers/:id/accept", jwtToken.CompareToken, sendCoinHandler.Accept)
+	api.Post("/transfers/:id/decline", jwtToken.CompareToken, sendCoinHandler.Decline)
+	api.Post("/sendItem", jwtToken.CompareToken, sendItemHandler.Handle)
+	api.Get("/buy/:item", jwtToken.CompareToken, idempotent, buyItemHandler.Handle)
+	api.Get("/buy/bundle/:bundle", jwtToken.CompareToken, idempotent, buyItemHandler.HandleBundle)
+	api.Get("/bundles", jwtToken.CompareToken, bundleHandler.List)
 	api.Get("/info", jwtToken.CompareToken, infoHandler.Handle)
+	api.Get("/items", jwtToken.CompareToken, catalogHandler.List)
+	api.Get("/items/:item/variants", jwtToken.CompareToken, catalogHandler.Variants)
+	api.Get("/cart", jwtToken.CompareToken, cartHandler.List)
+	api.Post("/cart", jwtToken.CompareToken, cartHandler.Add)
+	api.Delete("/cart/:item", jwtToken.CompareToken, cartHandler.Remove)
+	api.Post("/cart/checkout", jwtToken.CompareToken, idempotent, cartHandler.Checkout)
+	api.Get("/wishlist", jwtToken.CompareToken, wishlistHandler.List)
+	api.Post("/wishlist", jwtToken.CompareToken, wishlistHandler.Add)
+	api.Delete("/wishlist/:item", jwtToken.CompareToken, wishlistHandler.Remove)
+	api.Get("/notifications", jwtToken.CompareToken, wishlistHandler.Notifications)
+	api.Post("/notifications/read", jwtToken.CompareToken, wishlistHandler.MarkRead)
+	api.Get("/schedules", jwtToken.CompareToken, scheduleHandler.List)
+	api.Post("/schedules", jwtToken.CompareToken, scheduleHandler.Create)
+	api.Get("/schedules/:id/runs", jwtToken.CompareToken, scheduleHandler.Runs)
+	api.Post("/schedules/:id/pause", jwtToken.CompareToken, scheduleHandler.Pause)
+	api.Post("/schedules/:id/resume", jwtToken.CompareToken, scheduleHandler.Resume)
+	api.Delete("/schedules/:id", jwtToken.CompareToken, scheduleHandler.Cancel)
+	api.Post("/requests", jwtToken.CompareToken, paymentRequestHandler.Create)
+	api.Get("/requests/incoming", jwtToken.CompareToken, paymentRequestHandler.Incoming)
+	api.Get("/requests/outgoing", jwtToken.CompareToken, paymentRequestHandler.Outgoing)
+	api.Post("/requests/:id/pay", jwtToken.CompareToken, paymentRequestHandler.Pay)
+	api.Post("/requests/:id/decline", jwtToken.CompareToken, paymentRequestHandler.Decline)
+	api.Delete("/requests/:id", jwtToken.CompareToken, paymentRequestHandler.Cancel)
+	api.Get("/orders", jwtToken.CompareToken, orderHandler.Handle)
+	api.Post("/orders/:id/return", jwtToken.CompareToken, orderHandler.Return)
+	api.Get("/orders/:id/voucher", jwtToken.CompareToken, voucherHandler.Issue)
+	api.Get("/vouchers/key", voucherHandler.PublicKey)
+
+	staff := api.Group("/staff", jwtToken.CompareToken, roleCheck.Require(models.RoleStaff, models.RoleAdmin))
+	staff.Get("/orders", orderHandler.Queue)
+	staff.Post("/orders/:id/status", orderHandler.Move)
+	staff.Post("/vouchers/redeem", voucherHandler.Redeem)
+
+	admin := api.Group("/admin", jwtToken.CompareToken, roleCheck.Require(models.RoleAdmin))
+	admin.Post("/items", catalogHandler.Create)
+	admin.Patch("/items/:item/price", catalogHandler.Reprice)
+	admin.Patch("/items/:item/visibility", catalogHandler.SetVisibility)
+	admin.Patch("/items/:item/quota", catalogHandler.SetQuota)
+	admin.Delete("/items/:item", catalogHandler.Retire)
+	admin.Get("/items/:item/versions", catalogHandler.Versions)
+	admin.Post("/items/:item/restock", catalogHandler.Restock)
+	admin.Get("/items/:item/stock", catalogHandler.StockHistory)
+	admin.Post("/items/:item/variants", catalogHandler.CreateVariant)
+	admin.Post("/bundles", bundleHandler.Create)
+	admin.Delete("/bundles/:bundle", bundleHandler.Retire)
+	admin.Get("/promotions", promotionHandler.List)
+	admin.Post("/promotions", promotionHandler.Create)
+	admin.Delete("/promotions/:id", promotionHandler.End)
+	admin.Post("/coupons", couponHandler.Generate)
+	admin.Get("/coupons/batches/:id", couponHandler.Batch)
+	admin.Get("/coupons/:code/redemptions", couponHandler.Redemptions)
+	admin.Get("/ledger/reconcile", ledgerHandler.Reconcile)
+	admin.Post("/allowances/grant", allowanceHandler.Grant)
+	admin.Patch("/users/:username/active", allowanceHandler.SetActive)
+	admin.Put("/users/:username/limits/:period", sendCoinHandler.SetUserLimit)
+	admin.Delete("/users/:username/limits/:period", sendCoinHandler.ResetUserLimit)
+
+	go scheduleUC.Run(ctx, cfg.Schedule.Interval)
+	go sendCoinUC.RunExpiry(ctx, cfg.Transfers.ExpiryInterval)
+	go paymentRequestUC.RunExpiry(ctx, cfg.Transfers.ExpiryInterval)
+	go allowanceUC.RunGrants(ctx, cfg.Allowance.Interval)
 
 	log.Println(cfg.App.String())
 	if err := app.Listen(cfg.App.String()); err != nil {
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"net/url"
 	"os"
+	"time"
 
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/pkg/errors"
@@ -17,9 +18,14 @@ import (
 )
 
 type Config struct {
-	App      App      `yaml:"app"`
-	Postgres Postgres `yaml:"postgres"`
-	JWT      JWT      `yaml:"jwt"`
+	App       App       `yaml:"app"`
+	Postgres  Postgres  `yaml:"postgres"`
+	JWT       JWT       `yaml:"jwt"`
+	Shop      Shop      `yaml:"shop"`
+	Schedule  Schedule  `yaml:"schedule"`
+	Transfers Transfers `yaml:"transfers"`
+	Limits    Limits    `yaml:"limits"`
+	Allowance Allowance `yaml:"allowance"`
 }
 
 type App struct {
@@ -39,6 +45,56 @@ type JWT struct {
 	Secret string `yaml:"secret"`
 }
 
+// Shop - сколько времени после покупки её можно вернуть
+type Shop struct {
+	RefundWindow time.Duration `yaml:"refund_window" env-default:"72h"`
+}
+
+// Schedule - планировщик запланированных переводов: как часто он просыпается, через сколько
+// повторяется неудачный запуск и сколько попыток даётся одному запуску
+type Schedule struct {
+	Interval    time.Duration `yaml:"interval" env-default:"1m"`
+	RetryDelay  time.Duration `yaml:"retry_delay" env-default:"1h"`
+	MaxAttempts int           `yaml:"max_attempts" env-default:"3"`
+}
+
+// Transfers - отложенные переводы и запросы монет: сколько получатель может думать над переводом,
+// сколько живёт запрос монет и как часто закрываются просроченные
+type Transfers struct {
+	PendingTimeout time.Duration `yaml:"pending_timeout" env-default:"72h"`
+	RequestTimeout time.Duration `yaml:"request_timeout" env-default:"168h"`
+	ExpiryInterval time.Duration `yaml:"expiry_interval" env-default:"1m"`
+}
+
+// Limits - ограничения на переводы каждого пользователя за день и за календарный месяц
+type Limits struct {
+	Daily   LimitPolicy `yaml:"daily"`
+	Monthly LimitPolicy `yaml:"monthly"`
+}
+
+// LimitPolicy - сколько монет можно отправить всего, сколько переводов сделать и сколько монет
+// отправить одному получателю; 0 - без ограничения
+type LimitPolicy struct {
+	Amount          int64 `yaml:"amount" env-default:"0"`
+	Count           int64 `yaml:"count" env-default:"0"`
+	RecipientAmount int64 `yaml:"recipient_amount" env-default:"0"`
+}
+
+// Allowance - пособия: как часто проверяется, кому они положены, и список пособий
+type Allowance struct {
+	Interval time.Duration `yaml:"interval" env-default:"1h"`
+	Grants   []Grant       `yaml:"grants"`
+}
+
+// Grant - пособие Amount монет раз в Period (daily, weekly или monthly) активным пользователям
+// с одной из ролей Roles; без Roles - всем активным пользователям
+type Grant struct {
+	Name   string   `yaml:"name"`
+	Amount int64    `yaml:"amount"`
+	Period string   `yaml:"period"`
+	Roles  []string `yaml:"roles"`
+}
+
 func New() *Config {
 	return &Config{
 		App:      App{},
@@ -0,0 +1,10 @@
+package allowance
+
+import (
+	"context"
+)
+
+type manager interface {
+	GrantDue(ctx context.Context) (int, error)
+	SetActive(ctx context.Context, username string, active bool) error
+}
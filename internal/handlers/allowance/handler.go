@@ -0,0 +1,58 @@
+package allowance
+
+import (
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+
+	"AvitoTask/internal/models"
+)
+
+type Handler struct {
+	manager manager
+}
+
+func NewHandler(m manager) *Handler {
+	return &Handler{
+		manager: m,
+	}
+}
+
+// Grant - начисляет пособия за текущий период, не дожидаясь планировщика
+func (h *Handler) Grant(ctx *fiber.Ctx) error {
+	granted, err := h.manager.GrantDue(ctx.Context())
+	if err != nil {
+		return h.error(ctx, err)
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(grantOutput{Granted: granted})
+}
+
+// SetActive - включает или выключает пользователю начисление пособий
+func (h *Handler) SetActive(ctx *fiber.Ctx) error {
+	var req activeRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"errors": err.Error(),
+		})
+	}
+
+	if err := h.manager.SetActive(ctx.Context(), ctx.Params("username"), req.Active); err != nil {
+		return h.error(ctx, err)
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(fiber.Map{})
+}
+
+func (h *Handler) error(ctx *fiber.Ctx, err error) error {
+	switch {
+	case errors.Is(err, models.ErrUserNotFound):
+		return ctx.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"errors": err.Error(),
+		})
+	default:
+		return ctx.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"errors": err.Error(),
+		})
+	}
+}
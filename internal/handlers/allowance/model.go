@@ -0,0 +1,9 @@
+package allowance
+
+type activeRequest struct {
+	Active bool `json:"active"`
+}
+
+type grantOutput struct {
+	Granted int `json:"granted"`
+}
@@ -0,0 +1,13 @@
+package bundle
+
+import (
+	"context"
+
+	"AvitoTask/internal/models"
+)
+
+type manager interface {
+	CreateBundle(ctx context.Context, adminID string, draft models.Bundle) (models.Bundle, error)
+	ListBundles(ctx context.Context) ([]models.Bundle, error)
+	RetireBundle(ctx context.Context, name string) error
+}
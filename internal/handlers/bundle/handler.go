@@ -0,0 +1,96 @@
+package bundle
+
+import (
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+
+	"AvitoTask/internal/models"
+	"AvitoTask/internal/usecase/bundle"
+)
+
+type Handler struct {
+	manager manager
+}
+
+func NewHandler(m manager) *Handler {
+	return &Handler{
+		manager: m,
+	}
+}
+
+func (h *Handler) Create(ctx *fiber.Ctx) error {
+	adminID, ok := ctx.Context().Value("UserID").(string)
+	if !ok {
+		return ctx.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"errors": models.ErrAuthUser.Error(),
+		})
+	}
+
+	var req createRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"errors": err.Error(),
+		})
+	}
+
+	if err := validate(req); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"errors": err.Error(),
+		})
+	}
+
+	b, err := h.manager.CreateBundle(ctx.Context(), adminID, req.toBundle())
+	if err != nil {
+		return h.error(ctx, err)
+	}
+
+	return ctx.Status(fiber.StatusCreated).JSON(convertBundle(b))
+}
+
+func (h *Handler) List(ctx *fiber.Ctx) error {
+	bundles, err := h.manager.ListBundles(ctx.Context())
+	if err != nil {
+		return h.error(ctx, err)
+	}
+
+	out := make([]bundleOutput, 0, len(bundles))
+	for _, b := range bundles {
+		out = append(out, convertBundle(b))
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(out)
+}
+
+func (h *Handler) Retire(ctx *fiber.Ctx) error {
+	if err := h.manager.RetireBundle(ctx.Context(), ctx.Params("bundle")); err != nil {
+		return h.error(ctx, err)
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(fiber.Map{})
+}
+
+func (h *Handler) error(ctx *fiber.Ctx, err error) error {
+	switch {
+	case errors.Is(err, models.ErrBundleNotFound):
+		return ctx.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"errors": err.Error(),
+		})
+	case errors.Is(err, models.ErrItemNotFound),
+		errors.Is(err, models.ErrVariantNotFound),
+		errors.Is(err, models.ErrItemNotAvailable),
+		errors.Is(err, bundle.ErrBundleNotCheaper),
+		errors.Is(err, bundle.ErrDuplicateItem):
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"errors": err.Error(),
+		})
+	case errors.Is(err, bundle.ErrBundleExists):
+		return ctx.Status(fiber.StatusConflict).JSON(fiber.Map{
+			"errors": err.Error(),
+		})
+	default:
+		return ctx.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"errors": err.Error(),
+		})
+	}
+}
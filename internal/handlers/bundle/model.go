@@ -0,0 +1,78 @@
+package bundle
+
+import (
+	"fmt"
+
+	"github.com/go-playground/validator/v10"
+
+	"AvitoTask/internal/models"
+)
+
+type createRequest struct {
+	Name  string        `json:"name" validate:"required,max=255"`
+	Price int64         `json:"price" validate:"required,min=1"`
+	Items []itemRequest `json:"items" validate:"required,min=1,dive"`
+}
+
+type itemRequest struct {
+	Item     string `json:"item" validate:"required"`
+	Variant  string `json:"variant" validate:"max=64"`
+	Quantity int64  `json:"quantity" validate:"required,min=1"`
+}
+
+type bundleOutput struct {
+	Name  string       `json:"name"`
+	Price int64        `json:"price"`
+	Items []itemOutput `json:"items"`
+}
+
+type itemOutput struct {
+	Item     string `json:"item"`
+	Variant  string `json:"variant,omitempty"`
+	Quantity int64  `json:"quantity"`
+}
+
+func (r createRequest) toBundle() models.Bundle {
+	b := models.Bundle{
+		Name:  r.Name,
+		Price: r.Price,
+		Items: make([]models.BundleItem, 0, len(r.Items)),
+	}
+
+	for _, it := range r.Items {
+		b.Items = append(b.Items, models.BundleItem{
+			Item:     it.Item,
+			Variant:  it.Variant,
+			Quantity: it.Quantity,
+		})
+	}
+
+	return b
+}
+
+func convertBundle(b models.Bundle) bundleOutput {
+	out := bundleOutput{
+		Name:  b.Name,
+		Price: b.Price,
+		Items: make([]itemOutput, 0, len(b.Items)),
+	}
+
+	for _, it := range b.Items {
+		out.Items = append(out.Items, itemOutput{
+			Item:     it.Item,
+			Variant:  it.Variant,
+			Quantity: it.Quantity,
+		})
+	}
+
+	return out
+}
+
+func validate(r any) error {
+	validate := validator.New()
+	if err := validate.Struct(r); err != nil {
+		return fmt.Errorf("%s: %w", models.ErrValidation, err)
+	}
+
+	return nil
+}
@@ -3,5 +3,6 @@ package buy_item
 import "context"
 
 type buyer interface {
-	BuyItem(ctx context.Context, userID, item string, cost int64) error
+	BuyItem(ctx context.Context, userID, item, variant, coupon string) error
+	BuyBundle(ctx context.Context, userID, name string) error
 }
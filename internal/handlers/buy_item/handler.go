@@ -35,15 +35,70 @@ func (h *Handler) Handle(ctx *fiber.Ctx) error {
 		})
 	}
 
-	costItem, ok := models.PriceItem[item]
-	if !ok {
+	variant := ctx.Query("variant")
+	coupon := ctx.Query("coupon")
+
+	err := h.buyer.BuyItem(ctx.Context(), userID, item, variant, coupon)
+	if errors.Is(err, models.ErrIdempotencyKeyReused) {
+		return ctx.Status(fiber.StatusUnprocessableEntity).JSON(fiber.Map{
+			"errors": err.Error(),
+		})
+	}
+	if errors.Is(err, models.ErrItemNotFound) {
 		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
 			"errors": fmt.Sprintf("item %s is not exist", item),
 		})
 	}
+	if errors.Is(err, models.ErrVariantNotFound) {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"errors": fmt.Sprintf("variant %s of item %s is not exist", variant, item),
+		})
+	}
+	if errors.Is(err, models.ErrSoldOut) || errors.Is(err, models.ErrQuotaExceeded) {
+		return ctx.Status(fiber.StatusConflict).JSON(fiber.Map{
+			"errors": err.Error(),
+		})
+	}
+	if errors.Is(err, buy_item.ErrNotEnoughCoins) || errors.Is(err, models.ErrItemNotAvailable) || isCouponError(err) {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"errors": err.Error(),
+		})
+	}
+	if err != nil {
+		return ctx.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"errors": err.Error(),
+		})
+	}
 
-	err := h.buyer.BuyItem(ctx.Context(), userID, item, costItem)
-	if errors.Is(err, buy_item.ErrNotEnoughCoins) {
+	return ctx.Status(fiber.StatusOK).JSON(fiber.Map{})
+}
+
+func (h *Handler) HandleBundle(ctx *fiber.Ctx) error {
+	userID, ok := ctx.Context().Value("UserID").(string)
+	if !ok {
+		return ctx.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"errors": models.ErrAuthUser.Error(),
+		})
+	}
+
+	name := ctx.Params("bundle")
+	err := h.buyer.BuyBundle(ctx.Context(), userID, name)
+	if errors.Is(err, models.ErrIdempotencyKeyReused) {
+		return ctx.Status(fiber.StatusUnprocessableEntity).JSON(fiber.Map{
+			"errors": err.Error(),
+		})
+	}
+	if errors.Is(err, models.ErrBundleNotFound) {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"errors": fmt.Sprintf("bundle %s is not exist", name),
+		})
+	}
+	if errors.Is(err, models.ErrSoldOut) || errors.Is(err, models.ErrQuotaExceeded) {
+		return ctx.Status(fiber.StatusConflict).JSON(fiber.Map{
+			"errors": err.Error(),
+		})
+	}
+	if errors.Is(err, buy_item.ErrNotEnoughCoins) || errors.Is(err, models.ErrItemNotAvailable) {
 		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
 			"errors": err.Error(),
 		})
@@ -56,3 +111,12 @@ func (h *Handler) Handle(ctx *fiber.Ctx) error {
 
 	return ctx.Status(fiber.StatusOK).JSON(fiber.Map{})
 }
+
+func isCouponError(err error) bool {
+	return errors.Is(err, models.ErrCouponNotFound) ||
+		errors.Is(err, models.ErrCouponExpired) ||
+		errors.Is(err, models.ErrCouponExhausted) ||
+		errors.Is(err, models.ErrCouponAlreadyUsed) ||
+		errors.Is(err, models.ErrCouponNotApplicable) ||
+		errors.Is(err, models.ErrCouponMinSpend)
+}
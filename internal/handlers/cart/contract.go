@@ -0,0 +1,17 @@
+package cart
+
+import (
+	"context"
+
+	"AvitoTask/internal/models"
+)
+
+type manager interface {
+	AddItem(ctx context.Context, userID, item, variant string, quantity int64) (models.Cart, error)
+	RemoveItem(ctx context.Context, userID, item, variant string) (models.Cart, error)
+	GetCart(ctx context.Context, userID string) (models.Cart, error)
+}
+
+type checkouter interface {
+	Checkout(ctx context.Context, userID string) (models.Cart, error)
+}
@@ -0,0 +1,128 @@
+package cart
+
+import (
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+
+	"AvitoTask/internal/models"
+	"AvitoTask/internal/usecase/buy_item"
+)
+
+type Handler struct {
+	manager    manager
+	checkouter checkouter
+}
+
+func NewHandler(m manager, c checkouter) *Handler {
+	return &Handler{
+		manager:    m,
+		checkouter: c,
+	}
+}
+
+func (h *Handler) List(ctx *fiber.Ctx) error {
+	userID, ok := ctx.Context().Value("UserID").(string)
+	if !ok {
+		return ctx.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"errors": models.ErrAuthUser.Error(),
+		})
+	}
+
+	res, err := h.manager.GetCart(ctx.Context(), userID)
+	if err != nil {
+		return h.error(ctx, err)
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(convertCart(res))
+}
+
+func (h *Handler) Add(ctx *fiber.Ctx) error {
+	userID, ok := ctx.Context().Value("UserID").(string)
+	if !ok {
+		return ctx.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"errors": models.ErrAuthUser.Error(),
+		})
+	}
+
+	var req addRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"errors": err.Error(),
+		})
+	}
+
+	if err := validate(req); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"errors": err.Error(),
+		})
+	}
+
+	res, err := h.manager.AddItem(ctx.Context(), userID, req.Item, req.Variant, req.Quantity)
+	if err != nil {
+		return h.error(ctx, err)
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(convertCart(res))
+}
+
+func (h *Handler) Remove(ctx *fiber.Ctx) error {
+	userID, ok := ctx.Context().Value("UserID").(string)
+	if !ok {
+		return ctx.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"errors": models.ErrAuthUser.Error(),
+		})
+	}
+
+	res, err := h.manager.RemoveItem(ctx.Context(), userID, ctx.Params("item"), ctx.Query("variant"))
+	if err != nil {
+		return h.error(ctx, err)
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(convertCart(res))
+}
+
+func (h *Handler) Checkout(ctx *fiber.Ctx) error {
+	userID, ok := ctx.Context().Value("UserID").(string)
+	if !ok {
+		return ctx.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"errors": models.ErrAuthUser.Error(),
+		})
+	}
+
+	res, err := h.checkouter.Checkout(ctx.Context(), userID)
+	if err != nil {
+		return h.error(ctx, err)
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(convertCart(res))
+}
+
+func (h *Handler) error(ctx *fiber.Ctx, err error) error {
+	switch {
+	case errors.Is(err, models.ErrItemNotFound),
+		errors.Is(err, models.ErrVariantNotFound),
+		errors.Is(err, models.ErrNotInCart):
+		return ctx.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"errors": err.Error(),
+		})
+	case errors.Is(err, models.ErrSoldOut), errors.Is(err, models.ErrQuotaExceeded):
+		return ctx.Status(fiber.StatusConflict).JSON(fiber.Map{
+			"errors": err.Error(),
+		})
+	case errors.Is(err, models.ErrIdempotencyKeyReused):
+		return ctx.Status(fiber.StatusUnprocessableEntity).JSON(fiber.Map{
+			"errors": err.Error(),
+		})
+	case errors.Is(err, models.ErrItemNotAvailable),
+		errors.Is(err, buy_item.ErrNotEnoughCoins),
+		errors.Is(err, buy_item.ErrEmptyCart):
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"errors": err.Error(),
+		})
+	default:
+		return ctx.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"errors": err.Error(),
+		})
+	}
+}
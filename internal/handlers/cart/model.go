@@ -0,0 +1,54 @@
+package cart
+
+import (
+	"fmt"
+
+	"github.com/go-playground/validator/v10"
+
+	"AvitoTask/internal/models"
+)
+
+type addRequest struct {
+	Item     string `json:"item" validate:"required"`
+	Variant  string `json:"variant"`
+	Quantity int64  `json:"quantity" validate:"required,min=1"`
+}
+
+type lineOutput struct {
+	Item     string `json:"item"`
+	Variant  string `json:"variant,omitempty"`
+	Price    int64  `json:"price"`
+	Quantity int64  `json:"quantity"`
+}
+
+type cartOutput struct {
+	Lines []lineOutput `json:"lines"`
+	Total int64        `json:"total"`
+}
+
+func convertCart(c models.Cart) cartOutput {
+	out := cartOutput{
+		Lines: make([]lineOutput, 0, len(c.Lines)),
+		Total: c.Total,
+	}
+
+	for _, line := range c.Lines {
+		out.Lines = append(out.Lines, lineOutput{
+			Item:     line.Item,
+			Variant:  line.Variant,
+			Price:    line.Price,
+			Quantity: line.Quantity,
+		})
+	}
+
+	return out
+}
+
+func validate(r addRequest) error {
+	validate := validator.New()
+	if err := validate.Struct(r); err != nil {
+		return fmt.Errorf("%s: %w", models.ErrValidation, err)
+	}
+
+	return nil
+}
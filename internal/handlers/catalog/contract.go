@@ -0,0 +1,21 @@
+package catalog
+
+import (
+	"context"
+
+	"AvitoTask/internal/models"
+)
+
+type manager interface {
+	ListItems(ctx context.Context, userID string, filter models.CatalogFilter) ([]models.CatalogListItem, int64, error)
+	CreateItem(ctx context.Context, adminID string, draft models.CatalogItem) (models.CatalogItem, error)
+	RepriceItem(ctx context.Context, adminID, name string, price int64) (models.CatalogItem, error)
+	SetItemHidden(ctx context.Context, adminID, name string, hidden bool) (models.CatalogItem, error)
+	SetPurchaseLimit(ctx context.Context, adminID, name string, limit *int64, period string) (models.CatalogItem, error)
+	RetireItem(ctx context.Context, adminID, name string) (models.CatalogItem, error)
+	GetItemVersions(ctx context.Context, name string) ([]models.CatalogItemVersion, error)
+	Restock(ctx context.Context, adminID, name string, quantity int64) (models.CatalogItem, error)
+	GetStockMovements(ctx context.Context, name string) ([]models.StockMovement, error)
+	CreateVariant(ctx context.Context, name string, draft models.ItemVariant) (models.ItemVariant, error)
+	GetItemVariants(ctx context.Context, name string) ([]models.ItemVariant, error)
+}
@@ -0,0 +1,306 @@
+package catalog
+
+import (
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+
+	"AvitoTask/internal/models"
+	"AvitoTask/internal/usecase/catalog"
+)
+
+type Handler struct {
+	manager manager
+}
+
+func NewHandler(m manager) *Handler {
+	return &Handler{
+		manager: m,
+	}
+}
+
+func (h *Handler) List(ctx *fiber.Ctx) error {
+	userID, ok := ctx.Context().Value("UserID").(string)
+	if !ok {
+		return ctx.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"errors": models.ErrAuthUser.Error(),
+		})
+	}
+
+	var query listQuery
+	if err := ctx.QueryParser(&query); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"errors": err.Error(),
+		})
+	}
+
+	if err := validate(query); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"errors": err.Error(),
+		})
+	}
+
+	if query.MaxPrice > 0 && query.MinPrice > query.MaxPrice {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"errors": "minPrice must not be greater than maxPrice",
+		})
+	}
+
+	items, total, err := h.manager.ListItems(ctx.Context(), userID, query.toFilter())
+	if err != nil {
+		return h.error(ctx, err)
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(convertList(items, total))
+}
+
+func (h *Handler) Create(ctx *fiber.Ctx) error {
+	adminID, ok := ctx.Context().Value("UserID").(string)
+	if !ok {
+		return ctx.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"errors": models.ErrAuthUser.Error(),
+		})
+	}
+
+	var req createRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"errors": err.Error(),
+		})
+	}
+
+	if err := validate(req); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"errors": err.Error(),
+		})
+	}
+
+	item, err := h.manager.CreateItem(ctx.Context(), adminID, models.CatalogItem{
+		Name:          req.Name,
+		Category:      req.Category,
+		Price:         req.Price,
+		Stock:         req.Stock,
+		PurchaseLimit: req.PurchaseLimit,
+		LimitPeriod:   req.LimitPeriod,
+	})
+	if err != nil {
+		return h.error(ctx, err)
+	}
+
+	return ctx.Status(fiber.StatusCreated).JSON(item)
+}
+
+func (h *Handler) Reprice(ctx *fiber.Ctx) error {
+	adminID, ok := ctx.Context().Value("UserID").(string)
+	if !ok {
+		return ctx.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"errors": models.ErrAuthUser.Error(),
+		})
+	}
+
+	var req priceRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"errors": err.Error(),
+		})
+	}
+
+	if err := validate(req); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"errors": err.Error(),
+		})
+	}
+
+	item, err := h.manager.RepriceItem(ctx.Context(), adminID, ctx.Params("item"), req.Price)
+	if err != nil {
+		return h.error(ctx, err)
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(item)
+}
+
+func (h *Handler) SetVisibility(ctx *fiber.Ctx) error {
+	adminID, ok := ctx.Context().Value("UserID").(string)
+	if !ok {
+		return ctx.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"errors": models.ErrAuthUser.Error(),
+		})
+	}
+
+	var req visibilityRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"errors": err.Error(),
+		})
+	}
+
+	item, err := h.manager.SetItemHidden(ctx.Context(), adminID, ctx.Params("item"), req.Hidden)
+	if err != nil {
+		return h.error(ctx, err)
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(item)
+}
+
+func (h *Handler) SetQuota(ctx *fiber.Ctx) error {
+	adminID, ok := ctx.Context().Value("UserID").(string)
+	if !ok {
+		return ctx.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"errors": models.ErrAuthUser.Error(),
+		})
+	}
+
+	var req quotaRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"errors": err.Error(),
+		})
+	}
+
+	if err := validate(req); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"errors": err.Error(),
+		})
+	}
+
+	item, err := h.manager.SetPurchaseLimit(ctx.Context(), adminID, ctx.Params("item"), req.PurchaseLimit, req.LimitPeriod)
+	if err != nil {
+		return h.error(ctx, err)
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(item)
+}
+
+func (h *Handler) Retire(ctx *fiber.Ctx) error {
+	adminID, ok := ctx.Context().Value("UserID").(string)
+	if !ok {
+		return ctx.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"errors": models.ErrAuthUser.Error(),
+		})
+	}
+
+	item, err := h.manager.RetireItem(ctx.Context(), adminID, ctx.Params("item"))
+	if err != nil {
+		return h.error(ctx, err)
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(item)
+}
+
+func (h *Handler) Versions(ctx *fiber.Ctx) error {
+	versions, err := h.manager.GetItemVersions(ctx.Context(), ctx.Params("item"))
+	if err != nil {
+		return h.error(ctx, err)
+	}
+
+	if versions == nil {
+		versions = make([]models.CatalogItemVersion, 0)
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(versions)
+}
+
+func (h *Handler) Restock(ctx *fiber.Ctx) error {
+	adminID, ok := ctx.Context().Value("UserID").(string)
+	if !ok {
+		return ctx.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"errors": models.ErrAuthUser.Error(),
+		})
+	}
+
+	var req restockRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"errors": err.Error(),
+		})
+	}
+
+	if err := validate(req); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"errors": err.Error(),
+		})
+	}
+
+	item, err := h.manager.Restock(ctx.Context(), adminID, ctx.Params("item"), req.Quantity)
+	if err != nil {
+		return h.error(ctx, err)
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(item)
+}
+
+func (h *Handler) StockHistory(ctx *fiber.Ctx) error {
+	movements, err := h.manager.GetStockMovements(ctx.Context(), ctx.Params("item"))
+	if err != nil {
+		return h.error(ctx, err)
+	}
+
+	if movements == nil {
+		movements = make([]models.StockMovement, 0)
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(movements)
+}
+
+func (h *Handler) CreateVariant(ctx *fiber.Ctx) error {
+	var req variantRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"errors": err.Error(),
+		})
+	}
+
+	if err := validate(req); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"errors": err.Error(),
+		})
+	}
+
+	variant, err := h.manager.CreateVariant(ctx.Context(), ctx.Params("item"), models.ItemVariant{
+		SKU:   req.SKU,
+		Size:  req.Size,
+		Color: req.Color,
+		Price: req.Price,
+		Stock: req.Stock,
+	})
+	if err != nil {
+		return h.error(ctx, err)
+	}
+
+	return ctx.Status(fiber.StatusCreated).JSON(variant)
+}
+
+func (h *Handler) Variants(ctx *fiber.Ctx) error {
+	variants, err := h.manager.GetItemVariants(ctx.Context(), ctx.Params("item"))
+	if err != nil {
+		return h.error(ctx, err)
+	}
+
+	if variants == nil {
+		variants = make([]models.ItemVariant, 0)
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(variants)
+}
+
+func (h *Handler) error(ctx *fiber.Ctx, err error) error {
+	switch {
+	case errors.Is(err, models.ErrItemNotFound):
+		return ctx.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"errors": err.Error(),
+		})
+	case errors.Is(err, catalog.ErrInvalidQuota):
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"errors": err.Error(),
+		})
+	case errors.Is(err, catalog.ErrItemExists), errors.Is(err, catalog.ErrItemRetired), errors.Is(err, catalog.ErrVariantExists),
+		errors.Is(err, catalog.ErrItemUnlimited):
+		return ctx.Status(fiber.StatusConflict).JSON(fiber.Map{
+			"errors": err.Error(),
+		})
+	default:
+		return ctx.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"errors": err.Error(),
+		})
+	}
+}
@@ -0,0 +1,117 @@
+package catalog
+
+import (
+	"fmt"
+
+	"github.com/go-playground/validator/v10"
+
+	"AvitoTask/internal/models"
+)
+
+type createRequest struct {
+	Name     string `json:"name" validate:"required,max=255"`
+	Category string `json:"category" validate:"max=64"`
+	Price    int64  `json:"price" validate:"required,min=1"`
+	Stock    *int64 `json:"stock" validate:"omitempty,min=0"`
+
+	PurchaseLimit *int64 `json:"purchaseLimit" validate:"omitempty,min=1"`
+	LimitPeriod   string `json:"limitPeriod" validate:"omitempty,oneof=month quarter year"`
+}
+
+type variantRequest struct {
+	SKU   string `json:"sku" validate:"required,max=64"`
+	Size  string `json:"size" validate:"max=32"`
+	Color string `json:"color" validate:"max=32"`
+	Price *int64 `json:"price" validate:"omitempty,min=1"`
+	Stock *int64 `json:"stock" validate:"omitempty,min=0"`
+}
+
+type restockRequest struct {
+	Quantity int64 `json:"quantity" validate:"required,min=1"`
+}
+
+type priceRequest struct {
+	Price int64 `json:"price" validate:"required,min=1"`
+}
+
+// quotaRequest - purchaseLimit == nil снимает квоту с позиции
+type quotaRequest struct {
+	PurchaseLimit *int64 `json:"purchaseLimit" validate:"omitempty,min=1"`
+	LimitPeriod   string `json:"limitPeriod" validate:"omitempty,oneof=month quarter year"`
+}
+
+type visibilityRequest struct {
+	Hidden bool `json:"hidden"`
+}
+
+type listQuery struct {
+	MinPrice int64  `query:"minPrice" validate:"min=0"`
+	MaxPrice int64  `query:"maxPrice" validate:"min=0"`
+	Category string `query:"category"`
+	Sort     string `query:"sort" validate:"omitempty,oneof=price name"`
+	Order    string `query:"order" validate:"omitempty,oneof=asc desc"`
+	Limit    int64  `query:"limit" validate:"min=0,max=100"`
+	Offset   int64  `query:"offset" validate:"min=0"`
+}
+
+type listOutput struct {
+	Items []listItemOutput `json:"items"`
+	Total int64            `json:"total"`
+}
+
+type listItemOutput struct {
+	Name      string `json:"name"`
+	Category  string `json:"category"`
+	Price     int64  `json:"price"`
+	Stock     *int64 `json:"stock,omitempty"`
+	QuotaLeft *int64 `json:"quotaLeft,omitempty"`
+	Available bool   `json:"available"`
+	CanAfford bool   `json:"canAfford"`
+}
+
+func (q listQuery) toFilter() models.CatalogFilter {
+	limit := q.Limit
+	if limit == 0 {
+		limit = models.DefaultPageLimit
+	}
+
+	return models.CatalogFilter{
+		MinPrice: q.MinPrice,
+		MaxPrice: q.MaxPrice,
+		Category: q.Category,
+		SortBy:   q.Sort,
+		Desc:     q.Order == "desc",
+		Limit:    limit,
+		Offset:   q.Offset,
+	}
+}
+
+func convertList(items []models.CatalogListItem, total int64) listOutput {
+	out := listOutput{
+		Items: make([]listItemOutput, 0, len(items)),
+		Total: total,
+	}
+
+	for _, it := range items {
+		out.Items = append(out.Items, listItemOutput{
+			Name:      it.Name,
+			Category:  it.Category,
+			Price:     it.Price,
+			Stock:     it.Stock,
+			QuotaLeft: it.QuotaLeft,
+			Available: it.Available,
+			CanAfford: it.CanAfford,
+		})
+	}
+
+	return out
+}
+
+func validate(r any) error {
+	validate := validator.New()
+	if err := validate.Struct(r); err != nil {
+		return fmt.Errorf("%s: %w", models.ErrValidation, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,13 @@
+package coupon
+
+import (
+	"context"
+
+	"AvitoTask/internal/models"
+)
+
+type manager interface {
+	GenerateBatch(ctx context.Context, adminID, prefix string, count int, template models.Coupon) ([]models.Coupon, error)
+	GetBatch(ctx context.Context, batchID string) ([]models.Coupon, error)
+	GetRedemptions(ctx context.Context, code string) ([]models.CouponRedemption, error)
+}
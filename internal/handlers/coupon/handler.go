@@ -0,0 +1,106 @@
+package coupon
+
+import (
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+
+	"AvitoTask/internal/models"
+	"AvitoTask/internal/usecase/coupon"
+)
+
+type Handler struct {
+	manager manager
+}
+
+func NewHandler(m manager) *Handler {
+	return &Handler{
+		manager: m,
+	}
+}
+
+func (h *Handler) Generate(ctx *fiber.Ctx) error {
+	adminID, ok := ctx.Context().Value("UserID").(string)
+	if !ok {
+		return ctx.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"errors": models.ErrAuthUser.Error(),
+		})
+	}
+
+	var req batchRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"errors": err.Error(),
+		})
+	}
+
+	if err := validate(req); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"errors": err.Error(),
+		})
+	}
+
+	coupons, err := h.manager.GenerateBatch(ctx.Context(), adminID, req.Prefix, req.Count, req.toCoupon())
+	if err != nil {
+		return h.error(ctx, err)
+	}
+
+	return ctx.Status(fiber.StatusCreated).JSON(batchOutput{
+		BatchID: coupons[0].BatchID,
+		Coupons: coupons,
+	})
+}
+
+func (h *Handler) Batch(ctx *fiber.Ctx) error {
+	batchID := ctx.Params("id")
+	if _, err := uuid.Parse(batchID); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"errors": "batch id must be a valid uuid",
+		})
+	}
+
+	coupons, err := h.manager.GetBatch(ctx.Context(), batchID)
+	if err != nil {
+		return h.error(ctx, err)
+	}
+
+	if coupons == nil {
+		coupons = make([]models.Coupon, 0)
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(batchOutput{
+		BatchID: batchID,
+		Coupons: coupons,
+	})
+}
+
+func (h *Handler) Redemptions(ctx *fiber.Ctx) error {
+	redemptions, err := h.manager.GetRedemptions(ctx.Context(), ctx.Params("code"))
+	if err != nil {
+		return h.error(ctx, err)
+	}
+
+	if redemptions == nil {
+		redemptions = make([]models.CouponRedemption, 0)
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(redemptions)
+}
+
+func (h *Handler) error(ctx *fiber.Ctx, err error) error {
+	switch {
+	case errors.Is(err, models.ErrItemNotFound):
+		return ctx.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"errors": err.Error(),
+		})
+	case errors.Is(err, coupon.ErrInvalidDiscount), errors.Is(err, coupon.ErrInvalidExpiry):
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"errors": err.Error(),
+		})
+	default:
+		return ctx.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"errors": err.Error(),
+		})
+	}
+}
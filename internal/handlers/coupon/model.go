@@ -0,0 +1,46 @@
+package coupon
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+
+	"AvitoTask/internal/models"
+)
+
+type batchRequest struct {
+	Prefix         string     `json:"prefix" validate:"max=32"`
+	Count          int        `json:"count" validate:"required,min=1,max=1000"`
+	Percent        int64      `json:"percent" validate:"min=0,max=100"`
+	Amount         int64      `json:"amount" validate:"min=0"`
+	MaxRedemptions int64      `json:"maxRedemptions" validate:"required,min=1"`
+	MinSpend       int64      `json:"minSpend" validate:"min=0"`
+	Items          []string   `json:"items" validate:"dive,required"`
+	ExpiresAt      *time.Time `json:"expiresAt"`
+}
+
+type batchOutput struct {
+	BatchID string          `json:"batchId"`
+	Coupons []models.Coupon `json:"coupons"`
+}
+
+func (r batchRequest) toCoupon() models.Coupon {
+	return models.Coupon{
+		Percent:        r.Percent,
+		Amount:         r.Amount,
+		MaxRedemptions: r.MaxRedemptions,
+		MinSpend:       r.MinSpend,
+		Items:          r.Items,
+		ExpiresAt:      r.ExpiresAt,
+	}
+}
+
+func validate(r any) error {
+	validate := validator.New()
+	if err := validate.Struct(r); err != nil {
+		return fmt.Errorf("%s: %w", models.ErrValidation, err)
+	}
+
+	return nil
+}
@@ -7,5 +7,5 @@ import (
 )
 
 type infoUser interface {
-	GetInfo(ctx context.Context, userID string) (username string, res models.InfoResponse, err error)
+	GetInfo(ctx context.Context, userID, category string) (username string, res models.InfoResponse, err error)
 }
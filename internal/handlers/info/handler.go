@@ -22,7 +22,14 @@ func (h *Handler) Handle(c *fiber.Ctx) error {
 		})
 	}
 
-	username, infoResp, err := h.uc.GetInfo(c.Context(), userID)
+	category := c.Query("category")
+	if !models.ValidTransferCategory(category) {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "unknown transfer category " + category,
+		})
+	}
+
+	username, infoResp, err := h.uc.GetInfo(c.Context(), userID, category)
 	if err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 			"error": err.Error(),
@@ -1,15 +1,28 @@
 package info
 
-import "AvitoTask/internal/models"
+import (
+	"time"
+
+	"AvitoTask/internal/models"
+)
 
 type Output struct {
-	Coins       int64             `json:"coins"`
-	Inventory   []InvOutput       `json:"inventory"`
-	CoinHistory CoinHistoryOutput `json:"coinHistory"`
+	Coins           int64             `json:"coins"`
+	Inventory       []InvOutput       `json:"inventory"`
+	CoinHistory     CoinHistoryOutput `json:"coinHistory"`
+	PurchaseHistory []PurchaseItem    `json:"purchaseHistory"`
+	ItemHistory     ItemHistoryOutput `json:"itemHistory"`
+	Wishlist        []WishlistItem    `json:"wishlist"`
 }
 
 type InvOutput struct {
-	Type     string `json:"type"`
+	Type     string          `json:"type"`
+	Quantity int64           `json:"quantity"`
+	Variants []VariantOutput `json:"variants,omitempty"`
+}
+
+type VariantOutput struct {
+	SKU      string `json:"sku"`
 	Quantity int64  `json:"quantity"`
 }
 
@@ -18,48 +31,194 @@ type CoinHistoryOutput struct {
 	Sent     []SentItem     `json:"sent"`
 }
 
+// ReceivedItem и SentItem - переводы в истории; Status - completed для обычного перевода,
+// для отложенного - pending, accepted, declined или expired. System - начисление от сервиса (пособие),
+// у него нет отправителя, а в Message - имя пособия
 type ReceivedItem struct {
-	FromUser string `json:"fromUser"`
-	Amount   int64  `json:"amount"`
+	TransactionID string     `json:"transactionId"`
+	FromUser      string     `json:"fromUser"`
+	System        bool       `json:"system,omitempty"`
+	Amount        int64      `json:"amount"`
+	Message       string     `json:"message,omitempty"`
+	Category      string     `json:"category,omitempty"`
+	Status        string     `json:"status"`
+	ExpiresAt     *time.Time `json:"expiresAt,omitempty"`
 }
 
 type SentItem struct {
-	ToUser string `json:"toUser"`
-	Amount int64  `json:"amount"`
+	TransactionID string     `json:"transactionId"`
+	ToUser        string     `json:"toUser"`
+	Amount        int64      `json:"amount"`
+	Message       string     `json:"message,omitempty"`
+	Category      string     `json:"category,omitempty"`
+	Status        string     `json:"status"`
+	ExpiresAt     *time.Time `json:"expiresAt,omitempty"`
+}
+
+type ItemHistoryOutput struct {
+	Received []ReceivedGift `json:"received"`
+	Sent     []SentGift     `json:"sent"`
+}
+
+type ReceivedGift struct {
+	FromUser string `json:"fromUser"`
+	Item     string `json:"item"`
+	Variant  string `json:"variant,omitempty"`
+	Quantity int64  `json:"quantity"`
+}
+
+type SentGift struct {
+	ToUser   string `json:"toUser"`
+	Item     string `json:"item"`
+	Variant  string `json:"variant,omitempty"`
+	Quantity int64  `json:"quantity"`
+}
+
+type WishlistItem struct {
+	Item         string `json:"item"`
+	Price        int64  `json:"price"`
+	Available    bool   `json:"available"`
+	MissingCoins int64  `json:"missingCoins"`
+}
+
+type PurchaseItem struct {
+	OrderID        string    `json:"orderId"`
+	Kind           string    `json:"kind"`
+	RefundOf       string    `json:"refundOf,omitempty"`
+	Bundle         bool      `json:"bundle,omitempty"`
+	Item           string    `json:"item"`
+	Variant        string    `json:"variant,omitempty"`
+	Quantity       int64     `json:"quantity"`
+	UnitPrice      int64     `json:"unitPrice"`
+	Discount       int64     `json:"discount,omitempty"`
+	PromotionID    string    `json:"promotionId,omitempty"`
+	CouponCode     string    `json:"couponCode,omitempty"`
+	CouponDiscount int64     `json:"couponDiscount,omitempty"`
+	Total          int64     `json:"total"`
+	Status         string    `json:"status,omitempty"`
+	CreatedAt      time.Time `json:"createdAt"`
+}
+
+func ConvertPurchase(o models.Order) PurchaseItem {
+	return PurchaseItem{
+		OrderID:        o.ID,
+		Kind:           o.Kind,
+		RefundOf:       o.RefundOf,
+		Bundle:         o.BundleID != "",
+		Item:           o.Item,
+		Variant:        o.Variant,
+		Quantity:       o.Quantity,
+		UnitPrice:      o.UnitPrice,
+		Discount:       o.Discount,
+		PromotionID:    o.PromotionID,
+		CouponCode:     o.CouponCode,
+		CouponDiscount: o.CouponDiscount,
+		Total:          o.Total,
+		Status:         o.Status,
+		CreatedAt:      o.CreatedAt,
+	}
+}
+
+// groupInventory - сворачивает строки инвентаря по базовому предмету, варианты перечисляются внутри него
+func groupInventory(items []models.InventoryItem) []InvOutput {
+	out := make([]InvOutput, 0, len(items))
+	index := make(map[string]int, len(items))
+
+	for _, inv := range items {
+		i, ok := index[inv.ItemType]
+		if !ok {
+			i = len(out)
+			index[inv.ItemType] = i
+			out = append(out, InvOutput{Type: inv.ItemType})
+		}
+
+		out[i].Quantity += inv.Quantity
+		if inv.Variant != "" {
+			out[i].Variants = append(out[i].Variants, VariantOutput{
+				SKU:      inv.Variant,
+				Quantity: inv.Quantity,
+			})
+		}
+	}
+
+	return out
 }
 
 func ConvertInfoResponse(infoResp models.InfoResponse, currentUserID, username string) Output {
 	out := Output{
 		Coins:     infoResp.Coins,
-		Inventory: make([]InvOutput, 0, len(infoResp.Inventory)),
+		Inventory: groupInventory(infoResp.Inventory),
 		CoinHistory: CoinHistoryOutput{
 			Received: make([]ReceivedItem, 0),
 			Sent:     make([]SentItem, 0),
 		},
-	}
-
-	for _, inv := range infoResp.Inventory {
-		out.Inventory = append(out.Inventory, InvOutput{
-			Type:     inv.ItemType,
-			Quantity: inv.Quantity,
-		})
+		PurchaseHistory: make([]PurchaseItem, 0, len(infoResp.Orders)),
+		ItemHistory: ItemHistoryOutput{
+			Received: make([]ReceivedGift, 0),
+			Sent:     make([]SentGift, 0),
+		},
+		Wishlist: make([]WishlistItem, 0, len(infoResp.Wishlist)),
 	}
 
 	for _, tx := range infoResp.Transactions {
 		switch {
 		case tx.ToUserID == currentUserID:
 			out.CoinHistory.Received = append(out.CoinHistory.Received, ReceivedItem{
-				FromUser: tx.FromUsername,
-				Amount:   tx.Amount,
+				TransactionID: tx.ID,
+				FromUser:      tx.FromUsername,
+				System:        tx.FromUserID == "",
+				Amount:        tx.Amount,
+				Message:       tx.Message,
+				Category:      tx.Category,
+				Status:        tx.Status,
+				ExpiresAt:     tx.ExpiresAt,
 			})
 
 		case tx.FromUserID == currentUserID:
 			out.CoinHistory.Sent = append(out.CoinHistory.Sent, SentItem{
-				ToUser: tx.ToUserName,
-				Amount: tx.Amount,
+				TransactionID: tx.ID,
+				ToUser:        tx.ToUserName,
+				Amount:        tx.Amount,
+				Message:       tx.Message,
+				Category:      tx.Category,
+				Status:        tx.Status,
+				ExpiresAt:     tx.ExpiresAt,
 			})
 		}
 	}
 
+	for _, o := range infoResp.Orders {
+		out.PurchaseHistory = append(out.PurchaseHistory, ConvertPurchase(o))
+	}
+
+	for _, it := range infoResp.ItemHistory {
+		switch {
+		case it.ToUserID == currentUserID:
+			out.ItemHistory.Received = append(out.ItemHistory.Received, ReceivedGift{
+				FromUser: it.FromUsername,
+				Item:     it.ItemType,
+				Variant:  it.Variant,
+				Quantity: it.Quantity,
+			})
+
+		case it.FromUserID == currentUserID:
+			out.ItemHistory.Sent = append(out.ItemHistory.Sent, SentGift{
+				ToUser:   it.ToUserName,
+				Item:     it.ItemType,
+				Variant:  it.Variant,
+				Quantity: it.Quantity,
+			})
+		}
+	}
+
+	for _, e := range infoResp.Wishlist {
+		out.Wishlist = append(out.Wishlist, WishlistItem{
+			Item:         e.Item,
+			Price:        e.Price,
+			Available:    e.Available,
+			MissingCoins: e.MissingCoins,
+		})
+	}
+
 	return out
 }
@@ -0,0 +1,11 @@
+package ledger
+
+import (
+	"context"
+
+	"AvitoTask/internal/models"
+)
+
+type manager interface {
+	Reconcile(ctx context.Context) (models.LedgerReport, error)
+}
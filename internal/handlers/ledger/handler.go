@@ -0,0 +1,32 @@
+package ledger
+
+import (
+	"github.com/gofiber/fiber/v2"
+
+	"AvitoTask/internal/models"
+)
+
+type Handler struct {
+	manager manager
+}
+
+func NewHandler(m manager) *Handler {
+	return &Handler{
+		manager: m,
+	}
+}
+
+func (h *Handler) Reconcile(ctx *fiber.Ctx) error {
+	report, err := h.manager.Reconcile(ctx.Context())
+	if err != nil {
+		return ctx.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"errors": err.Error(),
+		})
+	}
+
+	if report.Mismatches == nil {
+		report.Mismatches = make([]models.BalanceMismatch, 0)
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(report)
+}
@@ -0,0 +1,14 @@
+package order
+
+import (
+	"context"
+
+	"AvitoTask/internal/models"
+)
+
+type manager interface {
+	ListOrders(ctx context.Context, userID string, limit, offset int64) ([]models.Order, int64, error)
+	ReturnOrder(ctx context.Context, userID, orderID string) (models.Order, error)
+	ListQueue(ctx context.Context, status string, limit, offset int64) ([]models.Order, int64, error)
+	MoveOrder(ctx context.Context, staffID, orderID, status string) (models.Order, error)
+}
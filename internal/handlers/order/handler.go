@@ -0,0 +1,179 @@
+package order
+
+import (
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+
+	"AvitoTask/internal/models"
+	"AvitoTask/internal/usecase/order"
+)
+
+type Handler struct {
+	manager manager
+}
+
+func NewHandler(m manager) *Handler {
+	return &Handler{
+		manager: m,
+	}
+}
+
+func (h *Handler) Handle(ctx *fiber.Ctx) error {
+	userID, ok := ctx.Context().Value("UserID").(string)
+	if !ok {
+		return ctx.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"errors": models.ErrAuthUser.Error(),
+		})
+	}
+
+	var query pageQuery
+	if err := ctx.QueryParser(&query); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"errors": err.Error(),
+		})
+	}
+
+	if err := validate(query); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"errors": err.Error(),
+		})
+	}
+
+	if query.Limit == 0 {
+		query.Limit = models.DefaultPageLimit
+	}
+
+	orders, total, err := h.manager.ListOrders(ctx.Context(), userID, query.Limit, query.Offset)
+	if err != nil {
+		return ctx.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"errors": err.Error(),
+		})
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(convertOrders(orders, total))
+}
+
+func (h *Handler) Return(ctx *fiber.Ctx) error {
+	userID, ok := ctx.Context().Value("UserID").(string)
+	if !ok {
+		return ctx.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"errors": models.ErrAuthUser.Error(),
+		})
+	}
+
+	orderID := ctx.Params("id")
+	if _, err := uuid.Parse(orderID); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"errors": "order id must be a valid uuid",
+		})
+	}
+
+	refund, err := h.manager.ReturnOrder(ctx.Context(), userID, orderID)
+	if errors.Is(err, models.ErrOrderNotFound) {
+		return ctx.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"errors": err.Error(),
+		})
+	}
+	if errors.Is(err, order.ErrNotRefundable) ||
+		errors.Is(err, order.ErrAlreadyRefunded) ||
+		errors.Is(err, order.ErrReturnWindowExpired) ||
+		errors.Is(err, order.ErrItemNoLongerOwned) {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"errors": err.Error(),
+		})
+	}
+	if err != nil {
+		return ctx.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"errors": err.Error(),
+		})
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(convertOrder(refund))
+}
+
+// Queue - очередь стойки выдачи; по умолчанию показывает оформленные и ещё не подготовленные заказы
+func (h *Handler) Queue(ctx *fiber.Ctx) error {
+	var query queueQuery
+	if err := ctx.QueryParser(&query); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"errors": err.Error(),
+		})
+	}
+
+	if err := validate(query); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"errors": err.Error(),
+		})
+	}
+
+	if query.Status == "" {
+		query.Status = models.OrderStatusPlaced
+	}
+	if query.Limit == 0 {
+		query.Limit = models.DefaultPageLimit
+	}
+
+	orders, total, err := h.manager.ListQueue(ctx.Context(), query.Status, query.Limit, query.Offset)
+	if err != nil {
+		return ctx.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"errors": err.Error(),
+		})
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(convertOrders(orders, total))
+}
+
+func (h *Handler) Move(ctx *fiber.Ctx) error {
+	staffID, ok := ctx.Context().Value("UserID").(string)
+	if !ok {
+		return ctx.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"errors": models.ErrAuthUser.Error(),
+		})
+	}
+
+	orderID := ctx.Params("id")
+	if _, err := uuid.Parse(orderID); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"errors": "order id must be a valid uuid",
+		})
+	}
+
+	var req statusRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"errors": err.Error(),
+		})
+	}
+
+	if err := validate(req); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"errors": err.Error(),
+		})
+	}
+
+	o, err := h.manager.MoveOrder(ctx.Context(), staffID, orderID, req.Status)
+	if errors.Is(err, models.ErrOrderNotFound) {
+		return ctx.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"errors": err.Error(),
+		})
+	}
+	if errors.Is(err, order.ErrInvalidTransition) || errors.Is(err, order.ErrItemNoLongerOwned) {
+		return ctx.Status(fiber.StatusConflict).JSON(fiber.Map{
+			"errors": err.Error(),
+		})
+	}
+	if errors.Is(err, order.ErrNoFulfillment) {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"errors": err.Error(),
+		})
+	}
+	if err != nil {
+		return ctx.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"errors": err.Error(),
+		})
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(convertOrder(o))
+}
@@ -0,0 +1,90 @@
+package order
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+
+	"AvitoTask/internal/models"
+)
+
+type pageQuery struct {
+	Limit  int64 `query:"limit" validate:"min=0,max=100"`
+	Offset int64 `query:"offset" validate:"min=0"`
+}
+
+type queueQuery struct {
+	Status string `query:"status" validate:"omitempty,oneof=placed ready handed_over cancelled"`
+	Limit  int64  `query:"limit" validate:"min=0,max=100"`
+	Offset int64  `query:"offset" validate:"min=0"`
+}
+
+type statusRequest struct {
+	Status string `json:"status" validate:"required,oneof=ready handed_over cancelled"`
+}
+
+type orderOutput struct {
+	OrderID        string    `json:"orderId"`
+	Kind           string    `json:"kind"`
+	RefundOf       string    `json:"refundOf,omitempty"`
+	Bundle         bool      `json:"bundle,omitempty"`
+	Item           string    `json:"item"`
+	Variant        string    `json:"variant,omitempty"`
+	Quantity       int64     `json:"quantity"`
+	UnitPrice      int64     `json:"unitPrice"`
+	Discount       int64     `json:"discount,omitempty"`
+	PromotionID    string    `json:"promotionId,omitempty"`
+	CouponCode     string    `json:"couponCode,omitempty"`
+	CouponDiscount int64     `json:"couponDiscount,omitempty"`
+	Total          int64     `json:"total"`
+	Status         string    `json:"status,omitempty"`
+	CreatedAt      time.Time `json:"createdAt"`
+}
+
+type listOutput struct {
+	Orders []orderOutput `json:"orders"`
+	Total  int64         `json:"total"`
+}
+
+func convertOrder(o models.Order) orderOutput {
+	return orderOutput{
+		OrderID:        o.ID,
+		Kind:           o.Kind,
+		RefundOf:       o.RefundOf,
+		Bundle:         o.BundleID != "",
+		Item:           o.Item,
+		Variant:        o.Variant,
+		Quantity:       o.Quantity,
+		UnitPrice:      o.UnitPrice,
+		Discount:       o.Discount,
+		PromotionID:    o.PromotionID,
+		CouponCode:     o.CouponCode,
+		CouponDiscount: o.CouponDiscount,
+		Total:          o.Total,
+		Status:         o.Status,
+		CreatedAt:      o.CreatedAt,
+	}
+}
+
+func convertOrders(orders []models.Order, total int64) listOutput {
+	out := listOutput{
+		Orders: make([]orderOutput, 0, len(orders)),
+		Total:  total,
+	}
+
+	for _, o := range orders {
+		out.Orders = append(out.Orders, convertOrder(o))
+	}
+
+	return out
+}
+
+func validate(r any) error {
+	validate := validator.New()
+	if err := validate.Struct(r); err != nil {
+		return fmt.Errorf("%s: %w", models.ErrValidation, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,16 @@
+package payment_request
+
+import (
+	"context"
+
+	"AvitoTask/internal/models"
+)
+
+type requester interface {
+	Create(ctx context.Context, userID, payer string, amount int64, note string) (models.PaymentRequest, error)
+	Incoming(ctx context.Context, userID string) ([]models.PaymentRequest, error)
+	Outgoing(ctx context.Context, userID string) ([]models.PaymentRequest, error)
+	Pay(ctx context.Context, userID, id string) (models.PaymentRequest, error)
+	Decline(ctx context.Context, userID, id string) (models.PaymentRequest, error)
+	Cancel(ctx context.Context, userID, id string) (models.PaymentRequest, error)
+}
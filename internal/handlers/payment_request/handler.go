@@ -0,0 +1,128 @@
+package payment_request
+
+import (
+	"context"
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+
+	"AvitoTask/internal/models"
+	"AvitoTask/internal/usecase/payment_request"
+	"AvitoTask/internal/usecase/send_coin"
+)
+
+type Handler struct {
+	requester requester
+}
+
+func NewHandler(r requester) *Handler {
+	return &Handler{
+		requester: r,
+	}
+}
+
+func (h *Handler) Create(ctx *fiber.Ctx) error {
+	userID, ok := ctx.Context().Value("UserID").(string)
+	if !ok {
+		return ctx.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"errors": models.ErrAuthUser.Error(),
+		})
+	}
+
+	var req createRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"errors": err.Error(),
+		})
+	}
+
+	if err := validate(req); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"errors": err.Error(),
+		})
+	}
+
+	res, err := h.requester.Create(ctx.Context(), userID, req.FromUser, req.Amount, req.Note)
+	if err != nil {
+		return h.error(ctx, err)
+	}
+
+	return ctx.Status(fiber.StatusCreated).JSON(convertRequest(res))
+}
+
+func (h *Handler) Incoming(ctx *fiber.Ctx) error {
+	return h.list(ctx, h.requester.Incoming)
+}
+
+func (h *Handler) Outgoing(ctx *fiber.Ctx) error {
+	return h.list(ctx, h.requester.Outgoing)
+}
+
+func (h *Handler) list(ctx *fiber.Ctx, get func(ctx context.Context, userID string) ([]models.PaymentRequest, error)) error {
+	userID, ok := ctx.Context().Value("UserID").(string)
+	if !ok {
+		return ctx.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"errors": models.ErrAuthUser.Error(),
+		})
+	}
+
+	res, err := get(ctx.Context(), userID)
+	if err != nil {
+		return h.error(ctx, err)
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(convertRequests(res))
+}
+
+func (h *Handler) Pay(ctx *fiber.Ctx) error {
+	return h.change(ctx, h.requester.Pay)
+}
+
+func (h *Handler) Decline(ctx *fiber.Ctx) error {
+	return h.change(ctx, h.requester.Decline)
+}
+
+func (h *Handler) Cancel(ctx *fiber.Ctx) error {
+	return h.change(ctx, h.requester.Cancel)
+}
+
+func (h *Handler) change(ctx *fiber.Ctx, action func(ctx context.Context, userID, id string) (models.PaymentRequest, error)) error {
+	userID, ok := ctx.Context().Value("UserID").(string)
+	if !ok {
+		return ctx.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"errors": models.ErrAuthUser.Error(),
+		})
+	}
+
+	res, err := action(ctx.Context(), userID, ctx.Params("id"))
+	if err != nil {
+		return h.error(ctx, err)
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(convertRequest(res))
+}
+
+func (h *Handler) error(ctx *fiber.Ctx, err error) error {
+	switch {
+	case errors.Is(err, models.ErrPaymentRequestNotFound):
+		return ctx.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"errors": err.Error(),
+		})
+	case errors.Is(err, payment_request.ErrRequestClosed),
+		errors.Is(err, payment_request.ErrRequestExpired):
+		return ctx.Status(fiber.StatusConflict).JSON(fiber.Map{
+			"errors": err.Error(),
+		})
+	case errors.Is(err, payment_request.ErrSameUser),
+		errors.Is(err, payment_request.ErrPayerNotFound),
+		errors.Is(err, send_coin.ErrNotEnoughCoins),
+		errors.Is(err, models.ErrTransferLimitExceeded):
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"errors": err.Error(),
+		})
+	default:
+		return ctx.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"errors": err.Error(),
+		})
+	}
+}
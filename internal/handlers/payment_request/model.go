@@ -0,0 +1,61 @@
+package payment_request
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+
+	"AvitoTask/internal/models"
+)
+
+type createRequest struct {
+	// FromUser - у кого просят монеты
+	FromUser string `json:"fromUser" validate:"required"`
+	Amount   int64  `json:"amount" validate:"required,min=1"`
+	Note     string `json:"note" validate:"max=255"`
+}
+
+type requestOutput struct {
+	ID            string    `json:"id"`
+	Requester     string    `json:"requester"`
+	Payer         string    `json:"payer"`
+	Amount        int64     `json:"amount"`
+	Note          string    `json:"note,omitempty"`
+	Status        string    `json:"status"`
+	TransactionID string    `json:"transactionId,omitempty"`
+	ExpiresAt     time.Time `json:"expiresAt"`
+	CreatedAt     time.Time `json:"createdAt"`
+}
+
+func convertRequest(r models.PaymentRequest) requestOutput {
+	return requestOutput{
+		ID:            r.ID,
+		Requester:     r.RequesterName,
+		Payer:         r.PayerName,
+		Amount:        r.Amount,
+		Note:          r.Note,
+		Status:        r.Status,
+		TransactionID: r.TransactionID,
+		ExpiresAt:     r.ExpiresAt,
+		CreatedAt:     r.CreatedAt,
+	}
+}
+
+func convertRequests(requests []models.PaymentRequest) []requestOutput {
+	out := make([]requestOutput, 0, len(requests))
+	for _, r := range requests {
+		out = append(out, convertRequest(r))
+	}
+
+	return out
+}
+
+func validate(r createRequest) error {
+	validate := validator.New()
+	if err := validate.Struct(r); err != nil {
+		return fmt.Errorf("%s: %w", models.ErrValidation, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,13 @@
+package promotion
+
+import (
+	"context"
+
+	"AvitoTask/internal/models"
+)
+
+type manager interface {
+	CreatePromotion(ctx context.Context, adminID string, draft models.Promotion) (models.Promotion, error)
+	ListPromotions(ctx context.Context) ([]models.Promotion, error)
+	EndPromotion(ctx context.Context, id string) error
+}
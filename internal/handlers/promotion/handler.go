@@ -0,0 +1,97 @@
+package promotion
+
+import (
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+
+	"AvitoTask/internal/models"
+	"AvitoTask/internal/usecase/promotion"
+)
+
+type Handler struct {
+	manager manager
+}
+
+func NewHandler(m manager) *Handler {
+	return &Handler{
+		manager: m,
+	}
+}
+
+func (h *Handler) Create(ctx *fiber.Ctx) error {
+	adminID, ok := ctx.Context().Value("UserID").(string)
+	if !ok {
+		return ctx.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"errors": models.ErrAuthUser.Error(),
+		})
+	}
+
+	var req createRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"errors": err.Error(),
+		})
+	}
+
+	if err := validate(req); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"errors": err.Error(),
+		})
+	}
+
+	p, err := h.manager.CreatePromotion(ctx.Context(), adminID, req.toPromotion())
+	if err != nil {
+		return h.error(ctx, err)
+	}
+
+	return ctx.Status(fiber.StatusCreated).JSON(p)
+}
+
+func (h *Handler) List(ctx *fiber.Ctx) error {
+	promotions, err := h.manager.ListPromotions(ctx.Context())
+	if err != nil {
+		return h.error(ctx, err)
+	}
+
+	if promotions == nil {
+		promotions = make([]models.Promotion, 0)
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(promotions)
+}
+
+func (h *Handler) End(ctx *fiber.Ctx) error {
+	id := ctx.Params("id")
+	if _, err := uuid.Parse(id); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"errors": "promotion id must be a valid uuid",
+		})
+	}
+
+	if err := h.manager.EndPromotion(ctx.Context(), id); err != nil {
+		return h.error(ctx, err)
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(fiber.Map{})
+}
+
+func (h *Handler) error(ctx *fiber.Ctx, err error) error {
+	switch {
+	case errors.Is(err, models.ErrItemNotFound), errors.Is(err, models.ErrPromotionNotFound):
+		return ctx.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"errors": err.Error(),
+		})
+	case errors.Is(err, promotion.ErrInvalidTarget),
+		errors.Is(err, promotion.ErrInvalidAmount),
+		errors.Is(err, promotion.ErrInvalidPeriod):
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"errors": err.Error(),
+		})
+	default:
+		return ctx.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"errors": err.Error(),
+		})
+	}
+}
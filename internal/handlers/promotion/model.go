@@ -0,0 +1,41 @@
+package promotion
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+
+	"AvitoTask/internal/models"
+)
+
+type createRequest struct {
+	Name     string    `json:"name" validate:"required,max=255"`
+	Item     string    `json:"item" validate:"required_without=Category,excluded_with=Category"`
+	Category string    `json:"category" validate:"max=64"`
+	Percent  int64     `json:"percent" validate:"min=0,max=100"`
+	Amount   int64     `json:"amount" validate:"min=0"`
+	StartsAt time.Time `json:"startsAt" validate:"required"`
+	EndsAt   time.Time `json:"endsAt" validate:"required"`
+}
+
+func (r createRequest) toPromotion() models.Promotion {
+	return models.Promotion{
+		Name:     r.Name,
+		Item:     r.Item,
+		Category: r.Category,
+		Percent:  r.Percent,
+		Amount:   r.Amount,
+		StartsAt: r.StartsAt,
+		EndsAt:   r.EndsAt,
+	}
+}
+
+func validate(r any) error {
+	validate := validator.New()
+	if err := validate.Struct(r); err != nil {
+		return fmt.Errorf("%s: %w", models.ErrValidation, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,16 @@
+package schedule
+
+import (
+	"context"
+
+	"AvitoTask/internal/models"
+)
+
+type manager interface {
+	Create(ctx context.Context, userID string, draft models.ScheduledTransfer) (models.ScheduledTransfer, error)
+	List(ctx context.Context, userID string) ([]models.ScheduledTransfer, error)
+	Runs(ctx context.Context, userID, id string) ([]models.ScheduledRun, error)
+	Pause(ctx context.Context, userID, id string) (models.ScheduledTransfer, error)
+	Resume(ctx context.Context, userID, id string) (models.ScheduledTransfer, error)
+	Cancel(ctx context.Context, userID, id string) (models.ScheduledTransfer, error)
+}
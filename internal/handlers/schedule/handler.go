@@ -0,0 +1,135 @@
+package schedule
+
+import (
+	"context"
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+
+	"AvitoTask/internal/models"
+	"AvitoTask/internal/usecase/schedule"
+)
+
+type Handler struct {
+	manager manager
+}
+
+func NewHandler(m manager) *Handler {
+	return &Handler{
+		manager: m,
+	}
+}
+
+func (h *Handler) Create(ctx *fiber.Ctx) error {
+	userID, ok := ctx.Context().Value("UserID").(string)
+	if !ok {
+		return ctx.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"errors": models.ErrAuthUser.Error(),
+		})
+	}
+
+	var req createRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"errors": err.Error(),
+		})
+	}
+
+	if err := validate(req); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"errors": err.Error(),
+		})
+	}
+
+	res, err := h.manager.Create(ctx.Context(), userID, req.draft())
+	if err != nil {
+		return h.error(ctx, err)
+	}
+
+	return ctx.Status(fiber.StatusCreated).JSON(convertSchedule(res))
+}
+
+func (h *Handler) List(ctx *fiber.Ctx) error {
+	userID, ok := ctx.Context().Value("UserID").(string)
+	if !ok {
+		return ctx.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"errors": models.ErrAuthUser.Error(),
+		})
+	}
+
+	res, err := h.manager.List(ctx.Context(), userID)
+	if err != nil {
+		return h.error(ctx, err)
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(convertSchedules(res))
+}
+
+func (h *Handler) Runs(ctx *fiber.Ctx) error {
+	userID, ok := ctx.Context().Value("UserID").(string)
+	if !ok {
+		return ctx.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"errors": models.ErrAuthUser.Error(),
+		})
+	}
+
+	res, err := h.manager.Runs(ctx.Context(), userID, ctx.Params("id"))
+	if err != nil {
+		return h.error(ctx, err)
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(convertRuns(res))
+}
+
+func (h *Handler) Pause(ctx *fiber.Ctx) error {
+	return h.change(ctx, h.manager.Pause)
+}
+
+func (h *Handler) Resume(ctx *fiber.Ctx) error {
+	return h.change(ctx, h.manager.Resume)
+}
+
+func (h *Handler) Cancel(ctx *fiber.Ctx) error {
+	return h.change(ctx, h.manager.Cancel)
+}
+
+func (h *Handler) change(ctx *fiber.Ctx, action func(ctx context.Context, userID, id string) (models.ScheduledTransfer, error)) error {
+	userID, ok := ctx.Context().Value("UserID").(string)
+	if !ok {
+		return ctx.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"errors": models.ErrAuthUser.Error(),
+		})
+	}
+
+	res, err := action(ctx.Context(), userID, ctx.Params("id"))
+	if err != nil {
+		return h.error(ctx, err)
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(convertSchedule(res))
+}
+
+func (h *Handler) error(ctx *fiber.Ctx, err error) error {
+	switch {
+	case errors.Is(err, models.ErrScheduleNotFound):
+		return ctx.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"errors": err.Error(),
+		})
+	case errors.Is(err, schedule.ErrScheduleClosed):
+		return ctx.Status(fiber.StatusConflict).JSON(fiber.Map{
+			"errors": err.Error(),
+		})
+	case errors.Is(err, schedule.ErrInvalidSchedule),
+		errors.Is(err, schedule.ErrRunAtInPast),
+		errors.Is(err, schedule.ErrUnknownCategory),
+		errors.Is(err, schedule.ErrSameUser),
+		errors.Is(err, schedule.ErrRecipientNotFound):
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"errors": err.Error(),
+		})
+	default:
+		return ctx.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"errors": err.Error(),
+		})
+	}
+}
@@ -0,0 +1,103 @@
+package schedule
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+
+	"AvitoTask/internal/models"
+)
+
+type createRequest struct {
+	ToUser    string    `json:"toUser" validate:"required"`
+	Amount    int64     `json:"amount" validate:"required,min=1"`
+	Message   string    `json:"message" validate:"max=255"`
+	Category  string    `json:"category"`
+	RunAt     time.Time `json:"runAt" validate:"required"`
+	Repeat    string    `json:"repeat"`
+	OnFailure string    `json:"onFailure"`
+}
+
+type scheduleOutput struct {
+	ID        string    `json:"id"`
+	ToUser    string    `json:"toUser"`
+	Amount    int64     `json:"amount"`
+	Message   string    `json:"message,omitempty"`
+	Category  string    `json:"category,omitempty"`
+	Repeat    string    `json:"repeat,omitempty"`
+	OnFailure string    `json:"onFailure"`
+	Status    string    `json:"status"`
+	NextRunAt time.Time `json:"nextRunAt"`
+	Attempts  int       `json:"attempts,omitempty"`
+	LastError string    `json:"lastError,omitempty"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+type runOutput struct {
+	RunAt      time.Time `json:"runAt"`
+	ExecutedAt time.Time `json:"executedAt"`
+	Status     string    `json:"status"`
+	Error      string    `json:"error,omitempty"`
+}
+
+func (r createRequest) draft() models.ScheduledTransfer {
+	return models.ScheduledTransfer{
+		ToUser:    r.ToUser,
+		Amount:    r.Amount,
+		Message:   r.Message,
+		Category:  r.Category,
+		RunAt:     r.RunAt,
+		Repeat:    r.Repeat,
+		OnFailure: r.OnFailure,
+	}
+}
+
+func convertSchedule(s models.ScheduledTransfer) scheduleOutput {
+	return scheduleOutput{
+		ID:        s.ID,
+		ToUser:    s.ToUser,
+		Amount:    s.Amount,
+		Message:   s.Message,
+		Category:  s.Category,
+		Repeat:    s.Repeat,
+		OnFailure: s.OnFailure,
+		Status:    s.Status,
+		NextRunAt: s.DueAt,
+		Attempts:  s.Attempts,
+		LastError: s.LastError,
+		CreatedAt: s.CreatedAt,
+	}
+}
+
+func convertSchedules(schedules []models.ScheduledTransfer) []scheduleOutput {
+	out := make([]scheduleOutput, 0, len(schedules))
+	for _, s := range schedules {
+		out = append(out, convertSchedule(s))
+	}
+
+	return out
+}
+
+func convertRuns(runs []models.ScheduledRun) []runOutput {
+	out := make([]runOutput, 0, len(runs))
+	for _, r := range runs {
+		out = append(out, runOutput{
+			RunAt:      r.RunAt,
+			ExecutedAt: r.ExecutedAt,
+			Status:     r.Status,
+			Error:      r.Error,
+		})
+	}
+
+	return out
+}
+
+func validate(r createRequest) error {
+	validate := validator.New()
+	if err := validate.Struct(r); err != nil {
+		return fmt.Errorf("%s: %w", models.ErrValidation, err)
+	}
+
+	return nil
+}
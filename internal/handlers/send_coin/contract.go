@@ -1,7 +1,19 @@
 package send_coin
 
-import "context"
+import (
+	"context"
+
+	"AvitoTask/internal/models"
+)
 
 type sender interface {
-	SendCoin(ctx context.Context, fromUser, toUser string, amount int64) error
+	SendCoin(ctx context.Context, fromUser, toUser string, amount int64, message, category string) (models.Transfer, error)
+	SendBatch(ctx context.Context, fromUser string, recipients []models.BatchRecipient, message, category string) ([]models.BatchResult, error)
+	SendPending(ctx context.Context, fromUser, toUser string, amount int64, message, category string) (models.Transfer, error)
+	PendingTransfers(ctx context.Context, userID string) ([]models.TransactionItem, error)
+	Accept(ctx context.Context, userID, transferID string) error
+	Decline(ctx context.Context, userID, transferID string) error
+	Limits(ctx context.Context, userID, toUser string) ([]models.PeriodLimits, error)
+	SetUserLimit(ctx context.Context, adminID, username, period string, p models.LimitPolicy) error
+	ResetUserLimit(ctx context.Context, username, period string) error
 }
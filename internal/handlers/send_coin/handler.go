@@ -1,6 +1,7 @@
 package send_coin
 
 import (
+	"context"
 	"errors"
 
 	"github.com/gofiber/fiber/v2"
@@ -40,17 +41,241 @@ func (h *Handler) Handle(ctx *fiber.Ctx) error {
 		})
 	}
 
-	err := h.sender.SendCoin(ctx.Context(), fromUser, req.ToUser, req.Amount)
-	if errors.Is(err, send_coin.ErrNotEnoughCoins) || errors.Is(err, send_coin.ErrSameUser) {
+	if req.Pending {
+		return h.pending(ctx, fromUser, req)
+	}
+
+	_, err := h.sender.SendCoin(ctx.Context(), fromUser, req.ToUser, req.Amount, req.Message, req.Category)
+	if errors.Is(err, models.ErrIdempotencyKeyReused) {
+		return ctx.Status(fiber.StatusUnprocessableEntity).JSON(fiber.Map{
+			"errors": err.Error(),
+		})
+	}
+	if errors.Is(err, send_coin.ErrNotEnoughCoins) || errors.Is(err, send_coin.ErrSameUser) ||
+		errors.Is(err, send_coin.ErrRecipientNotFound) ||
+		errors.Is(err, send_coin.ErrUnknownCategory) || errors.Is(err, models.ErrTransferLimitExceeded) {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"errors": err.Error(),
+		})
+	}
+	if err != nil {
+		return ctx.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"errors": err.Error(),
+		})
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(fiber.Map{})
+}
+
+// Batch - перевод нескольким получателям сразу; проходит целиком или не проходит вовсе
+func (h *Handler) Batch(ctx *fiber.Ctx) error {
+	fromUser, ok := ctx.Context().Value("UserID").(string)
+	if !ok {
+		return ctx.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"errors": models.ErrAuthUser.Error(),
+		})
+	}
+
+	var req batchRequest
+	if err := ctx.BodyParser(&req); err != nil {
 		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
 			"errors": err.Error(),
 		})
 	}
+
+	if err := validate(req); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"errors": err.Error(),
+		})
+	}
+
+	res, err := h.sender.SendBatch(ctx.Context(), fromUser, req.recipients(), req.Message, req.Category)
+	if errors.Is(err, send_coin.ErrBatchRejected) {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"errors":  err.Error(),
+			"results": convertBatch(res),
+		})
+	}
+	if errors.Is(err, models.ErrIdempotencyKeyReused) {
+		return ctx.Status(fiber.StatusUnprocessableEntity).JSON(fiber.Map{
+			"errors": err.Error(),
+		})
+	}
+	if errors.Is(err, send_coin.ErrNotEnoughCoins) ||
+		errors.Is(err, send_coin.ErrUnknownCategory) ||
+		errors.Is(err, send_coin.ErrEmptyBatch) ||
+		errors.Is(err, models.ErrTransferLimitExceeded) {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"errors": err.Error(),
+		})
+	}
+	if err != nil {
+		return ctx.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"errors": err.Error(),
+		})
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(fiber.Map{
+		"results": convertBatch(res),
+	})
+}
+
+// pending - отложенный перевод; в ответе id перевода, по которому получатель принимает или отклоняет его
+func (h *Handler) pending(ctx *fiber.Ctx, fromUser string, req request) error {
+	t, err := h.sender.SendPending(ctx.Context(), fromUser, req.ToUser, req.Amount, req.Message, req.Category)
+	if errors.Is(err, models.ErrIdempotencyKeyReused) {
+		return ctx.Status(fiber.StatusUnprocessableEntity).JSON(fiber.Map{
+			"errors": err.Error(),
+		})
+	}
+	if errors.Is(err, send_coin.ErrNotEnoughCoins) || errors.Is(err, send_coin.ErrSameUser) ||
+		errors.Is(err, send_coin.ErrRecipientNotFound) ||
+		errors.Is(err, send_coin.ErrUnknownCategory) || errors.Is(err, models.ErrTransferLimitExceeded) {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"errors": err.Error(),
+		})
+	}
+	if err != nil {
+		return ctx.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"errors": err.Error(),
+		})
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(convertPending(t))
+}
+
+// Pending - входящие переводы, которые ждут ответа пользователя
+func (h *Handler) Pending(ctx *fiber.Ctx) error {
+	userID, ok := ctx.Context().Value("UserID").(string)
+	if !ok {
+		return ctx.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"errors": models.ErrAuthUser.Error(),
+		})
+	}
+
+	res, err := h.sender.PendingTransfers(ctx.Context(), userID)
+	if err != nil {
+		return ctx.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"errors": err.Error(),
+		})
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(convertIncoming(res))
+}
+
+func (h *Handler) Accept(ctx *fiber.Ctx) error {
+	return h.answer(ctx, h.sender.Accept)
+}
+
+func (h *Handler) Decline(ctx *fiber.Ctx) error {
+	return h.answer(ctx, h.sender.Decline)
+}
+
+func (h *Handler) answer(ctx *fiber.Ctx, action func(ctx context.Context, userID, transferID string) error) error {
+	userID, ok := ctx.Context().Value("UserID").(string)
+	if !ok {
+		return ctx.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"errors": models.ErrAuthUser.Error(),
+		})
+	}
+
+	err := action(ctx.Context(), userID, ctx.Params("id"))
+	switch {
+	case errors.Is(err, models.ErrTransferNotFound):
+		return ctx.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"errors": err.Error(),
+		})
+	case errors.Is(err, models.ErrTransferNotPending) || errors.Is(err, send_coin.ErrPendingExpired):
+		return ctx.Status(fiber.StatusConflict).JSON(fiber.Map{
+			"errors": err.Error(),
+		})
+	case err != nil:
+		return ctx.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"errors": err.Error(),
+		})
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(fiber.Map{})
+}
+
+// Limits - ограничения на переводы пользователя и их остаток на сегодня и на текущий месяц;
+// ?toUser= добавляет остаток ограничения на переводы этому получателю
+func (h *Handler) Limits(ctx *fiber.Ctx) error {
+	userID, ok := ctx.Context().Value("UserID").(string)
+	if !ok {
+		return ctx.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"errors": models.ErrAuthUser.Error(),
+		})
+	}
+
+	toUser := ctx.Query("toUser")
+	res, err := h.sender.Limits(ctx.Context(), userID, toUser)
+	if errors.Is(err, send_coin.ErrRecipientNotFound) {
+		return ctx.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"errors": err.Error(),
+		})
+	}
 	if err != nil {
 		return ctx.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 			"errors": err.Error(),
 		})
 	}
 
+	return ctx.Status(fiber.StatusOK).JSON(convertLimits(res, toUser != ""))
+}
+
+// SetUserLimit - задаёт пользователю личные ограничения на переводы за период вместо общих
+func (h *Handler) SetUserLimit(ctx *fiber.Ctx) error {
+	adminID, ok := ctx.Context().Value("UserID").(string)
+	if !ok {
+		return ctx.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"errors": models.ErrAuthUser.Error(),
+		})
+	}
+
+	var req limitRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"errors": err.Error(),
+		})
+	}
+
+	if err := validate(req); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"errors": err.Error(),
+		})
+	}
+
+	err := h.sender.SetUserLimit(ctx.Context(), adminID, ctx.Params("username"), ctx.Params("period"), req.policy())
+	if err != nil {
+		return h.limitError(ctx, err)
+	}
+
 	return ctx.Status(fiber.StatusOK).JSON(fiber.Map{})
 }
+
+// ResetUserLimit - возвращает пользователю общие ограничения на переводы за период
+func (h *Handler) ResetUserLimit(ctx *fiber.Ctx) error {
+	if err := h.sender.ResetUserLimit(ctx.Context(), ctx.Params("username"), ctx.Params("period")); err != nil {
+		return h.limitError(ctx, err)
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(fiber.Map{})
+}
+
+func (h *Handler) limitError(ctx *fiber.Ctx, err error) error {
+	switch {
+	case errors.Is(err, models.ErrUserNotFound):
+		return ctx.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"errors": err.Error(),
+		})
+	case errors.Is(err, send_coin.ErrUnknownLimitPeriod):
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"errors": err.Error(),
+		})
+	default:
+		return ctx.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"errors": err.Error(),
+		})
+	}
+}
@@ -2,6 +2,7 @@ package send_coin
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/go-playground/validator/v10"
 
@@ -11,9 +12,150 @@ import (
 type request struct {
 	ToUser string `json:"toUser" validate:"required"`
 	Amount int64  `json:"amount" validate:"required,min=1"`
+	// Message и Category - необязательные сообщение и категория перевода (thanks, help, birthday, teamwork, other)
+	Message  string `json:"message" validate:"max=255"`
+	Category string `json:"category"`
+	// Pending - отложенный перевод: монеты ждут на эскроу, пока получатель не примет перевод
+	Pending bool `json:"pending"`
 }
 
-func validate(r request) error {
+type pendingOutput struct {
+	TransactionID string    `json:"transactionId"`
+	Status        string    `json:"status"`
+	ExpiresAt     time.Time `json:"expiresAt"`
+}
+
+type incomingTransfer struct {
+	TransactionID string    `json:"transactionId"`
+	FromUser      string    `json:"fromUser"`
+	Amount        int64     `json:"amount"`
+	Message       string    `json:"message,omitempty"`
+	Category      string    `json:"category,omitempty"`
+	CreatedAt     time.Time `json:"createdAt"`
+	ExpiresAt     time.Time `json:"expiresAt"`
+}
+
+type batchRequest struct {
+	Recipients []batchRecipient `json:"recipients" validate:"required,min=1,max=100,dive"`
+	Message    string           `json:"message" validate:"max=255"`
+	Category   string           `json:"category"`
+}
+
+type batchRecipient struct {
+	ToUser string `json:"toUser" validate:"required"`
+	Amount int64  `json:"amount" validate:"required,min=1"`
+}
+
+type batchResult struct {
+	ToUser        string `json:"toUser"`
+	Amount        int64  `json:"amount"`
+	Status        string `json:"status"`
+	TransactionID string `json:"transactionId,omitempty"`
+}
+
+func (r batchRequest) recipients() []models.BatchRecipient {
+	res := make([]models.BatchRecipient, 0, len(r.Recipients))
+	for _, rc := range r.Recipients {
+		res = append(res, models.BatchRecipient{ToUser: rc.ToUser, Amount: rc.Amount})
+	}
+	return res
+}
+
+func convertBatch(results []models.BatchResult) []batchResult {
+	out := make([]batchResult, 0, len(results))
+	for _, r := range results {
+		out = append(out, batchResult{
+			ToUser:        r.ToUser,
+			Amount:        r.Amount,
+			Status:        r.Status,
+			TransactionID: r.TransactionID,
+		})
+	}
+	return out
+}
+
+func convertPending(t models.Transfer) pendingOutput {
+	out := pendingOutput{TransactionID: t.ID, Status: t.Status}
+	if t.ExpiresAt != nil {
+		out.ExpiresAt = *t.ExpiresAt
+	}
+	return out
+}
+
+func convertIncoming(items []models.TransactionItem) []incomingTransfer {
+	out := make([]incomingTransfer, 0, len(items))
+	for _, t := range items {
+		in := incomingTransfer{
+			TransactionID: t.ID,
+			FromUser:      t.FromUsername,
+			Amount:        t.Amount,
+			Message:       t.Message,
+			Category:      t.Category,
+			CreatedAt:     t.CreatedAt,
+		}
+		if t.ExpiresAt != nil {
+			in.ExpiresAt = *t.ExpiresAt
+		}
+		out = append(out, in)
+	}
+	return out
+}
+
+// limitRequest - личная политика периода; 0 - без ограничения
+type limitRequest struct {
+	Amount          int64 `json:"amount" validate:"min=0"`
+	Count           int64 `json:"count" validate:"min=0"`
+	RecipientAmount int64 `json:"recipientAmount" validate:"min=0"`
+}
+
+func (r limitRequest) policy() models.LimitPolicy {
+	return models.LimitPolicy{Amount: r.Amount, Count: r.Count, RecipientAmount: r.RecipientAmount}
+}
+
+// limitOutput - ограничение, израсходованная часть и остаток; limit и remaining равны null, если ограничения нет
+type limitOutput struct {
+	Limit     *int64 `json:"limit"`
+	Used      int64  `json:"used"`
+	Remaining *int64 `json:"remaining"`
+}
+
+type periodLimitsOutput struct {
+	Since           time.Time    `json:"since"`
+	Personal        bool         `json:"personal"`
+	Amount          limitOutput  `json:"amount"`
+	Count           limitOutput  `json:"count"`
+	RecipientAmount *limitOutput `json:"recipientAmount,omitempty"`
+}
+
+func newLimitOutput(limit, used int64) limitOutput {
+	out := limitOutput{Used: used}
+	if limit > 0 {
+		remaining := max(limit-used, 0)
+		out.Limit, out.Remaining = &limit, &remaining
+	}
+	return out
+}
+
+// convertLimits - ограничения по периодам; recipientAmount отдаётся, только если спросили про конкретного получателя
+func convertLimits(limits []models.PeriodLimits, withRecipient bool) map[string]periodLimitsOutput {
+	out := make(map[string]periodLimitsOutput, len(limits))
+	for _, l := range limits {
+		p := periodLimitsOutput{
+			Since:    l.Since,
+			Personal: l.Personal,
+			Amount:   newLimitOutput(l.Policy.Amount, l.Used.Amount),
+			Count:    newLimitOutput(l.Policy.Count, l.Used.Count),
+		}
+		if withRecipient {
+			recipient := newLimitOutput(l.Policy.RecipientAmount, l.Used.RecipientAmount)
+			p.RecipientAmount = &recipient
+		}
+		out[l.Period] = p
+	}
+	return out
+}
+
+func validate(r any) error {
 	validate := validator.New()
 	if err := validate.Struct(r); err != nil {
 		return fmt.Errorf("%s: %w", models.ErrValidation, err)
@@ -0,0 +1,7 @@
+package send_item
+
+import "context"
+
+type sender interface {
+	SendItem(ctx context.Context, fromUser, toUser, item, variant string, quantity int64) error
+}
@@ -0,0 +1,25 @@
+package send_item
+
+import (
+	"fmt"
+
+	"github.com/go-playground/validator/v10"
+
+	"AvitoTask/internal/models"
+)
+
+type request struct {
+	ToUser   string `json:"toUser" validate:"required"`
+	Item     string `json:"item" validate:"required"`
+	Variant  string `json:"variant"`
+	Quantity int64  `json:"quantity" validate:"required,min=1"`
+}
+
+func validate(r request) error {
+	validate := validator.New()
+	if err := validate.Struct(r); err != nil {
+		return fmt.Errorf("%s: %w", models.ErrValidation, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,12 @@
+package voucher
+
+import (
+	"context"
+
+	"AvitoTask/internal/models"
+)
+
+type manager interface {
+	IssueVoucher(ctx context.Context, userID, orderID string) (string, error)
+	RedeemVoucher(ctx context.Context, staffID, token string) (models.Order, error)
+}
@@ -0,0 +1,133 @@
+package voucher
+
+import (
+	"crypto/ed25519"
+	"encoding/base64"
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/skip2/go-qrcode"
+
+	"AvitoTask/internal/models"
+	"AvitoTask/internal/usecase/voucher"
+)
+
+type Handler struct {
+	manager   manager
+	publicKey ed25519.PublicKey
+}
+
+func NewHandler(m manager, publicKey ed25519.PublicKey) *Handler {
+	return &Handler{
+		manager:   m,
+		publicKey: publicKey,
+	}
+}
+
+// Issue - талон на получение заказа; с ?format=png отдаётся картинкой с QR-кодом
+func (h *Handler) Issue(ctx *fiber.Ctx) error {
+	userID, ok := ctx.Context().Value("UserID").(string)
+	if !ok {
+		return ctx.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"errors": models.ErrAuthUser.Error(),
+		})
+	}
+
+	orderID := ctx.Params("id")
+	if _, err := uuid.Parse(orderID); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"errors": "order id must be a valid uuid",
+		})
+	}
+
+	var query issueQuery
+	if err := ctx.QueryParser(&query); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"errors": err.Error(),
+		})
+	}
+
+	if err := validate(query); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"errors": err.Error(),
+		})
+	}
+
+	token, err := h.manager.IssueVoucher(ctx.Context(), userID, orderID)
+	if err != nil {
+		return h.error(ctx, err)
+	}
+
+	if query.Format != "png" {
+		return ctx.Status(fiber.StatusOK).JSON(voucherOutput{Token: token})
+	}
+
+	png, err := qrcode.Encode(token, qrcode.Medium, qrSize)
+	if err != nil {
+		return ctx.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"errors": err.Error(),
+		})
+	}
+
+	ctx.Set(fiber.HeaderContentType, "image/png")
+	return ctx.Status(fiber.StatusOK).Send(png)
+}
+
+func (h *Handler) Redeem(ctx *fiber.Ctx) error {
+	staffID, ok := ctx.Context().Value("UserID").(string)
+	if !ok {
+		return ctx.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"errors": models.ErrAuthUser.Error(),
+		})
+	}
+
+	var req redeemRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"errors": err.Error(),
+		})
+	}
+
+	if err := validate(req); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"errors": err.Error(),
+		})
+	}
+
+	o, err := h.manager.RedeemVoucher(ctx.Context(), staffID, req.Token)
+	if err != nil {
+		return h.error(ctx, err)
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(convertOrder(o))
+}
+
+// PublicKey - открытый ключ для проверки талонов без доступа к серверу
+func (h *Handler) PublicKey(ctx *fiber.Ctx) error {
+	return ctx.Status(fiber.StatusOK).JSON(keyOutput{
+		Algorithm: "EdDSA",
+		PublicKey: base64.StdEncoding.EncodeToString(h.publicKey),
+	})
+}
+
+func (h *Handler) error(ctx *fiber.Ctx, err error) error {
+	switch {
+	case errors.Is(err, models.ErrOrderNotFound), errors.Is(err, models.ErrVoucherNotFound):
+		return ctx.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"errors": err.Error(),
+		})
+	case errors.Is(err, models.ErrVoucherInvalid):
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"errors": err.Error(),
+		})
+	case errors.Is(err, models.ErrVoucherConsumed), errors.Is(err, voucher.ErrOrderNotReady):
+		return ctx.Status(fiber.StatusConflict).JSON(fiber.Map{
+			"errors": err.Error(),
+		})
+	default:
+		return ctx.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"errors": err.Error(),
+		})
+	}
+}
@@ -0,0 +1,61 @@
+package voucher
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+
+	"AvitoTask/internal/models"
+)
+
+// qrSize - сторона PNG с QR-кодом талона в пикселях
+const qrSize = 256
+
+type issueQuery struct {
+	Format string `query:"format" validate:"omitempty,oneof=json png"`
+}
+
+type redeemRequest struct {
+	Token string `json:"token" validate:"required"`
+}
+
+type voucherOutput struct {
+	Token string `json:"token"`
+}
+
+type keyOutput struct {
+	Algorithm string `json:"alg"`
+	PublicKey string `json:"publicKey"`
+}
+
+type orderOutput struct {
+	OrderID   string    `json:"orderId"`
+	UserID    string    `json:"userId"`
+	Item      string    `json:"item"`
+	Variant   string    `json:"variant,omitempty"`
+	Quantity  int64     `json:"quantity"`
+	Status    string    `json:"status"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+func convertOrder(o models.Order) orderOutput {
+	return orderOutput{
+		OrderID:   o.ID,
+		UserID:    o.UserID,
+		Item:      o.Item,
+		Variant:   o.Variant,
+		Quantity:  o.Quantity,
+		Status:    o.Status,
+		CreatedAt: o.CreatedAt,
+	}
+}
+
+func validate(r any) error {
+	validate := validator.New()
+	if err := validate.Struct(r); err != nil {
+		return fmt.Errorf("%s: %w", models.ErrValidation, err)
+	}
+
+	return nil
+}
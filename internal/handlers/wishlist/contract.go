@@ -0,0 +1,15 @@
+package wishlist
+
+import (
+	"context"
+
+	"AvitoTask/internal/models"
+)
+
+type manager interface {
+	AddItem(ctx context.Context, userID, item string) ([]models.WishlistEntry, error)
+	RemoveItem(ctx context.Context, userID, item string) ([]models.WishlistEntry, error)
+	GetWishlist(ctx context.Context, userID string) ([]models.WishlistEntry, error)
+	GetNotifications(ctx context.Context, userID string, unreadOnly bool) ([]models.Notification, error)
+	MarkRead(ctx context.Context, userID string) (int64, error)
+}
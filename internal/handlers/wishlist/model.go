@@ -0,0 +1,73 @@
+package wishlist
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+
+	"AvitoTask/internal/models"
+)
+
+type addRequest struct {
+	Item string `json:"item" validate:"required"`
+}
+
+type notificationsQuery struct {
+	Unread bool `query:"unread"`
+}
+
+type entryOutput struct {
+	Item         string `json:"item"`
+	Price        int64  `json:"price"`
+	Available    bool   `json:"available"`
+	MissingCoins int64  `json:"missingCoins"`
+}
+
+type notificationOutput struct {
+	ID        string     `json:"id"`
+	Kind      string     `json:"kind"`
+	Item      string     `json:"item"`
+	Detail    string     `json:"detail"`
+	CreatedAt time.Time  `json:"createdAt"`
+	ReadAt    *time.Time `json:"readAt"`
+}
+
+func convertWishlist(entries []models.WishlistEntry) []entryOutput {
+	out := make([]entryOutput, 0, len(entries))
+	for _, e := range entries {
+		out = append(out, entryOutput{
+			Item:         e.Item,
+			Price:        e.Price,
+			Available:    e.Available,
+			MissingCoins: e.MissingCoins,
+		})
+	}
+
+	return out
+}
+
+func convertNotifications(notifications []models.Notification) []notificationOutput {
+	out := make([]notificationOutput, 0, len(notifications))
+	for _, n := range notifications {
+		out = append(out, notificationOutput{
+			ID:        n.ID,
+			Kind:      n.Kind,
+			Item:      n.Item,
+			Detail:    n.Detail,
+			CreatedAt: n.CreatedAt,
+			ReadAt:    n.ReadAt,
+		})
+	}
+
+	return out
+}
+
+func validate(r addRequest) error {
+	validate := validator.New()
+	if err := validate.Struct(r); err != nil {
+		return fmt.Errorf("%s: %w", models.ErrValidation, err)
+	}
+
+	return nil
+}
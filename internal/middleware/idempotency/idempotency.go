@@ -0,0 +1,37 @@
+package idempotency
+
+import (
+	"net/http"
+
+	"github.com/gofiber/fiber/v2"
+
+	"AvitoTask/internal/models"
+)
+
+// New - middleware для мутирующих маршрутов: если в запросе есть заголовок Idempotency-Key, кладёт
+// в контекст models.IdempotencyKey с хэшем запроса. Ставится после проверки токена
+func New() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		key := c.Get(models.IdempotencyKeyHeader)
+		if key == "" {
+			return c.Next()
+		}
+
+		if len(key) > models.MaxIdempotencyKeyLength {
+			return c.Status(http.StatusBadRequest).JSON(fiber.Map{
+				"errors": "idempotency key is too long",
+			})
+		}
+
+		userID, ok := c.Context().Value("UserID").(string)
+		if !ok {
+			return c.Status(http.StatusUnauthorized).JSON(fiber.Map{
+				"errors": models.ErrAuthUser.Error(),
+			})
+		}
+
+		c.Locals(models.IdempotencyKeyLocal, models.NewIdempotencyKey(userID, key, c.Method(), c.OriginalURL(), c.Body()))
+
+		return c.Next()
+	}
+}
@@ -0,0 +1,84 @@
+package jwt
+
+import (
+	"crypto/ed25519"
+	"crypto/sha256"
+	"fmt"
+
+	"github.com/golang-jwt/jwt/v4"
+
+	"AvitoTask/internal/models"
+)
+
+// voucherClaims - полезная нагрузка талона; короткие имена полей держат QR-код компактным
+type voucherClaims struct {
+	OrderID string `json:"oid"`
+	Item    string `json:"itm"`
+	Variant string `json:"var,omitempty"`
+	jwt.RegisteredClaims
+}
+
+// voucherKey - ключ подписи талонов выводится из того же секрета, что и ключ сессионных токенов,
+// так что отдельный секрет в конфиге не нужен; проверка при этом требует только открытый ключ
+func voucherKey(secretKey string) ed25519.PrivateKey {
+	seed := sha256.Sum256([]byte("voucher:" + secretKey))
+	return ed25519.NewKeyFromSeed(seed[:])
+}
+
+// VoucherPublicKey - открытый ключ для проверки талонов на стойке выдачи
+func (m *Middleware) VoucherPublicKey() ed25519.PublicKey {
+	return voucherKey(m.SecretKey).Public().(ed25519.PublicKey)
+}
+
+// SignVoucher - подписывает талон на получение заказа
+func (m *Middleware) SignVoucher(v models.Voucher) (string, error) {
+	claims := voucherClaims{
+		OrderID: v.OrderID,
+		Item:    v.Item,
+		Variant: v.Variant,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:       v.ID,
+			Subject:  v.UserID,
+			IssuedAt: jwt.NewNumericDate(v.IssuedAt),
+		},
+	}
+
+	token, err := jwt.NewWithClaims(jwt.SigningMethodEdDSA, claims).SignedString(voucherKey(m.SecretKey))
+	if err != nil {
+		return "", fmt.Errorf("failed to sign voucher %s: %w", v.ID, err)
+	}
+
+	return token, nil
+}
+
+// ParseVoucher - проверяет подпись талона ключом сервиса
+func (m *Middleware) ParseVoucher(token string) (models.Voucher, error) {
+	return ParseVoucher(token, m.VoucherPublicKey())
+}
+
+// ParseVoucher - проверяет подпись талона одним открытым ключом, без доступа к секрету и базе
+func ParseVoucher(token string, key ed25519.PublicKey) (models.Voucher, error) {
+	var claims voucherClaims
+	_, err := jwt.ParseWithClaims(token, &claims, func(token *jwt.Token) (interface{}, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodEd25519); !ok {
+			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+		}
+		return key, nil
+	})
+	if err != nil {
+		return models.Voucher{}, fmt.Errorf("%w: %v", models.ErrVoucherInvalid, err)
+	}
+
+	v := models.Voucher{
+		ID:      claims.ID,
+		OrderID: claims.OrderID,
+		UserID:  claims.Subject,
+		Item:    claims.Item,
+		Variant: claims.Variant,
+	}
+	if claims.IssuedAt != nil {
+		v.IssuedAt = claims.IssuedAt.UTC()
+	}
+
+	return v, nil
+}
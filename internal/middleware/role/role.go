@@ -0,0 +1,52 @@
+package role
+
+import (
+	"context"
+	"net/http"
+	"slices"
+
+	"github.com/gofiber/fiber/v2"
+
+	"AvitoTask/internal/models"
+)
+
+type roleGetter interface {
+	GetUserRole(ctx context.Context, userID string) (string, error)
+}
+
+type Middleware struct {
+	repo roleGetter
+}
+
+func NewMiddleware(repo roleGetter) *Middleware {
+	return &Middleware{
+		repo: repo,
+	}
+}
+
+// Require - пропускает запрос дальше, только если роль пользователя входит в roles
+func (m *Middleware) Require(roles ...string) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		userID, ok := c.Context().Value("UserID").(string)
+		if !ok {
+			return c.Status(http.StatusUnauthorized).JSON(fiber.Map{
+				"errors": models.ErrAuthUser.Error(),
+			})
+		}
+
+		userRole, err := m.repo.GetUserRole(c.Context(), userID)
+		if err != nil {
+			return c.Status(http.StatusForbidden).JSON(fiber.Map{
+				"errors": err.Error(),
+			})
+		}
+
+		if !slices.Contains(roles, userRole) {
+			return c.Status(http.StatusForbidden).JSON(fiber.Map{
+				"errors": "access denied for role " + userRole,
+			})
+		}
+
+		return c.Next()
+	}
+}
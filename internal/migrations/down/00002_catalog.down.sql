@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS "catalog_versions";
+DROP TABLE IF EXISTS "catalog";
+ALTER TABLE users DROP COLUMN IF EXISTS role;
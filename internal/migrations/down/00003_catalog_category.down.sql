@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS catalog_category_price_idx;
+ALTER TABLE catalog DROP COLUMN IF EXISTS category;
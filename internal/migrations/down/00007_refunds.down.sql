@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS orders_refund_of_uniq;
+ALTER TABLE orders DROP COLUMN IF EXISTS refund_of;
+ALTER TABLE orders DROP COLUMN IF EXISTS kind;
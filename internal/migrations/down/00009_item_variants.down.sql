@@ -0,0 +1,5 @@
+ALTER TABLE item_transfers DROP COLUMN IF EXISTS variant_sku;
+ALTER TABLE stock_movements DROP COLUMN IF EXISTS variant_sku;
+ALTER TABLE orders DROP COLUMN IF EXISTS variant_sku;
+ALTER TABLE inventory DROP COLUMN IF EXISTS variant_sku;
+DROP TABLE IF EXISTS "item_variants";
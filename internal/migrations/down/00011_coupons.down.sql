@@ -0,0 +1,4 @@
+ALTER TABLE orders DROP COLUMN IF EXISTS coupon_discount;
+ALTER TABLE orders DROP COLUMN IF EXISTS coupon_code;
+DROP TABLE IF EXISTS "coupon_redemptions";
+DROP TABLE IF EXISTS "coupons";
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS orders_user_item_idx;
+ALTER TABLE catalog DROP COLUMN IF EXISTS limit_period;
+ALTER TABLE catalog DROP COLUMN IF EXISTS purchase_limit;
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS order_status_changes;
+DROP INDEX IF EXISTS orders_status_idx;
+ALTER TABLE orders DROP COLUMN IF EXISTS status;
@@ -0,0 +1,3 @@
+ALTER TABLE orders DROP COLUMN IF EXISTS bundle_id;
+DROP TABLE IF EXISTS bundle_items;
+DROP TABLE IF EXISTS bundles;
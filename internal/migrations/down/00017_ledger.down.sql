@@ -0,0 +1,5 @@
+DROP TRIGGER IF EXISTS users_opening_grant ON users;
+DROP FUNCTION IF EXISTS ledger_opening_grant();
+DROP TABLE IF EXISTS ledger_postings;
+DROP FUNCTION IF EXISTS ledger_check_balanced();
+DROP TABLE IF EXISTS ledger_entries;
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS transactions_category_idx;
+
+ALTER TABLE transactions
+    DROP COLUMN IF EXISTS category,
+    DROP COLUMN IF EXISTS message;
@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS transactions_pending_to_idx;
+DROP INDEX IF EXISTS transactions_pending_idx;
+
+ALTER TABLE transactions
+    DROP COLUMN IF EXISTS resolved_at,
+    DROP COLUMN IF EXISTS expires_at,
+    DROP COLUMN IF EXISTS status;
@@ -0,0 +1 @@
+DROP INDEX IF EXISTS transactions_sender_created_idx;
@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS transactions_grant_idx;
+
+ALTER TABLE transactions
+    DROP COLUMN IF EXISTS grant_key;
+
+ALTER TABLE users
+    DROP COLUMN IF EXISTS active;
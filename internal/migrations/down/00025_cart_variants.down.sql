@@ -0,0 +1,10 @@
+DELETE FROM cart_items WHERE variant_sku <> '';
+
+ALTER TABLE cart_items
+    DROP CONSTRAINT cart_items_pkey;
+
+ALTER TABLE cart_items
+    ADD PRIMARY KEY (user_id, item_id);
+
+ALTER TABLE cart_items
+    DROP COLUMN IF EXISTS variant_sku;
@@ -0,0 +1,2 @@
+ALTER TABLE inventory
+    DROP CONSTRAINT IF EXISTS inventory_user_item_variant_key;
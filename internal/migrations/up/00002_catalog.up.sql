@@ -0,0 +1,43 @@
+ALTER TABLE users
+    ADD COLUMN role VARCHAR(32) NOT NULL DEFAULT 'user';
+
+CREATE TABLE catalog
+(
+    id         uuid PRIMARY KEY,
+    name       VARCHAR(255) UNIQUE NOT NULL,
+    price      INTEGER             NOT NULL CHECK (price > 0),
+    hidden     BOOLEAN             NOT NULL DEFAULT FALSE,
+    retired    BOOLEAN             NOT NULL DEFAULT FALSE,
+    version    INTEGER             NOT NULL DEFAULT 1,
+    updated_at TIMESTAMP                    DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE catalog_versions
+(
+    id         uuid PRIMARY KEY,
+    item_id    uuid REFERENCES catalog (id),
+    version    INTEGER      NOT NULL,
+    name       VARCHAR(255) NOT NULL,
+    price      INTEGER      NOT NULL,
+    hidden     BOOLEAN      NOT NULL,
+    retired    BOOLEAN      NOT NULL,
+    changed_by uuid REFERENCES users (id),
+    changed_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (item_id, version)
+);
+
+INSERT INTO catalog (id, name, price)
+VALUES (gen_random_uuid(), 't-shirt', 80),
+       (gen_random_uuid(), 'cup', 20),
+       (gen_random_uuid(), 'book', 50),
+       (gen_random_uuid(), 'pen', 10),
+       (gen_random_uuid(), 'powerbank', 200),
+       (gen_random_uuid(), 'hoody', 300),
+       (gen_random_uuid(), 'umbrella', 200),
+       (gen_random_uuid(), 'socks', 10),
+       (gen_random_uuid(), 'wallet', 50),
+       (gen_random_uuid(), 'pink-hoody', 500);
+
+INSERT INTO catalog_versions (id, item_id, version, name, price, hidden, retired)
+SELECT gen_random_uuid(), id, version, name, price, hidden, retired
+FROM catalog;
@@ -0,0 +1,8 @@
+ALTER TABLE catalog
+    ADD COLUMN category VARCHAR(64) NOT NULL DEFAULT 'merch';
+
+UPDATE catalog SET category = 'clothes' WHERE name IN ('t-shirt', 'hoody', 'pink-hoody', 'socks');
+UPDATE catalog SET category = 'accessories' WHERE name IN ('cup', 'powerbank', 'umbrella', 'wallet');
+UPDATE catalog SET category = 'stationery' WHERE name IN ('book', 'pen');
+
+CREATE INDEX catalog_category_price_idx ON catalog (category, price);
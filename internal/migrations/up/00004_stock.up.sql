@@ -0,0 +1,14 @@
+ALTER TABLE catalog
+    ADD COLUMN stock INTEGER CHECK (stock >= 0);
+
+CREATE TABLE stock_movements
+(
+    id         uuid PRIMARY KEY,
+    item_id    uuid REFERENCES catalog (id),
+    delta      INTEGER     NOT NULL,
+    reason     VARCHAR(32) NOT NULL,
+    user_id    uuid REFERENCES users (id),
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX stock_movements_item_idx ON stock_movements (item_id, created_at);
@@ -0,0 +1,8 @@
+CREATE TABLE cart_items
+(
+    user_id  uuid REFERENCES users (id),
+    item_id  uuid REFERENCES catalog (id),
+    quantity INTEGER NOT NULL CHECK (quantity > 0),
+    added_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (user_id, item_id)
+);
@@ -0,0 +1,13 @@
+CREATE TABLE orders
+(
+    id         uuid PRIMARY KEY,
+    user_id    uuid REFERENCES users (id),
+    item_id    uuid REFERENCES catalog (id),
+    item_name  VARCHAR(255) NOT NULL,
+    quantity   INTEGER      NOT NULL CHECK (quantity > 0),
+    unit_price INTEGER      NOT NULL CHECK (unit_price >= 0),
+    total      INTEGER      NOT NULL CHECK (total >= 0),
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX orders_user_created_idx ON orders (user_id, created_at DESC);
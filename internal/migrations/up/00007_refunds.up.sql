@@ -0,0 +1,5 @@
+ALTER TABLE orders
+    ADD COLUMN kind      VARCHAR(16) NOT NULL DEFAULT 'purchase',
+    ADD COLUMN refund_of uuid REFERENCES orders (id);
+
+CREATE UNIQUE INDEX orders_refund_of_uniq ON orders (refund_of) WHERE refund_of IS NOT NULL;
@@ -0,0 +1,12 @@
+CREATE TABLE item_transfers
+(
+    id           uuid PRIMARY KEY,
+    from_user_id uuid REFERENCES users (id),
+    to_user_id   uuid REFERENCES users (id),
+    item_type    VARCHAR(255) NOT NULL,
+    quantity     INTEGER      NOT NULL CHECK (quantity > 0),
+    created_at   TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX item_transfers_from_idx ON item_transfers (from_user_id);
+CREATE INDEX item_transfers_to_idx ON item_transfers (to_user_id);
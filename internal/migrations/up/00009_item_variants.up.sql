@@ -0,0 +1,25 @@
+CREATE TABLE item_variants
+(
+    id         uuid PRIMARY KEY,
+    item_id    uuid REFERENCES catalog (id),
+    sku        VARCHAR(64) UNIQUE NOT NULL,
+    size       VARCHAR(32) NOT NULL DEFAULT '',
+    color      VARCHAR(32) NOT NULL DEFAULT '',
+    price      INTEGER CHECK (price > 0),
+    stock      INTEGER CHECK (stock >= 0),
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX item_variants_item_idx ON item_variants (item_id);
+
+ALTER TABLE inventory
+    ADD COLUMN variant_sku VARCHAR(64) NOT NULL DEFAULT '';
+
+ALTER TABLE orders
+    ADD COLUMN variant_sku VARCHAR(64) NOT NULL DEFAULT '';
+
+ALTER TABLE stock_movements
+    ADD COLUMN variant_sku VARCHAR(64) NOT NULL DEFAULT '';
+
+ALTER TABLE item_transfers
+    ADD COLUMN variant_sku VARCHAR(64) NOT NULL DEFAULT '';
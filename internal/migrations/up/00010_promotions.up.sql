@@ -0,0 +1,22 @@
+CREATE TABLE promotions
+(
+    id         uuid PRIMARY KEY,
+    name       VARCHAR(255) NOT NULL,
+    item_id    uuid REFERENCES catalog (id),
+    category   VARCHAR(64),
+    percent    INTEGER CHECK (percent > 0 AND percent <= 100),
+    amount     INTEGER CHECK (amount > 0),
+    starts_at  TIMESTAMP    NOT NULL,
+    ends_at    TIMESTAMP    NOT NULL,
+    created_by uuid REFERENCES users (id),
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    CHECK ((item_id IS NULL) <> (category IS NULL)),
+    CHECK ((percent IS NULL) <> (amount IS NULL)),
+    CHECK (ends_at >= starts_at)
+);
+
+CREATE INDEX promotions_period_idx ON promotions (starts_at, ends_at);
+
+ALTER TABLE orders
+    ADD COLUMN promotion_id uuid REFERENCES promotions (id),
+    ADD COLUMN discount     INTEGER NOT NULL DEFAULT 0 CHECK (discount >= 0);
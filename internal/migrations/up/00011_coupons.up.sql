@@ -0,0 +1,31 @@
+CREATE TABLE coupons
+(
+    code            VARCHAR(64) PRIMARY KEY,
+    batch_id        uuid    NOT NULL,
+    percent         INTEGER CHECK (percent > 0 AND percent <= 100),
+    amount          INTEGER CHECK (amount > 0),
+    max_redemptions INTEGER NOT NULL CHECK (max_redemptions > 0),
+    redeemed        INTEGER NOT NULL DEFAULT 0 CHECK (redeemed >= 0 AND redeemed <= max_redemptions),
+    min_spend       INTEGER NOT NULL DEFAULT 0 CHECK (min_spend >= 0),
+    items           TEXT[]  NOT NULL DEFAULT '{}',
+    expires_at      TIMESTAMP,
+    created_by      uuid REFERENCES users (id),
+    created_at      TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    CHECK ((percent IS NULL) <> (amount IS NULL))
+);
+
+CREATE INDEX coupons_batch_idx ON coupons (batch_id);
+
+CREATE TABLE coupon_redemptions
+(
+    id         uuid PRIMARY KEY,
+    code       VARCHAR(64) REFERENCES coupons (code),
+    user_id    uuid REFERENCES users (id),
+    discount   INTEGER NOT NULL CHECK (discount >= 0),
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (code, user_id)
+);
+
+ALTER TABLE orders
+    ADD COLUMN coupon_code     VARCHAR(64) REFERENCES coupons (code),
+    ADD COLUMN coupon_discount INTEGER NOT NULL DEFAULT 0 CHECK (coupon_discount >= 0);
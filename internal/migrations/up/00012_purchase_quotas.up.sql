@@ -0,0 +1,10 @@
+ALTER TABLE catalog
+    ADD COLUMN purchase_limit INTEGER CHECK (purchase_limit > 0),
+    ADD COLUMN limit_period   VARCHAR(16) NOT NULL DEFAULT ''
+        CHECK (limit_period IN ('', 'month', 'quarter', 'year'));
+
+UPDATE catalog
+SET purchase_limit = 1
+WHERE name = 'pink-hoody';
+
+CREATE INDEX orders_user_item_idx ON orders (user_id, item_id, created_at);
@@ -0,0 +1,21 @@
+ALTER TABLE orders
+    ADD COLUMN status VARCHAR(16) NOT NULL DEFAULT ''
+        CHECK (status IN ('', 'placed', 'ready', 'handed_over', 'cancelled'));
+
+-- заказы, оформленные до появления выдачи, считаем уже выданными
+UPDATE orders
+SET status = 'handed_over'
+WHERE kind = 'purchase';
+
+CREATE INDEX orders_status_idx ON orders (status, created_at) WHERE status IN ('placed', 'ready');
+
+CREATE TABLE order_status_changes
+(
+    id         uuid PRIMARY KEY,
+    order_id   uuid        NOT NULL REFERENCES orders (id),
+    status     VARCHAR(16) NOT NULL,
+    changed_by uuid REFERENCES users (id),
+    changed_at TIMESTAMP   NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX order_status_changes_order_idx ON order_status_changes (order_id, changed_at);
@@ -0,0 +1,9 @@
+CREATE TABLE pickup_vouchers
+(
+    id          uuid PRIMARY KEY,
+    order_id    uuid UNIQUE NOT NULL REFERENCES orders (id),
+    user_id     uuid        NOT NULL REFERENCES users (id),
+    issued_at   TIMESTAMP   NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    consumed_at TIMESTAMP,
+    consumed_by uuid REFERENCES users (id)
+);
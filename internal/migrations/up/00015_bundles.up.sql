@@ -0,0 +1,22 @@
+CREATE TABLE bundles
+(
+    id         uuid PRIMARY KEY,
+    name       VARCHAR(255) UNIQUE NOT NULL,
+    price      INTEGER             NOT NULL CHECK (price > 0),
+    retired    BOOLEAN             NOT NULL DEFAULT FALSE,
+    created_by uuid REFERENCES users (id),
+    created_at TIMESTAMP           NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- состав набора не меняется после создания, поэтому возврат может опираться на него
+CREATE TABLE bundle_items
+(
+    bundle_id   uuid        NOT NULL REFERENCES bundles (id),
+    item_id     uuid        NOT NULL REFERENCES catalog (id),
+    variant_sku VARCHAR(64) NOT NULL DEFAULT '',
+    quantity    INTEGER     NOT NULL CHECK (quantity > 0),
+    PRIMARY KEY (bundle_id, item_id, variant_sku)
+);
+
+ALTER TABLE orders
+    ADD COLUMN bundle_id uuid REFERENCES bundles (id);
@@ -0,0 +1,22 @@
+CREATE TABLE wishlist
+(
+    user_id    uuid      NOT NULL REFERENCES users (id),
+    item_id    uuid      NOT NULL REFERENCES catalog (id),
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (user_id, item_id)
+);
+
+CREATE INDEX wishlist_item_idx ON wishlist (item_id);
+
+CREATE TABLE notifications
+(
+    id         uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id    uuid        NOT NULL REFERENCES users (id),
+    kind       VARCHAR(32) NOT NULL,
+    item_id    uuid REFERENCES catalog (id),
+    detail     TEXT        NOT NULL DEFAULT '',
+    created_at TIMESTAMP   NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    read_at    TIMESTAMP
+);
+
+CREATE INDEX notifications_user_idx ON notifications (user_id, created_at DESC);
@@ -0,0 +1,72 @@
+CREATE TABLE ledger_entries
+(
+    id         uuid PRIMARY KEY,
+    kind       VARCHAR(32) NOT NULL,
+    reference  VARCHAR(64) NOT NULL DEFAULT '',
+    created_at TIMESTAMP   NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE ledger_postings
+(
+    id       BIGSERIAL PRIMARY KEY,
+    entry_id uuid        NOT NULL REFERENCES ledger_entries (id),
+    account  VARCHAR(64) NOT NULL,
+    amount   BIGINT      NOT NULL CHECK (amount <> 0)
+);
+
+CREATE INDEX ledger_postings_entry_idx ON ledger_postings (entry_id);
+CREATE INDEX ledger_postings_account_idx ON ledger_postings (account);
+
+-- сумма проводок по записи должна быть нулевой; проверяется при коммите,
+-- чтобы записи можно было вставлять по одной проводке
+CREATE FUNCTION ledger_check_balanced() RETURNS trigger AS
+$$
+BEGIN
+    IF (SELECT SUM(amount) FROM ledger_postings WHERE entry_id = NEW.entry_id) <> 0 THEN
+        RAISE EXCEPTION 'ledger entry % is not balanced', NEW.entry_id;
+    END IF;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE CONSTRAINT TRIGGER ledger_postings_balanced
+    AFTER INSERT ON ledger_postings
+    DEFERRABLE INITIALLY DEFERRED
+    FOR EACH ROW
+EXECUTE FUNCTION ledger_check_balanced();
+
+-- стартовые монеты новых пользователей (DEFAULT в users.coins) начисляются со счёта эмиссии
+CREATE FUNCTION ledger_opening_grant() RETURNS trigger AS
+$$
+DECLARE
+    entry uuid := gen_random_uuid();
+BEGIN
+    IF NEW.coins > 0 THEN
+        INSERT INTO ledger_entries (id, kind, reference) VALUES (entry, 'grant', NEW.id::text);
+        INSERT INTO ledger_postings (entry_id, account, amount)
+        VALUES (entry, 'user:' || NEW.id, NEW.coins),
+               (entry, 'issuance', -NEW.coins);
+    END IF;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER users_opening_grant
+    AFTER INSERT ON users
+    FOR EACH ROW
+EXECUTE FUNCTION ledger_opening_grant();
+
+-- текущие балансы переносятся в журнал одной входящей записью на пользователя
+WITH opening AS (
+    INSERT INTO ledger_entries (id, kind, reference)
+        SELECT gen_random_uuid(), 'opening', id::text
+        FROM users
+        WHERE coins > 0
+        RETURNING id, reference)
+INSERT
+INTO ledger_postings (entry_id, account, amount)
+SELECT o.id, p.account, p.amount
+FROM opening o
+         JOIN users u ON u.id::text = o.reference
+         CROSS JOIN LATERAL (VALUES ('user:' || u.id, u.coins::BIGINT),
+                                    ('issuance', -u.coins::BIGINT)) AS p(account, amount);
@@ -0,0 +1,9 @@
+CREATE TABLE idempotency_keys
+(
+    user_id      uuid         NOT NULL REFERENCES users (id),
+    key          VARCHAR(255) NOT NULL,
+    request_hash CHAR(64)     NOT NULL,
+    response     JSONB,
+    created_at   TIMESTAMP    NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (user_id, key)
+);
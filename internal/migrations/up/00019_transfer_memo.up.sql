@@ -0,0 +1,5 @@
+ALTER TABLE transactions
+    ADD COLUMN message  VARCHAR(255) NOT NULL DEFAULT '',
+    ADD COLUMN category VARCHAR(32)  NOT NULL DEFAULT '';
+
+CREATE INDEX transactions_category_idx ON transactions (category) WHERE category <> '';
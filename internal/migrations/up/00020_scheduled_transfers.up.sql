@@ -0,0 +1,33 @@
+CREATE TABLE scheduled_transfers
+(
+    id         uuid PRIMARY KEY,
+    user_id    uuid         NOT NULL REFERENCES users (id),
+    to_user    VARCHAR(255) NOT NULL,
+    amount     BIGINT       NOT NULL CHECK (amount > 0),
+    message    VARCHAR(255) NOT NULL DEFAULT '',
+    category   VARCHAR(32)  NOT NULL DEFAULT '',
+    repeat     VARCHAR(16)  NOT NULL DEFAULT '',
+    on_failure VARCHAR(16)  NOT NULL DEFAULT 'retry',
+    status     VARCHAR(16)  NOT NULL DEFAULT 'active',
+    -- run_at - плановое время очередного запуска, due_at - когда его пробовать (отличается при повторе после ошибки)
+    run_at     TIMESTAMP    NOT NULL,
+    due_at     TIMESTAMP    NOT NULL,
+    attempts   INTEGER      NOT NULL DEFAULT 0,
+    last_error TEXT         NOT NULL DEFAULT '',
+    created_at TIMESTAMP    NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX scheduled_transfers_user_idx ON scheduled_transfers (user_id, created_at DESC);
+CREATE INDEX scheduled_transfers_due_idx ON scheduled_transfers (due_at) WHERE status = 'active';
+
+CREATE TABLE scheduled_transfer_runs
+(
+    id          uuid PRIMARY KEY,
+    schedule_id uuid        NOT NULL REFERENCES scheduled_transfers (id),
+    run_at      TIMESTAMP   NOT NULL,
+    executed_at TIMESTAMP   NOT NULL,
+    status      VARCHAR(16) NOT NULL,
+    error       TEXT        NOT NULL DEFAULT ''
+);
+
+CREATE INDEX scheduled_transfer_runs_schedule_idx ON scheduled_transfer_runs (schedule_id, executed_at DESC);
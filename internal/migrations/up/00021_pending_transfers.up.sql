@@ -0,0 +1,7 @@
+ALTER TABLE transactions
+    ADD COLUMN status      VARCHAR(16) NOT NULL DEFAULT 'completed',
+    ADD COLUMN expires_at  TIMESTAMP,
+    ADD COLUMN resolved_at TIMESTAMP;
+
+CREATE INDEX transactions_pending_idx ON transactions (expires_at) WHERE status = 'pending';
+CREATE INDEX transactions_pending_to_idx ON transactions (to_user_id) WHERE status = 'pending';
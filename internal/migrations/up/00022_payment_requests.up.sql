@@ -0,0 +1,19 @@
+CREATE TABLE payment_requests
+(
+    id             uuid PRIMARY KEY,
+    requester_id   uuid         NOT NULL REFERENCES users (id),
+    payer_id       uuid         NOT NULL REFERENCES users (id),
+    amount         BIGINT       NOT NULL CHECK (amount > 0),
+    note           VARCHAR(255) NOT NULL DEFAULT '',
+    status         VARCHAR(16)  NOT NULL DEFAULT 'open',
+    -- перевод, которым оплачен запрос
+    transaction_id uuid REFERENCES transactions (id),
+    expires_at     TIMESTAMP    NOT NULL,
+    created_at     TIMESTAMP    NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    resolved_at    TIMESTAMP,
+    CHECK (requester_id <> payer_id)
+);
+
+CREATE INDEX payment_requests_payer_idx ON payment_requests (payer_id, created_at DESC) WHERE status = 'open';
+CREATE INDEX payment_requests_requester_idx ON payment_requests (requester_id, created_at DESC);
+CREATE INDEX payment_requests_expires_idx ON payment_requests (expires_at) WHERE status = 'open';
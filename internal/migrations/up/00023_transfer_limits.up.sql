@@ -0,0 +1,2 @@
+-- суммы и число переводов пользователя за день и месяц считаются по этому индексу
+CREATE INDEX transactions_sender_created_idx ON transactions (from_user_id, created_at);
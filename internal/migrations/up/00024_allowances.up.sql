@@ -0,0 +1,10 @@
+-- неактивные пользователи не получают периодических начислений
+ALTER TABLE users
+    ADD COLUMN active BOOLEAN NOT NULL DEFAULT TRUE;
+
+-- начисления пособий - системные переводы без отправителя; grant_key - пособие и период,
+-- по одному начислению на пользователя за период
+ALTER TABLE transactions
+    ADD COLUMN grant_key VARCHAR(64);
+
+CREATE UNIQUE INDEX transactions_grant_idx ON transactions (to_user_id, grant_key);
@@ -0,0 +1,9 @@
+-- вариант входит в ключ корзины: разные варианты одной позиции - разные строки
+ALTER TABLE cart_items
+    ADD COLUMN variant_sku VARCHAR(64) NOT NULL DEFAULT '';
+
+ALTER TABLE cart_items
+    DROP CONSTRAINT cart_items_pkey;
+
+ALTER TABLE cart_items
+    ADD PRIMARY KEY (user_id, item_id, variant_sku);
@@ -0,0 +1,22 @@
+-- у пользователя одна строка на позицию и вариант: сначала сливаем дубликаты, затем вешаем уникальный ключ,
+-- по которому пополнение инвентаря делает upsert
+WITH totals AS (
+    SELECT DISTINCT ON (user_id, item_type, variant_sku) id,
+           SUM(quantity) OVER (PARTITION BY user_id, item_type, variant_sku) AS total
+    FROM inventory
+    ORDER BY user_id, item_type, variant_sku, id
+)
+UPDATE inventory i
+SET quantity = t.total
+FROM totals t
+WHERE i.id = t.id;
+
+DELETE FROM inventory i
+    USING inventory k
+WHERE i.user_id = k.user_id
+  AND i.item_type = k.item_type
+  AND i.variant_sku = k.variant_sku
+  AND i.id > k.id;
+
+ALTER TABLE inventory
+    ADD CONSTRAINT inventory_user_item_variant_key UNIQUE (user_id, item_type, variant_sku);
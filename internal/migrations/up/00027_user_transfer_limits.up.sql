@@ -0,0 +1,12 @@
+-- личные ограничения на переводы: заданная здесь политика периода заменяет общую из конфига
+CREATE TABLE user_transfer_limits
+(
+    user_id          uuid REFERENCES users (id),
+    period           VARCHAR(16) NOT NULL CHECK (period IN ('daily', 'monthly')),
+    amount           INTEGER     NOT NULL DEFAULT 0 CHECK (amount >= 0),
+    count            INTEGER     NOT NULL DEFAULT 0 CHECK (count >= 0),
+    recipient_amount INTEGER     NOT NULL DEFAULT 0 CHECK (recipient_amount >= 0),
+    set_by           uuid REFERENCES users (id),
+    updated_at       TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (user_id, period)
+);
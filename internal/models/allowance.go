@@ -0,0 +1,61 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// периоды начисления пособия
+const (
+	AllowanceDaily   = "daily"
+	AllowanceWeekly  = "weekly"
+	AllowanceMonthly = "monthly"
+)
+
+// maxAllowanceName - имя пособия входит в ключ начисления и в reference записи журнала
+const maxAllowanceName = 40
+
+var ErrInvalidAllowance = errors.New("allowance needs a name up to 40 characters, a positive amount and a daily, weekly or monthly period")
+
+// Allowance - пособие: Amount монет раз в Period каждому активному пользователю с одной из ролей Roles
+// (пустой Roles - всем активным пользователям)
+type Allowance struct {
+	Name   string
+	Amount int64
+	Period string
+	Roles  []string
+}
+
+// AllowanceGrant - начисление пособия одному пользователю, строка таблицы transactions
+type AllowanceGrant struct {
+	TransactionID string
+	UserID        string
+}
+
+func (a Allowance) Validate() error {
+	if a.Name == "" || len(a.Name) > maxAllowanceName || a.Amount <= 0 {
+		return fmt.Errorf("%w: %q", ErrInvalidAllowance, a.Name)
+	}
+	switch a.Period {
+	case AllowanceDaily, AllowanceWeekly, AllowanceMonthly:
+		return nil
+	default:
+		return fmt.Errorf("%w: %q", ErrInvalidAllowance, a.Name)
+	}
+}
+
+// GrantKey - ключ начисления за период, в который попадает now; один и тот же для всех запусков
+// в пределах периода, поэтому повторный запуск не начисляет пособие второй раз
+func (a Allowance) GrantKey(now time.Time) string {
+	now = now.UTC()
+	switch a.Period {
+	case AllowanceDaily:
+		return a.Name + ":" + now.Format("2006-01-02")
+	case AllowanceWeekly:
+		year, week := now.ISOWeek()
+		return fmt.Sprintf("%s:%d-W%02d", a.Name, year, week)
+	default:
+		return a.Name + ":" + now.Format("2006-01")
+	}
+}
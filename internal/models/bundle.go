@@ -0,0 +1,22 @@
+package models
+
+import "time"
+
+// Bundle - набор позиций каталога, который продаётся за одну цену
+type Bundle struct {
+	ID        string       `json:"id"`
+	Name      string       `json:"name"`
+	Price     int64        `json:"price"`
+	Retired   bool         `json:"retired"`
+	Items     []BundleItem `json:"items"`
+	CreatedBy string       `json:"created_by"`
+	CreatedAt time.Time    `json:"created_at"`
+}
+
+// BundleItem - позиция в наборе; Variant - SKU варианта, пустой для базовой позиции
+type BundleItem struct {
+	ItemID   string `json:"item_id"`
+	Item     string `json:"item"`
+	Variant  string `json:"variant"`
+	Quantity int64  `json:"quantity"`
+}
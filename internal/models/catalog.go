@@ -0,0 +1,138 @@
+package models
+
+import "time"
+
+type CatalogItem struct {
+	ID            string    `json:"id"`
+	Name          string    `json:"name"`
+	Category      string    `json:"category"`
+	Price         int64     `json:"price"`
+	Stock         *int64    `json:"stock"`
+	PurchaseLimit *int64    `json:"purchase_limit"`
+	LimitPeriod   string    `json:"limit_period"`
+	Hidden        bool      `json:"hidden"`
+	Retired       bool      `json:"retired"`
+	Version       int64     `json:"version"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
+
+// SoldOut - у позиции ограниченный запас, и он закончился. Stock == nil означает неограниченный запас
+func (i CatalogItem) SoldOut() bool {
+	return i.Stock != nil && *i.Stock <= 0
+}
+
+func (i CatalogItem) Available() bool {
+	return !i.Hidden && !i.Retired && !i.SoldOut()
+}
+
+// QuotaPeriodStart - начало текущего периода квоты; для бессрочной квоты - нулевое время
+func (i CatalogItem) QuotaPeriodStart(now time.Time) time.Time {
+	now = now.UTC()
+	switch i.LimitPeriod {
+	case LimitPeriodMonth:
+		return time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	case LimitPeriodQuarter:
+		return time.Date(now.Year(), (now.Month()-1)/3*3+1, 1, 0, 0, 0, 0, time.UTC)
+	case LimitPeriodYear:
+		return time.Date(now.Year(), time.January, 1, 0, 0, 0, 0, time.UTC)
+	default:
+		return time.Time{}
+	}
+}
+
+// QuotaLeft - остаток квоты пользователя: purchased - куплено за текущий период без учёта возвратов,
+// owned - сколько уже лежит в инвентаре; для бессрочной квоты учитывается большее из двух.
+// nil означает, что квоты у позиции нет
+func (i CatalogItem) QuotaLeft(purchased, owned int64) *int64 {
+	if i.PurchaseLimit == nil {
+		return nil
+	}
+
+	used := purchased
+	if i.LimitPeriod == "" {
+		used = max(purchased, owned)
+	}
+
+	left := max(*i.PurchaseLimit-used, 0)
+	return &left
+}
+
+// ItemVariant - вариант позиции каталога (размер, цвет) со своим SKU, запасом и, при необходимости, своей ценой
+type ItemVariant struct {
+	ID        string    `json:"id"`
+	ItemID    string    `json:"item_id"`
+	SKU       string    `json:"sku"`
+	Size      string    `json:"size"`
+	Color     string    `json:"color"`
+	Price     *int64    `json:"price"`
+	Stock     *int64    `json:"stock"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// PriceFor - цена варианта; без переопределения берётся цена базовой позиции
+func (v ItemVariant) PriceFor(item CatalogItem) int64 {
+	if v.Price != nil {
+		return *v.Price
+	}
+	return item.Price
+}
+
+type CatalogItemVersion struct {
+	ItemID    string    `json:"item_id"`
+	Version   int64     `json:"version"`
+	Name      string    `json:"name"`
+	Price     int64     `json:"price"`
+	Hidden    bool      `json:"hidden"`
+	Retired   bool      `json:"retired"`
+	ChangedBy string    `json:"changed_by"`
+	ChangedAt time.Time `json:"changed_at"`
+}
+
+type CatalogFilter struct {
+	MinPrice int64
+	MaxPrice int64
+	Category string
+	SortBy   string
+	Desc     bool
+	Limit    int64
+	Offset   int64
+}
+
+type CatalogListItem struct {
+	Name      string
+	Category  string
+	Price     int64
+	Stock     *int64
+	QuotaLeft *int64
+	Available bool
+	CanAfford bool
+}
+
+type StockMovement struct {
+	ID        string    `json:"id"`
+	ItemID    string    `json:"item_id"`
+	Variant   string    `json:"variant"`
+	Delta     int64     `json:"delta"`
+	Reason    string    `json:"reason"`
+	UserID    string    `json:"user_id"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type PurchaseLine struct {
+	Item     string
+	Variant  string
+	Quantity int64
+}
+
+type CartLine struct {
+	Item     string `json:"item"`
+	Variant  string `json:"variant,omitempty"`
+	Price    int64  `json:"price"`
+	Quantity int64  `json:"quantity"`
+}
+
+type Cart struct {
+	Lines    []CartLine `json:"lines"`
+	Discount int64      `json:"discount,omitempty"`
+	Total    int64      `json:"total"`
+}
@@ -4,23 +4,48 @@ import "time"
 
 const (
 	AuthorizationToken = "Authorization"
+
+	// IdempotencyKeyHeader - заголовок с ключом идемпотентности, IdempotencyKeyLocal - под этим
+	// именем middleware кладёт models.IdempotencyKey в контекст запроса
+	IdempotencyKeyHeader    = "Idempotency-Key"
+	IdempotencyKeyLocal     = "IdempotencyKey"
+	MaxIdempotencyKeyLength = 255
+
+	RoleUser  = "user"
+	RoleAdmin = "admin"
+	RoleStaff = "staff"
+
+	DefaultCategory = "merch"
+
+	SortByPrice = "price"
+	SortByName  = "name"
+
+	DefaultPageLimit = 20
+
+	StockReasonPurchase = "purchase"
+	StockReasonRestock  = "restock"
+	StockReasonRefund   = "refund"
+
+	OrderKindPurchase = "purchase"
+	OrderKindRefund   = "refund"
+
+	// статусы выдачи покупки; у записей возврата статуса нет
+	OrderStatusPlaced     = "placed"
+	OrderStatusReady      = "ready"
+	OrderStatusHandedOver = "handed_over"
+	OrderStatusCancelled  = "cancelled"
+
+	NotificationPriceDrop   = "price_drop"
+	NotificationBackInStock = "back_in_stock"
+
+	// периоды квоты на покупку; пустая строка - квота на всё время
+	LimitPeriodMonth   = "month"
+	LimitPeriodQuarter = "quarter"
+	LimitPeriodYear    = "year"
 )
 
 var (
 	DurationJwtToken = time.Hour * 24
 
 	MinEntropyBits = 50
-
-	PriceItem = map[string]int64{
-		"t-shirt":    80,
-		"cup":        20,
-		"book":       50,
-		"pen":        10,
-		"powerbank":  200,
-		"hoody":      300,
-		"umbrella":   200,
-		"socks":      10,
-		"wallet":     50,
-		"pink-hoody": 500,
-	}
 )
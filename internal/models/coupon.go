@@ -0,0 +1,39 @@
+package models
+
+import (
+	"slices"
+	"time"
+)
+
+// Coupon - промокод. MaxRedemptions == 1 - одноразовый код, больше - многоразовый;
+// один пользователь может погасить код только один раз. Пустой Items - код действует на любые позиции
+type Coupon struct {
+	Code           string     `json:"code"`
+	BatchID        string     `json:"batch_id"`
+	Percent        int64      `json:"percent,omitempty"`
+	Amount         int64      `json:"amount,omitempty"`
+	MaxRedemptions int64      `json:"max_redemptions"`
+	Redeemed       int64      `json:"redeemed"`
+	MinSpend       int64      `json:"min_spend"`
+	Items          []string   `json:"items"`
+	ExpiresAt      *time.Time `json:"expires_at"`
+	CreatedBy      string     `json:"created_by"`
+	CreatedAt      time.Time  `json:"created_at"`
+}
+
+func (c Coupon) Expired(t time.Time) bool {
+	return c.ExpiresAt != nil && !t.Before(*c.ExpiresAt)
+}
+
+func (c Coupon) Eligible(item string) bool {
+	return len(c.Items) == 0 || slices.Contains(c.Items, item)
+}
+
+type CouponRedemption struct {
+	ID        string    `json:"id"`
+	Code      string    `json:"code"`
+	UserID    string    `json:"user_id"`
+	Username  string    `json:"username"`
+	Discount  int64     `json:"discount"`
+	CreatedAt time.Time `json:"created_at"`
+}
@@ -5,4 +5,48 @@ import "errors"
 var (
 	ErrAuthUser   = errors.New("user is not authorized")
 	ErrValidation = errors.New("validation error")
+
+	ErrItemNotFound  = errors.New("item not found in catalog")
+	ErrSoldOut       = errors.New("item is sold out")
+	ErrQuotaExceeded = errors.New("purchase quota for this item is exceeded")
+
+	ErrVariantNotFound = errors.New("item variant not found")
+
+	ErrItemNotAvailable = errors.New("item is not available for purchase")
+	ErrNotInCart        = errors.New("item is not in the cart")
+	ErrNotInWishlist    = errors.New("item is not in the wishlist")
+
+	ErrOrderNotFound = errors.New("order not found")
+
+	ErrPromotionNotFound = errors.New("promotion not found or already ended")
+
+	ErrCouponNotFound      = errors.New("coupon not found")
+	ErrCouponExpired       = errors.New("coupon has expired")
+	ErrCouponExhausted     = errors.New("coupon has no redemptions left")
+	ErrCouponAlreadyUsed   = errors.New("coupon has already been used by this user")
+	ErrCouponNotApplicable = errors.New("coupon does not apply to these items")
+	ErrCouponMinSpend      = errors.New("order total is below the coupon minimum spend")
+
+	ErrBundleNotFound = errors.New("bundle not found")
+
+	ErrVoucherInvalid  = errors.New("voucher signature is invalid")
+	ErrVoucherNotFound = errors.New("voucher not found")
+	ErrVoucherConsumed = errors.New("voucher has already been redeemed")
+
+	ErrUnbalancedEntry = errors.New("ledger entry postings do not sum to zero")
+	ErrNotEnoughCoins  = errors.New("not enough coins")
+	ErrNotEnoughItems  = errors.New("not enough items in inventory")
+
+	ErrIdempotencyKeyReused = errors.New("idempotency key was already used with a different request")
+
+	ErrScheduleNotFound = errors.New("scheduled transfer not found")
+
+	ErrTransferNotFound   = errors.New("transfer not found")
+	ErrTransferNotPending = errors.New("transfer is not waiting for an answer")
+
+	ErrPaymentRequestNotFound = errors.New("payment request not found")
+
+	ErrTransferLimitExceeded = errors.New("transfer limit exceeded")
+
+	ErrUserNotFound = errors.New("user not found")
 )
@@ -0,0 +1,34 @@
+package models
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"time"
+)
+
+// IdempotencyKey - ключ идемпотентности мутирующего запроса: хэш запроса и сохранённый ответ на него.
+// Response пуст, пока операция с этим ключом не завершилась
+type IdempotencyKey struct {
+	UserID      string
+	Key         string
+	RequestHash string
+	Response    json.RawMessage
+	CreatedAt   time.Time
+}
+
+// NewIdempotencyKey - ключ с хэшем метода, адреса (вместе с query) и тела запроса
+func NewIdempotencyKey(userID, key, method, uri string, body []byte) IdempotencyKey {
+	h := sha256.New()
+	h.Write([]byte(method))
+	h.Write([]byte{'\n'})
+	h.Write([]byte(uri))
+	h.Write([]byte{'\n'})
+	h.Write(body)
+
+	return IdempotencyKey{
+		UserID:      userID,
+		Key:         key,
+		RequestHash: hex.EncodeToString(h.Sum(nil)),
+	}
+}
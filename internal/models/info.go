@@ -6,18 +6,38 @@ type InfoResponse struct {
 	Coins        int64             `json:"coins"`
 	Inventory    []InventoryItem   `json:"inventory"`
 	Transactions []TransactionItem `json:"transactions"`
+	Orders       []Order           `json:"orders"`
+	ItemHistory  []ItemTransfer    `json:"item_history"`
+	Wishlist     []WishlistEntry   `json:"wishlist"`
 }
 
 type InventoryItem struct {
 	ItemType string `json:"item_type"`
+	Variant  string `json:"variant"`
 	Quantity int64  `json:"quantity"`
 }
 
 type TransactionItem struct {
+	ID           string `json:"id"`
 	FromUserID   string `json:"from_user"`
 	FromUsername string
 	ToUserName   string
 	ToUserID     string
-	Amount       int64     `json:"amount"`
+	Amount       int64      `json:"amount"`
+	Message      string     `json:"message"`
+	Category     string     `json:"category"`
+	Status       string     `json:"status"`
+	ExpiresAt    *time.Time `json:"expires_at"`
+	CreatedAt    time.Time  `json:"created_at"`
+}
+
+type ItemTransfer struct {
+	FromUserID   string
+	FromUsername string
+	ToUserID     string
+	ToUserName   string
+	ItemType     string    `json:"item_type"`
+	Variant      string    `json:"variant"`
+	Quantity     int64     `json:"quantity"`
 	CreatedAt    time.Time `json:"created_at"`
 }
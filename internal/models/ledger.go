@@ -0,0 +1,89 @@
+package models
+
+import "time"
+
+// Системные счета журнала
+const (
+	AccountShop     = "shop"     // магазин: сюда уходят монеты за покупки, отсюда — возвраты
+	AccountIssuance = "issuance" // эмиссия: источник начислений пользователям
+	AccountEscrow   = "escrow"   // монеты переводов, которые ждут ответа получателя
+)
+
+// Виды записей журнала
+const (
+	EntryOpening  = "opening"
+	EntryGrant    = "grant"
+	EntryTransfer = "transfer"
+	EntryPurchase = "purchase"
+	EntryRefund   = "refund"
+
+	EntryEscrowHold    = "escrow_hold"
+	EntryEscrowRelease = "escrow_release"
+	EntryEscrowReturn  = "escrow_return"
+)
+
+// UserAccount - счёт пользователя в журнале
+func UserAccount(userID string) string {
+	return "user:" + userID
+}
+
+// Posting - проводка по счёту: положительная сумма зачисляет монеты, отрицательная списывает
+type Posting struct {
+	Account string `json:"account"`
+	Amount  int64  `json:"amount"`
+}
+
+// LedgerEntry - запись журнала; сумма её проводок всегда равна нулю
+type LedgerEntry struct {
+	ID        string    `json:"id"`
+	Kind      string    `json:"kind"`
+	Reference string    `json:"reference"`
+	Postings  []Posting `json:"postings"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// NewLedgerEntry - запись о переводе amount монет со счёта from на счёт to
+func NewLedgerEntry(id, kind, reference, from, to string, amount int64) LedgerEntry {
+	return LedgerEntry{
+		ID:        id,
+		Kind:      kind,
+		Reference: reference,
+		Postings: []Posting{
+			{Account: from, Amount: -amount},
+			{Account: to, Amount: amount},
+		},
+	}
+}
+
+// Balanced - проводки записи сходятся в ноль и ни одна не пустая
+func (e LedgerEntry) Balanced() bool {
+	if len(e.Postings) < 2 {
+		return false
+	}
+
+	var sum int64
+	for _, p := range e.Postings {
+		if p.Amount == 0 {
+			return false
+		}
+		sum += p.Amount
+	}
+
+	return sum == 0
+}
+
+// BalanceMismatch - пользователь, у которого сохранённый баланс расходится с журналом
+type BalanceMismatch struct {
+	UserID   string `json:"user_id"`
+	Username string `json:"username"`
+	Coins    int64  `json:"coins"`
+	Ledger   int64  `json:"ledger"`
+}
+
+// LedgerReport - сверка журнала: балансы системных счетов и расхождения с users.coins
+type LedgerReport struct {
+	Shop       int64             `json:"shop"`
+	Issuance   int64             `json:"issuance"`
+	Escrow     int64             `json:"escrow"`
+	Mismatches []BalanceMismatch `json:"mismatches"`
+}
@@ -0,0 +1,95 @@
+package models
+
+import (
+	"fmt"
+	"time"
+)
+
+// периоды ограничений на переводы
+const (
+	LimitDaily   = "daily"
+	LimitMonthly = "monthly"
+)
+
+// LimitPolicy - ограничения на переводы пользователя за период; 0 - без ограничения
+type LimitPolicy struct {
+	Amount          int64 // сколько монет можно отправить всего
+	Count           int64 // сколько переводов можно сделать
+	RecipientAmount int64 // сколько монет можно отправить одному получателю
+}
+
+// TransferLimits - ограничения на день и на календарный месяц (UTC)
+type TransferLimits struct {
+	Daily   LimitPolicy
+	Monthly LimitPolicy
+}
+
+// TransferUsage - отправлено за период: монет, переводов и монет одному получателю
+type TransferUsage struct {
+	Amount          int64
+	Count           int64
+	RecipientAmount int64
+}
+
+// PeriodLimits - ограничения периода, который начался в Since, и то, что из них уже израсходовано;
+// Personal - ограничения заданы пользователю лично, а не взяты из общей политики
+type PeriodLimits struct {
+	Period   string
+	Since    time.Time
+	Policy   LimitPolicy
+	Personal bool
+	Used     TransferUsage
+}
+
+// ValidLimitPeriod - period - один из периодов ограничений
+func ValidLimitPeriod(period string) bool {
+	return period == LimitDaily || period == LimitMonthly
+}
+
+// LimitPeriodStart - начало периода ограничений, в который попадает now
+func LimitPeriodStart(period string, now time.Time) time.Time {
+	now = now.UTC()
+	if period == LimitMonthly {
+		return time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	}
+	return time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// Policy - ограничения периода period
+func (l TransferLimits) Policy(period string) LimitPolicy {
+	if period == LimitMonthly {
+		return l.Monthly
+	}
+	return l.Daily
+}
+
+// Override - ограничения, в которых политики периодов из personal заменяют общие
+func (l TransferLimits) Override(personal map[string]LimitPolicy) TransferLimits {
+	if p, ok := personal[LimitDaily]; ok {
+		l.Daily = p
+	}
+	if p, ok := personal[LimitMonthly]; ok {
+		l.Monthly = p
+	}
+	return l
+}
+
+// Check - укладываются ли count переводов на amount монет в ограничения, если за период уже отправлено used.
+// Ограничение на одного получателя проверяется отдельно, через CheckRecipient
+func (p LimitPolicy) Check(period string, used TransferUsage, amount, count int64) error {
+	if p.Amount > 0 && used.Amount+amount > p.Amount {
+		return fmt.Errorf("%w: %s amount of %d coins", ErrTransferLimitExceeded, period, p.Amount)
+	}
+	if p.Count > 0 && used.Count+count > p.Count {
+		return fmt.Errorf("%w: %s count of %d transfers", ErrTransferLimitExceeded, period, p.Count)
+	}
+	return nil
+}
+
+// CheckRecipient - укладывается ли перевод amount монет получателю, которому за период уже отправлено used.RecipientAmount
+func (p LimitPolicy) CheckRecipient(period string, used TransferUsage, amount int64) error {
+	if p.RecipientAmount > 0 && used.RecipientAmount+amount > p.RecipientAmount {
+		return fmt.Errorf("%w: %s amount of %d coins to one recipient", ErrTransferLimitExceeded, period, p.RecipientAmount)
+	}
+	return nil
+}
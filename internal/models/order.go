@@ -0,0 +1,51 @@
+package models
+
+import (
+	"slices"
+	"time"
+)
+
+type Order struct {
+	ID             string    `json:"id"`
+	Kind           string    `json:"kind"`
+	RefundOf       string    `json:"refund_of"`
+	UserID         string    `json:"user_id"`
+	ItemID         string    `json:"item_id"`
+	BundleID       string    `json:"bundle_id"`
+	Item           string    `json:"item"`
+	Variant        string    `json:"variant"`
+	Quantity       int64     `json:"quantity"`
+	UnitPrice      int64     `json:"unit_price"`
+	Discount       int64     `json:"discount"`
+	PromotionID    string    `json:"promotion_id"`
+	CouponCode     string    `json:"coupon_code"`
+	CouponDiscount int64     `json:"coupon_discount"`
+	Total          int64     `json:"total"`
+	Status         string    `json:"status"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+// OrderStatusChange - запись журнала смены статуса заказа; ChangedBy пуст, если статус сменил сам покупатель
+type OrderStatusChange struct {
+	ID        string    `json:"id"`
+	OrderID   string    `json:"order_id"`
+	Status    string    `json:"status"`
+	ChangedBy string    `json:"changed_by"`
+	ChangedAt time.Time `json:"changed_at"`
+}
+
+// orderTransitions - допустимые переходы между статусами выдачи
+var orderTransitions = map[string][]string{
+	OrderStatusPlaced: {OrderStatusReady, OrderStatusCancelled},
+	OrderStatusReady:  {OrderStatusHandedOver, OrderStatusCancelled},
+}
+
+// CanMoveTo - можно ли перевести заказ в статус status
+func (o Order) CanMoveTo(status string) bool {
+	return slices.Contains(orderTransitions[o.Status], status)
+}
+
+// Pending - заказ оплачен, но ещё не выдан и не отменён
+func (o Order) Pending() bool {
+	return o.Status == OrderStatusPlaced || o.Status == OrderStatusReady
+}
@@ -0,0 +1,28 @@
+package models
+
+import "time"
+
+// статусы запроса монет: open ждёт ответа плательщика, остальные - окончательные
+const (
+	PaymentRequestOpen      = "open"
+	PaymentRequestPaid      = "paid"
+	PaymentRequestDeclined  = "declined"
+	PaymentRequestCancelled = "cancelled"
+	PaymentRequestExpired   = "expired"
+)
+
+// PaymentRequest - запрос монет: Requester просит Payer перевести ему Amount монет.
+// TransactionID - перевод, которым запрос оплачен
+type PaymentRequest struct {
+	ID            string
+	RequesterID   string
+	RequesterName string
+	PayerID       string
+	PayerName     string
+	Amount        int64
+	Note          string
+	Status        string
+	TransactionID string
+	ExpiresAt     time.Time
+	CreatedAt     time.Time
+}
@@ -0,0 +1,36 @@
+package models
+
+import "time"
+
+// Promotion - скидка на позицию или категорию каталога, действующая в интервале [StartsAt, EndsAt).
+// Задаётся либо процентом (Percent), либо фиксированной суммой (Amount)
+type Promotion struct {
+	ID        string    `json:"id"`
+	Name      string    `json:"name"`
+	ItemID    string    `json:"item_id,omitempty"`
+	Item      string    `json:"item,omitempty"`
+	Category  string    `json:"category,omitempty"`
+	Percent   int64     `json:"percent,omitempty"`
+	Amount    int64     `json:"amount,omitempty"`
+	StartsAt  time.Time `json:"starts_at"`
+	EndsAt    time.Time `json:"ends_at"`
+	CreatedBy string    `json:"created_by"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func (p Promotion) ActiveAt(t time.Time) bool {
+	return !t.Before(p.StartsAt) && t.Before(p.EndsAt)
+}
+
+// Discount - скидка на единицу товара с ценой price; не превышает саму цену
+func (p Promotion) Discount(price int64) int64 {
+	discount := p.Amount
+	if p.Percent > 0 {
+		discount = price * p.Percent / 100
+	}
+
+	if discount > price {
+		return price
+	}
+	return discount
+}
@@ -0,0 +1,127 @@
+package models
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	ScheduleActive    = "active"
+	SchedulePaused    = "paused"
+	ScheduleCancelled = "cancelled"
+	ScheduleDone      = "done"
+	ScheduleFailed    = "failed"
+
+	// периодичность запланированного перевода; пустая строка - разовый перевод
+	RepeatDaily   = "daily"
+	RepeatWeekly  = "weekly"
+	RepeatMonthly = "monthly"
+
+	// что делать, если запуск не удался: повторить позже или пропустить
+	OnFailureRetry = "retry"
+	OnFailureSkip  = "skip"
+
+	RunSent   = "sent"
+	RunFailed = "failed"
+)
+
+// ScheduledTransfer - перевод монет, запланированный на время RunAt, разовый или повторяющийся.
+// DueAt - когда запуск будет выполнен: совпадает с RunAt, пока запуск не отложен повтором после ошибки
+type ScheduledTransfer struct {
+	ID        string
+	UserID    string
+	ToUser    string
+	Amount    int64
+	Message   string
+	Category  string
+	Repeat    string
+	OnFailure string
+	Status    string
+	RunAt     time.Time
+	DueAt     time.Time
+	Attempts  int
+	LastError string
+	CreatedAt time.Time
+}
+
+// ScheduledRun - попытка выполнить запланированный перевод
+type ScheduledRun struct {
+	ID         string
+	ScheduleID string
+	RunAt      time.Time
+	ExecutedAt time.Time
+	Status     string
+	Error      string
+}
+
+func ValidRepeat(repeat string) bool {
+	switch repeat {
+	case "", RepeatDaily, RepeatWeekly, RepeatMonthly:
+		return true
+	default:
+		return false
+	}
+}
+
+func ValidOnFailure(policy string) bool {
+	return policy == OnFailureRetry || policy == OnFailureSkip
+}
+
+// Closed - расписание больше не выполняется и не может быть возобновлено
+func (s ScheduledTransfer) Closed() bool {
+	return s.Status == ScheduleCancelled || s.Status == ScheduleDone || s.Status == ScheduleFailed
+}
+
+// IdempotencyKey - ключ запуска: один и тот же для всех попыток одного планового запуска,
+// чтобы перевод не ушёл дважды, если результат запуска не успел сохраниться
+func (s ScheduledTransfer) IdempotencyKey() IdempotencyKey {
+	key := fmt.Sprintf("schedule:%s:%d", s.ID, s.RunAt.Unix())
+	return NewIdempotencyKey(s.UserID, key, "SCHEDULE", s.ID, nil)
+}
+
+// NextAfter - первый плановый запуск повторяющегося перевода позже now; пропущенные запуски не догоняются
+func (s ScheduledTransfer) NextAfter(now time.Time) time.Time {
+	next := s.RunAt
+	for !next.After(now) {
+		switch s.Repeat {
+		case RepeatDaily:
+			next = next.AddDate(0, 0, 1)
+		case RepeatWeekly:
+			next = next.AddDate(0, 0, 7)
+		case RepeatMonthly:
+			next = next.AddDate(0, 1, 0)
+		default:
+			return s.RunAt
+		}
+	}
+	return next
+}
+
+// Advance - состояние расписания после запуска в момент now. Неудачный запуск при политике retry
+// откладывается на retryDelay, пока не исчерпано maxAttempts попыток; в остальных случаях
+// разовый перевод завершается, а повторяющийся переходит к следующему плановому запуску
+func (s ScheduledTransfer) Advance(now time.Time, runErr error, retryDelay time.Duration, maxAttempts int) ScheduledTransfer {
+	if runErr != nil {
+		s.Attempts++
+		s.LastError = runErr.Error()
+		if s.OnFailure == OnFailureRetry && s.Attempts < maxAttempts {
+			s.DueAt = now.Add(retryDelay)
+			return s
+		}
+	} else {
+		s.LastError = ""
+	}
+
+	s.Attempts = 0
+	if s.Repeat == "" {
+		s.Status = ScheduleDone
+		if runErr != nil {
+			s.Status = ScheduleFailed
+		}
+		return s
+	}
+
+	s.RunAt = s.NextAfter(now)
+	s.DueAt = s.RunAt
+	return s
+}
@@ -0,0 +1,67 @@
+package models
+
+import (
+	"slices"
+	"time"
+)
+
+// категории перевода монет; пустая строка - перевод без категории
+const (
+	TransferThanks   = "thanks"
+	TransferHelp     = "help"
+	TransferBirthday = "birthday"
+	TransferTeamwork = "teamwork"
+	TransferOther    = "other"
+)
+
+// статусы перевода. Отложенный перевод (pending) списан у отправителя на эскроу и ждёт ответа
+// получателя: accepted - принят, declined - отклонён, expired - не принят вовремя и вернулся отправителю
+const (
+	TransferCompleted = "completed"
+	TransferPending   = "pending"
+	TransferAccepted  = "accepted"
+	TransferDeclined  = "declined"
+	TransferExpired   = "expired"
+)
+
+var TransferCategories = []string{TransferThanks, TransferHelp, TransferBirthday, TransferTeamwork, TransferOther}
+
+// Transfer - перевод монет между пользователями, строка таблицы transactions
+type Transfer struct {
+	ID         string
+	FromUserID string
+	ToUserID   string
+	Amount     int64
+	Message    string
+	Category   string
+	Status     string
+	ExpiresAt  *time.Time
+}
+
+// ValidTransferCategory - category пустая или одна из TransferCategories
+func ValidTransferCategory(category string) bool {
+	return category == "" || slices.Contains(TransferCategories, category)
+}
+
+// статусы получателя в пакетном переводе; пакет отклоняется целиком, если хоть один получатель не sent/not_sent
+const (
+	BatchSent               = "sent"
+	BatchNotSent            = "not_sent"
+	BatchUnknownRecipient   = "unknown_recipient"
+	BatchSameUser           = "same_user"
+	BatchDuplicateRecipient = "duplicate_recipient"
+)
+
+// BatchRecipient - получатель пакетного перевода и его сумма
+type BatchRecipient struct {
+	ToUser string
+	Amount int64
+}
+
+// BatchResult - итог перевода одному получателю из пакета
+type BatchResult struct {
+	ToUser        string `json:"to_user"`
+	Amount        int64  `json:"amount"`
+	Status        string `json:"status"`
+	TransactionID string `json:"transaction_id,omitempty"`
+}
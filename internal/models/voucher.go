@@ -0,0 +1,20 @@
+package models
+
+import "time"
+
+// Voucher - талон на получение заказа на стойке выдачи; гасится один раз
+type Voucher struct {
+	ID         string     `json:"id"`
+	OrderID    string     `json:"order_id"`
+	UserID     string     `json:"user_id"`
+	Item       string     `json:"item"`
+	Variant    string     `json:"variant"`
+	IssuedAt   time.Time  `json:"issued_at"`
+	ConsumedAt *time.Time `json:"consumed_at"`
+	ConsumedBy string     `json:"consumed_by"`
+}
+
+// Consumed - талон уже погашен
+func (v Voucher) Consumed() bool {
+	return v.ConsumedAt != nil
+}
@@ -0,0 +1,31 @@
+package models
+
+import "time"
+
+// WishlistEntry - позиция из списка желаний пользователя с текущей ценой
+type WishlistEntry struct {
+	ItemID       string    `json:"item_id"`
+	Item         string    `json:"item"`
+	Price        int64     `json:"price"`
+	Available    bool      `json:"available"`
+	MissingCoins int64     `json:"missing_coins"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+// WithBalance - сколько монет не хватает на позицию при балансе coins
+func (e WishlistEntry) WithBalance(coins int64) WishlistEntry {
+	e.MissingCoins = max(e.Price-coins, 0)
+	return e
+}
+
+// Notification - уведомление пользователю о позиции из его списка желаний
+type Notification struct {
+	ID        string     `json:"id"`
+	UserID    string     `json:"user_id"`
+	Kind      string     `json:"kind"`
+	ItemID    string     `json:"item_id"`
+	Item      string     `json:"item"`
+	Detail    string     `json:"detail"`
+	CreatedAt time.Time  `json:"created_at"`
+	ReadAt    *time.Time `json:"read_at"`
+}
@@ -0,0 +1,78 @@
+package allowance
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"AvitoTask/internal/models"
+)
+
+type Repository struct {
+	pool *pgxpool.Pool
+}
+
+func NewRepository(pool *pgxpool.Pool) *Repository {
+	return &Repository{pool: pool}
+}
+
+func (r *Repository) BeginTx(ctx context.Context) (pgx.Tx, error) {
+	return r.pool.Begin(ctx)
+}
+
+// InsertGrants - записывает начисления пособия a с ключом key до limit активным пользователям, которые его
+// ещё не получили. Параллельный запуск на другом экземпляре сервиса упрётся в уникальный индекс
+// по (to_user_id, grant_key), и пользователь не получит пособие дважды
+func (r *Repository) InsertGrants(ctx context.Context, tx pgx.Tx, a models.Allowance, key string, limit int64) ([]models.AllowanceGrant, error) {
+	query := `
+        INSERT INTO transactions (id, to_user_id, amount, message, status, grant_key)
+        SELECT gen_random_uuid(), u.id, $1, $2, 'completed', $3
+        FROM users u
+        WHERE u.active
+          AND (cardinality($4::text[]) = 0 OR u.role = ANY ($4::text[]))
+          AND NOT EXISTS (SELECT 1 FROM transactions t WHERE t.to_user_id = u.id AND t.grant_key = $3)
+        ORDER BY u.id
+        LIMIT $5
+        ON CONFLICT (to_user_id, grant_key) DO NOTHING
+        RETURNING id, to_user_id
+    `
+	roles := a.Roles
+	if roles == nil {
+		roles = []string{}
+	}
+
+	rows, err := tx.Query(ctx, query, a.Amount, a.Name, key, roles, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to insert grants %s: %w", key, err)
+	}
+	defer rows.Close()
+
+	var result []models.AllowanceGrant
+	for rows.Next() {
+		var g models.AllowanceGrant
+		if err := rows.Scan(&g.TransactionID, &g.UserID); err != nil {
+			return nil, fmt.Errorf("failed to scan grant row: %w", err)
+		}
+		result = append(result, g)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error during rows iteration: %w", err)
+	}
+
+	return result, nil
+}
+
+// SetUserActive - включает или выключает пользователю начисление пособий
+func (r *Repository) SetUserActive(ctx context.Context, tx pgx.Tx, username string, active bool) error {
+	tag, err := tx.Exec(ctx, `UPDATE users SET active = $2 WHERE username = $1`, username, active)
+	if err != nil {
+		return fmt.Errorf("failed to set active=%t for user %s: %w", active, username, err)
+	}
+	if tag.RowsAffected() == 0 {
+		return models.ErrUserNotFound
+	}
+	return nil
+}
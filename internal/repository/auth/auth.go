@@ -100,14 +100,50 @@ func (r *Repository) GetUserById(ctx context.Context, tx pgx.Tx, userID string)
 	return user, nil
 }
 
-func (r *Repository) UpdateUserCoins(ctx context.Context, tx pgx.Tx, userID string, newCoins int64) error {
+// LockUser - блокирует строку пользователя до конца транзакции, чтобы параллельные покупки одного
+// пользователя выполнялись по очереди
+func (r *Repository) LockUser(ctx context.Context, tx pgx.Tx, userID string) error {
+	var id string
+	query := `SELECT id FROM users WHERE id = $1 FOR UPDATE`
+	err := tx.QueryRow(ctx, query, userID).Scan(&id)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return ErrNoUserExist
+	}
+	if err != nil {
+		return fmt.Errorf("failed to lock user %s: %w", userID, err)
+	}
+	return nil
+}
+
+// DebitUserCoins - списывает amount монет одним условным UPDATE: проверка баланса и списание атомарны,
+// поэтому параллельные списания не могут увести баланс в минус или потерять обновление
+func (r *Repository) DebitUserCoins(ctx context.Context, tx pgx.Tx, userID string, amount int64) error {
 	query := `UPDATE users
-              SET coins = $1
+              SET coins = coins - $1
+              WHERE id = $2 AND coins >= $1`
+
+	tag, err := tx.Exec(ctx, query, amount, userID)
+	if err != nil {
+		return fmt.Errorf("failed to debit coins for userID=%s: %w", userID, err)
+	}
+	if tag.RowsAffected() == 0 {
+		return models.ErrNotEnoughCoins
+	}
+
+	return nil
+}
+
+func (r *Repository) CreditUserCoins(ctx context.Context, tx pgx.Tx, userID string, amount int64) error {
+	query := `UPDATE users
+              SET coins = coins + $1
               WHERE id = $2`
 
-	_, err := tx.Exec(ctx, query, newCoins, userID)
+	tag, err := tx.Exec(ctx, query, amount, userID)
 	if err != nil {
-		return fmt.Errorf("failed to update coins for userID=%s: %w", userID, err)
+		return fmt.Errorf("failed to credit coins for userID=%s: %w", userID, err)
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrNoUserExist
 	}
 
 	return nil
@@ -122,3 +158,16 @@ func (r *Repository) GetUserCoins(ctx context.Context, tx pgx.Tx, userID string)
 	}
 	return coins, nil
 }
+
+func (r *Repository) GetUserRole(ctx context.Context, userID string) (string, error) {
+	var role string
+	query := `SELECT role FROM users WHERE id = $1 LIMIT 1`
+	err := r.pool.QueryRow(ctx, query, userID).Scan(&role)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return "", ErrNoUserExist
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to get user role (userID=%s): %w", userID, err)
+	}
+	return role, nil
+}
@@ -398,25 +398,38 @@ func (s *TxTestSuite) TestGetUserById_OtherError() {
 	s.Equal(models.User{}, user)
 }
 
-func (s *TxTestSuite) TestUpdateUserCoins_Success() {
+func (s *TxTestSuite) TestDebitUserCoins_Success() {
 	ctx := context.Background()
 	userID := "user-id-123"
-	newCoins := int64(200)
 
 	tx := &fakeTx{
 		execFunc: func(ctx context.Context, query string, args ...any) (pgconn.CommandTag, error) {
-			return pgconn.CommandTag{}, nil
+			s.Equal([]any{int64(200), userID}, args)
+			return pgconn.NewCommandTag("UPDATE 1"), nil
 		},
 	}
 
-	err := s.repo.UpdateUserCoins(ctx, tx, userID, newCoins)
+	err := s.repo.DebitUserCoins(ctx, tx, userID, 200)
 	s.NoError(err)
 }
 
-func (s *TxTestSuite) TestUpdateUserCoins_Error() {
+func (s *TxTestSuite) TestDebitUserCoins_NotEnoughCoins() {
+	ctx := context.Background()
+	userID := "user-id-123"
+
+	tx := &fakeTx{
+		execFunc: func(ctx context.Context, query string, args ...any) (pgconn.CommandTag, error) {
+			return pgconn.NewCommandTag("UPDATE 0"), nil
+		},
+	}
+
+	err := s.repo.DebitUserCoins(ctx, tx, userID, 200)
+	s.ErrorIs(err, models.ErrNotEnoughCoins)
+}
+
+func (s *TxTestSuite) TestDebitUserCoins_Error() {
 	ctx := context.Background()
 	userID := "user-id-123"
-	newCoins := int64(200)
 	expectedErr := errors.New("update error")
 
 	tx := &fakeTx{
@@ -425,9 +438,36 @@ func (s *TxTestSuite) TestUpdateUserCoins_Error() {
 		},
 	}
 
-	err := s.repo.UpdateUserCoins(ctx, tx, userID, newCoins)
+	err := s.repo.DebitUserCoins(ctx, tx, userID, 200)
 	s.Error(err)
-	s.Contains(err.Error(), fmt.Sprintf("failed to update coins for userID=%s", userID))
+	s.Contains(err.Error(), fmt.Sprintf("failed to debit coins for userID=%s", userID))
+}
+
+func (s *TxTestSuite) TestCreditUserCoins_Success() {
+	ctx := context.Background()
+	userID := "user-id-123"
+
+	tx := &fakeTx{
+		execFunc: func(ctx context.Context, query string, args ...any) (pgconn.CommandTag, error) {
+			return pgconn.NewCommandTag("UPDATE 1"), nil
+		},
+	}
+
+	err := s.repo.CreditUserCoins(ctx, tx, userID, 200)
+	s.NoError(err)
+}
+
+func (s *TxTestSuite) TestCreditUserCoins_NoUser() {
+	ctx := context.Background()
+
+	tx := &fakeTx{
+		execFunc: func(ctx context.Context, query string, args ...any) (pgconn.CommandTag, error) {
+			return pgconn.NewCommandTag("UPDATE 0"), nil
+		},
+	}
+
+	err := s.repo.CreditUserCoins(ctx, tx, "user-id-123", 200)
+	s.ErrorIs(err, auth.ErrNoUserExist)
 }
 
 func (s *TxTestSuite) TestGetUserCoins_Success() {
@@ -0,0 +1,150 @@
+package bundle
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"AvitoTask/internal/models"
+)
+
+type Repository struct {
+	pool *pgxpool.Pool
+}
+
+func NewRepository(pool *pgxpool.Pool) *Repository {
+	return &Repository{pool: pool}
+}
+
+func (r *Repository) BeginTx(ctx context.Context) (pgx.Tx, error) {
+	return r.pool.Begin(ctx)
+}
+
+// InsertBundle - сохраняет набор вместе с составом
+func (r *Repository) InsertBundle(ctx context.Context, tx pgx.Tx, b models.Bundle) error {
+	query := `
+        INSERT INTO bundles (id, name, price, created_by)
+        VALUES ($1, $2, $3, NULLIF($4, '')::uuid)
+    `
+	if _, err := tx.Exec(ctx, query, b.ID, b.Name, b.Price, b.CreatedBy); err != nil {
+		return fmt.Errorf("failed to insert bundle '%s': %w", b.Name, err)
+	}
+
+	itemQuery := `
+        INSERT INTO bundle_items (bundle_id, item_id, variant_sku, quantity)
+        VALUES ($1, $2, $3, $4)
+    `
+	for _, it := range b.Items {
+		if _, err := tx.Exec(ctx, itemQuery, b.ID, it.ItemID, it.Variant, it.Quantity); err != nil {
+			return fmt.Errorf("failed to insert item '%s' of bundle '%s': %w", it.Item, b.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// GetBundleByName - набор с составом
+func (r *Repository) GetBundleByName(ctx context.Context, tx pgx.Tx, name string) (models.Bundle, error) {
+	var b models.Bundle
+	query := `
+        SELECT id, name, price, retired, COALESCE(created_by::text, ''), created_at
+        FROM bundles
+        WHERE name = $1
+    `
+	err := tx.QueryRow(ctx, query, name).Scan(&b.ID, &b.Name, &b.Price, &b.Retired, &b.CreatedBy, &b.CreatedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return models.Bundle{}, models.ErrBundleNotFound
+	}
+	if err != nil {
+		return models.Bundle{}, fmt.Errorf("cannot find bundle '%s': %w", name, err)
+	}
+
+	b.Items, err = r.GetBundleItems(ctx, tx, b.ID)
+	if err != nil {
+		return models.Bundle{}, err
+	}
+
+	return b, nil
+}
+
+func (r *Repository) GetBundleItems(ctx context.Context, tx pgx.Tx, bundleID string) ([]models.BundleItem, error) {
+	query := `
+        SELECT bi.item_id, c.name, bi.variant_sku, bi.quantity
+        FROM bundle_items bi
+        JOIN catalog c ON c.id = bi.item_id
+        WHERE bi.bundle_id = $1
+        ORDER BY c.name, bi.variant_sku
+    `
+	rows, err := tx.Query(ctx, query, bundleID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query items of bundle %s: %w", bundleID, err)
+	}
+	defer rows.Close()
+
+	var result []models.BundleItem
+	for rows.Next() {
+		var it models.BundleItem
+		if err := rows.Scan(&it.ItemID, &it.Item, &it.Variant, &it.Quantity); err != nil {
+			return nil, fmt.Errorf("failed to scan bundle item row: %w", err)
+		}
+		result = append(result, it)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error during rows iteration: %w", err)
+	}
+
+	return result, nil
+}
+
+// ListBundles - наборы, которые ещё продаются, по имени
+func (r *Repository) ListBundles(ctx context.Context, tx pgx.Tx) ([]models.Bundle, error) {
+	query := `
+        SELECT id, name, price, retired, COALESCE(created_by::text, ''), created_at
+        FROM bundles
+        WHERE NOT retired
+        ORDER BY name
+    `
+	rows, err := tx.Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query bundles: %w", err)
+	}
+
+	var result []models.Bundle
+	for rows.Next() {
+		var b models.Bundle
+		if err := rows.Scan(&b.ID, &b.Name, &b.Price, &b.Retired, &b.CreatedBy, &b.CreatedAt); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("failed to scan bundle row: %w", err)
+		}
+		result = append(result, b)
+	}
+	rows.Close()
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error during rows iteration: %w", err)
+	}
+
+	for i := range result {
+		if result[i].Items, err = r.GetBundleItems(ctx, tx, result[i].ID); err != nil {
+			return nil, err
+		}
+	}
+
+	return result, nil
+}
+
+func (r *Repository) RetireBundle(ctx context.Context, tx pgx.Tx, name string) error {
+	query := `UPDATE bundles SET retired = TRUE WHERE name = $1 AND NOT retired`
+	tag, err := tx.Exec(ctx, query, name)
+	if err != nil {
+		return fmt.Errorf("failed to retire bundle '%s': %w", name, err)
+	}
+	if tag.RowsAffected() == 0 {
+		return models.ErrBundleNotFound
+	}
+	return nil
+}
@@ -0,0 +1,108 @@
+package cart
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"AvitoTask/internal/models"
+)
+
+type Repository struct {
+	pool *pgxpool.Pool
+}
+
+func NewRepository(pool *pgxpool.Pool) *Repository {
+	return &Repository{pool: pool}
+}
+
+func (r *Repository) BeginTx(ctx context.Context) (pgx.Tx, error) {
+	return r.pool.Begin(ctx)
+}
+
+// AddItem - добавляет позицию в корзину; variant - SKU варианта, пустая строка означает базовую позицию
+func (r *Repository) AddItem(ctx context.Context, tx pgx.Tx, userID, itemID, variant string, quantity int64) error {
+	query := `
+        INSERT INTO cart_items (user_id, item_id, variant_sku, quantity)
+        VALUES ($1, $2, $3, $4)
+        ON CONFLICT (user_id, item_id, variant_sku)
+        DO UPDATE SET quantity = cart_items.quantity + EXCLUDED.quantity
+    `
+	_, err := tx.Exec(ctx, query, userID, itemID, variant, quantity)
+	if err != nil {
+		return fmt.Errorf("failed to add item %s to cart of user %s: %w", itemID, userID, err)
+	}
+	return nil
+}
+
+func (r *Repository) RemoveItem(ctx context.Context, tx pgx.Tx, userID, itemID, variant string) error {
+	query := `DELETE FROM cart_items WHERE user_id = $1 AND item_id = $2 AND variant_sku = $3`
+	tag, err := tx.Exec(ctx, query, userID, itemID, variant)
+	if err != nil {
+		return fmt.Errorf("failed to remove item %s from cart of user %s: %w", itemID, userID, err)
+	}
+	if tag.RowsAffected() == 0 {
+		return models.ErrNotInCart
+	}
+	return nil
+}
+
+func (r *Repository) ClearCart(ctx context.Context, tx pgx.Tx, userID string) error {
+	query := `DELETE FROM cart_items WHERE user_id = $1`
+	_, err := tx.Exec(ctx, query, userID)
+	if err != nil {
+		return fmt.Errorf("failed to clear cart of user %s: %w", userID, err)
+	}
+	return nil
+}
+
+func (r *Repository) GetCart(ctx context.Context, tx pgx.Tx, userID string) ([]models.CartLine, error) {
+	query := `
+        SELECT c.name, ci.variant_sku, COALESCE(v.price, c.price), ci.quantity
+        FROM cart_items ci
+        JOIN catalog c ON c.id = ci.item_id
+        LEFT JOIN item_variants v ON v.sku = ci.variant_sku
+        WHERE ci.user_id = $1
+        ORDER BY ci.added_at, c.name, ci.variant_sku
+    `
+	return r.queryLines(ctx, tx, query, userID)
+}
+
+// LockCart - то же, что GetCart, но блокирует строки корзины до конца транзакции
+func (r *Repository) LockCart(ctx context.Context, tx pgx.Tx, userID string) ([]models.CartLine, error) {
+	query := `
+        SELECT c.name, ci.variant_sku, COALESCE(v.price, c.price), ci.quantity
+        FROM cart_items ci
+        JOIN catalog c ON c.id = ci.item_id
+        LEFT JOIN item_variants v ON v.sku = ci.variant_sku
+        WHERE ci.user_id = $1
+        ORDER BY ci.added_at, c.name, ci.variant_sku
+        FOR UPDATE OF ci
+    `
+	return r.queryLines(ctx, tx, query, userID)
+}
+
+func (r *Repository) queryLines(ctx context.Context, tx pgx.Tx, query, userID string) ([]models.CartLine, error) {
+	rows, err := tx.Query(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query cart: %w", err)
+	}
+	defer rows.Close()
+
+	var result []models.CartLine
+	for rows.Next() {
+		var line models.CartLine
+		if err := rows.Scan(&line.Item, &line.Variant, &line.Price, &line.Quantity); err != nil {
+			return nil, fmt.Errorf("failed to scan cart row: %w", err)
+		}
+		result = append(result, line)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error during rows iteration: %w", err)
+	}
+
+	return result, nil
+}
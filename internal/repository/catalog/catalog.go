@@ -0,0 +1,275 @@
+package catalog
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"AvitoTask/internal/models"
+)
+
+type Repository struct {
+	pool *pgxpool.Pool
+}
+
+func NewRepository(pool *pgxpool.Pool) *Repository {
+	return &Repository{pool: pool}
+}
+
+func (r *Repository) BeginTx(ctx context.Context) (pgx.Tx, error) {
+	return r.pool.Begin(ctx)
+}
+
+func (r *Repository) GetItemByName(ctx context.Context, tx pgx.Tx, name string) (models.CatalogItem, error) {
+	query := `SELECT id, name, category, price, stock, purchase_limit, limit_period, hidden, retired, version, updated_at
+              FROM catalog
+              WHERE name = $1
+              LIMIT 1`
+
+	return scanItem(tx.QueryRow(ctx, query, name), name)
+}
+
+func (r *Repository) LockItemByName(ctx context.Context, tx pgx.Tx, name string) (models.CatalogItem, error) {
+	query := `SELECT id, name, category, price, stock, purchase_limit, limit_period, hidden, retired, version, updated_at
+              FROM catalog
+              WHERE name = $1
+              LIMIT 1
+              FOR UPDATE`
+
+	return scanItem(tx.QueryRow(ctx, query, name), name)
+}
+
+func (r *Repository) InsertItem(ctx context.Context, tx pgx.Tx, item models.CatalogItem) error {
+	query := `
+        INSERT INTO catalog (id, name, category, price, stock, purchase_limit, limit_period, hidden, retired, version)
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+    `
+	_, err := tx.Exec(ctx, query, item.ID, item.Name, item.Category, item.Price, item.Stock, item.PurchaseLimit, item.LimitPeriod,
+		item.Hidden, item.Retired, item.Version)
+	if err != nil {
+		return fmt.Errorf("failed to insert catalog item '%s': %w", item.Name, err)
+	}
+	return nil
+}
+
+func (r *Repository) UpdateItem(ctx context.Context, tx pgx.Tx, item models.CatalogItem) error {
+	query := `
+        UPDATE catalog
+        SET price = $1, purchase_limit = $2, limit_period = $3, hidden = $4, retired = $5, version = $6,
+            updated_at = CURRENT_TIMESTAMP
+        WHERE id = $7
+    `
+	_, err := tx.Exec(ctx, query, item.Price, item.PurchaseLimit, item.LimitPeriod, item.Hidden, item.Retired, item.Version, item.ID)
+	if err != nil {
+		return fmt.Errorf("failed to update catalog item '%s': %w", item.Name, err)
+	}
+	return nil
+}
+
+func (r *Repository) InsertItemVersion(ctx context.Context, tx pgx.Tx, id string, item models.CatalogItem, changedBy string) error {
+	query := `
+        INSERT INTO catalog_versions (id, item_id, version, name, price, hidden, retired, changed_by)
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+    `
+	_, err := tx.Exec(ctx, query, id, item.ID, item.Version, item.Name, item.Price, item.Hidden, item.Retired, changedBy)
+	if err != nil {
+		return fmt.Errorf("failed to insert version %d of catalog item '%s': %w", item.Version, item.Name, err)
+	}
+	return nil
+}
+
+func (r *Repository) GetItemVersions(ctx context.Context, tx pgx.Tx, itemID string) ([]models.CatalogItemVersion, error) {
+	query := `
+        SELECT item_id, version, name, price, hidden, retired, COALESCE(changed_by::text, ''), changed_at
+        FROM catalog_versions
+        WHERE item_id = $1
+        ORDER BY version DESC
+    `
+	rows, err := tx.Query(ctx, query, itemID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query catalog versions: %w", err)
+	}
+	defer rows.Close()
+
+	var result []models.CatalogItemVersion
+	for rows.Next() {
+		var v models.CatalogItemVersion
+		if err := rows.Scan(&v.ItemID, &v.Version, &v.Name, &v.Price, &v.Hidden, &v.Retired, &v.ChangedBy, &v.ChangedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan catalog version row: %w", err)
+		}
+		result = append(result, v)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error during rows iteration: %w", err)
+	}
+
+	return result, nil
+}
+
+func (r *Repository) DecrementStock(ctx context.Context, tx pgx.Tx, itemID string, quantity int64) error {
+	query := `
+        UPDATE catalog
+        SET stock = stock - $1
+        WHERE id = $2 AND stock IS NOT NULL AND stock >= $1
+    `
+	tag, err := tx.Exec(ctx, query, quantity, itemID)
+	if err != nil {
+		return fmt.Errorf("failed to decrement stock of item %s: %w", itemID, err)
+	}
+	if tag.RowsAffected() == 0 {
+		return models.ErrSoldOut
+	}
+	return nil
+}
+
+// AddStock - пополняет ограниченный запас позиции; неограниченный запас (NULL) не трогает
+func (r *Repository) AddStock(ctx context.Context, tx pgx.Tx, itemID string, quantity int64) (int64, error) {
+	var stock int64
+	query := `
+        UPDATE catalog
+        SET stock = stock + $1
+        WHERE id = $2 AND stock IS NOT NULL
+        RETURNING stock
+    `
+	err := tx.QueryRow(ctx, query, quantity, itemID).Scan(&stock)
+	if err != nil {
+		return 0, fmt.Errorf("failed to add stock to item %s: %w", itemID, err)
+	}
+	return stock, nil
+}
+
+// ReturnStock - возвращает товар на склад; для позиций с неограниченным запасом ничего не делает и возвращает false
+func (r *Repository) ReturnStock(ctx context.Context, tx pgx.Tx, itemID string, quantity int64) (bool, error) {
+	query := `
+        UPDATE catalog
+        SET stock = stock + $1
+        WHERE id = $2 AND stock IS NOT NULL
+    `
+	tag, err := tx.Exec(ctx, query, quantity, itemID)
+	if err != nil {
+		return false, fmt.Errorf("failed to return stock of item %s: %w", itemID, err)
+	}
+	return tag.RowsAffected() > 0, nil
+}
+
+func (r *Repository) InsertStockMovement(ctx context.Context, tx pgx.Tx, m models.StockMovement) error {
+	query := `
+        INSERT INTO stock_movements (id, item_id, variant_sku, delta, reason, user_id)
+        VALUES ($1, $2, $3, $4, $5, $6)
+    `
+	_, err := tx.Exec(ctx, query, m.ID, m.ItemID, m.Variant, m.Delta, m.Reason, m.UserID)
+	if err != nil {
+		return fmt.Errorf("failed to insert stock movement for item %s: %w", m.ItemID, err)
+	}
+	return nil
+}
+
+func (r *Repository) GetStockMovements(ctx context.Context, tx pgx.Tx, itemID string) ([]models.StockMovement, error) {
+	query := `
+        SELECT id, item_id, variant_sku, delta, reason, COALESCE(user_id::text, ''), created_at
+        FROM stock_movements
+        WHERE item_id = $1
+        ORDER BY created_at DESC
+    `
+	rows, err := tx.Query(ctx, query, itemID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query stock movements: %w", err)
+	}
+	defer rows.Close()
+
+	var result []models.StockMovement
+	for rows.Next() {
+		var m models.StockMovement
+		if err := rows.Scan(&m.ID, &m.ItemID, &m.Variant, &m.Delta, &m.Reason, &m.UserID, &m.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan stock movement row: %w", err)
+		}
+		result = append(result, m)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error during rows iteration: %w", err)
+	}
+
+	return result, nil
+}
+
+func (r *Repository) ListItems(ctx context.Context, tx pgx.Tx, filter models.CatalogFilter) ([]models.CatalogItem, int64, error) {
+	conditions := []string{"hidden = FALSE", "retired = FALSE"}
+	var args []any
+
+	if filter.MinPrice > 0 {
+		args = append(args, filter.MinPrice)
+		conditions = append(conditions, fmt.Sprintf("price >= $%d", len(args)))
+	}
+	if filter.MaxPrice > 0 {
+		args = append(args, filter.MaxPrice)
+		conditions = append(conditions, fmt.Sprintf("price <= $%d", len(args)))
+	}
+	if filter.Category != "" {
+		args = append(args, filter.Category)
+		conditions = append(conditions, fmt.Sprintf("category = $%d", len(args)))
+	}
+	where := strings.Join(conditions, " AND ")
+
+	var total int64
+	countQuery := `SELECT COUNT(*) FROM catalog WHERE ` + where
+	if err := tx.QueryRow(ctx, countQuery, args...).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("failed to count catalog items: %w", err)
+	}
+
+	orderBy := "name"
+	if filter.SortBy == models.SortByPrice {
+		orderBy = "price"
+	}
+	direction := "ASC"
+	if filter.Desc {
+		direction = "DESC"
+	}
+
+	args = append(args, filter.Limit, filter.Offset)
+	query := fmt.Sprintf(`
+        SELECT id, name, category, price, stock, purchase_limit, limit_period, hidden, retired, version, updated_at
+        FROM catalog
+        WHERE %s
+        ORDER BY %s %s, name ASC
+        LIMIT $%d OFFSET $%d
+    `, where, orderBy, direction, len(args)-1, len(args))
+
+	rows, err := tx.Query(ctx, query, args...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to query catalog: %w", err)
+	}
+	defer rows.Close()
+
+	var result []models.CatalogItem
+	for rows.Next() {
+		var item models.CatalogItem
+		if err := rows.Scan(&item.ID, &item.Name, &item.Category, &item.Price, &item.Stock, &item.PurchaseLimit, &item.LimitPeriod, &item.Hidden, &item.Retired, &item.Version, &item.UpdatedAt); err != nil {
+			return nil, 0, fmt.Errorf("failed to scan catalog row: %w", err)
+		}
+		result = append(result, item)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("error during rows iteration: %w", err)
+	}
+
+	return result, total, nil
+}
+
+func scanItem(row pgx.Row, name string) (models.CatalogItem, error) {
+	var item models.CatalogItem
+	err := row.Scan(&item.ID, &item.Name, &item.Category, &item.Price, &item.Stock, &item.PurchaseLimit, &item.LimitPeriod, &item.Hidden, &item.Retired, &item.Version, &item.UpdatedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return models.CatalogItem{}, models.ErrItemNotFound
+	}
+	if err != nil {
+		return models.CatalogItem{}, fmt.Errorf("cannot find catalog item '%s': %w", name, err)
+	}
+	return item, nil
+}
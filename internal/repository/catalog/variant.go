@@ -0,0 +1,99 @@
+package catalog
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+
+	"AvitoTask/internal/models"
+)
+
+func (r *Repository) InsertVariant(ctx context.Context, tx pgx.Tx, v models.ItemVariant) error {
+	query := `
+        INSERT INTO item_variants (id, item_id, sku, size, color, price, stock)
+        VALUES ($1, $2, $3, $4, $5, $6, $7)
+    `
+	_, err := tx.Exec(ctx, query, v.ID, v.ItemID, v.SKU, v.Size, v.Color, v.Price, v.Stock)
+	if err != nil {
+		return fmt.Errorf("failed to insert item variant '%s': %w", v.SKU, err)
+	}
+	return nil
+}
+
+func (r *Repository) GetVariantBySKU(ctx context.Context, tx pgx.Tx, sku string) (models.ItemVariant, error) {
+	var v models.ItemVariant
+	query := `
+        SELECT id, item_id, sku, size, color, price, stock, created_at
+        FROM item_variants
+        WHERE sku = $1
+    `
+	err := tx.QueryRow(ctx, query, sku).Scan(&v.ID, &v.ItemID, &v.SKU, &v.Size, &v.Color, &v.Price, &v.Stock, &v.CreatedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return models.ItemVariant{}, models.ErrVariantNotFound
+	}
+	if err != nil {
+		return models.ItemVariant{}, fmt.Errorf("cannot find item variant '%s': %w", sku, err)
+	}
+	return v, nil
+}
+
+func (r *Repository) GetItemVariants(ctx context.Context, tx pgx.Tx, itemID string) ([]models.ItemVariant, error) {
+	query := `
+        SELECT id, item_id, sku, size, color, price, stock, created_at
+        FROM item_variants
+        WHERE item_id = $1
+        ORDER BY sku
+    `
+	rows, err := tx.Query(ctx, query, itemID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query item variants: %w", err)
+	}
+	defer rows.Close()
+
+	var result []models.ItemVariant
+	for rows.Next() {
+		var v models.ItemVariant
+		if err := rows.Scan(&v.ID, &v.ItemID, &v.SKU, &v.Size, &v.Color, &v.Price, &v.Stock, &v.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan item variant row: %w", err)
+		}
+		result = append(result, v)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error during rows iteration: %w", err)
+	}
+
+	return result, nil
+}
+
+func (r *Repository) DecrementVariantStock(ctx context.Context, tx pgx.Tx, sku string, quantity int64) error {
+	query := `
+        UPDATE item_variants
+        SET stock = stock - $1
+        WHERE sku = $2 AND stock IS NOT NULL AND stock >= $1
+    `
+	tag, err := tx.Exec(ctx, query, quantity, sku)
+	if err != nil {
+		return fmt.Errorf("failed to decrement stock of variant %s: %w", sku, err)
+	}
+	if tag.RowsAffected() == 0 {
+		return models.ErrSoldOut
+	}
+	return nil
+}
+
+// ReturnVariantStock - как ReturnStock, но для варианта позиции
+func (r *Repository) ReturnVariantStock(ctx context.Context, tx pgx.Tx, sku string, quantity int64) (bool, error) {
+	query := `
+        UPDATE item_variants
+        SET stock = stock + $1
+        WHERE sku = $2 AND stock IS NOT NULL
+    `
+	tag, err := tx.Exec(ctx, query, quantity, sku)
+	if err != nil {
+		return false, fmt.Errorf("failed to return stock of variant %s: %w", sku, err)
+	}
+	return tag.RowsAffected() > 0, nil
+}
@@ -0,0 +1,181 @@
+package coupon
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"AvitoTask/internal/models"
+)
+
+type Repository struct {
+	pool *pgxpool.Pool
+}
+
+func NewRepository(pool *pgxpool.Pool) *Repository {
+	return &Repository{pool: pool}
+}
+
+func (r *Repository) BeginTx(ctx context.Context) (pgx.Tx, error) {
+	return r.pool.Begin(ctx)
+}
+
+const selectCoupon = `
+        SELECT code, batch_id, COALESCE(percent, 0), COALESCE(amount, 0), max_redemptions, redeemed, min_spend,
+               items, expires_at, COALESCE(created_by::text, ''), created_at
+        FROM coupons
+`
+
+func (r *Repository) InsertCoupon(ctx context.Context, tx pgx.Tx, c models.Coupon) error {
+	query := `
+        INSERT INTO coupons (code, batch_id, percent, amount, max_redemptions, min_spend, items, expires_at, created_by)
+        VALUES ($1, $2, NULLIF($3, 0), NULLIF($4, 0), $5, $6, $7, $8, $9)
+    `
+	_, err := tx.Exec(ctx, query, c.Code, c.BatchID, c.Percent, c.Amount, c.MaxRedemptions, c.MinSpend, c.Items, c.ExpiresAt, c.CreatedBy)
+	if err != nil {
+		return fmt.Errorf("failed to insert coupon '%s': %w", c.Code, err)
+	}
+	return nil
+}
+
+// LockCoupon - блокирует строку купона до конца транзакции, чтобы параллельные покупки гасили код по очереди
+func (r *Repository) LockCoupon(ctx context.Context, tx pgx.Tx, code string) (models.Coupon, error) {
+	query := selectCoupon + `
+        WHERE code = $1
+        FOR UPDATE
+    `
+	c, err := scanCoupon(tx.QueryRow(ctx, query, code))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return models.Coupon{}, models.ErrCouponNotFound
+	}
+	if err != nil {
+		return models.Coupon{}, fmt.Errorf("cannot find coupon '%s': %w", code, err)
+	}
+	return c, nil
+}
+
+func (r *Repository) GetBatchCoupons(ctx context.Context, tx pgx.Tx, batchID string) ([]models.Coupon, error) {
+	query := selectCoupon + `
+        WHERE batch_id = $1
+        ORDER BY code
+    `
+	rows, err := tx.Query(ctx, query, batchID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query coupons: %w", err)
+	}
+	defer rows.Close()
+
+	var result []models.Coupon
+	for rows.Next() {
+		c, err := scanCoupon(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan coupon row: %w", err)
+		}
+		result = append(result, c)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error during rows iteration: %w", err)
+	}
+
+	return result, nil
+}
+
+func (r *Repository) IncrementRedemptions(ctx context.Context, tx pgx.Tx, code string) error {
+	query := `
+        UPDATE coupons
+        SET redeemed = redeemed + 1
+        WHERE code = $1 AND redeemed < max_redemptions
+    `
+	tag, err := tx.Exec(ctx, query, code)
+	if err != nil {
+		return fmt.Errorf("failed to redeem coupon %s: %w", code, err)
+	}
+	if tag.RowsAffected() == 0 {
+		return models.ErrCouponExhausted
+	}
+	return nil
+}
+
+func (r *Repository) HasRedeemed(ctx context.Context, tx pgx.Tx, code, userID string) (bool, error) {
+	var exists bool
+	query := `SELECT EXISTS(SELECT 1 FROM coupon_redemptions WHERE code = $1 AND user_id = $2)`
+	if err := tx.QueryRow(ctx, query, code, userID).Scan(&exists); err != nil {
+		return false, fmt.Errorf("failed to check redemption of coupon %s: %w", code, err)
+	}
+	return exists, nil
+}
+
+func (r *Repository) InsertRedemption(ctx context.Context, tx pgx.Tx, red models.CouponRedemption) error {
+	query := `
+        INSERT INTO coupon_redemptions (id, code, user_id, discount)
+        VALUES ($1, $2, $3, $4)
+    `
+	_, err := tx.Exec(ctx, query, red.ID, red.Code, red.UserID, red.Discount)
+	if err != nil {
+		return fmt.Errorf("failed to insert redemption of coupon %s: %w", red.Code, err)
+	}
+	return nil
+}
+
+// ReleaseRedemption - отменяет погашение промокода пользователем: код снова можно применить,
+// а у партии освобождается одно погашение
+func (r *Repository) ReleaseRedemption(ctx context.Context, tx pgx.Tx, code, userID string) error {
+	tag, err := tx.Exec(ctx, `DELETE FROM coupon_redemptions WHERE code = $1 AND user_id = $2`, code, userID)
+	if err != nil {
+		return fmt.Errorf("failed to delete redemption of coupon %s: %w", code, err)
+	}
+	if tag.RowsAffected() == 0 {
+		return nil
+	}
+
+	query := `
+        UPDATE coupons
+        SET redeemed = redeemed - 1
+        WHERE code = $1 AND redeemed > 0
+    `
+	if _, err = tx.Exec(ctx, query, code); err != nil {
+		return fmt.Errorf("failed to release coupon %s: %w", code, err)
+	}
+	return nil
+}
+
+func (r *Repository) GetRedemptions(ctx context.Context, tx pgx.Tx, code string) ([]models.CouponRedemption, error) {
+	query := `
+        SELECT cr.id, cr.code, cr.user_id, COALESCE(u.username, ''), cr.discount, cr.created_at
+        FROM coupon_redemptions cr
+        LEFT JOIN users u ON u.id = cr.user_id
+        WHERE cr.code = $1
+        ORDER BY cr.created_at DESC
+    `
+	rows, err := tx.Query(ctx, query, code)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query coupon redemptions: %w", err)
+	}
+	defer rows.Close()
+
+	var result []models.CouponRedemption
+	for rows.Next() {
+		var red models.CouponRedemption
+		if err := rows.Scan(&red.ID, &red.Code, &red.UserID, &red.Username, &red.Discount, &red.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan coupon redemption row: %w", err)
+		}
+		result = append(result, red)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error during rows iteration: %w", err)
+	}
+
+	return result, nil
+}
+
+func scanCoupon(row pgx.Row) (models.Coupon, error) {
+	var c models.Coupon
+	err := row.Scan(&c.Code, &c.BatchID, &c.Percent, &c.Amount, &c.MaxRedemptions, &c.Redeemed, &c.MinSpend,
+		&c.Items, &c.ExpiresAt, &c.CreatedBy, &c.CreatedAt)
+	return c, err
+}
@@ -0,0 +1,57 @@
+package idempotency
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"AvitoTask/internal/models"
+)
+
+type Repository struct {
+	pool *pgxpool.Pool
+}
+
+func NewRepository(pool *pgxpool.Pool) *Repository {
+	return &Repository{pool: pool}
+}
+
+// ClaimKey - занимает ключ в транзакции tx и возвращает его как есть, если ключ новый. Если ключ уже
+// занят, возвращает сохранённую запись. Параллельный запрос с тем же ключом ждёт на вставке, пока
+// транзакция, занявшая ключ, не завершится, поэтому незавершённых записей он не видит
+func (r *Repository) ClaimKey(ctx context.Context, tx pgx.Tx, k models.IdempotencyKey) (models.IdempotencyKey, error) {
+	query := `
+        INSERT INTO idempotency_keys (user_id, key, request_hash)
+        VALUES ($1, $2, $3)
+        ON CONFLICT (user_id, key) DO NOTHING
+    `
+	tag, err := tx.Exec(ctx, query, k.UserID, k.Key, k.RequestHash)
+	if err != nil {
+		return k, fmt.Errorf("failed to claim idempotency key %s of user %s: %w", k.Key, k.UserID, err)
+	}
+	if tag.RowsAffected() == 1 {
+		return k, nil
+	}
+
+	stored := models.IdempotencyKey{UserID: k.UserID, Key: k.Key}
+	var response []byte
+	query = `SELECT request_hash, response, created_at FROM idempotency_keys WHERE user_id = $1 AND key = $2`
+	err = tx.QueryRow(ctx, query, k.UserID, k.Key).Scan(&stored.RequestHash, &response, &stored.CreatedAt)
+	if err != nil {
+		return k, fmt.Errorf("failed to get idempotency key %s of user %s: %w", k.Key, k.UserID, err)
+	}
+	stored.Response = response
+
+	return stored, nil
+}
+
+// SaveResponse - сохраняет ответ на запрос в той же транзакции, что и саму операцию
+func (r *Repository) SaveResponse(ctx context.Context, tx pgx.Tx, k models.IdempotencyKey) error {
+	query := `UPDATE idempotency_keys SET response = $3 WHERE user_id = $1 AND key = $2`
+	if _, err := tx.Exec(ctx, query, k.UserID, k.Key, string(k.Response)); err != nil {
+		return fmt.Errorf("failed to save response for idempotency key %s of user %s: %w", k.Key, k.UserID, err)
+	}
+	return nil
+}
@@ -23,49 +23,86 @@ func (r *Repository) BeginTx(ctx context.Context) (pgx.Tx, error) {
 	return r.pool.Begin(ctx)
 }
 
-func (r *Repository) GetInventoryItem(ctx context.Context, tx pgx.Tx, userID, itemType string) (int64, error) {
+// GetInventoryItem - количество позиции у пользователя; строка блокируется до конца транзакции,
+// чтобы остаток не изменился, пока по нему принимается решение
+func (r *Repository) GetInventoryItem(ctx context.Context, tx pgx.Tx, userID, itemType, variant string) (int64, error) {
 	var quantity int64
 	query := `SELECT quantity 
               FROM inventory 
-              WHERE user_id = $1 AND item_type = $2
-              LIMIT 1`
-	err := tx.QueryRow(ctx, query, userID, itemType).Scan(&quantity)
+              WHERE user_id = $1 AND item_type = $2 AND variant_sku = $3
+              LIMIT 1
+              FOR UPDATE`
+	err := tx.QueryRow(ctx, query, userID, itemType, variant).Scan(&quantity)
 	if err != nil {
 		return 0, err
 	}
 	return quantity, nil
 }
 
-func (r *Repository) InsertInventoryItem(ctx context.Context, tx pgx.Tx, id, userID, itemType string) error {
+func (r *Repository) InsertInventoryItem(ctx context.Context, tx pgx.Tx, id, userID, itemType, variant string) error {
 	query := `
-        INSERT INTO inventory (id, user_id, item_type, quantity)
-        VALUES ($1, $2, $3, 1)
+        INSERT INTO inventory (id, user_id, item_type, variant_sku, quantity)
+        VALUES ($1, $2, $3, $4, 1)
     `
-	_, err := tx.Exec(ctx, query, id, userID, itemType)
+	_, err := tx.Exec(ctx, query, id, userID, itemType, variant)
 	if err != nil {
 		return fmt.Errorf("failed to insert new item '%s' for user %s: %w", itemType, userID, err)
 	}
 	return nil
 }
 
-func (r *Repository) UpdateInventoryItem(ctx context.Context, tx pgx.Tx, userID, itemType string, newQuantity int64) error {
+func (r *Repository) UpdateInventoryItem(ctx context.Context, tx pgx.Tx, userID, itemType, variant string, newQuantity int64) error {
 	query := `
         UPDATE inventory 
         SET quantity = $1
-        WHERE user_id = $2 AND item_type = $3
+        WHERE user_id = $2 AND item_type = $3 AND variant_sku = $4
     `
-	_, err := tx.Exec(ctx, query, newQuantity, userID, itemType)
+	_, err := tx.Exec(ctx, query, newQuantity, userID, itemType, variant)
 	if err != nil {
 		return fmt.Errorf("failed to update item '%s' for user %s: %w", itemType, userID, err)
 	}
 	return nil
 }
 
+// TakeInventoryItem - списывает quantity единиц позиции у пользователя, если их хватает;
+// иначе возвращает models.ErrNotEnoughItems
+func (r *Repository) TakeInventoryItem(ctx context.Context, tx pgx.Tx, userID, itemType, variant string, quantity int64) error {
+	query := `
+        UPDATE inventory
+        SET quantity = quantity - $1
+        WHERE user_id = $2 AND item_type = $3 AND variant_sku = $4 AND quantity >= $1
+    `
+	tag, err := tx.Exec(ctx, query, quantity, userID, itemType, variant)
+	if err != nil {
+		return fmt.Errorf("failed to take item '%s' from user %s: %w", itemType, userID, err)
+	}
+	if tag.RowsAffected() == 0 {
+		return models.ErrNotEnoughItems
+	}
+	return nil
+}
+
+// AddInventoryItem - добавляет quantity единиц позиции к инвентарю пользователя, при необходимости создавая строку.
+// Upsert по уникальному ключу не даёт параллельным пополнениям создать вторую строку той же позиции
+func (r *Repository) AddInventoryItem(ctx context.Context, tx pgx.Tx, id, userID, itemType, variant string, quantity int64) error {
+	query := `
+        INSERT INTO inventory (id, user_id, item_type, variant_sku, quantity)
+        VALUES ($1, $2, $3, $4, $5)
+        ON CONFLICT (user_id, item_type, variant_sku)
+        DO UPDATE SET quantity = inventory.quantity + EXCLUDED.quantity
+    `
+	if _, err := tx.Exec(ctx, query, id, userID, itemType, variant, quantity); err != nil {
+		return fmt.Errorf("failed to add item '%s' for user %s: %w", itemType, userID, err)
+	}
+	return nil
+}
+
 func (r *Repository) GetUserInventory(ctx context.Context, tx pgx.Tx, userID string) ([]models.InventoryItem, error) {
 	query := `
-        SELECT item_type, quantity
+        SELECT item_type, variant_sku, quantity
         FROM inventory
         WHERE user_id = $1
+        ORDER BY item_type, variant_sku
     `
 	rows, err := tx.Query(ctx, query, userID)
 	if errors.Is(err, pgx.ErrNoRows) {
@@ -79,7 +116,7 @@ func (r *Repository) GetUserInventory(ctx context.Context, tx pgx.Tx, userID str
 	var result []models.InventoryItem
 	for rows.Next() {
 		var it models.InventoryItem
-		if err := rows.Scan(&it.ItemType, &it.Quantity); err != nil {
+		if err := rows.Scan(&it.ItemType, &it.Variant, &it.Quantity); err != nil {
 			return nil, fmt.Errorf("failed to scan inventory row: %w", err)
 		}
 		result = append(result, it)
@@ -91,3 +128,13 @@ func (r *Repository) GetUserInventory(ctx context.Context, tx pgx.Tx, userID str
 
 	return result, nil
 }
+
+// CountUserItems - сколько единиц позиции лежит в инвентаре пользователя по всем вариантам
+func (r *Repository) CountUserItems(ctx context.Context, tx pgx.Tx, userID, itemType string) (int64, error) {
+	var count int64
+	query := `SELECT COALESCE(SUM(quantity), 0) FROM inventory WHERE user_id = $1 AND item_type = $2`
+	if err := tx.QueryRow(ctx, query, userID, itemType).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count item '%s' for user %s: %w", itemType, userID, err)
+	}
+	return count, nil
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/stretchr/testify/suite"
 
+	"AvitoTask/internal/models"
 	"AvitoTask/internal/repository/inventory"
 )
 
@@ -213,6 +214,7 @@ func (s *InventoryRepoTestSuite) TestGetInventoryItem_Success() {
 	ctx := context.Background()
 	userID := "user-123"
 	itemType := "potion"
+	variant := "potion-xl"
 	expectedQuantity := int64(5)
 
 	row := &fakeRow{
@@ -223,11 +225,12 @@ func (s *InventoryRepoTestSuite) TestGetInventoryItem_Success() {
 			s.Contains(query, "SELECT quantity")
 			s.Equal(userID, args[0])
 			s.Equal(itemType, args[1])
+			s.Equal(variant, args[2])
 			return row
 		},
 	}
 
-	quantity, err := s.repo.GetInventoryItem(ctx, tx, userID, itemType)
+	quantity, err := s.repo.GetInventoryItem(ctx, tx, userID, itemType, variant)
 	s.NoError(err)
 	s.Equal(expectedQuantity, quantity)
 }
@@ -237,6 +240,7 @@ func (s *InventoryRepoTestSuite) TestGetInventoryItem_Error() {
 	ctx := context.Background()
 	userID := "user-123"
 	itemType := "potion"
+	variant := "potion-xl"
 	expectedErr := errors.New("query error")
 
 	row := &fakeRow{
@@ -248,7 +252,7 @@ func (s *InventoryRepoTestSuite) TestGetInventoryItem_Error() {
 		},
 	}
 
-	quantity, err := s.repo.GetInventoryItem(ctx, tx, userID, itemType)
+	quantity, err := s.repo.GetInventoryItem(ctx, tx, userID, itemType, variant)
 	s.Error(err)
 	s.Equal(int64(0), quantity)
 	s.Equal(expectedErr, err)
@@ -260,6 +264,7 @@ func (s *InventoryRepoTestSuite) TestInsertInventoryItem_Success() {
 	id := "item-123"
 	userID := "user-123"
 	itemType := "potion"
+	variant := "potion-xl"
 
 	tx := &fakeTx{
 		execFunc: func(ctx context.Context, query string, args ...any) (pgconn.CommandTag, error) {
@@ -267,12 +272,13 @@ func (s *InventoryRepoTestSuite) TestInsertInventoryItem_Success() {
 			s.Equal(id, args[0])
 			s.Equal(userID, args[1])
 			s.Equal(itemType, args[2])
+			s.Equal(variant, args[3])
 			// Имитация успешной вставки.
 			return pgconn.CommandTag{}, nil
 		},
 	}
 
-	err := s.repo.InsertInventoryItem(ctx, tx, id, userID, itemType)
+	err := s.repo.InsertInventoryItem(ctx, tx, id, userID, itemType, variant)
 	s.NoError(err)
 }
 
@@ -282,6 +288,7 @@ func (s *InventoryRepoTestSuite) TestInsertInventoryItem_Error() {
 	id := "item-123"
 	userID := "user-123"
 	itemType := "potion"
+	variant := "potion-xl"
 	expectedErr := errors.New("exec error")
 
 	tx := &fakeTx{
@@ -290,7 +297,7 @@ func (s *InventoryRepoTestSuite) TestInsertInventoryItem_Error() {
 		},
 	}
 
-	err := s.repo.InsertInventoryItem(ctx, tx, id, userID, itemType)
+	err := s.repo.InsertInventoryItem(ctx, tx, id, userID, itemType, variant)
 	s.Error(err)
 	s.Contains(err.Error(), fmt.Sprintf("failed to insert new item '%s' for user %s", itemType, userID))
 }
@@ -300,6 +307,7 @@ func (s *InventoryRepoTestSuite) TestUpdateInventoryItem_Success() {
 	ctx := context.Background()
 	userID := "user-123"
 	itemType := "potion"
+	variant := "potion-xl"
 	newQuantity := int64(10)
 
 	tx := &fakeTx{
@@ -308,11 +316,12 @@ func (s *InventoryRepoTestSuite) TestUpdateInventoryItem_Success() {
 			s.Equal(newQuantity, args[0])
 			s.Equal(userID, args[1])
 			s.Equal(itemType, args[2])
+			s.Equal(variant, args[3])
 			return pgconn.CommandTag{}, nil
 		},
 	}
 
-	err := s.repo.UpdateInventoryItem(ctx, tx, userID, itemType, newQuantity)
+	err := s.repo.UpdateInventoryItem(ctx, tx, userID, itemType, variant, newQuantity)
 	s.NoError(err)
 }
 
@@ -321,6 +330,7 @@ func (s *InventoryRepoTestSuite) TestUpdateInventoryItem_Error() {
 	ctx := context.Background()
 	userID := "user-123"
 	itemType := "potion"
+	variant := "potion-xl"
 	newQuantity := int64(10)
 	expectedErr := errors.New("update error")
 
@@ -330,19 +340,92 @@ func (s *InventoryRepoTestSuite) TestUpdateInventoryItem_Error() {
 		},
 	}
 
-	err := s.repo.UpdateInventoryItem(ctx, tx, userID, itemType, newQuantity)
+	err := s.repo.UpdateInventoryItem(ctx, tx, userID, itemType, variant, newQuantity)
 	s.Error(err)
 	s.Contains(err.Error(), fmt.Sprintf("failed to update item '%s' for user %s", itemType, userID))
 }
 
+// Тест для TakeInventoryItem: списание, когда предметов хватает.
+func (s *InventoryRepoTestSuite) TestTakeInventoryItem_Success() {
+	ctx := context.Background()
+	userID := "user-123"
+	itemType := "potion"
+	variant := "potion-xl"
+
+	tx := &fakeTx{
+		execFunc: func(ctx context.Context, query string, args ...any) (pgconn.CommandTag, error) {
+			s.Contains(query, "quantity = quantity - $1")
+			s.Contains(query, "quantity >= $1")
+			s.Equal(int64(2), args[0])
+			s.Equal(userID, args[1])
+			s.Equal(itemType, args[2])
+			s.Equal(variant, args[3])
+			return pgconn.NewCommandTag("UPDATE 1"), nil
+		},
+	}
+
+	err := s.repo.TakeInventoryItem(ctx, tx, userID, itemType, variant, 2)
+	s.NoError(err)
+}
+
+// Тест для TakeInventoryItem: предметов не хватает, строка не обновлена.
+func (s *InventoryRepoTestSuite) TestTakeInventoryItem_NotEnough() {
+	ctx := context.Background()
+
+	tx := &fakeTx{
+		execFunc: func(ctx context.Context, query string, args ...any) (pgconn.CommandTag, error) {
+			return pgconn.NewCommandTag("UPDATE 0"), nil
+		},
+	}
+
+	err := s.repo.TakeInventoryItem(ctx, tx, "user-123", "potion", "", 2)
+	s.ErrorIs(err, models.ErrNotEnoughItems)
+}
+
+// Тест для AddInventoryItem: одна вставка, которая при существующей строке увеличивает количество.
+func (s *InventoryRepoTestSuite) TestAddInventoryItem_Upsert() {
+	ctx := context.Background()
+	calls := 0
+
+	tx := &fakeTx{
+		execFunc: func(ctx context.Context, query string, args ...any) (pgconn.CommandTag, error) {
+			calls++
+			s.Contains(query, "INSERT INTO inventory")
+			s.Contains(query, "ON CONFLICT (user_id, item_type, variant_sku)")
+			s.Contains(query, "quantity = inventory.quantity + EXCLUDED.quantity")
+			s.Equal([]any{"item-123", "user-123", "potion", "potion-xl", int64(3)}, args)
+			return pgconn.NewCommandTag("INSERT 0 1"), nil
+		},
+	}
+
+	err := s.repo.AddInventoryItem(ctx, tx, "item-123", "user-123", "potion", "potion-xl", 3)
+	s.NoError(err)
+	s.Equal(1, calls)
+}
+
+// Тест для AddInventoryItem: ошибка базы оборачивается.
+func (s *InventoryRepoTestSuite) TestAddInventoryItem_Error() {
+	ctx := context.Background()
+	dbErr := errors.New("db down")
+
+	tx := &fakeTx{
+		execFunc: func(ctx context.Context, query string, args ...any) (pgconn.CommandTag, error) {
+			return pgconn.CommandTag{}, dbErr
+		},
+	}
+
+	err := s.repo.AddInventoryItem(ctx, tx, "item-123", "user-123", "potion", "", 3)
+	s.ErrorIs(err, dbErr)
+}
+
 func (s *InventoryRepoTestSuite) TestGetUserInventory_Success() {
 	ctx := context.Background()
 	userID := "user-123"
 
 	fRows := &fakeRows{
 		data: [][]interface{}{
-			{"potion", int64(5)},
-			{"elixir", int64(3)},
+			{"elixir", "", int64(3)},
+			{"potion", "potion-xl", int64(5)},
 		},
 		idx: 0,
 		err: nil,
@@ -351,9 +434,10 @@ func (s *InventoryRepoTestSuite) TestGetUserInventory_Success() {
 	tx := &fakeTx{
 		queryFunc: func(ctx context.Context, query string, args ...any) (pgx.Rows, error) {
 			s.Contains(query, `
-        SELECT item_type, quantity
+        SELECT item_type, variant_sku, quantity
         FROM inventory
         WHERE user_id = $1
+        ORDER BY item_type, variant_sku
     `)
 			s.Equal(userID, args[0])
 			return fRows, nil
@@ -363,10 +447,12 @@ func (s *InventoryRepoTestSuite) TestGetUserInventory_Success() {
 	items, err := s.repo.GetUserInventory(ctx, tx, userID)
 	s.NoError(err)
 	s.Len(items, 2)
-	s.Equal("potion", items[0].ItemType)
-	s.Equal(int64(5), items[0].Quantity)
-	s.Equal("elixir", items[1].ItemType)
-	s.Equal(int64(3), items[1].Quantity)
+	s.Equal("elixir", items[0].ItemType)
+	s.Equal("", items[0].Variant)
+	s.Equal(int64(3), items[0].Quantity)
+	s.Equal("potion", items[1].ItemType)
+	s.Equal("potion-xl", items[1].Variant)
+	s.Equal(int64(5), items[1].Quantity)
 }
 
 // Тест для GetUserInventory: ошибка запроса (например, ошибка выполнения Query).
@@ -420,7 +506,7 @@ func (s *InventoryRepoTestSuite) TestGetUserInventory_RowsErr() {
 	expectedErr := errors.New("rows error")
 	fRows := &fakeRows{
 		data: [][]interface{}{
-			{"potion", int64(5)},
+			{"potion", "", int64(5)},
 		},
 		idx: 0,
 		err: expectedErr,
@@ -0,0 +1,63 @@
+package item_transfer
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"AvitoTask/internal/models"
+)
+
+type Repository struct {
+	pool *pgxpool.Pool
+}
+
+func NewRepository(pool *pgxpool.Pool) *Repository {
+	return &Repository{pool: pool}
+}
+
+func (r *Repository) InsertItemTransfer(ctx context.Context, tx pgx.Tx, id, fromUserID, toUserID, itemType, variant string, quantity int64) error {
+	query := `
+        INSERT INTO item_transfers (id, from_user_id, to_user_id, item_type, variant_sku, quantity)
+        VALUES ($1, $2, $3, $4, $5, $6)
+    `
+	_, err := tx.Exec(ctx, query, id, fromUserID, toUserID, itemType, variant, quantity)
+	if err != nil {
+		return fmt.Errorf("failed to insert item transfer: %w", err)
+	}
+	return nil
+}
+
+func (r *Repository) GetUserItemTransfers(ctx context.Context, tx pgx.Tx, userID string) ([]models.ItemTransfer, error) {
+	query := `
+        SELECT from_user_id, to_user_id, item_type, variant_sku, quantity, created_at, u1.username, u2.username
+        FROM item_transfers
+        LEFT JOIN users as u1 ON u1.id = item_transfers.to_user_id
+        LEFT JOIN users as u2 ON u2.id = item_transfers.from_user_id
+        WHERE from_user_id = $1
+           OR to_user_id = $1
+        ORDER BY created_at DESC
+    `
+	rows, err := tx.Query(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query item transfers: %w", err)
+	}
+	defer rows.Close()
+
+	var result []models.ItemTransfer
+	for rows.Next() {
+		var t models.ItemTransfer
+		if err := rows.Scan(&t.FromUserID, &t.ToUserID, &t.ItemType, &t.Variant, &t.Quantity, &t.CreatedAt, &t.ToUserName, &t.FromUsername); err != nil {
+			return nil, fmt.Errorf("failed to scan item transfer row: %w", err)
+		}
+		result = append(result, t)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error during rows iteration: %w", err)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,91 @@
+package ledger
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"AvitoTask/internal/models"
+)
+
+type Repository struct {
+	pool *pgxpool.Pool
+}
+
+func NewRepository(pool *pgxpool.Pool) *Repository {
+	return &Repository{pool: pool}
+}
+
+func (r *Repository) BeginTx(ctx context.Context) (pgx.Tx, error) {
+	return r.pool.Begin(ctx)
+}
+
+// PostEntry - записывает запись журнала со всеми её проводками; несбалансированная запись отклоняется
+// ещё до обращения к базе, а в базе баланс дополнительно проверяется при коммите
+func (r *Repository) PostEntry(ctx context.Context, tx pgx.Tx, e models.LedgerEntry) error {
+	if !e.Balanced() {
+		return fmt.Errorf("%w: entry %s (%s)", models.ErrUnbalancedEntry, e.ID, e.Kind)
+	}
+
+	query := `INSERT INTO ledger_entries (id, kind, reference) VALUES ($1, $2, $3)`
+	if _, err := tx.Exec(ctx, query, e.ID, e.Kind, e.Reference); err != nil {
+		return fmt.Errorf("failed to insert ledger entry %s: %w", e.ID, err)
+	}
+
+	query = `INSERT INTO ledger_postings (entry_id, account, amount) VALUES ($1, $2, $3)`
+	for _, p := range e.Postings {
+		if _, err := tx.Exec(ctx, query, e.ID, p.Account, p.Amount); err != nil {
+			return fmt.Errorf("failed to insert posting to %s for ledger entry %s: %w", p.Account, e.ID, err)
+		}
+	}
+
+	return nil
+}
+
+// GetAccountBalance - баланс счёта как сумма всех его проводок
+func (r *Repository) GetAccountBalance(ctx context.Context, tx pgx.Tx, account string) (int64, error) {
+	var balance int64
+	query := `SELECT COALESCE(SUM(amount), 0) FROM ledger_postings WHERE account = $1`
+	if err := tx.QueryRow(ctx, query, account).Scan(&balance); err != nil {
+		return 0, fmt.Errorf("failed to get balance of account %s: %w", account, err)
+	}
+	return balance, nil
+}
+
+// GetBalanceMismatches - пользователи, у которых users.coins не совпадает с суммой проводок по их счёту
+func (r *Repository) GetBalanceMismatches(ctx context.Context, tx pgx.Tx) ([]models.BalanceMismatch, error) {
+	query := `
+        SELECT u.id, u.username, u.coins, COALESCE(p.balance, 0)
+        FROM users u
+        LEFT JOIN (
+            SELECT account, SUM(amount) AS balance
+            FROM ledger_postings
+            WHERE account LIKE 'user:%'
+            GROUP BY account
+        ) p ON p.account = 'user:' || u.id
+        WHERE u.coins <> COALESCE(p.balance, 0)
+        ORDER BY u.username
+    `
+	rows, err := tx.Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query balance mismatches: %w", err)
+	}
+	defer rows.Close()
+
+	var result []models.BalanceMismatch
+	for rows.Next() {
+		var m models.BalanceMismatch
+		if err := rows.Scan(&m.UserID, &m.Username, &m.Coins, &m.Ledger); err != nil {
+			return nil, fmt.Errorf("failed to scan balance mismatch row: %w", err)
+		}
+		result = append(result, m)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error during rows iteration: %w", err)
+	}
+
+	return result, nil
+}
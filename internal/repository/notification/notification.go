@@ -0,0 +1,80 @@
+package notification
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"AvitoTask/internal/models"
+)
+
+type Repository struct {
+	pool *pgxpool.Pool
+}
+
+func NewRepository(pool *pgxpool.Pool) *Repository {
+	return &Repository{pool: pool}
+}
+
+func (r *Repository) BeginTx(ctx context.Context) (pgx.Tx, error) {
+	return r.pool.Begin(ctx)
+}
+
+// QueueWishlistNotifications - ставит уведомление в очередь каждому, у кого в списке желаний есть позиция n.ItemID
+// или любая позиция категории category; возвращает число созданных уведомлений
+func (r *Repository) QueueWishlistNotifications(ctx context.Context, tx pgx.Tx, n models.Notification, category string) (int64, error) {
+	query := `
+        INSERT INTO notifications (user_id, kind, item_id, detail)
+        SELECT w.user_id, $1, w.item_id, $2
+        FROM wishlist w
+        JOIN catalog c ON c.id = w.item_id
+        WHERE w.item_id = NULLIF($3, '')::uuid OR c.category = NULLIF($4, '')
+    `
+	tag, err := tx.Exec(ctx, query, n.Kind, n.Detail, n.ItemID, category)
+	if err != nil {
+		return 0, fmt.Errorf("failed to queue '%s' notifications: %w", n.Kind, err)
+	}
+	return tag.RowsAffected(), nil
+}
+
+// GetNotifications - уведомления пользователя от новых к старым
+func (r *Repository) GetNotifications(ctx context.Context, tx pgx.Tx, userID string, unreadOnly bool) ([]models.Notification, error) {
+	query := `
+        SELECT n.id, n.user_id, n.kind, COALESCE(n.item_id::text, ''), COALESCE(c.name, ''), n.detail, n.created_at, n.read_at
+        FROM notifications n
+        LEFT JOIN catalog c ON c.id = n.item_id
+        WHERE n.user_id = $1 AND (NOT $2 OR n.read_at IS NULL)
+        ORDER BY n.created_at DESC, n.id
+    `
+	rows, err := tx.Query(ctx, query, userID, unreadOnly)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query notifications: %w", err)
+	}
+	defer rows.Close()
+
+	var result []models.Notification
+	for rows.Next() {
+		var n models.Notification
+		if err := rows.Scan(&n.ID, &n.UserID, &n.Kind, &n.ItemID, &n.Item, &n.Detail, &n.CreatedAt, &n.ReadAt); err != nil {
+			return nil, fmt.Errorf("failed to scan notification row: %w", err)
+		}
+		result = append(result, n)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error during rows iteration: %w", err)
+	}
+
+	return result, nil
+}
+
+func (r *Repository) MarkNotificationsRead(ctx context.Context, tx pgx.Tx, userID string) (int64, error) {
+	query := `UPDATE notifications SET read_at = CURRENT_TIMESTAMP WHERE user_id = $1 AND read_at IS NULL`
+	tag, err := tx.Exec(ctx, query, userID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to mark notifications of user %s as read: %w", userID, err)
+	}
+	return tag.RowsAffected(), nil
+}
@@ -0,0 +1,227 @@
+package order
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"AvitoTask/internal/models"
+)
+
+type Repository struct {
+	pool *pgxpool.Pool
+}
+
+func NewRepository(pool *pgxpool.Pool) *Repository {
+	return &Repository{pool: pool}
+}
+
+func (r *Repository) BeginTx(ctx context.Context) (pgx.Tx, error) {
+	return r.pool.Begin(ctx)
+}
+
+func (r *Repository) InsertOrder(ctx context.Context, tx pgx.Tx, o models.Order) error {
+	query := `
+        INSERT INTO orders (id, kind, refund_of, user_id, item_id, bundle_id, item_name, variant_sku, quantity, unit_price,
+                            discount, promotion_id, coupon_code, coupon_discount, total, status)
+        VALUES ($1, $2, NULLIF($3, '')::uuid, $4, NULLIF($5, '')::uuid, NULLIF($6, '')::uuid, $7, $8, $9, $10, $11,
+                NULLIF($12, '')::uuid, NULLIF($13, ''), $14, $15, $16)
+    `
+	_, err := tx.Exec(ctx, query, o.ID, o.Kind, o.RefundOf, o.UserID, o.ItemID, o.BundleID, o.Item, o.Variant, o.Quantity, o.UnitPrice,
+		o.Discount, o.PromotionID, o.CouponCode, o.CouponDiscount, o.Total, o.Status)
+	if err != nil {
+		return fmt.Errorf("failed to insert order for user %s: %w", o.UserID, err)
+	}
+	return nil
+}
+
+func (r *Repository) LockOrder(ctx context.Context, tx pgx.Tx, orderID string) (models.Order, error) {
+	var o models.Order
+	query := `
+        SELECT id, kind, COALESCE(refund_of::text, ''), user_id, COALESCE(item_id::text, ''), COALESCE(bundle_id::text, ''),
+               item_name, variant_sku, quantity, unit_price, discount, COALESCE(promotion_id::text, ''), COALESCE(coupon_code, ''),
+               coupon_discount, total, status, created_at
+        FROM orders
+        WHERE id = $1
+        FOR UPDATE
+    `
+	err := tx.QueryRow(ctx, query, orderID).Scan(&o.ID, &o.Kind, &o.RefundOf, &o.UserID, &o.ItemID, &o.BundleID, &o.Item, &o.Variant, &o.Quantity, &o.UnitPrice,
+		&o.Discount, &o.PromotionID, &o.CouponCode, &o.CouponDiscount, &o.Total, &o.Status, &o.CreatedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return models.Order{}, models.ErrOrderNotFound
+	}
+	if err != nil {
+		return models.Order{}, fmt.Errorf("cannot find order '%s': %w", orderID, err)
+	}
+	return o, nil
+}
+
+func (r *Repository) HasRefund(ctx context.Context, tx pgx.Tx, orderID string) (bool, error) {
+	var exists bool
+	query := `SELECT EXISTS(SELECT 1 FROM orders WHERE refund_of = $1)`
+	if err := tx.QueryRow(ctx, query, orderID).Scan(&exists); err != nil {
+		return false, fmt.Errorf("failed to check refund of order %s: %w", orderID, err)
+	}
+	return exists, nil
+}
+
+// GetUserOrders - заказы пользователя от новых к старым; limit <= 0 возвращает все заказы
+func (r *Repository) GetUserOrders(ctx context.Context, tx pgx.Tx, userID string, limit, offset int64) ([]models.Order, int64, error) {
+	var total int64
+	countQuery := `SELECT COUNT(*) FROM orders WHERE user_id = $1`
+	if err := tx.QueryRow(ctx, countQuery, userID).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("failed to count orders: %w", err)
+	}
+
+	query := `
+        SELECT id, kind, COALESCE(refund_of::text, ''), user_id, COALESCE(item_id::text, ''), COALESCE(bundle_id::text, ''),
+               item_name, variant_sku, quantity, unit_price, discount, COALESCE(promotion_id::text, ''), COALESCE(coupon_code, ''),
+               coupon_discount, total, status, created_at
+        FROM orders
+        WHERE user_id = $1
+        ORDER BY created_at DESC, id
+        LIMIT NULLIF($2, 0) OFFSET $3
+    `
+	if limit < 0 {
+		limit = 0
+	}
+	rows, err := tx.Query(ctx, query, userID, limit, offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to query orders: %w", err)
+	}
+	defer rows.Close()
+
+	var result []models.Order
+	for rows.Next() {
+		var o models.Order
+		if err := rows.Scan(&o.ID, &o.Kind, &o.RefundOf, &o.UserID, &o.ItemID, &o.BundleID, &o.Item, &o.Variant, &o.Quantity, &o.UnitPrice,
+			&o.Discount, &o.PromotionID, &o.CouponCode, &o.CouponDiscount, &o.Total, &o.Status, &o.CreatedAt); err != nil {
+			return nil, 0, fmt.Errorf("failed to scan order row: %w", err)
+		}
+		result = append(result, o)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("error during rows iteration: %w", err)
+	}
+
+	return result, total, nil
+}
+
+// CountUserPurchases - сколько единиц позиции пользователь купил начиная с since, не считая возвращённых заказов;
+// единицы позиции в купленных наборах тоже считаются
+func (r *Repository) CountUserPurchases(ctx context.Context, tx pgx.Tx, userID, itemID string, since time.Time) (int64, error) {
+	var count int64
+	query := `
+        SELECT COALESCE(SUM(o.quantity * COALESCE(bi.quantity, 1)), 0)
+        FROM orders o
+        LEFT JOIN bundle_items bi ON bi.bundle_id = o.bundle_id AND bi.item_id = $2
+        WHERE o.user_id = $1 AND (o.item_id = $2 OR bi.item_id IS NOT NULL)
+          AND o.kind = 'purchase' AND o.created_at >= $3
+          AND NOT EXISTS(SELECT 1 FROM orders r WHERE r.refund_of = o.id)
+    `
+	if err := tx.QueryRow(ctx, query, userID, itemID, since).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count purchases of item %s for user %s: %w", itemID, userID, err)
+	}
+	return count, nil
+}
+
+// CountOpenOrderItems - сколько единиц позиции (или её варианта) из инвентаря пользователя относится
+// к его заказам, которые ещё не выданы; единицы позиции в наборах тоже считаются
+func (r *Repository) CountOpenOrderItems(ctx context.Context, tx pgx.Tx, userID, item, variant string) (int64, error) {
+	var count int64
+	query := `
+        SELECT COALESCE(SUM(o.quantity * COALESCE(bi.quantity, 1)), 0)
+        FROM orders o
+        LEFT JOIN bundle_items bi ON bi.bundle_id = o.bundle_id
+        JOIN catalog c ON c.id = COALESCE(bi.item_id, o.item_id)
+        WHERE o.user_id = $1 AND c.name = $2 AND COALESCE(bi.variant_sku, o.variant_sku) = $3
+          AND o.kind = 'purchase' AND o.status IN ('placed', 'ready')
+          AND NOT EXISTS(SELECT 1 FROM orders r WHERE r.refund_of = o.id)
+    `
+	if err := tx.QueryRow(ctx, query, userID, item, variant).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count open orders of item '%s' for user %s: %w", item, userID, err)
+	}
+	return count, nil
+}
+
+// CountCouponPurchases - сколько невозвращённых покупок пользователя, кроме exceptOrderID, оплачено с промокодом code
+func (r *Repository) CountCouponPurchases(ctx context.Context, tx pgx.Tx, userID, code, exceptOrderID string) (int64, error) {
+	var count int64
+	query := `
+        SELECT COUNT(*)
+        FROM orders o
+        WHERE o.user_id = $1 AND o.coupon_code = $2 AND o.kind = 'purchase' AND o.id <> $3
+          AND NOT EXISTS(SELECT 1 FROM orders r WHERE r.refund_of = o.id)
+    `
+	if err := tx.QueryRow(ctx, query, userID, code, exceptOrderID).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count purchases with coupon %s for user %s: %w", code, userID, err)
+	}
+	return count, nil
+}
+
+func (r *Repository) UpdateOrderStatus(ctx context.Context, tx pgx.Tx, orderID, status string) error {
+	query := `UPDATE orders SET status = $1 WHERE id = $2`
+	if _, err := tx.Exec(ctx, query, status, orderID); err != nil {
+		return fmt.Errorf("failed to set status '%s' for order %s: %w", status, orderID, err)
+	}
+	return nil
+}
+
+func (r *Repository) InsertStatusChange(ctx context.Context, tx pgx.Tx, c models.OrderStatusChange) error {
+	query := `
+        INSERT INTO order_status_changes (id, order_id, status, changed_by)
+        VALUES ($1, $2, $3, NULLIF($4, '')::uuid)
+    `
+	if _, err := tx.Exec(ctx, query, c.ID, c.OrderID, c.Status, c.ChangedBy); err != nil {
+		return fmt.Errorf("failed to insert status change of order %s: %w", c.OrderID, err)
+	}
+	return nil
+}
+
+// GetOrdersByStatus - очередь выдачи: заказы в статусе status от старых к новым; limit <= 0 возвращает все заказы
+func (r *Repository) GetOrdersByStatus(ctx context.Context, tx pgx.Tx, status string, limit, offset int64) ([]models.Order, int64, error) {
+	var total int64
+	countQuery := `SELECT COUNT(*) FROM orders WHERE status = $1`
+	if err := tx.QueryRow(ctx, countQuery, status).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("failed to count orders: %w", err)
+	}
+
+	query := `
+        SELECT id, kind, COALESCE(refund_of::text, ''), user_id, COALESCE(item_id::text, ''), COALESCE(bundle_id::text, ''),
+               item_name, variant_sku, quantity, unit_price, discount, COALESCE(promotion_id::text, ''), COALESCE(coupon_code, ''),
+               coupon_discount, total, status, created_at
+        FROM orders
+        WHERE status = $1
+        ORDER BY created_at, id
+        LIMIT NULLIF($2, 0) OFFSET $3
+    `
+	if limit < 0 {
+		limit = 0
+	}
+	rows, err := tx.Query(ctx, query, status, limit, offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to query orders: %w", err)
+	}
+	defer rows.Close()
+
+	var result []models.Order
+	for rows.Next() {
+		var o models.Order
+		if err := rows.Scan(&o.ID, &o.Kind, &o.RefundOf, &o.UserID, &o.ItemID, &o.BundleID, &o.Item, &o.Variant, &o.Quantity, &o.UnitPrice,
+			&o.Discount, &o.PromotionID, &o.CouponCode, &o.CouponDiscount, &o.Total, &o.Status, &o.CreatedAt); err != nil {
+			return nil, 0, fmt.Errorf("failed to scan order row: %w", err)
+		}
+		result = append(result, o)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("error during rows iteration: %w", err)
+	}
+
+	return result, total, nil
+}
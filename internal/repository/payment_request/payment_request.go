@@ -0,0 +1,141 @@
+package payment_request
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"AvitoTask/internal/models"
+)
+
+const requestColumns = `r.id, r.requester_id, requester.username, r.payer_id, payer.username, r.amount, r.note, r.status,
+        COALESCE(r.transaction_id::text, ''), r.expires_at, r.created_at`
+
+const requestTables = `payment_requests r
+        JOIN users requester ON requester.id = r.requester_id
+        JOIN users payer ON payer.id = r.payer_id`
+
+type Repository struct {
+	pool *pgxpool.Pool
+}
+
+func NewRepository(pool *pgxpool.Pool) *Repository {
+	return &Repository{pool: pool}
+}
+
+func (r *Repository) BeginTx(ctx context.Context) (pgx.Tx, error) {
+	return r.pool.Begin(ctx)
+}
+
+// InsertRequest - сохраняет запрос с тем же временем создания, что вернули клиенту
+func (r *Repository) InsertRequest(ctx context.Context, tx pgx.Tx, req models.PaymentRequest) error {
+	query := `
+        INSERT INTO payment_requests (id, requester_id, payer_id, amount, note, status, expires_at, created_at)
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+    `
+	_, err := tx.Exec(ctx, query, req.ID, req.RequesterID, req.PayerID, req.Amount, req.Note, req.Status, req.ExpiresAt,
+		req.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to insert payment request %s: %w", req.ID, err)
+	}
+	return nil
+}
+
+func (r *Repository) LockRequest(ctx context.Context, tx pgx.Tx, id string) (models.PaymentRequest, error) {
+	query := `SELECT ` + requestColumns + ` FROM ` + requestTables + ` WHERE r.id = $1 FOR UPDATE OF r`
+	req, err := scanRequest(tx.QueryRow(ctx, query, id))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return req, models.ErrPaymentRequestNotFound
+	}
+	if err != nil {
+		return req, fmt.Errorf("failed to lock payment request %s: %w", id, err)
+	}
+	return req, nil
+}
+
+// GetIncomingRequests - открытые запросы, которые ждут оплаты от userID
+func (r *Repository) GetIncomingRequests(ctx context.Context, tx pgx.Tx, userID string) ([]models.PaymentRequest, error) {
+	query := `
+        SELECT ` + requestColumns + `
+        FROM ` + requestTables + `
+        WHERE r.payer_id = $1 AND r.status = 'open'
+        ORDER BY r.created_at DESC
+    `
+	return r.queryRequests(ctx, tx, query, userID)
+}
+
+// GetOutgoingRequests - все запросы, которые userID отправил другим пользователям
+func (r *Repository) GetOutgoingRequests(ctx context.Context, tx pgx.Tx, userID string) ([]models.PaymentRequest, error) {
+	query := `
+        SELECT ` + requestColumns + `
+        FROM ` + requestTables + `
+        WHERE r.requester_id = $1
+        ORDER BY r.created_at DESC
+    `
+	return r.queryRequests(ctx, tx, query, userID)
+}
+
+// ResolveRequest - закрывает открытый запрос со статусом status; transactionID указывается для оплаченного запроса
+func (r *Repository) ResolveRequest(ctx context.Context, tx pgx.Tx, id, status, transactionID string) error {
+	query := `
+        UPDATE payment_requests
+        SET status = $2, transaction_id = NULLIF($3, '')::uuid, resolved_at = CURRENT_TIMESTAMP
+        WHERE id = $1 AND status = 'open'
+    `
+	tag, err := tx.Exec(ctx, query, id, status, transactionID)
+	if err != nil {
+		return fmt.Errorf("failed to resolve payment request %s: %w", id, err)
+	}
+	if tag.RowsAffected() == 0 {
+		return models.ErrPaymentRequestNotFound
+	}
+	return nil
+}
+
+// ExpireRequests - закрывает открытые запросы, срок которых истёк к now; возвращает их количество
+func (r *Repository) ExpireRequests(ctx context.Context, tx pgx.Tx, now time.Time) (int64, error) {
+	query := `
+        UPDATE payment_requests
+        SET status = 'expired', resolved_at = CURRENT_TIMESTAMP
+        WHERE status = 'open' AND expires_at <= $1
+    `
+	tag, err := tx.Exec(ctx, query, now)
+	if err != nil {
+		return 0, fmt.Errorf("failed to expire payment requests: %w", err)
+	}
+	return tag.RowsAffected(), nil
+}
+
+func (r *Repository) queryRequests(ctx context.Context, tx pgx.Tx, query string, args ...any) ([]models.PaymentRequest, error) {
+	rows, err := tx.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query payment requests: %w", err)
+	}
+	defer rows.Close()
+
+	var result []models.PaymentRequest
+	for rows.Next() {
+		req, err := scanRequest(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan payment request row: %w", err)
+		}
+		result = append(result, req)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error during rows iteration: %w", err)
+	}
+
+	return result, nil
+}
+
+func scanRequest(row pgx.Row) (models.PaymentRequest, error) {
+	var req models.PaymentRequest
+	err := row.Scan(&req.ID, &req.RequesterID, &req.RequesterName, &req.PayerID, &req.PayerName, &req.Amount, &req.Note,
+		&req.Status, &req.TransactionID, &req.ExpiresAt, &req.CreatedAt)
+	return req, err
+}
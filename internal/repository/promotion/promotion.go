@@ -0,0 +1,102 @@
+package promotion
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"AvitoTask/internal/models"
+)
+
+type Repository struct {
+	pool *pgxpool.Pool
+}
+
+func NewRepository(pool *pgxpool.Pool) *Repository {
+	return &Repository{pool: pool}
+}
+
+func (r *Repository) BeginTx(ctx context.Context) (pgx.Tx, error) {
+	return r.pool.Begin(ctx)
+}
+
+const selectPromotion = `
+        SELECT p.id, p.name, COALESCE(p.item_id::text, ''), COALESCE(c.name, ''), COALESCE(p.category, ''),
+               COALESCE(p.percent, 0), COALESCE(p.amount, 0), p.starts_at, p.ends_at,
+               COALESCE(p.created_by::text, ''), p.created_at
+        FROM promotions p
+        LEFT JOIN catalog c ON c.id = p.item_id
+`
+
+func (r *Repository) InsertPromotion(ctx context.Context, tx pgx.Tx, p models.Promotion) error {
+	query := `
+        INSERT INTO promotions (id, name, item_id, category, percent, amount, starts_at, ends_at, created_by)
+        VALUES ($1, $2, NULLIF($3, '')::uuid, NULLIF($4, ''), NULLIF($5, 0), NULLIF($6, 0), $7, $8, $9)
+    `
+	_, err := tx.Exec(ctx, query, p.ID, p.Name, p.ItemID, p.Category, p.Percent, p.Amount, p.StartsAt, p.EndsAt, p.CreatedBy)
+	if err != nil {
+		return fmt.Errorf("failed to insert promotion '%s': %w", p.Name, err)
+	}
+	return nil
+}
+
+// GetActivePromotions - акции на позицию itemID или её категорию, действующие в момент at
+func (r *Repository) GetActivePromotions(ctx context.Context, tx pgx.Tx, itemID, category string, at time.Time) ([]models.Promotion, error) {
+	query := selectPromotion + `
+        WHERE (p.item_id = $1 OR p.category = $2)
+          AND p.starts_at <= $3 AND p.ends_at > $3
+    `
+	return r.query(ctx, tx, query, itemID, category, at)
+}
+
+func (r *Repository) ListPromotions(ctx context.Context, tx pgx.Tx) ([]models.Promotion, error) {
+	query := selectPromotion + `
+        ORDER BY p.starts_at DESC, p.id
+    `
+	return r.query(ctx, tx, query)
+}
+
+// EndPromotion - досрочно завершает акцию в момент at; ещё не начавшаяся акция так и не начнётся
+func (r *Repository) EndPromotion(ctx context.Context, tx pgx.Tx, id string, at time.Time) error {
+	query := `
+        UPDATE promotions
+        SET ends_at = GREATEST(starts_at, $2)
+        WHERE id = $1 AND ends_at > $2
+    `
+	tag, err := tx.Exec(ctx, query, id, at)
+	if err != nil {
+		return fmt.Errorf("failed to end promotion %s: %w", id, err)
+	}
+	if tag.RowsAffected() == 0 {
+		return models.ErrPromotionNotFound
+	}
+	return nil
+}
+
+func (r *Repository) query(ctx context.Context, tx pgx.Tx, query string, args ...any) ([]models.Promotion, error) {
+	rows, err := tx.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query promotions: %w", err)
+	}
+	defer rows.Close()
+
+	var result []models.Promotion
+	for rows.Next() {
+		var p models.Promotion
+		err := rows.Scan(&p.ID, &p.Name, &p.ItemID, &p.Item, &p.Category, &p.Percent, &p.Amount,
+			&p.StartsAt, &p.EndsAt, &p.CreatedBy, &p.CreatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan promotion row: %w", err)
+		}
+		result = append(result, p)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error during rows iteration: %w", err)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,161 @@
+package schedule
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"AvitoTask/internal/models"
+)
+
+const scheduleColumns = `id, user_id, to_user, amount, message, category, repeat, on_failure, status,
+        run_at, due_at, attempts, last_error, created_at`
+
+type Repository struct {
+	pool *pgxpool.Pool
+}
+
+func NewRepository(pool *pgxpool.Pool) *Repository {
+	return &Repository{pool: pool}
+}
+
+func (r *Repository) BeginTx(ctx context.Context) (pgx.Tx, error) {
+	return r.pool.Begin(ctx)
+}
+
+func (r *Repository) InsertSchedule(ctx context.Context, tx pgx.Tx, s models.ScheduledTransfer) error {
+	query := `
+        INSERT INTO scheduled_transfers (id, user_id, to_user, amount, message, category, repeat, on_failure,
+                                         status, run_at, due_at)
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+    `
+	_, err := tx.Exec(ctx, query, s.ID, s.UserID, s.ToUser, s.Amount, s.Message, s.Category, s.Repeat, s.OnFailure,
+		s.Status, s.RunAt, s.DueAt)
+	if err != nil {
+		return fmt.Errorf("failed to insert scheduled transfer %s: %w", s.ID, err)
+	}
+	return nil
+}
+
+// UpdateSchedule - сохраняет статус и состояние запусков расписания
+func (r *Repository) UpdateSchedule(ctx context.Context, tx pgx.Tx, s models.ScheduledTransfer) error {
+	query := `
+        UPDATE scheduled_transfers
+        SET status = $2, run_at = $3, due_at = $4, attempts = $5, last_error = $6
+        WHERE id = $1
+    `
+	tag, err := tx.Exec(ctx, query, s.ID, s.Status, s.RunAt, s.DueAt, s.Attempts, s.LastError)
+	if err != nil {
+		return fmt.Errorf("failed to update scheduled transfer %s: %w", s.ID, err)
+	}
+	if tag.RowsAffected() == 0 {
+		return models.ErrScheduleNotFound
+	}
+	return nil
+}
+
+func (r *Repository) LockSchedule(ctx context.Context, tx pgx.Tx, id string) (models.ScheduledTransfer, error) {
+	query := `SELECT ` + scheduleColumns + ` FROM scheduled_transfers WHERE id = $1 FOR UPDATE`
+	s, err := scanSchedule(tx.QueryRow(ctx, query, id))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return s, models.ErrScheduleNotFound
+	}
+	if err != nil {
+		return s, fmt.Errorf("failed to lock scheduled transfer %s: %w", id, err)
+	}
+	return s, nil
+}
+
+func (r *Repository) GetUserSchedules(ctx context.Context, tx pgx.Tx, userID string) ([]models.ScheduledTransfer, error) {
+	query := `SELECT ` + scheduleColumns + ` FROM scheduled_transfers WHERE user_id = $1 ORDER BY created_at DESC`
+	return r.querySchedules(ctx, tx, query, userID)
+}
+
+// LockDueSchedules - активные расписания, чей запуск уже наступил. Строки, которые обрабатывает
+// другой экземпляр сервиса, пропускаются
+func (r *Repository) LockDueSchedules(ctx context.Context, tx pgx.Tx, now time.Time, limit int64) ([]models.ScheduledTransfer, error) {
+	query := `
+        SELECT ` + scheduleColumns + `
+        FROM scheduled_transfers
+        WHERE status = 'active' AND due_at <= $1
+        ORDER BY due_at
+        LIMIT $2
+        FOR UPDATE SKIP LOCKED
+    `
+	return r.querySchedules(ctx, tx, query, now, limit)
+}
+
+func (r *Repository) InsertRun(ctx context.Context, tx pgx.Tx, run models.ScheduledRun) error {
+	query := `
+        INSERT INTO scheduled_transfer_runs (id, schedule_id, run_at, executed_at, status, error)
+        VALUES ($1, $2, $3, $4, $5, $6)
+    `
+	_, err := tx.Exec(ctx, query, run.ID, run.ScheduleID, run.RunAt, run.ExecutedAt, run.Status, run.Error)
+	if err != nil {
+		return fmt.Errorf("failed to insert run of scheduled transfer %s: %w", run.ScheduleID, err)
+	}
+	return nil
+}
+
+func (r *Repository) GetRuns(ctx context.Context, tx pgx.Tx, scheduleID string) ([]models.ScheduledRun, error) {
+	query := `
+        SELECT id, schedule_id, run_at, executed_at, status, error
+        FROM scheduled_transfer_runs
+        WHERE schedule_id = $1
+        ORDER BY executed_at DESC
+    `
+	rows, err := tx.Query(ctx, query, scheduleID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query runs of scheduled transfer %s: %w", scheduleID, err)
+	}
+	defer rows.Close()
+
+	var result []models.ScheduledRun
+	for rows.Next() {
+		var run models.ScheduledRun
+		if err := rows.Scan(&run.ID, &run.ScheduleID, &run.RunAt, &run.ExecutedAt, &run.Status, &run.Error); err != nil {
+			return nil, fmt.Errorf("failed to scan scheduled run row: %w", err)
+		}
+		result = append(result, run)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error during rows iteration: %w", err)
+	}
+
+	return result, nil
+}
+
+func (r *Repository) querySchedules(ctx context.Context, tx pgx.Tx, query string, args ...any) ([]models.ScheduledTransfer, error) {
+	rows, err := tx.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query scheduled transfers: %w", err)
+	}
+	defer rows.Close()
+
+	var result []models.ScheduledTransfer
+	for rows.Next() {
+		s, err := scanSchedule(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan scheduled transfer row: %w", err)
+		}
+		result = append(result, s)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error during rows iteration: %w", err)
+	}
+
+	return result, nil
+}
+
+func scanSchedule(row pgx.Row) (models.ScheduledTransfer, error) {
+	var s models.ScheduledTransfer
+	err := row.Scan(&s.ID, &s.UserID, &s.ToUser, &s.Amount, &s.Message, &s.Category, &s.Repeat, &s.OnFailure, &s.Status,
+		&s.RunAt, &s.DueAt, &s.Attempts, &s.LastError, &s.CreatedAt)
+	return s, err
+}
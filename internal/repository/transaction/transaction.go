@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
@@ -19,29 +20,32 @@ func NewRepository(pool *pgxpool.Pool) *Repository {
 	return &Repository{pool: pool}
 }
 
-func (r *Repository) InsertTransaction(ctx context.Context, tx pgx.Tx, id, fromUserID, toUserID string, amount int64) error {
+func (r *Repository) InsertTransaction(ctx context.Context, tx pgx.Tx, t models.Transfer) error {
 	query := `
-        INSERT INTO transactions (id, from_user_id, to_user_id, amount)
-        VALUES ($1, $2, $3, $4)
+        INSERT INTO transactions (id, from_user_id, to_user_id, amount, message, category, status, expires_at)
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
     `
-	_, err := tx.Exec(ctx, query, id, fromUserID, toUserID, amount)
+	_, err := tx.Exec(ctx, query, t.ID, t.FromUserID, t.ToUserID, t.Amount, t.Message, t.Category, t.Status, t.ExpiresAt)
 	if err != nil {
 		return fmt.Errorf("failed to insert transaction: %w", err)
 	}
 	return nil
 }
 
-func (r *Repository) GetUserTransactions(ctx context.Context, tx pgx.Tx, userID string) ([]models.TransactionItem, error) {
+// GetUserTransactions - переводы пользователя; непустая category оставляет только переводы этой категории.
+// У системных начислений нет отправителя: FromUserID и FromUsername пустые
+func (r *Repository) GetUserTransactions(ctx context.Context, tx pgx.Tx, userID, category string) ([]models.TransactionItem, error) {
 	query := `
-        SELECT from_user_id, to_user_id, amount, created_at, u1.username, u2.username
+        SELECT transactions.id, COALESCE(from_user_id::text, ''), to_user_id, amount, message, category, status,
+               expires_at, created_at, u1.username, COALESCE(u2.username, '')
         FROM transactions
         LEFT JOIN users as u1 ON u1.id = transactions.to_user_id
         LEFT JOIN users as u2 ON u2.id = transactions.from_user_id
-        WHERE from_user_id = $1
-           OR to_user_id = $1
+        WHERE (from_user_id = $1 OR to_user_id = $1)
+          AND ($2 = '' OR category = $2)
         ORDER BY created_at DESC
     `
-	rows, err := tx.Query(ctx, query, userID)
+	rows, err := tx.Query(ctx, query, userID, category)
 	if errors.Is(err, pgx.ErrNoRows) {
 		return nil, nil
 	}
@@ -53,7 +57,7 @@ func (r *Repository) GetUserTransactions(ctx context.Context, tx pgx.Tx, userID
 	var result []models.TransactionItem
 	for rows.Next() {
 		var t models.TransactionItem
-		if err := rows.Scan(&t.FromUserID, &t.ToUserID, &t.Amount, &t.CreatedAt, &t.ToUserName, &t.FromUsername); err != nil {
+		if err := rows.Scan(&t.ID, &t.FromUserID, &t.ToUserID, &t.Amount, &t.Message, &t.Category, &t.Status, &t.ExpiresAt, &t.CreatedAt, &t.ToUserName, &t.FromUsername); err != nil {
 			return nil, fmt.Errorf("failed to scan transaction row: %w", err)
 		}
 		result = append(result, t)
@@ -65,3 +69,130 @@ func (r *Repository) GetUserTransactions(ctx context.Context, tx pgx.Tx, userID
 
 	return result, nil
 }
+
+// GetSentTotals - сколько монет и переводов userID отправил начиная с since и сколько из них получателю toUserID
+// (пустой toUserID - без разбивки по получателю). Отклонённые и просроченные отложенные переводы не учитываются:
+// их монеты вернулись отправителю
+func (r *Repository) GetSentTotals(ctx context.Context, tx pgx.Tx, userID, toUserID string, since time.Time) (models.TransferUsage, error) {
+	query := `
+        SELECT COALESCE(SUM(amount), 0),
+               COUNT(*),
+               COALESCE(SUM(amount) FILTER (WHERE to_user_id = NULLIF($2, '')::uuid), 0)
+        FROM transactions
+        WHERE from_user_id = $1
+          AND created_at >= $3
+          AND status NOT IN ('declined', 'expired')
+    `
+	var u models.TransferUsage
+	if err := tx.QueryRow(ctx, query, userID, toUserID, since).Scan(&u.Amount, &u.Count, &u.RecipientAmount); err != nil {
+		return u, fmt.Errorf("failed to sum transfers of user %s: %w", userID, err)
+	}
+	return u, nil
+}
+
+// GetPendingTransfers - отложенные переводы, которые ждут ответа получателя userID
+func (r *Repository) GetPendingTransfers(ctx context.Context, tx pgx.Tx, userID string) ([]models.TransactionItem, error) {
+	query := `
+        SELECT t.id, t.from_user_id, t.to_user_id, t.amount, t.message, t.category, t.status, t.expires_at, t.created_at,
+               u.username
+        FROM transactions t
+        LEFT JOIN users u ON u.id = t.from_user_id
+        WHERE t.to_user_id = $1 AND t.status = 'pending'
+        ORDER BY t.created_at
+    `
+	rows, err := tx.Query(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query pending transfers: %w", err)
+	}
+	defer rows.Close()
+
+	var result []models.TransactionItem
+	for rows.Next() {
+		var t models.TransactionItem
+		if err := rows.Scan(&t.ID, &t.FromUserID, &t.ToUserID, &t.Amount, &t.Message, &t.Category, &t.Status, &t.ExpiresAt,
+			&t.CreatedAt, &t.FromUsername); err != nil {
+			return nil, fmt.Errorf("failed to scan pending transfer row: %w", err)
+		}
+		result = append(result, t)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error during rows iteration: %w", err)
+	}
+
+	return result, nil
+}
+
+func (r *Repository) LockTransaction(ctx context.Context, tx pgx.Tx, id string) (models.Transfer, error) {
+	query := `
+        SELECT id, COALESCE(from_user_id::text, ''), to_user_id, amount, message, category, status, expires_at
+        FROM transactions
+        WHERE id = $1
+        FOR UPDATE
+    `
+	t, err := scanTransfer(tx.QueryRow(ctx, query, id))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return t, models.ErrTransferNotFound
+	}
+	if err != nil {
+		return t, fmt.Errorf("failed to lock transaction %s: %w", id, err)
+	}
+	return t, nil
+}
+
+// LockExpiredTransfers - отложенные переводы, срок ответа на которые истёк к now; строки, которые
+// обрабатывает другой экземпляр сервиса, пропускаются
+func (r *Repository) LockExpiredTransfers(ctx context.Context, tx pgx.Tx, now time.Time, limit int64) ([]models.Transfer, error) {
+	query := `
+        SELECT id, COALESCE(from_user_id::text, ''), to_user_id, amount, message, category, status, expires_at
+        FROM transactions
+        WHERE status = 'pending' AND expires_at <= $1
+        ORDER BY expires_at
+        LIMIT $2
+        FOR UPDATE SKIP LOCKED
+    `
+	rows, err := tx.Query(ctx, query, now, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query expired transfers: %w", err)
+	}
+	defer rows.Close()
+
+	var result []models.Transfer
+	for rows.Next() {
+		t, err := scanTransfer(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan expired transfer row: %w", err)
+		}
+		result = append(result, t)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error during rows iteration: %w", err)
+	}
+
+	return result, nil
+}
+
+// ResolveTransaction - переводит отложенный перевод в итоговый статус; перевод, который уже
+// не ждёт ответа, не меняется
+func (r *Repository) ResolveTransaction(ctx context.Context, tx pgx.Tx, id, status string) error {
+	query := `
+        UPDATE transactions
+        SET status = $2, resolved_at = CURRENT_TIMESTAMP
+        WHERE id = $1 AND status = 'pending'
+    `
+	tag, err := tx.Exec(ctx, query, id, status)
+	if err != nil {
+		return fmt.Errorf("failed to resolve transaction %s: %w", id, err)
+	}
+	if tag.RowsAffected() == 0 {
+		return models.ErrTransferNotPending
+	}
+	return nil
+}
+
+func scanTransfer(row pgx.Row) (models.Transfer, error) {
+	var t models.Transfer
+	err := row.Scan(&t.ID, &t.FromUserID, &t.ToUserID, &t.Amount, &t.Message, &t.Category, &t.Status, &t.ExpiresAt)
+	return t, err
+}
@@ -0,0 +1,78 @@
+package transfer_limit
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"AvitoTask/internal/models"
+)
+
+type Repository struct {
+	pool *pgxpool.Pool
+}
+
+func NewRepository(pool *pgxpool.Pool) *Repository {
+	return &Repository{pool: pool}
+}
+
+func (r *Repository) BeginTx(ctx context.Context) (pgx.Tx, error) {
+	return r.pool.Begin(ctx)
+}
+
+// GetUserLimits - личные ограничения пользователя по периодам; периода без личной политики в результате нет
+func (r *Repository) GetUserLimits(ctx context.Context, tx pgx.Tx, userID string) (map[string]models.LimitPolicy, error) {
+	query := `
+        SELECT period, amount, count, recipient_amount
+        FROM user_transfer_limits
+        WHERE user_id = $1
+    `
+	rows, err := tx.Query(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query transfer limits of user %s: %w", userID, err)
+	}
+	defer rows.Close()
+
+	result := make(map[string]models.LimitPolicy)
+	for rows.Next() {
+		var period string
+		var p models.LimitPolicy
+		if err := rows.Scan(&period, &p.Amount, &p.Count, &p.RecipientAmount); err != nil {
+			return nil, fmt.Errorf("failed to scan transfer limit row: %w", err)
+		}
+		result[period] = p
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error during rows iteration: %w", err)
+	}
+
+	return result, nil
+}
+
+// SetUserLimit - задаёт пользователю личную политику периода period вместо общей
+func (r *Repository) SetUserLimit(ctx context.Context, tx pgx.Tx, userID, period string, p models.LimitPolicy, setBy string) error {
+	query := `
+        INSERT INTO user_transfer_limits (user_id, period, amount, count, recipient_amount, set_by)
+        VALUES ($1, $2, $3, $4, $5, NULLIF($6, '')::uuid)
+        ON CONFLICT (user_id, period)
+        DO UPDATE SET amount = EXCLUDED.amount, count = EXCLUDED.count, recipient_amount = EXCLUDED.recipient_amount,
+                      set_by = EXCLUDED.set_by, updated_at = CURRENT_TIMESTAMP
+    `
+	_, err := tx.Exec(ctx, query, userID, period, p.Amount, p.Count, p.RecipientAmount, setBy)
+	if err != nil {
+		return fmt.Errorf("failed to set %s transfer limit of user %s: %w", period, userID, err)
+	}
+	return nil
+}
+
+// DeleteUserLimit - убирает личную политику периода period: пользователь снова ограничен общей
+func (r *Repository) DeleteUserLimit(ctx context.Context, tx pgx.Tx, userID, period string) error {
+	query := `DELETE FROM user_transfer_limits WHERE user_id = $1 AND period = $2`
+	if _, err := tx.Exec(ctx, query, userID, period); err != nil {
+		return fmt.Errorf("failed to delete %s transfer limit of user %s: %w", period, userID, err)
+	}
+	return nil
+}
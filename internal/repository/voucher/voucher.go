@@ -0,0 +1,73 @@
+package voucher
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"AvitoTask/internal/models"
+)
+
+type Repository struct {
+	pool *pgxpool.Pool
+}
+
+func NewRepository(pool *pgxpool.Pool) *Repository {
+	return &Repository{pool: pool}
+}
+
+func (r *Repository) BeginTx(ctx context.Context) (pgx.Tx, error) {
+	return r.pool.Begin(ctx)
+}
+
+// IssueVoucher - выдаёт талон на заказ; если талон уже выдан, возвращает существующий
+func (r *Repository) IssueVoucher(ctx context.Context, tx pgx.Tx, v models.Voucher) (models.Voucher, error) {
+	query := `
+        INSERT INTO pickup_vouchers (id, order_id, user_id)
+        VALUES ($1, $2, $3)
+        ON CONFLICT (order_id) DO UPDATE SET order_id = EXCLUDED.order_id
+        RETURNING id, issued_at, consumed_at, COALESCE(consumed_by::text, '')
+    `
+	err := tx.QueryRow(ctx, query, v.ID, v.OrderID, v.UserID).Scan(&v.ID, &v.IssuedAt, &v.ConsumedAt, &v.ConsumedBy)
+	if err != nil {
+		return models.Voucher{}, fmt.Errorf("failed to issue voucher for order %s: %w", v.OrderID, err)
+	}
+	return v, nil
+}
+
+func (r *Repository) LockVoucher(ctx context.Context, tx pgx.Tx, id string) (models.Voucher, error) {
+	var v models.Voucher
+	query := `
+        SELECT id, order_id, user_id, issued_at, consumed_at, COALESCE(consumed_by::text, '')
+        FROM pickup_vouchers
+        WHERE id = $1
+        FOR UPDATE
+    `
+	err := tx.QueryRow(ctx, query, id).Scan(&v.ID, &v.OrderID, &v.UserID, &v.IssuedAt, &v.ConsumedAt, &v.ConsumedBy)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return models.Voucher{}, models.ErrVoucherNotFound
+	}
+	if err != nil {
+		return models.Voucher{}, fmt.Errorf("cannot find voucher %s: %w", id, err)
+	}
+	return v, nil
+}
+
+func (r *Repository) ConsumeVoucher(ctx context.Context, tx pgx.Tx, id, staffID string) error {
+	query := `
+        UPDATE pickup_vouchers
+        SET consumed_at = CURRENT_TIMESTAMP, consumed_by = $1
+        WHERE id = $2 AND consumed_at IS NULL
+    `
+	tag, err := tx.Exec(ctx, query, staffID, id)
+	if err != nil {
+		return fmt.Errorf("failed to consume voucher %s: %w", id, err)
+	}
+	if tag.RowsAffected() == 0 {
+		return models.ErrVoucherConsumed
+	}
+	return nil
+}
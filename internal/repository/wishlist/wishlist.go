@@ -0,0 +1,79 @@
+package wishlist
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"AvitoTask/internal/models"
+)
+
+type Repository struct {
+	pool *pgxpool.Pool
+}
+
+func NewRepository(pool *pgxpool.Pool) *Repository {
+	return &Repository{pool: pool}
+}
+
+func (r *Repository) BeginTx(ctx context.Context) (pgx.Tx, error) {
+	return r.pool.Begin(ctx)
+}
+
+// AddToWishlist - повторное добавление той же позиции ничего не меняет
+func (r *Repository) AddToWishlist(ctx context.Context, tx pgx.Tx, userID, itemID string) error {
+	query := `
+        INSERT INTO wishlist (user_id, item_id)
+        VALUES ($1, $2)
+        ON CONFLICT (user_id, item_id) DO NOTHING
+    `
+	if _, err := tx.Exec(ctx, query, userID, itemID); err != nil {
+		return fmt.Errorf("failed to add item %s to wishlist of user %s: %w", itemID, userID, err)
+	}
+	return nil
+}
+
+func (r *Repository) RemoveFromWishlist(ctx context.Context, tx pgx.Tx, userID, itemID string) error {
+	query := `DELETE FROM wishlist WHERE user_id = $1 AND item_id = $2`
+	tag, err := tx.Exec(ctx, query, userID, itemID)
+	if err != nil {
+		return fmt.Errorf("failed to remove item %s from wishlist of user %s: %w", itemID, userID, err)
+	}
+	if tag.RowsAffected() == 0 {
+		return models.ErrNotInWishlist
+	}
+	return nil
+}
+
+// GetWishlist - список желаний с текущими ценами; недоступна позиция, которая скрыта, выведена из продажи или распродана
+func (r *Repository) GetWishlist(ctx context.Context, tx pgx.Tx, userID string) ([]models.WishlistEntry, error) {
+	query := `
+        SELECT c.id, c.name, c.price, NOT c.hidden AND NOT c.retired AND COALESCE(c.stock, 1) > 0, w.created_at
+        FROM wishlist w
+        JOIN catalog c ON c.id = w.item_id
+        WHERE w.user_id = $1
+        ORDER BY w.created_at, c.name
+    `
+	rows, err := tx.Query(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query wishlist: %w", err)
+	}
+	defer rows.Close()
+
+	var result []models.WishlistEntry
+	for rows.Next() {
+		var e models.WishlistEntry
+		if err := rows.Scan(&e.ItemID, &e.Item, &e.Price, &e.Available, &e.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan wishlist row: %w", err)
+		}
+		result = append(result, e)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error during rows iteration: %w", err)
+	}
+
+	return result, nil
+}
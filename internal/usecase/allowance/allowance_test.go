@@ -0,0 +1,163 @@
+package allowance_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/jackc/pgx/v5"
+
+	"AvitoTask/internal/models"
+	"AvitoTask/internal/usecase/allowance"
+	"AvitoTask/internal/usecase/allowance/mocks"
+)
+
+var (
+	now     = time.Date(2025, 3, 17, 15, 30, 0, 0, time.UTC)
+	monthly = models.Allowance{Name: "monthly", Amount: 200, Period: models.AllowanceMonthly}
+)
+
+func TestGrantDue_CreditsAndPostsBalancedEntry(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockAllowance := mocks.NewMockallowance(ctrl)
+	mockUser := mocks.NewMockuser(ctrl)
+	mockLedger := mocks.NewMockledger(ctrl)
+	mockTx := mocks.NewMockTx(ctrl)
+
+	grants := []models.AllowanceGrant{{TransactionID: "t2", UserID: "user2"}, {TransactionID: "t1", UserID: "user1"}}
+	mockAllowance.EXPECT().BeginTx(ctx).Return(mockTx, nil)
+	mockAllowance.EXPECT().InsertGrants(ctx, mockTx, monthly, "monthly:2025-03", int64(500)).Return(grants, nil)
+	gomock.InOrder(
+		mockUser.EXPECT().CreditUserCoins(ctx, mockTx, "user1", int64(200)).Return(nil),
+		mockUser.EXPECT().CreditUserCoins(ctx, mockTx, "user2", int64(200)).Return(nil),
+	)
+	mockLedger.EXPECT().PostEntry(ctx, mockTx, gomock.Any()).DoAndReturn(
+		func(_ context.Context, _ pgx.Tx, e models.LedgerEntry) error {
+			if e.Kind != models.EntryGrant || e.Reference != "monthly:2025-03" || !e.Balanced() || len(e.Postings) != 3 ||
+				e.Postings[0] != (models.Posting{Account: models.AccountIssuance, Amount: -400}) {
+				t.Errorf("unexpected entry %+v", e)
+			}
+			return nil
+		})
+	mockTx.EXPECT().Commit(ctx).Return(nil)
+
+	uc := allowance.NewUsecase(mockAllowance, mockUser, mockLedger, []models.Allowance{monthly})
+	uc.Now = func() time.Time { return now }
+
+	granted, err := uc.GrantDue(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if granted != 2 {
+		t.Errorf("expected 2 grants, got %d", granted)
+	}
+}
+
+func TestGrantDue_AlreadyGranted(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockAllowance := mocks.NewMockallowance(ctrl)
+	mockTx := mocks.NewMockTx(ctrl)
+
+	mockAllowance.EXPECT().BeginTx(ctx).Return(mockTx, nil)
+	mockAllowance.EXPECT().InsertGrants(ctx, mockTx, monthly, "monthly:2025-03", int64(500)).Return(nil, nil)
+	mockTx.EXPECT().Commit(ctx).Return(nil)
+
+	uc := allowance.NewUsecase(mockAllowance, mocks.NewMockuser(ctrl), mocks.NewMockledger(ctrl), []models.Allowance{monthly})
+	uc.Now = func() time.Time { return now }
+
+	granted, err := uc.GrantDue(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if granted != 0 {
+		t.Errorf("expected no grants, got %d", granted)
+	}
+}
+
+func TestGrantDue_ContinuesWhileBatchIsFull(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockAllowance := mocks.NewMockallowance(ctrl)
+	mockUser := mocks.NewMockuser(ctrl)
+	mockLedger := mocks.NewMockledger(ctrl)
+	mockTx := mocks.NewMockTx(ctrl)
+
+	full := make([]models.AllowanceGrant, 500)
+	for i := range full {
+		full[i] = models.AllowanceGrant{TransactionID: fmt.Sprintf("t%d", i), UserID: fmt.Sprintf("user%03d", i)}
+	}
+	mockAllowance.EXPECT().BeginTx(ctx).Return(mockTx, nil).Times(2)
+	gomock.InOrder(
+		mockAllowance.EXPECT().InsertGrants(ctx, mockTx, monthly, "monthly:2025-03", int64(500)).Return(full, nil),
+		mockAllowance.EXPECT().InsertGrants(ctx, mockTx, monthly, "monthly:2025-03", int64(500)).
+			Return([]models.AllowanceGrant{{TransactionID: "t500", UserID: "user500"}}, nil),
+	)
+	mockUser.EXPECT().CreditUserCoins(ctx, mockTx, gomock.Any(), int64(200)).Return(nil).Times(501)
+	mockLedger.EXPECT().PostEntry(ctx, mockTx, gomock.Any()).Return(nil).Times(2)
+	mockTx.EXPECT().Commit(ctx).Return(nil).Times(2)
+
+	uc := allowance.NewUsecase(mockAllowance, mockUser, mockLedger, []models.Allowance{monthly})
+	uc.Now = func() time.Time { return now }
+
+	granted, err := uc.GrantDue(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if granted != 501 {
+		t.Errorf("expected 501 grants, got %d", granted)
+	}
+}
+
+func TestGrantDue_RollsBackOnCreditError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockAllowance := mocks.NewMockallowance(ctrl)
+	mockUser := mocks.NewMockuser(ctrl)
+	mockTx := mocks.NewMockTx(ctrl)
+
+	dbErr := errors.New("db down")
+	mockAllowance.EXPECT().BeginTx(ctx).Return(mockTx, nil)
+	mockAllowance.EXPECT().InsertGrants(ctx, mockTx, monthly, "monthly:2025-03", int64(500)).
+		Return([]models.AllowanceGrant{{TransactionID: "t1", UserID: "user1"}}, nil)
+	mockUser.EXPECT().CreditUserCoins(ctx, mockTx, "user1", int64(200)).Return(dbErr)
+	mockTx.EXPECT().Rollback(ctx).Return(nil)
+
+	uc := allowance.NewUsecase(mockAllowance, mockUser, mocks.NewMockledger(ctrl), []models.Allowance{monthly})
+	uc.Now = func() time.Time { return now }
+
+	if _, err := uc.GrantDue(ctx); !errors.Is(err, dbErr) {
+		t.Fatalf("expected %v, got %v", dbErr, err)
+	}
+}
+
+func TestSetActive_UserNotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockAllowance := mocks.NewMockallowance(ctrl)
+	mockTx := mocks.NewMockTx(ctrl)
+
+	mockAllowance.EXPECT().BeginTx(ctx).Return(mockTx, nil)
+	mockAllowance.EXPECT().SetUserActive(ctx, mockTx, "ghost", false).Return(models.ErrUserNotFound)
+	mockTx.EXPECT().Rollback(ctx).Return(nil)
+
+	uc := allowance.NewUsecase(mockAllowance, mocks.NewMockuser(ctrl), mocks.NewMockledger(ctrl), nil)
+
+	if err := uc.SetActive(ctx, "ghost", false); !errors.Is(err, models.ErrUserNotFound) {
+		t.Fatalf("expected ErrUserNotFound, got %v", err)
+	}
+}
@@ -0,0 +1,25 @@
+//go:generate mockgen -source=contract.go -destination=mocks/mock.go -package=mocks $GOPACKAGE
+//go:generate mockgen -destination=mocks/mock_tx.go -package=mocks github.com/jackc/pgx/v5 Tx
+package allowance
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+
+	"AvitoTask/internal/models"
+)
+
+type allowance interface {
+	BeginTx(ctx context.Context) (pgx.Tx, error)
+	InsertGrants(ctx context.Context, tx pgx.Tx, a models.Allowance, key string, limit int64) ([]models.AllowanceGrant, error)
+	SetUserActive(ctx context.Context, tx pgx.Tx, username string, active bool) error
+}
+
+type user interface {
+	CreditUserCoins(ctx context.Context, tx pgx.Tx, userID string, amount int64) error
+}
+
+type ledger interface {
+	PostEntry(ctx context.Context, tx pgx.Tx, e models.LedgerEntry) error
+}
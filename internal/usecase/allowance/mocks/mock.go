@@ -0,0 +1,155 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: contract.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	models "AvitoTask/internal/models"
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	pgx "github.com/jackc/pgx/v5"
+)
+
+// Mockallowance is a mock of allowance interface.
+type Mockallowance struct {
+	ctrl     *gomock.Controller
+	recorder *MockallowanceMockRecorder
+}
+
+// MockallowanceMockRecorder is the mock recorder for Mockallowance.
+type MockallowanceMockRecorder struct {
+	mock *Mockallowance
+}
+
+// NewMockallowance creates a new mock instance.
+func NewMockallowance(ctrl *gomock.Controller) *Mockallowance {
+	mock := &Mockallowance{ctrl: ctrl}
+	mock.recorder = &MockallowanceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mockallowance) EXPECT() *MockallowanceMockRecorder {
+	return m.recorder
+}
+
+// BeginTx mocks base method.
+func (m *Mockallowance) BeginTx(ctx context.Context) (pgx.Tx, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BeginTx", ctx)
+	ret0, _ := ret[0].(pgx.Tx)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BeginTx indicates an expected call of BeginTx.
+func (mr *MockallowanceMockRecorder) BeginTx(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BeginTx", reflect.TypeOf((*Mockallowance)(nil).BeginTx), ctx)
+}
+
+// InsertGrants mocks base method.
+func (m *Mockallowance) InsertGrants(ctx context.Context, tx pgx.Tx, a models.Allowance, key string, limit int64) ([]models.AllowanceGrant, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertGrants", ctx, tx, a, key, limit)
+	ret0, _ := ret[0].([]models.AllowanceGrant)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InsertGrants indicates an expected call of InsertGrants.
+func (mr *MockallowanceMockRecorder) InsertGrants(ctx, tx, a, key, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertGrants", reflect.TypeOf((*Mockallowance)(nil).InsertGrants), ctx, tx, a, key, limit)
+}
+
+// SetUserActive mocks base method.
+func (m *Mockallowance) SetUserActive(ctx context.Context, tx pgx.Tx, username string, active bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetUserActive", ctx, tx, username, active)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetUserActive indicates an expected call of SetUserActive.
+func (mr *MockallowanceMockRecorder) SetUserActive(ctx, tx, username, active interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetUserActive", reflect.TypeOf((*Mockallowance)(nil).SetUserActive), ctx, tx, username, active)
+}
+
+// Mockuser is a mock of user interface.
+type Mockuser struct {
+	ctrl     *gomock.Controller
+	recorder *MockuserMockRecorder
+}
+
+// MockuserMockRecorder is the mock recorder for Mockuser.
+type MockuserMockRecorder struct {
+	mock *Mockuser
+}
+
+// NewMockuser creates a new mock instance.
+func NewMockuser(ctrl *gomock.Controller) *Mockuser {
+	mock := &Mockuser{ctrl: ctrl}
+	mock.recorder = &MockuserMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mockuser) EXPECT() *MockuserMockRecorder {
+	return m.recorder
+}
+
+// CreditUserCoins mocks base method.
+func (m *Mockuser) CreditUserCoins(ctx context.Context, tx pgx.Tx, userID string, amount int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreditUserCoins", ctx, tx, userID, amount)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreditUserCoins indicates an expected call of CreditUserCoins.
+func (mr *MockuserMockRecorder) CreditUserCoins(ctx, tx, userID, amount interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreditUserCoins", reflect.TypeOf((*Mockuser)(nil).CreditUserCoins), ctx, tx, userID, amount)
+}
+
+// Mockledger is a mock of ledger interface.
+type Mockledger struct {
+	ctrl     *gomock.Controller
+	recorder *MockledgerMockRecorder
+}
+
+// MockledgerMockRecorder is the mock recorder for Mockledger.
+type MockledgerMockRecorder struct {
+	mock *Mockledger
+}
+
+// NewMockledger creates a new mock instance.
+func NewMockledger(ctrl *gomock.Controller) *Mockledger {
+	mock := &Mockledger{ctrl: ctrl}
+	mock.recorder = &MockledgerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mockledger) EXPECT() *MockledgerMockRecorder {
+	return m.recorder
+}
+
+// PostEntry mocks base method.
+func (m *Mockledger) PostEntry(ctx context.Context, tx pgx.Tx, e models.LedgerEntry) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PostEntry", ctx, tx, e)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PostEntry indicates an expected call of PostEntry.
+func (mr *MockledgerMockRecorder) PostEntry(ctx, tx, e interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PostEntry", reflect.TypeOf((*Mockledger)(nil).PostEntry), ctx, tx, e)
+}
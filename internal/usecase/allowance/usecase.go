@@ -0,0 +1,159 @@
+package allowance
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+
+	"AvitoTask/internal/models"
+	"AvitoTask/internal/utils"
+)
+
+// grantBatch - скольким пользователям пособие начисляется за одну транзакцию
+const grantBatch = 500
+
+// conflictAttempts - сколько раз пачка начислений повторяется при конфликте с переводами пользователей
+const conflictAttempts = 3
+
+type Usecase struct {
+	repo       allowance
+	repoUser   user
+	repoLedger ledger
+	allowances []models.Allowance
+	Now        func() time.Time
+}
+
+func NewUsecase(a allowance, u user, l ledger, allowances []models.Allowance) *Usecase {
+	return &Usecase{
+		repo:       a,
+		repoUser:   u,
+		repoLedger: l,
+		allowances: allowances,
+		Now: func() time.Time {
+			return time.Now().UTC()
+		},
+	}
+}
+
+// GrantDue - начисляет пособия за текущий период всем, кто их ещё не получил; возвращает число начислений
+func (u *Usecase) GrantDue(ctx context.Context) (granted int, err error) {
+	now := u.Now()
+	for _, a := range u.allowances {
+		key := a.GrantKey(now)
+		for {
+			n, err := u.grantBatch(ctx, a, key)
+			if err != nil {
+				return granted, fmt.Errorf("allowance %s: %w", key, err)
+			}
+			granted += n
+			if n < grantBatch {
+				break
+			}
+		}
+	}
+
+	return granted, nil
+}
+
+func (u *Usecase) grantBatch(ctx context.Context, a models.Allowance, key string) (n int, err error) {
+	err = utils.RetryOnConflict(ctx, conflictAttempts, func() (err error) {
+		tx, err := u.repo.BeginTx(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to begin tx: %w", err)
+		}
+
+		defer func() {
+			if err != nil {
+				_ = tx.Rollback(ctx)
+			} else {
+				err = tx.Commit(ctx)
+			}
+		}()
+
+		grants, err := u.repo.InsertGrants(ctx, tx, a, key, grantBatch)
+		if err != nil {
+			return err
+		}
+		n = len(grants)
+		if n == 0 {
+			return nil
+		}
+
+		return u.credit(ctx, tx, a, key, grants)
+	})
+
+	return n, err
+}
+
+// credit - зачисляет пособие пользователям в порядке их id и проводит всю пачку одной записью журнала со счёта эмиссии
+func (u *Usecase) credit(ctx context.Context, tx pgx.Tx, a models.Allowance, key string, grants []models.AllowanceGrant) error {
+	slices.SortFunc(grants, func(x, y models.AllowanceGrant) int {
+		return strings.Compare(x.UserID, y.UserID)
+	})
+
+	entry := models.LedgerEntry{
+		ID:        uuid.New().String(),
+		Kind:      models.EntryGrant,
+		Reference: key,
+		Postings:  []models.Posting{{Account: models.AccountIssuance, Amount: -a.Amount * int64(len(grants))}},
+	}
+	for _, g := range grants {
+		if err := u.repoUser.CreditUserCoins(ctx, tx, g.UserID, a.Amount); err != nil {
+			return fmt.Errorf("failed to update user coins: %w", err)
+		}
+		entry.Postings = append(entry.Postings, models.Posting{Account: models.UserAccount(g.UserID), Amount: a.Amount})
+	}
+
+	if err := u.repoLedger.PostEntry(ctx, tx, entry); err != nil {
+		return fmt.Errorf("failed to post ledger entry: %w", err)
+	}
+
+	return nil
+}
+
+// SetActive - включает или выключает пользователю начисление пособий
+func (u *Usecase) SetActive(ctx context.Context, username string, active bool) (err error) {
+	tx, err := u.repo.BeginTx(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin tx: %w", err)
+	}
+
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback(ctx)
+		} else {
+			err = tx.Commit(ctx)
+		}
+	}()
+
+	return u.repo.SetUserActive(ctx, tx, username, active)
+}
+
+// RunGrants - начисляет пособия при старте и затем раз в interval, пока не отменён ctx. Начисление за период
+// идемпотентно, поэтому перезапуск сервиса не начисляет пособие повторно
+func (u *Usecase) RunGrants(ctx context.Context, interval time.Duration) {
+	if len(u.allowances) == 0 {
+		return
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := u.GrantDue(ctx); err != nil {
+			log.Printf("allowances: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,100 @@
+package bundle_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/jackc/pgx/v5"
+
+	"AvitoTask/internal/models"
+	"AvitoTask/internal/usecase/bundle"
+	"AvitoTask/internal/usecase/bundle/mocks"
+)
+
+func welcomeKit(price int64) models.Bundle {
+	return models.Bundle{
+		Name:  "welcome-kit",
+		Price: price,
+		Items: []models.BundleItem{
+			{Item: "t-shirt", Quantity: 1},
+			{Item: "cup", Quantity: 1},
+			{Item: "pen", Quantity: 2},
+		},
+	}
+}
+
+func expectParts(ctx context.Context, mockCatalog *mocks.Mockcatalog, mockTx pgx.Tx) {
+	mockCatalog.EXPECT().GetItemByName(ctx, mockTx, "t-shirt").Return(models.CatalogItem{ID: "item-1", Name: "t-shirt", Price: 80}, nil)
+	mockCatalog.EXPECT().GetItemByName(ctx, mockTx, "cup").Return(models.CatalogItem{ID: "item-2", Name: "cup", Price: 20}, nil)
+	mockCatalog.EXPECT().GetItemByName(ctx, mockTx, "pen").Return(models.CatalogItem{ID: "item-3", Name: "pen", Price: 10}, nil)
+}
+
+func TestCreateBundle_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockBundle := mocks.NewMockbundle(ctrl)
+	mockCatalog := mocks.NewMockcatalog(ctrl)
+	mockTx := mocks.NewMockTx(ctrl)
+
+	mockBundle.EXPECT().BeginTx(ctx).Return(mockTx, nil)
+	mockBundle.EXPECT().GetBundleByName(ctx, mockTx, "welcome-kit").Return(models.Bundle{}, models.ErrBundleNotFound)
+	expectParts(ctx, mockCatalog, mockTx)
+	mockBundle.EXPECT().InsertBundle(ctx, mockTx, gomock.Any()).DoAndReturn(
+		func(_ context.Context, _ pgx.Tx, b models.Bundle) error {
+			if b.CreatedBy != "admin" || len(b.Items) != 3 || b.Items[2].ItemID != "item-3" {
+				t.Errorf("unexpected bundle %+v", b)
+			}
+			return nil
+		})
+	mockTx.EXPECT().Commit(ctx).Return(nil)
+
+	uc := bundle.NewUsecase(mockBundle, mockCatalog)
+	if _, err := uc.CreateBundle(ctx, "admin", welcomeKit(100)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestCreateBundle_NotCheaper(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockBundle := mocks.NewMockbundle(ctrl)
+	mockCatalog := mocks.NewMockcatalog(ctrl)
+	mockTx := mocks.NewMockTx(ctrl)
+
+	mockBundle.EXPECT().BeginTx(ctx).Return(mockTx, nil)
+	mockBundle.EXPECT().GetBundleByName(ctx, mockTx, "welcome-kit").Return(models.Bundle{}, models.ErrBundleNotFound)
+	expectParts(ctx, mockCatalog, mockTx)
+	mockTx.EXPECT().Rollback(ctx).Return(nil)
+
+	uc := bundle.NewUsecase(mockBundle, mockCatalog)
+	_, err := uc.CreateBundle(ctx, "admin", welcomeKit(120))
+	if !errors.Is(err, bundle.ErrBundleNotCheaper) {
+		t.Errorf("expected error %v, got %v", bundle.ErrBundleNotCheaper, err)
+	}
+}
+
+func TestCreateBundle_AlreadyExists(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockBundle := mocks.NewMockbundle(ctrl)
+	mockCatalog := mocks.NewMockcatalog(ctrl)
+	mockTx := mocks.NewMockTx(ctrl)
+
+	mockBundle.EXPECT().BeginTx(ctx).Return(mockTx, nil)
+	mockBundle.EXPECT().GetBundleByName(ctx, mockTx, "welcome-kit").Return(welcomeKit(100), nil)
+	mockTx.EXPECT().Rollback(ctx).Return(nil)
+
+	uc := bundle.NewUsecase(mockBundle, mockCatalog)
+	_, err := uc.CreateBundle(ctx, "admin", welcomeKit(100))
+	if !errors.Is(err, bundle.ErrBundleExists) {
+		t.Errorf("expected error %v, got %v", bundle.ErrBundleExists, err)
+	}
+}
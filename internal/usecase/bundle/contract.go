@@ -0,0 +1,24 @@
+//go:generate mockgen -source=contract.go -destination=mocks/mock.go -package=mocks $GOPACKAGE
+//go:generate mockgen -destination=mocks/mock_tx.go -package=mocks github.com/jackc/pgx/v5 Tx
+package bundle
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+
+	"AvitoTask/internal/models"
+)
+
+type bundle interface {
+	BeginTx(ctx context.Context) (pgx.Tx, error)
+	InsertBundle(ctx context.Context, tx pgx.Tx, b models.Bundle) error
+	GetBundleByName(ctx context.Context, tx pgx.Tx, name string) (models.Bundle, error)
+	ListBundles(ctx context.Context, tx pgx.Tx) ([]models.Bundle, error)
+	RetireBundle(ctx context.Context, tx pgx.Tx, name string) error
+}
+
+type catalog interface {
+	GetItemByName(ctx context.Context, tx pgx.Tx, name string) (models.CatalogItem, error)
+	GetVariantBySKU(ctx context.Context, tx pgx.Tx, sku string) (models.ItemVariant, error)
+}
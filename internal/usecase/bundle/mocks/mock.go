@@ -0,0 +1,163 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: contract.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	models "AvitoTask/internal/models"
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	pgx "github.com/jackc/pgx/v5"
+)
+
+// Mockbundle is a mock of bundle interface.
+type Mockbundle struct {
+	ctrl     *gomock.Controller
+	recorder *MockbundleMockRecorder
+}
+
+// MockbundleMockRecorder is the mock recorder for Mockbundle.
+type MockbundleMockRecorder struct {
+	mock *Mockbundle
+}
+
+// NewMockbundle creates a new mock instance.
+func NewMockbundle(ctrl *gomock.Controller) *Mockbundle {
+	mock := &Mockbundle{ctrl: ctrl}
+	mock.recorder = &MockbundleMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mockbundle) EXPECT() *MockbundleMockRecorder {
+	return m.recorder
+}
+
+// BeginTx mocks base method.
+func (m *Mockbundle) BeginTx(ctx context.Context) (pgx.Tx, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BeginTx", ctx)
+	ret0, _ := ret[0].(pgx.Tx)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BeginTx indicates an expected call of BeginTx.
+func (mr *MockbundleMockRecorder) BeginTx(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BeginTx", reflect.TypeOf((*Mockbundle)(nil).BeginTx), ctx)
+}
+
+// GetBundleByName mocks base method.
+func (m *Mockbundle) GetBundleByName(ctx context.Context, tx pgx.Tx, name string) (models.Bundle, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBundleByName", ctx, tx, name)
+	ret0, _ := ret[0].(models.Bundle)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBundleByName indicates an expected call of GetBundleByName.
+func (mr *MockbundleMockRecorder) GetBundleByName(ctx, tx, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBundleByName", reflect.TypeOf((*Mockbundle)(nil).GetBundleByName), ctx, tx, name)
+}
+
+// InsertBundle mocks base method.
+func (m *Mockbundle) InsertBundle(ctx context.Context, tx pgx.Tx, b models.Bundle) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertBundle", ctx, tx, b)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InsertBundle indicates an expected call of InsertBundle.
+func (mr *MockbundleMockRecorder) InsertBundle(ctx, tx, b interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertBundle", reflect.TypeOf((*Mockbundle)(nil).InsertBundle), ctx, tx, b)
+}
+
+// ListBundles mocks base method.
+func (m *Mockbundle) ListBundles(ctx context.Context, tx pgx.Tx) ([]models.Bundle, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListBundles", ctx, tx)
+	ret0, _ := ret[0].([]models.Bundle)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListBundles indicates an expected call of ListBundles.
+func (mr *MockbundleMockRecorder) ListBundles(ctx, tx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListBundles", reflect.TypeOf((*Mockbundle)(nil).ListBundles), ctx, tx)
+}
+
+// RetireBundle mocks base method.
+func (m *Mockbundle) RetireBundle(ctx context.Context, tx pgx.Tx, name string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RetireBundle", ctx, tx, name)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RetireBundle indicates an expected call of RetireBundle.
+func (mr *MockbundleMockRecorder) RetireBundle(ctx, tx, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RetireBundle", reflect.TypeOf((*Mockbundle)(nil).RetireBundle), ctx, tx, name)
+}
+
+// Mockcatalog is a mock of catalog interface.
+type Mockcatalog struct {
+	ctrl     *gomock.Controller
+	recorder *MockcatalogMockRecorder
+}
+
+// MockcatalogMockRecorder is the mock recorder for Mockcatalog.
+type MockcatalogMockRecorder struct {
+	mock *Mockcatalog
+}
+
+// NewMockcatalog creates a new mock instance.
+func NewMockcatalog(ctrl *gomock.Controller) *Mockcatalog {
+	mock := &Mockcatalog{ctrl: ctrl}
+	mock.recorder = &MockcatalogMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mockcatalog) EXPECT() *MockcatalogMockRecorder {
+	return m.recorder
+}
+
+// GetItemByName mocks base method.
+func (m *Mockcatalog) GetItemByName(ctx context.Context, tx pgx.Tx, name string) (models.CatalogItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetItemByName", ctx, tx, name)
+	ret0, _ := ret[0].(models.CatalogItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetItemByName indicates an expected call of GetItemByName.
+func (mr *MockcatalogMockRecorder) GetItemByName(ctx, tx, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetItemByName", reflect.TypeOf((*Mockcatalog)(nil).GetItemByName), ctx, tx, name)
+}
+
+// GetVariantBySKU mocks base method.
+func (m *Mockcatalog) GetVariantBySKU(ctx context.Context, tx pgx.Tx, sku string) (models.ItemVariant, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetVariantBySKU", ctx, tx, sku)
+	ret0, _ := ret[0].(models.ItemVariant)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetVariantBySKU indicates an expected call of GetVariantBySKU.
+func (mr *MockcatalogMockRecorder) GetVariantBySKU(ctx, tx, sku interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVariantBySKU", reflect.TypeOf((*Mockcatalog)(nil).GetVariantBySKU), ctx, tx, sku)
+}
@@ -0,0 +1,156 @@
+package bundle
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+
+	"AvitoTask/internal/models"
+)
+
+var (
+	ErrBundleExists     = errors.New("bundle already exists")
+	ErrBundleNotCheaper = errors.New("bundle price must be lower than the sum of its items")
+	ErrDuplicateItem    = errors.New("bundle lists the same item twice")
+)
+
+type Usecase struct {
+	repo        bundle
+	repoCatalog catalog
+}
+
+func NewUsecase(b bundle, c catalog) *Usecase {
+	return &Usecase{
+		repo:        b,
+		repoCatalog: c,
+	}
+}
+
+// CreateBundle - заводит набор; draft.Items задают позиции по имени и SKU варианта.
+// Цена набора должна быть ниже суммы текущих цен его позиций
+func (u *Usecase) CreateBundle(ctx context.Context, adminID string, draft models.Bundle) (b models.Bundle, err error) {
+	tx, err := u.repo.BeginTx(ctx)
+	if err != nil {
+		return b, fmt.Errorf("failed to begin tx: %w", err)
+	}
+
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback(ctx)
+		} else {
+			err = tx.Commit(ctx)
+		}
+	}()
+
+	_, err = u.repo.GetBundleByName(ctx, tx, draft.Name)
+	if err == nil {
+		err = ErrBundleExists
+		return b, err
+	}
+	if !errors.Is(err, models.ErrBundleNotFound) {
+		return b, err
+	}
+
+	b = models.Bundle{
+		ID:        uuid.New().String(),
+		Name:      draft.Name,
+		Price:     draft.Price,
+		CreatedBy: adminID,
+		Items:     make([]models.BundleItem, 0, len(draft.Items)),
+	}
+
+	var partsPrice int64
+	seen := make(map[models.BundleItem]bool, len(draft.Items))
+	for _, it := range draft.Items {
+		key := models.BundleItem{Item: it.Item, Variant: it.Variant}
+		if seen[key] {
+			err = fmt.Errorf("%w: %s %s", ErrDuplicateItem, it.Item, it.Variant)
+			return b, err
+		}
+		seen[key] = true
+
+		var price int64
+		price, err = u.itemPrice(ctx, tx, &it)
+		if err != nil {
+			return b, err
+		}
+
+		partsPrice += price * it.Quantity
+		b.Items = append(b.Items, it)
+	}
+
+	if b.Price >= partsPrice {
+		err = fmt.Errorf("%w: %d >= %d", ErrBundleNotCheaper, b.Price, partsPrice)
+		return b, err
+	}
+
+	if err = u.repo.InsertBundle(ctx, tx, b); err != nil {
+		return b, err
+	}
+
+	return b, nil
+}
+
+// itemPrice - находит позицию набора в каталоге, проставляет её ID и возвращает цену за единицу
+func (u *Usecase) itemPrice(ctx context.Context, tx pgx.Tx, it *models.BundleItem) (int64, error) {
+	item, err := u.repoCatalog.GetItemByName(ctx, tx, it.Item)
+	if err != nil {
+		return 0, err
+	}
+	if item.Retired {
+		return 0, fmt.Errorf("%w: %s", models.ErrItemNotAvailable, item.Name)
+	}
+	it.ItemID = item.ID
+
+	if it.Variant == "" {
+		return item.Price, nil
+	}
+
+	variant, err := u.repoCatalog.GetVariantBySKU(ctx, tx, it.Variant)
+	if err != nil {
+		return 0, err
+	}
+	if variant.ItemID != item.ID {
+		return 0, models.ErrVariantNotFound
+	}
+
+	return variant.PriceFor(item), nil
+}
+
+func (u *Usecase) ListBundles(ctx context.Context) (bundles []models.Bundle, err error) {
+	tx, err := u.repo.BeginTx(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin tx: %w", err)
+	}
+
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback(ctx)
+		} else {
+			err = tx.Commit(ctx)
+		}
+	}()
+
+	return u.repo.ListBundles(ctx, tx)
+}
+
+// RetireBundle - снимает набор с продажи; уже купленные наборы по-прежнему можно вернуть
+func (u *Usecase) RetireBundle(ctx context.Context, name string) (err error) {
+	tx, err := u.repo.BeginTx(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin tx: %w", err)
+	}
+
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback(ctx)
+		} else {
+			err = tx.Commit(ctx)
+		}
+	}()
+
+	return u.repo.RetireBundle(ctx, tx, name)
+}
@@ -2,13 +2,16 @@ package buy_item_test
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/jackc/pgx/v5"
 
+	"AvitoTask/internal/models"
 	"AvitoTask/internal/usecase/buy_item"
 	"AvitoTask/internal/usecase/buy_item/mocks"
 )
@@ -20,16 +23,20 @@ func TestBuyItem_BeginTxError(t *testing.T) {
 	ctx := context.Background()
 	userID := "user123"
 	item := "sword"
-	cost := int64(100)
 
 	mockUser := mocks.NewMockuser(ctrl)
 	mockInventory := mocks.NewMockinventory(ctrl)
+	mockCatalog := mocks.NewMockcatalog(ctrl)
+	mockCart := mocks.NewMockcart(ctrl)
+	mockOrder := mocks.NewMockorder(ctrl)
+	mockPromotion := mocks.NewMockpromotion(ctrl)
+	mockCoupon := mocks.NewMockcoupon(ctrl)
 
 	beginErr := errors.New("begin tx error")
 	mockUser.EXPECT().BeginTx(ctx).Return(nil, beginErr)
 
-	uc := buy_item.NewUsecase(mockUser, mockInventory)
-	err := uc.BuyItem(ctx, userID, item, cost)
+	uc := buy_item.NewUsecase(mockUser, mockInventory, mockCatalog, mockCart, mockOrder, mockPromotion, mockCoupon, nil, acceptLedger(ctrl), nil)
+	err := uc.BuyItem(ctx, userID, item, "", "")
 	if err == nil {
 		t.Fatalf("expected error, got nil")
 	}
@@ -39,35 +46,63 @@ func TestBuyItem_BeginTxError(t *testing.T) {
 	}
 }
 
-func TestBuyItem_GetUserCoinsError(t *testing.T) {
+func TestBuyItem_ItemNotFound(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
 	ctx := context.Background()
 	userID := "user123"
 	item := "sword"
-	cost := int64(100)
 
 	mockUser := mocks.NewMockuser(ctrl)
 	mockInventory := mocks.NewMockinventory(ctrl)
+	mockCatalog := mocks.NewMockcatalog(ctrl)
+	mockCart := mocks.NewMockcart(ctrl)
+	mockOrder := mocks.NewMockorder(ctrl)
+	mockPromotion := mocks.NewMockpromotion(ctrl)
+	mockCoupon := mocks.NewMockcoupon(ctrl)
 	mockTx := mocks.NewMockTx(ctrl)
 
 	mockUser.EXPECT().BeginTx(ctx).Return(mockTx, nil)
-	getCoinsErr := errors.New("failed to get coins")
-	mockUser.EXPECT().GetUserCoins(ctx, mockTx, userID).Return(int64(0), getCoinsErr)
+	mockCatalog.EXPECT().GetItemByName(ctx, mockTx, item).Return(models.CatalogItem{}, models.ErrItemNotFound)
 	mockTx.EXPECT().Rollback(ctx).Return(nil)
 
-	uc := buy_item.NewUsecase(mockUser, mockInventory)
-	err := uc.BuyItem(ctx, userID, item, cost)
-	if err == nil {
-		t.Fatalf("expected error, got nil")
+	uc := buy_item.NewUsecase(mockUser, mockInventory, mockCatalog, mockCart, mockOrder, mockPromotion, mockCoupon, nil, acceptLedger(ctrl), nil)
+	err := uc.BuyItem(ctx, userID, item, "", "")
+	if !errors.Is(err, models.ErrItemNotFound) {
+		t.Errorf("expected error %v, got %v", models.ErrItemNotFound, err)
 	}
-	if !errors.Is(err, getCoinsErr) {
-		t.Errorf("expected error %v, got %v", getCoinsErr, err)
+}
+
+func TestBuyItem_ItemNotAvailable(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	userID := "user123"
+	item := "sword"
+
+	mockUser := mocks.NewMockuser(ctrl)
+	mockInventory := mocks.NewMockinventory(ctrl)
+	mockCatalog := mocks.NewMockcatalog(ctrl)
+	mockCart := mocks.NewMockcart(ctrl)
+	mockOrder := mocks.NewMockorder(ctrl)
+	mockPromotion := mocks.NewMockpromotion(ctrl)
+	mockCoupon := mocks.NewMockcoupon(ctrl)
+	mockTx := mocks.NewMockTx(ctrl)
+
+	mockUser.EXPECT().BeginTx(ctx).Return(mockTx, nil)
+	mockCatalog.EXPECT().GetItemByName(ctx, mockTx, item).Return(models.CatalogItem{Name: item, Price: 100, Hidden: true}, nil)
+	mockTx.EXPECT().Rollback(ctx).Return(nil)
+
+	uc := buy_item.NewUsecase(mockUser, mockInventory, mockCatalog, mockCart, mockOrder, mockPromotion, mockCoupon, nil, acceptLedger(ctrl), nil)
+	err := uc.BuyItem(ctx, userID, item, "", "")
+	if !errors.Is(err, models.ErrItemNotAvailable) {
+		t.Errorf("expected error %v, got %v", models.ErrItemNotAvailable, err)
 	}
 }
 
-func TestBuyItem_NotEnoughCoins(t *testing.T) {
+func TestBuyItem_DebitUserCoinsError(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
@@ -78,23 +113,31 @@ func TestBuyItem_NotEnoughCoins(t *testing.T) {
 
 	mockUser := mocks.NewMockuser(ctrl)
 	mockInventory := mocks.NewMockinventory(ctrl)
+	mockCatalog := mocks.NewMockcatalog(ctrl)
+	mockCart := mocks.NewMockcart(ctrl)
+	mockOrder := mocks.NewMockorder(ctrl)
+	mockPromotion := mocks.NewMockpromotion(ctrl)
+	mockCoupon := mocks.NewMockcoupon(ctrl)
 	mockTx := mocks.NewMockTx(ctrl)
 
 	mockUser.EXPECT().BeginTx(ctx).Return(mockTx, nil)
-	mockUser.EXPECT().GetUserCoins(ctx, mockTx, userID).Return(int64(50), nil)
+	mockCatalog.EXPECT().GetItemByName(ctx, mockTx, item).Return(models.CatalogItem{Name: item, Price: cost}, nil)
+	mockPromotion.EXPECT().GetActivePromotions(ctx, mockTx, gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil)
+	getCoinsErr := errors.New("failed to debit coins")
+	mockUser.EXPECT().DebitUserCoins(ctx, mockTx, userID, cost).Return(getCoinsErr)
 	mockTx.EXPECT().Rollback(ctx).Return(nil)
 
-	uc := buy_item.NewUsecase(mockUser, mockInventory)
-	err := uc.BuyItem(ctx, userID, item, cost)
+	uc := buy_item.NewUsecase(mockUser, mockInventory, mockCatalog, mockCart, mockOrder, mockPromotion, mockCoupon, nil, acceptLedger(ctrl), nil)
+	err := uc.BuyItem(ctx, userID, item, "", "")
 	if err == nil {
 		t.Fatalf("expected error, got nil")
 	}
-	if !errors.Is(err, buy_item.ErrNotEnoughCoins) {
-		t.Errorf("expected error %v, got %v", buy_item.ErrNotEnoughCoins, err)
+	if !errors.Is(err, getCoinsErr) {
+		t.Errorf("expected error %v, got %v", getCoinsErr, err)
 	}
 }
 
-func TestBuyItem_UpdateUserCoinsError(t *testing.T) {
+func TestBuyItem_NotEnoughCoins(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
@@ -102,30 +145,33 @@ func TestBuyItem_UpdateUserCoinsError(t *testing.T) {
 	userID := "user123"
 	item := "sword"
 	cost := int64(100)
-	startingCoins := int64(150)
 
 	mockUser := mocks.NewMockuser(ctrl)
 	mockInventory := mocks.NewMockinventory(ctrl)
+	mockCatalog := mocks.NewMockcatalog(ctrl)
+	mockCart := mocks.NewMockcart(ctrl)
+	mockOrder := mocks.NewMockorder(ctrl)
+	mockPromotion := mocks.NewMockpromotion(ctrl)
+	mockCoupon := mocks.NewMockcoupon(ctrl)
 	mockTx := mocks.NewMockTx(ctrl)
 
 	mockUser.EXPECT().BeginTx(ctx).Return(mockTx, nil)
-	mockUser.EXPECT().GetUserCoins(ctx, mockTx, userID).Return(startingCoins, nil)
-	newCoins := startingCoins - cost
-	updateErr := errors.New("failed to update coins")
-	mockUser.EXPECT().UpdateUserCoins(ctx, mockTx, userID, newCoins).Return(updateErr)
+	mockCatalog.EXPECT().GetItemByName(ctx, mockTx, item).Return(models.CatalogItem{Name: item, Price: cost}, nil)
+	mockPromotion.EXPECT().GetActivePromotions(ctx, mockTx, gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil)
+	mockUser.EXPECT().DebitUserCoins(ctx, mockTx, userID, cost).Return(models.ErrNotEnoughCoins)
 	mockTx.EXPECT().Rollback(ctx).Return(nil)
 
-	uc := buy_item.NewUsecase(mockUser, mockInventory)
-	err := uc.BuyItem(ctx, userID, item, cost)
+	uc := buy_item.NewUsecase(mockUser, mockInventory, mockCatalog, mockCart, mockOrder, mockPromotion, mockCoupon, nil, acceptLedger(ctrl), nil)
+	err := uc.BuyItem(ctx, userID, item, "", "")
 	if err == nil {
 		t.Fatalf("expected error, got nil")
 	}
-	if !errors.Is(err, updateErr) {
-		t.Errorf("expected error %v, got %v", updateErr, err)
+	if !errors.Is(err, buy_item.ErrNotEnoughCoins) {
+		t.Errorf("expected error %v, got %v", buy_item.ErrNotEnoughCoins, err)
 	}
 }
 
-func TestBuyItem_GetInventoryItemError_NotNoRows(t *testing.T) {
+func TestBuyItem_AddInventoryItemError(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
@@ -133,23 +179,27 @@ func TestBuyItem_GetInventoryItemError_NotNoRows(t *testing.T) {
 	userID := "user123"
 	item := "sword"
 	cost := int64(100)
-	startingCoins := int64(200)
 
 	mockUser := mocks.NewMockuser(ctrl)
 	mockInventory := mocks.NewMockinventory(ctrl)
+	mockCatalog := mocks.NewMockcatalog(ctrl)
+	mockCart := mocks.NewMockcart(ctrl)
+	mockOrder := mocks.NewMockorder(ctrl)
+	mockPromotion := mocks.NewMockpromotion(ctrl)
+	mockCoupon := mocks.NewMockcoupon(ctrl)
 	mockTx := mocks.NewMockTx(ctrl)
 
 	mockUser.EXPECT().BeginTx(ctx).Return(mockTx, nil)
-	mockUser.EXPECT().GetUserCoins(ctx, mockTx, userID).Return(startingCoins, nil)
-	newCoins := startingCoins - cost
-	mockUser.EXPECT().UpdateUserCoins(ctx, mockTx, userID, newCoins).Return(nil)
+	mockCatalog.EXPECT().GetItemByName(ctx, mockTx, item).Return(models.CatalogItem{Name: item, Price: cost}, nil)
+	mockPromotion.EXPECT().GetActivePromotions(ctx, mockTx, gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil)
+	mockUser.EXPECT().DebitUserCoins(ctx, mockTx, userID, cost).Return(nil)
 
 	invErr := errors.New("inventory error")
-	mockInventory.EXPECT().GetInventoryItem(ctx, mockTx, userID, item).Return(int64(0), invErr)
+	mockInventory.EXPECT().AddInventoryItem(ctx, mockTx, gomock.Any(), userID, item, "", int64(1)).Return(invErr)
 	mockTx.EXPECT().Rollback(ctx).Return(nil)
 
-	uc := buy_item.NewUsecase(mockUser, mockInventory)
-	err := uc.BuyItem(ctx, userID, item, cost)
+	uc := buy_item.NewUsecase(mockUser, mockInventory, mockCatalog, mockCart, mockOrder, mockPromotion, mockCoupon, nil, acceptLedger(ctrl), nil)
+	err := uc.BuyItem(ctx, userID, item, "", "")
 	if err == nil {
 		t.Fatalf("expected error, got nil")
 	}
@@ -158,7 +208,7 @@ func TestBuyItem_GetInventoryItemError_NotNoRows(t *testing.T) {
 	}
 }
 
-func TestBuyItem_InsertInventoryItemError(t *testing.T) {
+func TestBuyItem_Success(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
@@ -166,130 +216,776 @@ func TestBuyItem_InsertInventoryItemError(t *testing.T) {
 	userID := "user123"
 	item := "sword"
 	cost := int64(100)
-	startingCoins := int64(200)
 
 	mockUser := mocks.NewMockuser(ctrl)
 	mockInventory := mocks.NewMockinventory(ctrl)
+	mockCatalog := mocks.NewMockcatalog(ctrl)
+	mockCart := mocks.NewMockcart(ctrl)
+	mockOrder := mocks.NewMockorder(ctrl)
+	mockPromotion := mocks.NewMockpromotion(ctrl)
+	mockCoupon := mocks.NewMockcoupon(ctrl)
 	mockTx := mocks.NewMockTx(ctrl)
 
 	mockUser.EXPECT().BeginTx(ctx).Return(mockTx, nil)
-	mockUser.EXPECT().GetUserCoins(ctx, mockTx, userID).Return(startingCoins, nil)
-	newCoins := startingCoins - cost
-	mockUser.EXPECT().UpdateUserCoins(ctx, mockTx, userID, newCoins).Return(nil)
+	mockCatalog.EXPECT().GetItemByName(ctx, mockTx, item).Return(models.CatalogItem{Name: item, Price: cost}, nil)
+	mockPromotion.EXPECT().GetActivePromotions(ctx, mockTx, gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil)
+	mockUser.EXPECT().DebitUserCoins(ctx, mockTx, userID, cost).Return(nil)
+
+	mockInventory.EXPECT().AddInventoryItem(ctx, mockTx, gomock.Any(), userID, item, "", int64(1)).Return(nil)
+
+	mockOrder.EXPECT().InsertOrder(ctx, mockTx, gomock.Any()).DoAndReturn(
+		func(_ context.Context, _ pgx.Tx, o models.Order) error {
+			if o.UserID != userID || o.Item != item || o.Quantity != 1 || o.UnitPrice != cost || o.Total != cost {
+				t.Errorf("unexpected order %+v", o)
+			}
+			return nil
+		})
+
+	mockTx.EXPECT().Commit(ctx).Return(nil)
 
-	mockInventory.EXPECT().GetInventoryItem(ctx, mockTx, userID, item).Return(int64(0), pgx.ErrNoRows)
-	insertErr := errors.New("failed to insert inventory")
-	mockInventory.EXPECT().InsertInventoryItem(ctx, mockTx, gomock.Any(), userID, item).Return(insertErr)
+	uc := buy_item.NewUsecase(mockUser, mockInventory, mockCatalog, mockCart, mockOrder, mockPromotion, mockCoupon, nil, acceptLedger(ctrl), nil)
+	err := uc.BuyItem(ctx, userID, item, "", "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestBuyItem_SoldOut(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	userID := "user123"
+	item := "pink-hoody"
+	stock := int64(0)
+
+	mockUser := mocks.NewMockuser(ctrl)
+	mockInventory := mocks.NewMockinventory(ctrl)
+	mockCatalog := mocks.NewMockcatalog(ctrl)
+	mockCart := mocks.NewMockcart(ctrl)
+	mockOrder := mocks.NewMockorder(ctrl)
+	mockPromotion := mocks.NewMockpromotion(ctrl)
+	mockCoupon := mocks.NewMockcoupon(ctrl)
+	mockTx := mocks.NewMockTx(ctrl)
+
+	mockUser.EXPECT().BeginTx(ctx).Return(mockTx, nil)
+	mockCatalog.EXPECT().GetItemByName(ctx, mockTx, item).Return(models.CatalogItem{Name: item, Price: 500, Stock: &stock}, nil)
 	mockTx.EXPECT().Rollback(ctx).Return(nil)
 
-	uc := buy_item.NewUsecase(mockUser, mockInventory)
-	err := uc.BuyItem(ctx, userID, item, cost)
-	if err == nil {
-		t.Fatalf("expected error, got nil")
+	uc := buy_item.NewUsecase(mockUser, mockInventory, mockCatalog, mockCart, mockOrder, mockPromotion, mockCoupon, nil, acceptLedger(ctrl), nil)
+	err := uc.BuyItem(ctx, userID, item, "", "")
+	if !errors.Is(err, models.ErrSoldOut) {
+		t.Errorf("expected error %v, got %v", models.ErrSoldOut, err)
 	}
-	if !errors.Is(err, insertErr) {
-		t.Errorf("expected error %v, got %v", insertErr, err)
+}
+
+func TestBuyItem_SoldOutConcurrently(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	userID := "user123"
+	item := "pink-hoody"
+	stock := int64(1)
+
+	mockUser := mocks.NewMockuser(ctrl)
+	mockInventory := mocks.NewMockinventory(ctrl)
+	mockCatalog := mocks.NewMockcatalog(ctrl)
+	mockCart := mocks.NewMockcart(ctrl)
+	mockOrder := mocks.NewMockorder(ctrl)
+	mockPromotion := mocks.NewMockpromotion(ctrl)
+	mockCoupon := mocks.NewMockcoupon(ctrl)
+	mockTx := mocks.NewMockTx(ctrl)
+
+	mockUser.EXPECT().BeginTx(ctx).Return(mockTx, nil)
+	mockCatalog.EXPECT().GetItemByName(ctx, mockTx, item).Return(models.CatalogItem{ID: "item-1", Name: item, Price: 500, Stock: &stock}, nil)
+	mockPromotion.EXPECT().GetActivePromotions(ctx, mockTx, gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil)
+	mockUser.EXPECT().DebitUserCoins(ctx, mockTx, userID, int64(500)).Return(nil)
+	mockCatalog.EXPECT().DecrementStock(ctx, mockTx, "item-1", int64(1)).Return(models.ErrSoldOut)
+	mockTx.EXPECT().Rollback(ctx).Return(nil)
+
+	uc := buy_item.NewUsecase(mockUser, mockInventory, mockCatalog, mockCart, mockOrder, mockPromotion, mockCoupon, nil, acceptLedger(ctrl), nil)
+	err := uc.BuyItem(ctx, userID, item, "", "")
+	if !errors.Is(err, models.ErrSoldOut) {
+		t.Errorf("expected error %v, got %v", models.ErrSoldOut, err)
 	}
 }
 
-func TestBuyItem_UpdateInventoryItemError(t *testing.T) {
+func TestBuyItem_Success_LimitedStock(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
 	ctx := context.Background()
 	userID := "user123"
-	item := "sword"
-	cost := int64(100)
-	startingCoins := int64(200)
+	item := "pink-hoody"
+	stock := int64(3)
+
+	mockUser := mocks.NewMockuser(ctrl)
+	mockInventory := mocks.NewMockinventory(ctrl)
+	mockCatalog := mocks.NewMockcatalog(ctrl)
+	mockCart := mocks.NewMockcart(ctrl)
+	mockOrder := mocks.NewMockorder(ctrl)
+	mockPromotion := mocks.NewMockpromotion(ctrl)
+	mockCoupon := mocks.NewMockcoupon(ctrl)
+	mockTx := mocks.NewMockTx(ctrl)
+
+	mockUser.EXPECT().BeginTx(ctx).Return(mockTx, nil)
+	mockCatalog.EXPECT().GetItemByName(ctx, mockTx, item).Return(models.CatalogItem{ID: "item-1", Name: item, Price: 500, Stock: &stock}, nil)
+	mockPromotion.EXPECT().GetActivePromotions(ctx, mockTx, gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil)
+	mockUser.EXPECT().DebitUserCoins(ctx, mockTx, userID, int64(500)).Return(nil)
+	mockCatalog.EXPECT().DecrementStock(ctx, mockTx, "item-1", int64(1)).Return(nil)
+	mockCatalog.EXPECT().InsertStockMovement(ctx, mockTx, gomock.Any()).Return(nil)
+	mockInventory.EXPECT().AddInventoryItem(ctx, mockTx, gomock.Any(), userID, item, "", int64(1)).Return(nil)
+	mockOrder.EXPECT().InsertOrder(ctx, mockTx, gomock.Any()).Return(nil)
+	mockTx.EXPECT().Commit(ctx).Return(nil)
+
+	uc := buy_item.NewUsecase(mockUser, mockInventory, mockCatalog, mockCart, mockOrder, mockPromotion, mockCoupon, nil, acceptLedger(ctrl), nil)
+	err := uc.BuyItem(ctx, userID, item, "", "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestBuyItem_QuotaExceeded(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	userID := "user123"
+	item := "pink-hoody"
+	limit := int64(1)
 
 	mockUser := mocks.NewMockuser(ctrl)
 	mockInventory := mocks.NewMockinventory(ctrl)
+	mockCatalog := mocks.NewMockcatalog(ctrl)
+	mockCart := mocks.NewMockcart(ctrl)
+	mockOrder := mocks.NewMockorder(ctrl)
+	mockPromotion := mocks.NewMockpromotion(ctrl)
+	mockCoupon := mocks.NewMockcoupon(ctrl)
 	mockTx := mocks.NewMockTx(ctrl)
 
 	mockUser.EXPECT().BeginTx(ctx).Return(mockTx, nil)
-	mockUser.EXPECT().GetUserCoins(ctx, mockTx, userID).Return(startingCoins, nil)
-	newCoins := startingCoins - cost
-	mockUser.EXPECT().UpdateUserCoins(ctx, mockTx, userID, newCoins).Return(nil)
-
-	existingQuantity := int64(2)
-	mockInventory.EXPECT().GetInventoryItem(ctx, mockTx, userID, item).Return(existingQuantity, nil)
-	updateInvErr := errors.New("failed to update inventory")
-	newQuantity := existingQuantity + 1
-	mockInventory.EXPECT().UpdateInventoryItem(ctx, mockTx, userID, item, newQuantity).Return(updateInvErr)
+	mockCatalog.EXPECT().GetItemByName(ctx, mockTx, item).
+		Return(models.CatalogItem{ID: "item-1", Name: item, Price: 500, PurchaseLimit: &limit}, nil)
+	mockPromotion.EXPECT().GetActivePromotions(ctx, mockTx, gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil)
+	mockUser.EXPECT().LockUser(ctx, mockTx, userID).Return(nil)
+	mockOrder.EXPECT().CountUserPurchases(ctx, mockTx, userID, "item-1", time.Time{}).Return(int64(0), nil)
+	mockInventory.EXPECT().CountUserItems(ctx, mockTx, userID, item).Return(int64(1), nil)
 	mockTx.EXPECT().Rollback(ctx).Return(nil)
 
-	uc := buy_item.NewUsecase(mockUser, mockInventory)
-	err := uc.BuyItem(ctx, userID, item, cost)
-	if err == nil {
-		t.Fatalf("expected error, got nil")
+	uc := buy_item.NewUsecase(mockUser, mockInventory, mockCatalog, mockCart, mockOrder, mockPromotion, mockCoupon, nil, acceptLedger(ctrl), nil)
+	err := uc.BuyItem(ctx, userID, item, "", "")
+	if !errors.Is(err, models.ErrQuotaExceeded) {
+		t.Errorf("expected error %v, got %v", models.ErrQuotaExceeded, err)
 	}
-	if !errors.Is(err, updateInvErr) {
-		t.Errorf("expected error %v, got %v", updateInvErr, err)
+}
+
+func TestBuyItem_Success_MonthlyQuota(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	userID := "user123"
+	item := "pink-hoody"
+	limit := int64(2)
+	now := time.Date(2024, time.March, 10, 8, 0, 0, 0, time.UTC)
+
+	mockUser := mocks.NewMockuser(ctrl)
+	mockInventory := mocks.NewMockinventory(ctrl)
+	mockCatalog := mocks.NewMockcatalog(ctrl)
+	mockCart := mocks.NewMockcart(ctrl)
+	mockOrder := mocks.NewMockorder(ctrl)
+	mockPromotion := mocks.NewMockpromotion(ctrl)
+	mockCoupon := mocks.NewMockcoupon(ctrl)
+	mockTx := mocks.NewMockTx(ctrl)
+
+	mockUser.EXPECT().BeginTx(ctx).Return(mockTx, nil)
+	mockCatalog.EXPECT().GetItemByName(ctx, mockTx, item).
+		Return(models.CatalogItem{ID: "item-1", Name: item, Price: 500, PurchaseLimit: &limit, LimitPeriod: models.LimitPeriodMonth}, nil)
+	mockPromotion.EXPECT().GetActivePromotions(ctx, mockTx, gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil)
+	mockUser.EXPECT().LockUser(ctx, mockTx, userID).Return(nil)
+	mockOrder.EXPECT().CountUserPurchases(ctx, mockTx, userID, "item-1", time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC)).
+		Return(int64(1), nil)
+	// предметы, купленные в прошлых месяцах, не мешают месячной квоте
+	mockInventory.EXPECT().CountUserItems(ctx, mockTx, userID, item).Return(int64(5), nil)
+	mockUser.EXPECT().DebitUserCoins(ctx, mockTx, userID, int64(500)).Return(nil)
+	mockInventory.EXPECT().AddInventoryItem(ctx, mockTx, gomock.Any(), userID, item, "", int64(1)).Return(nil)
+	mockOrder.EXPECT().InsertOrder(ctx, mockTx, gomock.Any()).Return(nil)
+	mockTx.EXPECT().Commit(ctx).Return(nil)
+
+	uc := buy_item.NewUsecase(mockUser, mockInventory, mockCatalog, mockCart, mockOrder, mockPromotion, mockCoupon, nil, acceptLedger(ctrl), nil)
+	uc.Now = func() time.Time { return now }
+	if err := uc.BuyItem(ctx, userID, item, "", ""); err != nil {
+		t.Fatalf("unexpected error: %v", err)
 	}
 }
 
-func TestBuyItem_Success_ExistingItem(t *testing.T) {
+func TestBuyItem_Success_VariantPriceOverride(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
 	ctx := context.Background()
 	userID := "user123"
-	item := "sword"
-	cost := int64(100)
-	startingCoins := int64(200)
+	item := "t-shirt"
+	sku := "t-shirt-l-black"
+	variantPrice := int64(120)
+	variantStock := int64(2)
 
 	mockUser := mocks.NewMockuser(ctrl)
 	mockInventory := mocks.NewMockinventory(ctrl)
+	mockCatalog := mocks.NewMockcatalog(ctrl)
+	mockCart := mocks.NewMockcart(ctrl)
+	mockOrder := mocks.NewMockorder(ctrl)
+	mockPromotion := mocks.NewMockpromotion(ctrl)
+	mockCoupon := mocks.NewMockcoupon(ctrl)
 	mockTx := mocks.NewMockTx(ctrl)
 
 	mockUser.EXPECT().BeginTx(ctx).Return(mockTx, nil)
-	mockUser.EXPECT().GetUserCoins(ctx, mockTx, userID).Return(startingCoins, nil)
-	newCoins := startingCoins - cost
-	mockUser.EXPECT().UpdateUserCoins(ctx, mockTx, userID, newCoins).Return(nil)
+	mockCatalog.EXPECT().GetItemByName(ctx, mockTx, item).Return(models.CatalogItem{ID: "item-1", Name: item, Price: 80}, nil)
+	mockPromotion.EXPECT().GetActivePromotions(ctx, mockTx, gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil)
+	mockCatalog.EXPECT().GetVariantBySKU(ctx, mockTx, sku).Return(models.ItemVariant{ItemID: "item-1", SKU: sku, Price: &variantPrice, Stock: &variantStock}, nil)
+	mockUser.EXPECT().DebitUserCoins(ctx, mockTx, userID, int64(120)).Return(nil)
+	mockCatalog.EXPECT().DecrementVariantStock(ctx, mockTx, sku, int64(1)).Return(nil)
+	mockCatalog.EXPECT().InsertStockMovement(ctx, mockTx, gomock.Any()).DoAndReturn(
+		func(_ context.Context, _ pgx.Tx, m models.StockMovement) error {
+			if m.Variant != sku || m.ItemID != "item-1" || m.Delta != -1 {
+				t.Errorf("unexpected stock movement %+v", m)
+			}
+			return nil
+		})
+	mockInventory.EXPECT().AddInventoryItem(ctx, mockTx, gomock.Any(), userID, item, sku, int64(1)).Return(nil)
+	mockOrder.EXPECT().InsertOrder(ctx, mockTx, gomock.Any()).DoAndReturn(
+		func(_ context.Context, _ pgx.Tx, o models.Order) error {
+			if o.Variant != sku || o.UnitPrice != variantPrice {
+				t.Errorf("unexpected order %+v", o)
+			}
+			return nil
+		})
+	mockTx.EXPECT().Commit(ctx).Return(nil)
 
-	existingQuantity := int64(3)
-	mockInventory.EXPECT().GetInventoryItem(ctx, mockTx, userID, item).Return(existingQuantity, nil)
-	newQuantity := existingQuantity + 1
-	mockInventory.EXPECT().UpdateInventoryItem(ctx, mockTx, userID, item, newQuantity).Return(nil)
+	uc := buy_item.NewUsecase(mockUser, mockInventory, mockCatalog, mockCart, mockOrder, mockPromotion, mockCoupon, nil, acceptLedger(ctrl), nil)
+	err := uc.BuyItem(ctx, userID, item, sku, "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
 
+func TestBuyItem_VariantOfAnotherItem(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	userID := "user123"
+	item := "hoody"
+
+	mockUser := mocks.NewMockuser(ctrl)
+	mockInventory := mocks.NewMockinventory(ctrl)
+	mockCatalog := mocks.NewMockcatalog(ctrl)
+	mockCart := mocks.NewMockcart(ctrl)
+	mockOrder := mocks.NewMockorder(ctrl)
+	mockPromotion := mocks.NewMockpromotion(ctrl)
+	mockCoupon := mocks.NewMockcoupon(ctrl)
+	mockTx := mocks.NewMockTx(ctrl)
+
+	mockUser.EXPECT().BeginTx(ctx).Return(mockTx, nil)
+	mockCatalog.EXPECT().GetItemByName(ctx, mockTx, item).Return(models.CatalogItem{ID: "item-2", Name: item, Price: 300}, nil)
+	mockCatalog.EXPECT().GetVariantBySKU(ctx, mockTx, "t-shirt-l-black").Return(models.ItemVariant{ItemID: "item-1", SKU: "t-shirt-l-black"}, nil)
+	mockTx.EXPECT().Rollback(ctx).Return(nil)
+
+	uc := buy_item.NewUsecase(mockUser, mockInventory, mockCatalog, mockCart, mockOrder, mockPromotion, mockCoupon, nil, acceptLedger(ctrl), nil)
+	err := uc.BuyItem(ctx, userID, item, "t-shirt-l-black", "")
+	if !errors.Is(err, models.ErrVariantNotFound) {
+		t.Fatalf("expected ErrVariantNotFound, got %v", err)
+	}
+}
+
+func TestBuyItem_Success_BestPromotionApplied(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	userID := "user123"
+	item := "book"
+	now := time.Date(2025, 2, 10, 12, 0, 0, 0, time.UTC)
+
+	mockUser := mocks.NewMockuser(ctrl)
+	mockInventory := mocks.NewMockinventory(ctrl)
+	mockCatalog := mocks.NewMockcatalog(ctrl)
+	mockCart := mocks.NewMockcart(ctrl)
+	mockOrder := mocks.NewMockorder(ctrl)
+	mockPromotion := mocks.NewMockpromotion(ctrl)
+	mockCoupon := mocks.NewMockcoupon(ctrl)
+	mockTx := mocks.NewMockTx(ctrl)
+
+	mockUser.EXPECT().BeginTx(ctx).Return(mockTx, nil)
+	mockCatalog.EXPECT().GetItemByName(ctx, mockTx, item).Return(models.CatalogItem{ID: "item-1", Name: item, Category: "books", Price: 100}, nil)
+	mockPromotion.EXPECT().GetActivePromotions(ctx, mockTx, "item-1", "books", now).Return([]models.Promotion{
+		{ID: "promo-fixed", Amount: 30},
+		{ID: "promo-half", Percent: 50},
+	}, nil)
+	mockUser.EXPECT().DebitUserCoins(ctx, mockTx, userID, int64(50)).Return(nil)
+	mockInventory.EXPECT().AddInventoryItem(ctx, mockTx, gomock.Any(), userID, item, "", int64(1)).Return(nil)
+	mockOrder.EXPECT().InsertOrder(ctx, mockTx, gomock.Any()).DoAndReturn(
+		func(_ context.Context, _ pgx.Tx, o models.Order) error {
+			if o.PromotionID != "promo-half" || o.Discount != 50 || o.UnitPrice != 50 || o.Total != 50 {
+				t.Errorf("unexpected order %+v", o)
+			}
+			return nil
+		})
 	mockTx.EXPECT().Commit(ctx).Return(nil)
 
-	uc := buy_item.NewUsecase(mockUser, mockInventory)
-	err := uc.BuyItem(ctx, userID, item, cost)
+	uc := buy_item.NewUsecase(mockUser, mockInventory, mockCatalog, mockCart, mockOrder, mockPromotion, mockCoupon, nil, acceptLedger(ctrl), nil)
+	uc.Now = func() time.Time { return now }
+	err := uc.BuyItem(ctx, userID, item, "", "")
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
 }
 
-func TestBuyItem_Success_NewItem(t *testing.T) {
+func TestBuyItem_Success_Coupon(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
 	ctx := context.Background()
 	userID := "user123"
-	item := "sword"
-	cost := int64(100)
-	startingCoins := int64(200)
+	item := "book"
+	code := "HACK-ABCDEFGH"
+
+	mockUser := mocks.NewMockuser(ctrl)
+	mockInventory := mocks.NewMockinventory(ctrl)
+	mockCatalog := mocks.NewMockcatalog(ctrl)
+	mockCart := mocks.NewMockcart(ctrl)
+	mockOrder := mocks.NewMockorder(ctrl)
+	mockPromotion := mocks.NewMockpromotion(ctrl)
+	mockCoupon := mocks.NewMockcoupon(ctrl)
+	mockTx := mocks.NewMockTx(ctrl)
+
+	mockUser.EXPECT().BeginTx(ctx).Return(mockTx, nil)
+	mockCatalog.EXPECT().GetItemByName(ctx, mockTx, item).Return(models.CatalogItem{ID: "item-1", Name: item, Price: 100}, nil)
+	mockPromotion.EXPECT().GetActivePromotions(ctx, mockTx, "item-1", gomock.Any(), gomock.Any()).Return(nil, nil)
+	mockCoupon.EXPECT().LockCoupon(ctx, mockTx, code).Return(models.Coupon{
+		Code: code, Percent: 25, MaxRedemptions: 10, Redeemed: 3, MinSpend: 50, Items: []string{item},
+	}, nil)
+	mockCoupon.EXPECT().HasRedeemed(ctx, mockTx, code, userID).Return(false, nil)
+	mockCoupon.EXPECT().IncrementRedemptions(ctx, mockTx, code).Return(nil)
+	mockCoupon.EXPECT().InsertRedemption(ctx, mockTx, gomock.Any()).Return(nil)
+	mockUser.EXPECT().DebitUserCoins(ctx, mockTx, userID, int64(75)).Return(nil)
+	mockInventory.EXPECT().AddInventoryItem(ctx, mockTx, gomock.Any(), userID, item, "", int64(1)).Return(nil)
+	mockOrder.EXPECT().InsertOrder(ctx, mockTx, gomock.Any()).DoAndReturn(
+		func(_ context.Context, _ pgx.Tx, o models.Order) error {
+			if o.CouponCode != code || o.CouponDiscount != 25 || o.Total != 75 {
+				t.Errorf("unexpected order %+v", o)
+			}
+			return nil
+		})
+	mockTx.EXPECT().Commit(ctx).Return(nil)
+
+	uc := buy_item.NewUsecase(mockUser, mockInventory, mockCatalog, mockCart, mockOrder, mockPromotion, mockCoupon, nil, acceptLedger(ctrl), nil)
+	err := uc.BuyItem(ctx, userID, item, "", code)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestBuyItem_CouponRejected(t *testing.T) {
+	now := time.Date(2025, 2, 10, 12, 0, 0, 0, time.UTC)
+	expired := now.Add(-time.Minute)
+
+	tests := []struct {
+		name       string
+		coupon     models.Coupon
+		usedBefore bool
+		want       error
+	}{
+		{
+			name:   "expired",
+			coupon: models.Coupon{Amount: 10, MaxRedemptions: 5, ExpiresAt: &expired},
+			want:   models.ErrCouponExpired,
+		},
+		{
+			name:   "exhausted",
+			coupon: models.Coupon{Amount: 10, MaxRedemptions: 1, Redeemed: 1},
+			want:   models.ErrCouponExhausted,
+		},
+		{
+			name:   "below minimum spend",
+			coupon: models.Coupon{Amount: 10, MaxRedemptions: 5, MinSpend: 500},
+			want:   models.ErrCouponMinSpend,
+		},
+		{
+			name:       "already used by user",
+			coupon:     models.Coupon{Amount: 10, MaxRedemptions: 5},
+			usedBefore: true,
+			want:       models.ErrCouponAlreadyUsed,
+		},
+		{
+			name:   "item not eligible",
+			coupon: models.Coupon{Amount: 10, MaxRedemptions: 5, Items: []string{"hoody"}},
+			want:   models.ErrCouponNotApplicable,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			ctx := context.Background()
+			mockUser := mocks.NewMockuser(ctrl)
+			mockCatalog := mocks.NewMockcatalog(ctrl)
+			mockPromotion := mocks.NewMockpromotion(ctrl)
+			mockCoupon := mocks.NewMockcoupon(ctrl)
+			mockTx := mocks.NewMockTx(ctrl)
+
+			mockUser.EXPECT().BeginTx(ctx).Return(mockTx, nil)
+			mockCatalog.EXPECT().GetItemByName(ctx, mockTx, "book").Return(models.CatalogItem{ID: "item-1", Name: "book", Price: 100}, nil)
+			mockPromotion.EXPECT().GetActivePromotions(ctx, mockTx, "item-1", gomock.Any(), now).Return(nil, nil)
+			mockCoupon.EXPECT().LockCoupon(ctx, mockTx, "CODE").Return(tt.coupon, nil)
+			mockCoupon.EXPECT().HasRedeemed(ctx, mockTx, "CODE", "user123").Return(tt.usedBefore, nil).MaxTimes(1)
+			mockTx.EXPECT().Rollback(ctx).Return(nil)
+
+			uc := buy_item.NewUsecase(mockUser, mocks.NewMockinventory(ctrl), mockCatalog, mocks.NewMockcart(ctrl),
+				mocks.NewMockorder(ctrl), mockPromotion, mockCoupon, nil, acceptLedger(ctrl), nil)
+			uc.Now = func() time.Time { return now }
+			err := uc.BuyItem(ctx, "user123", "book", "", "CODE")
+			if !errors.Is(err, tt.want) {
+				t.Errorf("expected error %v, got %v", tt.want, err)
+			}
+		})
+	}
+}
+
+func TestBuyItem_InsertOrderError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	userID := "user123"
+	item := "cup"
+
+	mockUser := mocks.NewMockuser(ctrl)
+	mockInventory := mocks.NewMockinventory(ctrl)
+	mockCatalog := mocks.NewMockcatalog(ctrl)
+	mockCart := mocks.NewMockcart(ctrl)
+	mockOrder := mocks.NewMockorder(ctrl)
+	mockPromotion := mocks.NewMockpromotion(ctrl)
+	mockCoupon := mocks.NewMockcoupon(ctrl)
+	mockTx := mocks.NewMockTx(ctrl)
+
+	orderErr := errors.New("failed to insert order")
+	mockUser.EXPECT().BeginTx(ctx).Return(mockTx, nil)
+	mockCatalog.EXPECT().GetItemByName(ctx, mockTx, item).Return(models.CatalogItem{ID: "item-1", Name: item, Price: 20}, nil)
+	mockPromotion.EXPECT().GetActivePromotions(ctx, mockTx, gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil)
+	mockUser.EXPECT().DebitUserCoins(ctx, mockTx, userID, int64(20)).Return(nil)
+	mockInventory.EXPECT().AddInventoryItem(ctx, mockTx, gomock.Any(), userID, item, "", int64(1)).Return(nil)
+	mockOrder.EXPECT().InsertOrder(ctx, mockTx, gomock.Any()).Return(orderErr)
+	mockTx.EXPECT().Rollback(ctx).Return(nil)
+
+	uc := buy_item.NewUsecase(mockUser, mockInventory, mockCatalog, mockCart, mockOrder, mockPromotion, mockCoupon, nil, acceptLedger(ctrl), nil)
+	err := uc.BuyItem(ctx, userID, item, "", "")
+	if !errors.Is(err, orderErr) {
+		t.Errorf("expected error %v, got %v", orderErr, err)
+	}
+}
+
+func kitBundle() models.Bundle {
+	return models.Bundle{
+		ID:    "bundle-1",
+		Name:  "welcome-kit",
+		Price: 100,
+		Items: []models.BundleItem{
+			{ItemID: "item-1", Item: "t-shirt", Quantity: 1},
+			{ItemID: "item-2", Item: "pen", Quantity: 2},
+		},
+	}
+}
+
+func TestBuyBundle_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	userID := "user123"
+
+	mockUser := mocks.NewMockuser(ctrl)
+	mockInventory := mocks.NewMockinventory(ctrl)
+	mockCatalog := mocks.NewMockcatalog(ctrl)
+	mockCart := mocks.NewMockcart(ctrl)
+	mockOrder := mocks.NewMockorder(ctrl)
+	mockPromotion := mocks.NewMockpromotion(ctrl)
+	mockCoupon := mocks.NewMockcoupon(ctrl)
+	mockBundle := mocks.NewMockbundle(ctrl)
+	mockTx := mocks.NewMockTx(ctrl)
+
+	mockUser.EXPECT().BeginTx(ctx).Return(mockTx, nil)
+	mockBundle.EXPECT().GetBundleByName(ctx, mockTx, "welcome-kit").Return(kitBundle(), nil)
+	mockCatalog.EXPECT().GetItemByName(ctx, mockTx, "t-shirt").Return(models.CatalogItem{ID: "item-1", Name: "t-shirt", Price: 80}, nil)
+	mockCatalog.EXPECT().GetItemByName(ctx, mockTx, "pen").Return(models.CatalogItem{ID: "item-2", Name: "pen", Price: 10}, nil)
+	mockUser.EXPECT().DebitUserCoins(ctx, mockTx, userID, int64(100)).Return(nil)
+	mockInventory.EXPECT().AddInventoryItem(ctx, mockTx, gomock.Any(), userID, "t-shirt", "", int64(1)).Return(nil)
+	mockInventory.EXPECT().AddInventoryItem(ctx, mockTx, gomock.Any(), userID, "pen", "", int64(2)).Return(nil)
+	mockOrder.EXPECT().InsertOrder(ctx, mockTx, gomock.Any()).DoAndReturn(
+		func(_ context.Context, _ pgx.Tx, o models.Order) error {
+			if o.BundleID != "bundle-1" || o.Item != "welcome-kit" || o.Quantity != 1 || o.Total != 100 {
+				t.Errorf("unexpected bundle order %+v", o)
+			}
+			return nil
+		})
+	mockTx.EXPECT().Commit(ctx).Return(nil)
+
+	uc := buy_item.NewUsecase(mockUser, mockInventory, mockCatalog, mockCart, mockOrder, mockPromotion, mockCoupon, mockBundle, acceptLedger(ctrl), nil)
+	if err := uc.BuyBundle(ctx, userID, "welcome-kit"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestBuyBundle_ComponentSoldOut(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	userID := "user123"
+	stock := int64(1)
+
+	mockUser := mocks.NewMockuser(ctrl)
+	mockInventory := mocks.NewMockinventory(ctrl)
+	mockCatalog := mocks.NewMockcatalog(ctrl)
+	mockCart := mocks.NewMockcart(ctrl)
+	mockOrder := mocks.NewMockorder(ctrl)
+	mockPromotion := mocks.NewMockpromotion(ctrl)
+	mockCoupon := mocks.NewMockcoupon(ctrl)
+	mockBundle := mocks.NewMockbundle(ctrl)
+	mockTx := mocks.NewMockTx(ctrl)
+
+	mockUser.EXPECT().BeginTx(ctx).Return(mockTx, nil)
+	mockBundle.EXPECT().GetBundleByName(ctx, mockTx, "welcome-kit").Return(kitBundle(), nil)
+	mockCatalog.EXPECT().GetItemByName(ctx, mockTx, "t-shirt").Return(models.CatalogItem{ID: "item-1", Name: "t-shirt", Price: 80}, nil)
+	mockCatalog.EXPECT().GetItemByName(ctx, mockTx, "pen").Return(models.CatalogItem{ID: "item-2", Name: "pen", Price: 10, Stock: &stock}, nil)
+	mockTx.EXPECT().Rollback(ctx).Return(nil)
+
+	uc := buy_item.NewUsecase(mockUser, mockInventory, mockCatalog, mockCart, mockOrder, mockPromotion, mockCoupon, mockBundle, acceptLedger(ctrl), nil)
+	err := uc.BuyBundle(ctx, userID, "welcome-kit")
+	if !errors.Is(err, models.ErrSoldOut) {
+		t.Errorf("expected error %v, got %v", models.ErrSoldOut, err)
+	}
+}
+
+func TestCheckout_EmptyCart(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	userID := "user123"
+
+	mockUser := mocks.NewMockuser(ctrl)
+	mockInventory := mocks.NewMockinventory(ctrl)
+	mockCatalog := mocks.NewMockcatalog(ctrl)
+	mockCart := mocks.NewMockcart(ctrl)
+	mockOrder := mocks.NewMockorder(ctrl)
+	mockPromotion := mocks.NewMockpromotion(ctrl)
+	mockCoupon := mocks.NewMockcoupon(ctrl)
+	mockTx := mocks.NewMockTx(ctrl)
+
+	mockUser.EXPECT().BeginTx(ctx).Return(mockTx, nil)
+	mockCart.EXPECT().LockCart(ctx, mockTx, userID).Return(nil, nil)
+	mockTx.EXPECT().Rollback(ctx).Return(nil)
+
+	uc := buy_item.NewUsecase(mockUser, mockInventory, mockCatalog, mockCart, mockOrder, mockPromotion, mockCoupon, nil, acceptLedger(ctrl), nil)
+	_, err := uc.Checkout(ctx, userID)
+	if !errors.Is(err, buy_item.ErrEmptyCart) {
+		t.Errorf("expected error %v, got %v", buy_item.ErrEmptyCart, err)
+	}
+}
+
+func TestCheckout_NotEnoughCoinsForTotal(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	userID := "user123"
+
+	mockUser := mocks.NewMockuser(ctrl)
+	mockInventory := mocks.NewMockinventory(ctrl)
+	mockCatalog := mocks.NewMockcatalog(ctrl)
+	mockCart := mocks.NewMockcart(ctrl)
+	mockOrder := mocks.NewMockorder(ctrl)
+	mockPromotion := mocks.NewMockpromotion(ctrl)
+	mockCoupon := mocks.NewMockcoupon(ctrl)
+	mockTx := mocks.NewMockTx(ctrl)
+
+	mockUser.EXPECT().BeginTx(ctx).Return(mockTx, nil)
+	mockCart.EXPECT().LockCart(ctx, mockTx, userID).Return([]models.CartLine{
+		{Item: "pen", Quantity: 5},
+		{Item: "cup", Quantity: 1},
+	}, nil)
+	mockCatalog.EXPECT().GetItemByName(ctx, mockTx, "pen").Return(models.CatalogItem{Name: "pen", Price: 10}, nil)
+	mockPromotion.EXPECT().GetActivePromotions(ctx, mockTx, gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil)
+	mockCatalog.EXPECT().GetItemByName(ctx, mockTx, "cup").Return(models.CatalogItem{Name: "cup", Price: 20}, nil)
+	mockPromotion.EXPECT().GetActivePromotions(ctx, mockTx, gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil)
+	mockUser.EXPECT().DebitUserCoins(ctx, mockTx, userID, int64(70)).Return(models.ErrNotEnoughCoins)
+	mockTx.EXPECT().Rollback(ctx).Return(nil)
+
+	uc := buy_item.NewUsecase(mockUser, mockInventory, mockCatalog, mockCart, mockOrder, mockPromotion, mockCoupon, nil, acceptLedger(ctrl), nil)
+	_, err := uc.Checkout(ctx, userID)
+	if !errors.Is(err, buy_item.ErrNotEnoughCoins) {
+		t.Errorf("expected error %v, got %v", buy_item.ErrNotEnoughCoins, err)
+	}
+}
+
+func TestCheckout_LineFailsRollsBack(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	userID := "user123"
+	stock := int64(1)
 
 	mockUser := mocks.NewMockuser(ctrl)
 	mockInventory := mocks.NewMockinventory(ctrl)
+	mockCatalog := mocks.NewMockcatalog(ctrl)
+	mockCart := mocks.NewMockcart(ctrl)
+	mockOrder := mocks.NewMockorder(ctrl)
+	mockPromotion := mocks.NewMockpromotion(ctrl)
+	mockCoupon := mocks.NewMockcoupon(ctrl)
 	mockTx := mocks.NewMockTx(ctrl)
 
 	mockUser.EXPECT().BeginTx(ctx).Return(mockTx, nil)
-	mockUser.EXPECT().GetUserCoins(ctx, mockTx, userID).Return(startingCoins, nil)
-	newCoins := startingCoins - cost
-	mockUser.EXPECT().UpdateUserCoins(ctx, mockTx, userID, newCoins).Return(nil)
+	mockCart.EXPECT().LockCart(ctx, mockTx, userID).Return([]models.CartLine{
+		{Item: "pen", Quantity: 5},
+		{Item: "pink-hoody", Quantity: 2},
+	}, nil)
+	mockCatalog.EXPECT().GetItemByName(ctx, mockTx, "pen").Return(models.CatalogItem{Name: "pen", Price: 10}, nil)
+	mockPromotion.EXPECT().GetActivePromotions(ctx, mockTx, gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil)
+	mockCatalog.EXPECT().GetItemByName(ctx, mockTx, "pink-hoody").Return(models.CatalogItem{Name: "pink-hoody", Price: 500, Stock: &stock}, nil)
+	mockTx.EXPECT().Rollback(ctx).Return(nil)
 
-	mockInventory.EXPECT().GetInventoryItem(ctx, mockTx, userID, item).Return(int64(0), pgx.ErrNoRows)
+	uc := buy_item.NewUsecase(mockUser, mockInventory, mockCatalog, mockCart, mockOrder, mockPromotion, mockCoupon, nil, acceptLedger(ctrl), nil)
+	_, err := uc.Checkout(ctx, userID)
+	if !errors.Is(err, models.ErrSoldOut) {
+		t.Errorf("expected error %v, got %v", models.ErrSoldOut, err)
+	}
+}
 
-	mockInventory.EXPECT().InsertInventoryItem(ctx, mockTx, gomock.Any(), userID, item).Return(nil)
+func TestCheckout_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
 
-	mockInventory.EXPECT().UpdateInventoryItem(ctx, mockTx, userID, item, int64(1)).Return(nil)
+	ctx := context.Background()
+	userID := "user123"
+
+	mockUser := mocks.NewMockuser(ctrl)
+	mockInventory := mocks.NewMockinventory(ctrl)
+	mockCatalog := mocks.NewMockcatalog(ctrl)
+	mockCart := mocks.NewMockcart(ctrl)
+	mockOrder := mocks.NewMockorder(ctrl)
+	mockPromotion := mocks.NewMockpromotion(ctrl)
+	mockCoupon := mocks.NewMockcoupon(ctrl)
+	mockTx := mocks.NewMockTx(ctrl)
+
+	mockUser.EXPECT().BeginTx(ctx).Return(mockTx, nil)
+	mockCart.EXPECT().LockCart(ctx, mockTx, userID).Return([]models.CartLine{
+		{Item: "pen", Quantity: 5},
+		{Item: "cup", Quantity: 1},
+	}, nil)
+	mockCatalog.EXPECT().GetItemByName(ctx, mockTx, "pen").Return(models.CatalogItem{Name: "pen", Price: 10}, nil)
+	mockPromotion.EXPECT().GetActivePromotions(ctx, mockTx, gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil)
+	mockCatalog.EXPECT().GetItemByName(ctx, mockTx, "cup").Return(models.CatalogItem{Name: "cup", Price: 20}, nil)
+	mockPromotion.EXPECT().GetActivePromotions(ctx, mockTx, gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil)
+	mockUser.EXPECT().DebitUserCoins(ctx, mockTx, userID, int64(70)).Return(nil)
+	mockInventory.EXPECT().AddInventoryItem(ctx, mockTx, gomock.Any(), userID, "pen", "", int64(5)).Return(nil)
+	mockInventory.EXPECT().AddInventoryItem(ctx, mockTx, gomock.Any(), userID, "cup", "", int64(1)).Return(nil)
+	mockOrder.EXPECT().InsertOrder(ctx, mockTx, gomock.Any()).Return(nil).Times(2)
+	mockCart.EXPECT().ClearCart(ctx, mockTx, userID).Return(nil)
 	mockTx.EXPECT().Commit(ctx).Return(nil)
 
-	uc := buy_item.NewUsecase(mockUser, mockInventory)
-	err := uc.BuyItem(ctx, userID, item, cost)
+	uc := buy_item.NewUsecase(mockUser, mockInventory, mockCatalog, mockCart, mockOrder, mockPromotion, mockCoupon, nil, acceptLedger(ctrl), nil)
+	res, err := uc.Checkout(ctx, userID)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
+	if res.Total != 70 || len(res.Lines) != 2 {
+		t.Errorf("unexpected checkout result %+v", res)
+	}
+}
+
+func TestCheckout_ReplaysStoredCart(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	key := models.NewIdempotencyKey("user123", "checkout-1", "POST", "/api/cart/checkout", nil)
+	ctx := context.WithValue(context.Background(), models.IdempotencyKeyLocal, key)
+
+	mockUser := mocks.NewMockuser(ctrl)
+	mockIdempotency := mocks.NewMockidempotency(ctrl)
+	mockTx := mocks.NewMockTx(ctrl)
+
+	stored := key
+	stored.Response, _ = json.Marshal(models.Cart{Lines: []models.CartLine{{Item: "pen", Quantity: 5}}, Total: 50})
+
+	mockUser.EXPECT().BeginTx(ctx).Return(mockTx, nil)
+	mockIdempotency.EXPECT().ClaimKey(ctx, mockTx, key).Return(stored, nil)
+	mockTx.EXPECT().Commit(ctx).Return(nil)
+
+	uc := buy_item.NewUsecase(mockUser, mocks.NewMockinventory(ctrl), mocks.NewMockcatalog(ctrl), mocks.NewMockcart(ctrl),
+		mocks.NewMockorder(ctrl), mocks.NewMockpromotion(ctrl), mocks.NewMockcoupon(ctrl), nil, mocks.NewMockledger(ctrl), mockIdempotency)
+	res, err := uc.Checkout(ctx, "user123")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if res.Total != 50 || len(res.Lines) != 1 || res.Lines[0].Item != "pen" {
+		t.Errorf("unexpected replayed cart %+v", res)
+	}
+}
+
+// acceptLedger - журнал, который принимает любые записи; для тестов, где проводки не проверяются
+func acceptLedger(ctrl *gomock.Controller) *mocks.Mockledger {
+	l := mocks.NewMockledger(ctrl)
+	l.EXPECT().PostEntry(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+	return l
+}
+
+func TestBuyItem_PostsPurchaseToLedger(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+
+	mockUser := mocks.NewMockuser(ctrl)
+	mockInventory := mocks.NewMockinventory(ctrl)
+	mockCatalog := mocks.NewMockcatalog(ctrl)
+	mockOrder := mocks.NewMockorder(ctrl)
+	mockPromotion := mocks.NewMockpromotion(ctrl)
+	mockLedger := mocks.NewMockledger(ctrl)
+	mockTx := mocks.NewMockTx(ctrl)
+
+	var orderID string
+
+	mockUser.EXPECT().BeginTx(ctx).Return(mockTx, nil)
+	mockCatalog.EXPECT().GetItemByName(ctx, mockTx, "cup").Return(models.CatalogItem{ID: "item-1", Name: "cup", Price: 20}, nil)
+	mockPromotion.EXPECT().GetActivePromotions(ctx, mockTx, "item-1", gomock.Any(), gomock.Any()).Return(nil, nil)
+	mockUser.EXPECT().DebitUserCoins(ctx, mockTx, "user123", int64(20)).Return(nil)
+	mockInventory.EXPECT().AddInventoryItem(ctx, mockTx, gomock.Any(), "user123", "cup", "", int64(1)).Return(nil)
+	mockOrder.EXPECT().InsertOrder(ctx, mockTx, gomock.Any()).DoAndReturn(
+		func(_ context.Context, _ pgx.Tx, o models.Order) error {
+			orderID = o.ID
+			return nil
+		})
+	mockLedger.EXPECT().PostEntry(ctx, mockTx, gomock.Any()).DoAndReturn(
+		func(_ context.Context, _ pgx.Tx, e models.LedgerEntry) error {
+			if e.Kind != models.EntryPurchase || e.Reference != orderID || !e.Balanced() {
+				t.Errorf("unexpected ledger entry %+v", e)
+			}
+			if e.Postings[0] != (models.Posting{Account: "user:user123", Amount: -20}) ||
+				e.Postings[1] != (models.Posting{Account: models.AccountShop, Amount: 20}) {
+				t.Errorf("unexpected postings %+v", e.Postings)
+			}
+			return nil
+		})
+	mockTx.EXPECT().Commit(ctx).Return(nil)
+
+	uc := buy_item.NewUsecase(mockUser, mockInventory, mockCatalog, mocks.NewMockcart(ctrl), mockOrder, mockPromotion,
+		mocks.NewMockcoupon(ctrl), nil, mockLedger, nil)
+	if err := uc.BuyItem(ctx, "user123", "cup", "", ""); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
 }
@@ -4,6 +4,7 @@ package buy_item
 
 import (
 	"context"
+	"time"
 
 	"github.com/jackc/pgx/v5"
 
@@ -15,12 +16,54 @@ type user interface {
 	GetUserById(ctx context.Context, tx pgx.Tx, userID string) (models.User, error)
 	GetUserByLoginWithTx(ctx context.Context, tx pgx.Tx, login string) (models.User, error)
 	IsUserExists(ctx context.Context, user models.User) (bool, error)
-	UpdateUserCoins(ctx context.Context, tx pgx.Tx, userID string, newCoins int64) error
+	DebitUserCoins(ctx context.Context, tx pgx.Tx, userID string, amount int64) error
 	GetUserCoins(ctx context.Context, tx pgx.Tx, userID string) (int64, error)
+	LockUser(ctx context.Context, tx pgx.Tx, userID string) error
 }
 
 type inventory interface {
-	GetInventoryItem(ctx context.Context, tx pgx.Tx, userID, itemType string) (int64, error)
-	InsertInventoryItem(ctx context.Context, tx pgx.Tx, id, userID, itemType string) error
-	UpdateInventoryItem(ctx context.Context, tx pgx.Tx, userID, itemType string, newQuantity int64) error
+	AddInventoryItem(ctx context.Context, tx pgx.Tx, id, userID, itemType, variant string, quantity int64) error
+	CountUserItems(ctx context.Context, tx pgx.Tx, userID, itemType string) (int64, error)
+}
+
+type catalog interface {
+	GetItemByName(ctx context.Context, tx pgx.Tx, name string) (models.CatalogItem, error)
+	GetVariantBySKU(ctx context.Context, tx pgx.Tx, sku string) (models.ItemVariant, error)
+	DecrementStock(ctx context.Context, tx pgx.Tx, itemID string, quantity int64) error
+	DecrementVariantStock(ctx context.Context, tx pgx.Tx, sku string, quantity int64) error
+	InsertStockMovement(ctx context.Context, tx pgx.Tx, m models.StockMovement) error
+}
+
+type cart interface {
+	LockCart(ctx context.Context, tx pgx.Tx, userID string) ([]models.CartLine, error)
+	ClearCart(ctx context.Context, tx pgx.Tx, userID string) error
+}
+
+type order interface {
+	InsertOrder(ctx context.Context, tx pgx.Tx, o models.Order) error
+	CountUserPurchases(ctx context.Context, tx pgx.Tx, userID, itemID string, since time.Time) (int64, error)
+}
+
+type promotion interface {
+	GetActivePromotions(ctx context.Context, tx pgx.Tx, itemID, category string, at time.Time) ([]models.Promotion, error)
+}
+
+type bundle interface {
+	GetBundleByName(ctx context.Context, tx pgx.Tx, name string) (models.Bundle, error)
+}
+
+type ledger interface {
+	PostEntry(ctx context.Context, tx pgx.Tx, e models.LedgerEntry) error
+}
+
+type coupon interface {
+	LockCoupon(ctx context.Context, tx pgx.Tx, code string) (models.Coupon, error)
+	HasRedeemed(ctx context.Context, tx pgx.Tx, code, userID string) (bool, error)
+	IncrementRedemptions(ctx context.Context, tx pgx.Tx, code string) error
+	InsertRedemption(ctx context.Context, tx pgx.Tx, r models.CouponRedemption) error
+}
+
+type idempotency interface {
+	ClaimKey(ctx context.Context, tx pgx.Tx, k models.IdempotencyKey) (models.IdempotencyKey, error)
+	SaveResponse(ctx context.Context, tx pgx.Tx, k models.IdempotencyKey) error
 }
@@ -8,6 +8,7 @@ import (
 	models "AvitoTask/internal/models"
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	gomock "github.com/golang/mock/gomock"
 	pgx "github.com/jackc/pgx/v5"
@@ -51,6 +52,20 @@ func (mr *MockuserMockRecorder) BeginTx(ctx interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BeginTx", reflect.TypeOf((*Mockuser)(nil).BeginTx), ctx)
 }
 
+// DebitUserCoins mocks base method.
+func (m *Mockuser) DebitUserCoins(ctx context.Context, tx pgx.Tx, userID string, amount int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DebitUserCoins", ctx, tx, userID, amount)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DebitUserCoins indicates an expected call of DebitUserCoins.
+func (mr *MockuserMockRecorder) DebitUserCoins(ctx, tx, userID, amount interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DebitUserCoins", reflect.TypeOf((*Mockuser)(nil).DebitUserCoins), ctx, tx, userID, amount)
+}
+
 // GetUserById mocks base method.
 func (m *Mockuser) GetUserById(ctx context.Context, tx pgx.Tx, userID string) (models.User, error) {
 	m.ctrl.T.Helper()
@@ -111,18 +126,18 @@ func (mr *MockuserMockRecorder) IsUserExists(ctx, user interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsUserExists", reflect.TypeOf((*Mockuser)(nil).IsUserExists), ctx, user)
 }
 
-// UpdateUserCoins mocks base method.
-func (m *Mockuser) UpdateUserCoins(ctx context.Context, tx pgx.Tx, userID string, newCoins int64) error {
+// LockUser mocks base method.
+func (m *Mockuser) LockUser(ctx context.Context, tx pgx.Tx, userID string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateUserCoins", ctx, tx, userID, newCoins)
+	ret := m.ctrl.Call(m, "LockUser", ctx, tx, userID)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// UpdateUserCoins indicates an expected call of UpdateUserCoins.
-func (mr *MockuserMockRecorder) UpdateUserCoins(ctx, tx, userID, newCoins interface{}) *gomock.Call {
+// LockUser indicates an expected call of LockUser.
+func (mr *MockuserMockRecorder) LockUser(ctx, tx, userID interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUserCoins", reflect.TypeOf((*Mockuser)(nil).UpdateUserCoins), ctx, tx, userID, newCoins)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LockUser", reflect.TypeOf((*Mockuser)(nil).LockUser), ctx, tx, userID)
 }
 
 // Mockinventory is a mock of inventory interface.
@@ -148,45 +163,476 @@ func (m *Mockinventory) EXPECT() *MockinventoryMockRecorder {
 	return m.recorder
 }
 
-// GetInventoryItem mocks base method.
-func (m *Mockinventory) GetInventoryItem(ctx context.Context, tx pgx.Tx, userID, itemType string) (int64, error) {
+// AddInventoryItem mocks base method.
+func (m *Mockinventory) AddInventoryItem(ctx context.Context, tx pgx.Tx, id, userID, itemType, variant string, quantity int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddInventoryItem", ctx, tx, id, userID, itemType, variant, quantity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddInventoryItem indicates an expected call of AddInventoryItem.
+func (mr *MockinventoryMockRecorder) AddInventoryItem(ctx, tx, id, userID, itemType, variant, quantity interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddInventoryItem", reflect.TypeOf((*Mockinventory)(nil).AddInventoryItem), ctx, tx, id, userID, itemType, variant, quantity)
+}
+
+// CountUserItems mocks base method.
+func (m *Mockinventory) CountUserItems(ctx context.Context, tx pgx.Tx, userID, itemType string) (int64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetInventoryItem", ctx, tx, userID, itemType)
+	ret := m.ctrl.Call(m, "CountUserItems", ctx, tx, userID, itemType)
 	ret0, _ := ret[0].(int64)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetInventoryItem indicates an expected call of GetInventoryItem.
-func (mr *MockinventoryMockRecorder) GetInventoryItem(ctx, tx, userID, itemType interface{}) *gomock.Call {
+// CountUserItems indicates an expected call of CountUserItems.
+func (mr *MockinventoryMockRecorder) CountUserItems(ctx, tx, userID, itemType interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInventoryItem", reflect.TypeOf((*Mockinventory)(nil).GetInventoryItem), ctx, tx, userID, itemType)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountUserItems", reflect.TypeOf((*Mockinventory)(nil).CountUserItems), ctx, tx, userID, itemType)
 }
 
-// InsertInventoryItem mocks base method.
-func (m *Mockinventory) InsertInventoryItem(ctx context.Context, tx pgx.Tx, id, userID, itemType string) error {
+// Mockcatalog is a mock of catalog interface.
+type Mockcatalog struct {
+	ctrl     *gomock.Controller
+	recorder *MockcatalogMockRecorder
+}
+
+// MockcatalogMockRecorder is the mock recorder for Mockcatalog.
+type MockcatalogMockRecorder struct {
+	mock *Mockcatalog
+}
+
+// NewMockcatalog creates a new mock instance.
+func NewMockcatalog(ctrl *gomock.Controller) *Mockcatalog {
+	mock := &Mockcatalog{ctrl: ctrl}
+	mock.recorder = &MockcatalogMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mockcatalog) EXPECT() *MockcatalogMockRecorder {
+	return m.recorder
+}
+
+// DecrementStock mocks base method.
+func (m *Mockcatalog) DecrementStock(ctx context.Context, tx pgx.Tx, itemID string, quantity int64) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "InsertInventoryItem", ctx, tx, id, userID, itemType)
+	ret := m.ctrl.Call(m, "DecrementStock", ctx, tx, itemID, quantity)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// InsertInventoryItem indicates an expected call of InsertInventoryItem.
-func (mr *MockinventoryMockRecorder) InsertInventoryItem(ctx, tx, id, userID, itemType interface{}) *gomock.Call {
+// DecrementStock indicates an expected call of DecrementStock.
+func (mr *MockcatalogMockRecorder) DecrementStock(ctx, tx, itemID, quantity interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DecrementStock", reflect.TypeOf((*Mockcatalog)(nil).DecrementStock), ctx, tx, itemID, quantity)
+}
+
+// DecrementVariantStock mocks base method.
+func (m *Mockcatalog) DecrementVariantStock(ctx context.Context, tx pgx.Tx, sku string, quantity int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DecrementVariantStock", ctx, tx, sku, quantity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DecrementVariantStock indicates an expected call of DecrementVariantStock.
+func (mr *MockcatalogMockRecorder) DecrementVariantStock(ctx, tx, sku, quantity interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DecrementVariantStock", reflect.TypeOf((*Mockcatalog)(nil).DecrementVariantStock), ctx, tx, sku, quantity)
+}
+
+// GetItemByName mocks base method.
+func (m *Mockcatalog) GetItemByName(ctx context.Context, tx pgx.Tx, name string) (models.CatalogItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetItemByName", ctx, tx, name)
+	ret0, _ := ret[0].(models.CatalogItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetItemByName indicates an expected call of GetItemByName.
+func (mr *MockcatalogMockRecorder) GetItemByName(ctx, tx, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetItemByName", reflect.TypeOf((*Mockcatalog)(nil).GetItemByName), ctx, tx, name)
+}
+
+// GetVariantBySKU mocks base method.
+func (m *Mockcatalog) GetVariantBySKU(ctx context.Context, tx pgx.Tx, sku string) (models.ItemVariant, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetVariantBySKU", ctx, tx, sku)
+	ret0, _ := ret[0].(models.ItemVariant)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetVariantBySKU indicates an expected call of GetVariantBySKU.
+func (mr *MockcatalogMockRecorder) GetVariantBySKU(ctx, tx, sku interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVariantBySKU", reflect.TypeOf((*Mockcatalog)(nil).GetVariantBySKU), ctx, tx, sku)
+}
+
+// InsertStockMovement mocks base method.
+func (m_2 *Mockcatalog) InsertStockMovement(ctx context.Context, tx pgx.Tx, m models.StockMovement) error {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "InsertStockMovement", ctx, tx, m)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InsertStockMovement indicates an expected call of InsertStockMovement.
+func (mr *MockcatalogMockRecorder) InsertStockMovement(ctx, tx, m interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertStockMovement", reflect.TypeOf((*Mockcatalog)(nil).InsertStockMovement), ctx, tx, m)
+}
+
+// Mockcart is a mock of cart interface.
+type Mockcart struct {
+	ctrl     *gomock.Controller
+	recorder *MockcartMockRecorder
+}
+
+// MockcartMockRecorder is the mock recorder for Mockcart.
+type MockcartMockRecorder struct {
+	mock *Mockcart
+}
+
+// NewMockcart creates a new mock instance.
+func NewMockcart(ctrl *gomock.Controller) *Mockcart {
+	mock := &Mockcart{ctrl: ctrl}
+	mock.recorder = &MockcartMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mockcart) EXPECT() *MockcartMockRecorder {
+	return m.recorder
+}
+
+// ClearCart mocks base method.
+func (m *Mockcart) ClearCart(ctx context.Context, tx pgx.Tx, userID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClearCart", ctx, tx, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ClearCart indicates an expected call of ClearCart.
+func (mr *MockcartMockRecorder) ClearCart(ctx, tx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClearCart", reflect.TypeOf((*Mockcart)(nil).ClearCart), ctx, tx, userID)
+}
+
+// LockCart mocks base method.
+func (m *Mockcart) LockCart(ctx context.Context, tx pgx.Tx, userID string) ([]models.CartLine, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LockCart", ctx, tx, userID)
+	ret0, _ := ret[0].([]models.CartLine)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LockCart indicates an expected call of LockCart.
+func (mr *MockcartMockRecorder) LockCart(ctx, tx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LockCart", reflect.TypeOf((*Mockcart)(nil).LockCart), ctx, tx, userID)
+}
+
+// Mockorder is a mock of order interface.
+type Mockorder struct {
+	ctrl     *gomock.Controller
+	recorder *MockorderMockRecorder
+}
+
+// MockorderMockRecorder is the mock recorder for Mockorder.
+type MockorderMockRecorder struct {
+	mock *Mockorder
+}
+
+// NewMockorder creates a new mock instance.
+func NewMockorder(ctrl *gomock.Controller) *Mockorder {
+	mock := &Mockorder{ctrl: ctrl}
+	mock.recorder = &MockorderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mockorder) EXPECT() *MockorderMockRecorder {
+	return m.recorder
+}
+
+// CountUserPurchases mocks base method.
+func (m *Mockorder) CountUserPurchases(ctx context.Context, tx pgx.Tx, userID, itemID string, since time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountUserPurchases", ctx, tx, userID, itemID, since)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountUserPurchases indicates an expected call of CountUserPurchases.
+func (mr *MockorderMockRecorder) CountUserPurchases(ctx, tx, userID, itemID, since interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountUserPurchases", reflect.TypeOf((*Mockorder)(nil).CountUserPurchases), ctx, tx, userID, itemID, since)
+}
+
+// InsertOrder mocks base method.
+func (m *Mockorder) InsertOrder(ctx context.Context, tx pgx.Tx, o models.Order) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertOrder", ctx, tx, o)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InsertOrder indicates an expected call of InsertOrder.
+func (mr *MockorderMockRecorder) InsertOrder(ctx, tx, o interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertOrder", reflect.TypeOf((*Mockorder)(nil).InsertOrder), ctx, tx, o)
+}
+
+// Mockpromotion is a mock of promotion interface.
+type Mockpromotion struct {
+	ctrl     *gomock.Controller
+	recorder *MockpromotionMockRecorder
+}
+
+// MockpromotionMockRecorder is the mock recorder for Mockpromotion.
+type MockpromotionMockRecorder struct {
+	mock *Mockpromotion
+}
+
+// NewMockpromotion creates a new mock instance.
+func NewMockpromotion(ctrl *gomock.Controller) *Mockpromotion {
+	mock := &Mockpromotion{ctrl: ctrl}
+	mock.recorder = &MockpromotionMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mockpromotion) EXPECT() *MockpromotionMockRecorder {
+	return m.recorder
+}
+
+// GetActivePromotions mocks base method.
+func (m *Mockpromotion) GetActivePromotions(ctx context.Context, tx pgx.Tx, itemID, category string, at time.Time) ([]models.Promotion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetActivePromotions", ctx, tx, itemID, category, at)
+	ret0, _ := ret[0].([]models.Promotion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetActivePromotions indicates an expected call of GetActivePromotions.
+func (mr *MockpromotionMockRecorder) GetActivePromotions(ctx, tx, itemID, category, at interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetActivePromotions", reflect.TypeOf((*Mockpromotion)(nil).GetActivePromotions), ctx, tx, itemID, category, at)
+}
+
+// Mockbundle is a mock of bundle interface.
+type Mockbundle struct {
+	ctrl     *gomock.Controller
+	recorder *MockbundleMockRecorder
+}
+
+// MockbundleMockRecorder is the mock recorder for Mockbundle.
+type MockbundleMockRecorder struct {
+	mock *Mockbundle
+}
+
+// NewMockbundle creates a new mock instance.
+func NewMockbundle(ctrl *gomock.Controller) *Mockbundle {
+	mock := &Mockbundle{ctrl: ctrl}
+	mock.recorder = &MockbundleMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mockbundle) EXPECT() *MockbundleMockRecorder {
+	return m.recorder
+}
+
+// GetBundleByName mocks base method.
+func (m *Mockbundle) GetBundleByName(ctx context.Context, tx pgx.Tx, name string) (models.Bundle, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBundleByName", ctx, tx, name)
+	ret0, _ := ret[0].(models.Bundle)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBundleByName indicates an expected call of GetBundleByName.
+func (mr *MockbundleMockRecorder) GetBundleByName(ctx, tx, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBundleByName", reflect.TypeOf((*Mockbundle)(nil).GetBundleByName), ctx, tx, name)
+}
+
+// Mockledger is a mock of ledger interface.
+type Mockledger struct {
+	ctrl     *gomock.Controller
+	recorder *MockledgerMockRecorder
+}
+
+// MockledgerMockRecorder is the mock recorder for Mockledger.
+type MockledgerMockRecorder struct {
+	mock *Mockledger
+}
+
+// NewMockledger creates a new mock instance.
+func NewMockledger(ctrl *gomock.Controller) *Mockledger {
+	mock := &Mockledger{ctrl: ctrl}
+	mock.recorder = &MockledgerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mockledger) EXPECT() *MockledgerMockRecorder {
+	return m.recorder
+}
+
+// PostEntry mocks base method.
+func (m *Mockledger) PostEntry(ctx context.Context, tx pgx.Tx, e models.LedgerEntry) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PostEntry", ctx, tx, e)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PostEntry indicates an expected call of PostEntry.
+func (mr *MockledgerMockRecorder) PostEntry(ctx, tx, e interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PostEntry", reflect.TypeOf((*Mockledger)(nil).PostEntry), ctx, tx, e)
+}
+
+// Mockcoupon is a mock of coupon interface.
+type Mockcoupon struct {
+	ctrl     *gomock.Controller
+	recorder *MockcouponMockRecorder
+}
+
+// MockcouponMockRecorder is the mock recorder for Mockcoupon.
+type MockcouponMockRecorder struct {
+	mock *Mockcoupon
+}
+
+// NewMockcoupon creates a new mock instance.
+func NewMockcoupon(ctrl *gomock.Controller) *Mockcoupon {
+	mock := &Mockcoupon{ctrl: ctrl}
+	mock.recorder = &MockcouponMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mockcoupon) EXPECT() *MockcouponMockRecorder {
+	return m.recorder
+}
+
+// HasRedeemed mocks base method.
+func (m *Mockcoupon) HasRedeemed(ctx context.Context, tx pgx.Tx, code, userID string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HasRedeemed", ctx, tx, code, userID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HasRedeemed indicates an expected call of HasRedeemed.
+func (mr *MockcouponMockRecorder) HasRedeemed(ctx, tx, code, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HasRedeemed", reflect.TypeOf((*Mockcoupon)(nil).HasRedeemed), ctx, tx, code, userID)
+}
+
+// IncrementRedemptions mocks base method.
+func (m *Mockcoupon) IncrementRedemptions(ctx context.Context, tx pgx.Tx, code string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IncrementRedemptions", ctx, tx, code)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// IncrementRedemptions indicates an expected call of IncrementRedemptions.
+func (mr *MockcouponMockRecorder) IncrementRedemptions(ctx, tx, code interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IncrementRedemptions", reflect.TypeOf((*Mockcoupon)(nil).IncrementRedemptions), ctx, tx, code)
+}
+
+// InsertRedemption mocks base method.
+func (m *Mockcoupon) InsertRedemption(ctx context.Context, tx pgx.Tx, r models.CouponRedemption) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertRedemption", ctx, tx, r)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InsertRedemption indicates an expected call of InsertRedemption.
+func (mr *MockcouponMockRecorder) InsertRedemption(ctx, tx, r interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertRedemption", reflect.TypeOf((*Mockcoupon)(nil).InsertRedemption), ctx, tx, r)
+}
+
+// LockCoupon mocks base method.
+func (m *Mockcoupon) LockCoupon(ctx context.Context, tx pgx.Tx, code string) (models.Coupon, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LockCoupon", ctx, tx, code)
+	ret0, _ := ret[0].(models.Coupon)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LockCoupon indicates an expected call of LockCoupon.
+func (mr *MockcouponMockRecorder) LockCoupon(ctx, tx, code interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LockCoupon", reflect.TypeOf((*Mockcoupon)(nil).LockCoupon), ctx, tx, code)
+}
+
+// Mockidempotency is a mock of idempotency interface.
+type Mockidempotency struct {
+	ctrl     *gomock.Controller
+	recorder *MockidempotencyMockRecorder
+}
+
+// MockidempotencyMockRecorder is the mock recorder for Mockidempotency.
+type MockidempotencyMockRecorder struct {
+	mock *Mockidempotency
+}
+
+// NewMockidempotency creates a new mock instance.
+func NewMockidempotency(ctrl *gomock.Controller) *Mockidempotency {
+	mock := &Mockidempotency{ctrl: ctrl}
+	mock.recorder = &MockidempotencyMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mockidempotency) EXPECT() *MockidempotencyMockRecorder {
+	return m.recorder
+}
+
+// ClaimKey mocks base method.
+func (m *Mockidempotency) ClaimKey(ctx context.Context, tx pgx.Tx, k models.IdempotencyKey) (models.IdempotencyKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimKey", ctx, tx, k)
+	ret0, _ := ret[0].(models.IdempotencyKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimKey indicates an expected call of ClaimKey.
+func (mr *MockidempotencyMockRecorder) ClaimKey(ctx, tx, k interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertInventoryItem", reflect.TypeOf((*Mockinventory)(nil).InsertInventoryItem), ctx, tx, id, userID, itemType)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimKey", reflect.TypeOf((*Mockidempotency)(nil).ClaimKey), ctx, tx, k)
 }
 
-// UpdateInventoryItem mocks base method.
-func (m *Mockinventory) UpdateInventoryItem(ctx context.Context, tx pgx.Tx, userID, itemType string, newQuantity int64) error {
+// SaveResponse mocks base method.
+func (m *Mockidempotency) SaveResponse(ctx context.Context, tx pgx.Tx, k models.IdempotencyKey) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateInventoryItem", ctx, tx, userID, itemType, newQuantity)
+	ret := m.ctrl.Call(m, "SaveResponse", ctx, tx, k)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// UpdateInventoryItem indicates an expected call of UpdateInventoryItem.
-func (mr *MockinventoryMockRecorder) UpdateInventoryItem(ctx, tx, userID, itemType, newQuantity interface{}) *gomock.Call {
+// SaveResponse indicates an expected call of SaveResponse.
+func (mr *MockidempotencyMockRecorder) SaveResponse(ctx, tx, k interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateInventoryItem", reflect.TypeOf((*Mockinventory)(nil).UpdateInventoryItem), ctx, tx, userID, itemType, newQuantity)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveResponse", reflect.TypeOf((*Mockidempotency)(nil).SaveResponse), ctx, tx, k)
 }
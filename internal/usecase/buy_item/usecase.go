@@ -4,26 +4,64 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
+
+	"AvitoTask/internal/models"
+	"AvitoTask/internal/utils"
 )
 
-var ErrNotEnoughCoins = errors.New("not enough coins to buy this item")
+// conflictAttempts - сколько раз покупка повторяется при конфликте транзакций в базе
+const conflictAttempts = 3
+
+var (
+	ErrNotEnoughCoins = errors.New("not enough coins to buy this item")
+	ErrEmptyCart      = errors.New("cart is empty")
+)
 
 type Usecase struct {
-	repoUser      user
-	repoInventory inventory
+	repoUser        user
+	repoInventory   inventory
+	repoCatalog     catalog
+	repoCart        cart
+	repoOrder       order
+	repoPromotion   promotion
+	repoCoupon      coupon
+	repoBundle      bundle
+	repoLedger      ledger
+	repoIdempotency idempotency
+	Now             func() time.Time
 }
 
-func NewUsecase(u user, i inventory) *Usecase {
+func NewUsecase(u user, i inventory, c catalog, ct cart, o order, p promotion, cp coupon, b bundle, l ledger, id idempotency) *Usecase {
 	return &Usecase{
-		repoUser:      u,
-		repoInventory: i,
+		repoUser:        u,
+		repoInventory:   i,
+		repoCatalog:     c,
+		repoCart:        ct,
+		repoOrder:       o,
+		repoPromotion:   p,
+		repoCoupon:      cp,
+		repoBundle:      b,
+		repoLedger:      l,
+		repoIdempotency: id,
+		Now: func() time.Time {
+			return time.Now().UTC()
+		},
 	}
 }
 
-func (u *Usecase) BuyItem(ctx context.Context, userID string, item string, cost int64) (err error) {
+// BuyItem - покупает одну единицу позиции; variant - SKU варианта, coupon - промокод,
+// пустые строки означают базовую позицию и покупку без промокода
+func (u *Usecase) BuyItem(ctx context.Context, userID, item, variant, coupon string) error {
+	return utils.RetryOnConflict(ctx, conflictAttempts, func() error {
+		return u.buyItem(ctx, userID, item, variant, coupon)
+	})
+}
+
+func (u *Usecase) buyItem(ctx context.Context, userID, item, variant, coupon string) (err error) {
 	tx, err := u.repoUser.BeginTx(ctx)
 	if err != nil {
 		return fmt.Errorf("failed to begin tx: %w", err)
@@ -37,37 +75,483 @@ func (u *Usecase) BuyItem(ctx context.Context, userID string, item string, cost
 		}
 	}()
 
-	currentCoins, err := u.repoUser.GetUserCoins(ctx, tx, userID)
+	_, err = utils.Idempotent(ctx, tx, u.repoIdempotency, func() (models.Cart, error) {
+		return u.purchase(ctx, tx, userID, []models.PurchaseLine{{Item: item, Variant: variant, Quantity: 1}}, coupon)
+	})
+
+	return err
+}
+
+// BuyBundle - покупает набор по его цене: монеты списываются один раз, а все позиции набора попадают
+// в инвентарь в той же транзакции. Акции и промокоды на наборы не действуют
+func (u *Usecase) BuyBundle(ctx context.Context, userID, name string) error {
+	return utils.RetryOnConflict(ctx, conflictAttempts, func() error {
+		return u.buyBundle(ctx, userID, name)
+	})
+}
+
+func (u *Usecase) buyBundle(ctx context.Context, userID, name string) (err error) {
+	tx, err := u.repoUser.BeginTx(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin tx: %w", err)
+	}
+
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback(ctx)
+		} else {
+			err = tx.Commit(ctx)
+		}
+	}()
+
+	_, err = utils.Idempotent(ctx, tx, u.repoIdempotency, func() (struct{}, error) {
+		return struct{}{}, u.purchaseBundle(ctx, tx, userID, name)
+	})
+
+	return err
+}
+
+func (u *Usecase) purchaseBundle(ctx context.Context, tx pgx.Tx, userID, name string) error {
+	b, err := u.repoBundle.GetBundleByName(ctx, tx, name)
 	if err != nil {
 		return err
 	}
 
-	if currentCoins < cost {
-		err = ErrNotEnoughCoins
+	if b.Retired {
+		return models.ErrItemNotAvailable
+	}
+
+	items := make([]purchaseItem, 0, len(b.Items))
+	lines := make([]models.PurchaseLine, 0, len(b.Items))
+	for _, it := range b.Items {
+		line := models.PurchaseLine{Item: it.Item, Variant: it.Variant, Quantity: it.Quantity}
+		p, err := u.lookup(ctx, tx, line)
+		if err != nil {
+			return err
+		}
+		items = append(items, p)
+		lines = append(lines, line)
+	}
+
+	if err = u.checkQuota(ctx, tx, userID, items, lines, u.Now()); err != nil {
 		return err
 	}
 
-	newCoins := currentCoins - cost
-	if err = u.repoUser.UpdateUserCoins(ctx, tx, userID, newCoins); err != nil {
+	if err = u.debit(ctx, tx, userID, b.Price); err != nil {
 		return err
 	}
 
-	quantity, err := u.repoInventory.GetInventoryItem(ctx, tx, userID, item)
+	for i, p := range items {
+		if err = u.takeStock(ctx, tx, userID, p, lines[i].Quantity); err != nil {
+			return err
+		}
+
+		if err = u.addToInventory(ctx, tx, userID, p.item.Name, lines[i].Variant, lines[i].Quantity); err != nil {
+			return err
+		}
+	}
+
+	o := models.Order{
+		ID:        uuid.New().String(),
+		Kind:      models.OrderKindPurchase,
+		UserID:    userID,
+		BundleID:  b.ID,
+		Item:      b.Name,
+		Quantity:  1,
+		UnitPrice: b.Price,
+		Total:     b.Price,
+		Status:    models.OrderStatusPlaced,
+	}
+	if err = u.repoOrder.InsertOrder(ctx, tx, o); err != nil {
+		return err
+	}
+
+	return u.charge(ctx, tx, userID, o.ID, o.Total)
+}
+
+// Checkout - покупает все позиции корзины пользователя в одной транзакции и очищает корзину
+func (u *Usecase) Checkout(ctx context.Context, userID string) (res models.Cart, err error) {
+	err = utils.RetryOnConflict(ctx, conflictAttempts, func() error {
+		res, err = u.checkout(ctx, userID)
+		return err
+	})
+
+	return res, err
+}
+
+func (u *Usecase) checkout(ctx context.Context, userID string) (res models.Cart, err error) {
+	tx, err := u.repoUser.BeginTx(ctx)
 	if err != nil {
-		if errors.Is(err, pgx.ErrNoRows) {
-			if insertErr := u.repoInventory.InsertInventoryItem(ctx, tx, uuid.New().String(), userID, item); insertErr != nil {
-				err = insertErr
-				return err
-			}
+		return res, fmt.Errorf("failed to begin tx: %w", err)
+	}
+
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback(ctx)
 		} else {
+			err = tx.Commit(ctx)
+		}
+	}()
+
+	return utils.Idempotent(ctx, tx, u.repoIdempotency, func() (models.Cart, error) {
+		return u.purchaseCart(ctx, tx, userID)
+	})
+}
+
+// purchaseCart - покупает содержимое корзины внутри уже открытой транзакции
+func (u *Usecase) purchaseCart(ctx context.Context, tx pgx.Tx, userID string) (res models.Cart, err error) {
+	cartLines, err := u.repoCart.LockCart(ctx, tx, userID)
+	if err != nil {
+		return res, err
+	}
+
+	if len(cartLines) == 0 {
+		return res, ErrEmptyCart
+	}
+
+	lines := make([]models.PurchaseLine, 0, len(cartLines))
+	for _, line := range cartLines {
+		lines = append(lines, models.PurchaseLine{Item: line.Item, Variant: line.Variant, Quantity: line.Quantity})
+	}
+
+	res, err = u.purchase(ctx, tx, userID, lines, "")
+	if err != nil {
+		return res, err
+	}
+
+	if err = u.repoCart.ClearCart(ctx, tx, userID); err != nil {
+		return res, err
+	}
+
+	return res, nil
+}
+
+// purchaseItem - позиция каталога, выбранный вариант (если есть), применённая акция и итоговая цена за единицу
+type purchaseItem struct {
+	item        models.CatalogItem
+	variant     *models.ItemVariant
+	price       int64
+	discount    int64
+	promotionID string
+	// couponDiscount - скидка по промокоду на всю строку, а не на единицу
+	couponDiscount int64
+}
+
+// purchase - списывает монеты за все строки разом и выдаёт товары; вызывается внутри уже открытой транзакции
+func (u *Usecase) purchase(ctx context.Context, tx pgx.Tx, userID string, lines []models.PurchaseLine, coupon string) (res models.Cart, err error) {
+	now := u.Now()
+	items := make([]purchaseItem, 0, len(lines))
+	for _, line := range lines {
+		p, err := u.resolve(ctx, tx, line, now)
+		if err != nil {
+			return res, err
+		}
+
+		items = append(items, p)
+		res.Lines = append(res.Lines, models.CartLine{
+			Item:     p.item.Name,
+			Variant:  line.Variant,
+			Price:    p.price,
+			Quantity: line.Quantity,
+		})
+		res.Total += p.price * line.Quantity
+	}
+
+	if err = u.checkQuota(ctx, tx, userID, items, lines, now); err != nil {
+		return res, err
+	}
+
+	if coupon != "" {
+		res.Discount, err = u.redeemCoupon(ctx, tx, userID, coupon, items, lines, res.Total, now)
+		if err != nil {
+			return res, err
+		}
+		res.Total -= res.Discount
+	}
+
+	if err = u.debit(ctx, tx, userID, res.Total); err != nil {
+		return res, err
+	}
+
+	for i, p := range items {
+		quantity := lines[i].Quantity
+		variant := lines[i].Variant
+
+		if err = u.takeStock(ctx, tx, userID, p, quantity); err != nil {
+			return res, err
+		}
+
+		if err = u.addToInventory(ctx, tx, userID, p.item.Name, variant, quantity); err != nil {
+			return res, err
+		}
+
+		o := models.Order{
+			ID:             uuid.New().String(),
+			Kind:           models.OrderKindPurchase,
+			UserID:         userID,
+			ItemID:         p.item.ID,
+			Item:           p.item.Name,
+			Variant:        variant,
+			Quantity:       quantity,
+			UnitPrice:      p.price,
+			Discount:       p.discount,
+			PromotionID:    p.promotionID,
+			CouponCode:     p.couponCode(coupon),
+			CouponDiscount: p.couponDiscount,
+			Total:          p.price*quantity - p.couponDiscount,
+			Status:         models.OrderStatusPlaced,
+		}
+		if err = u.repoOrder.InsertOrder(ctx, tx, o); err != nil {
+			return res, err
+		}
+
+		if err = u.charge(ctx, tx, userID, o.ID, o.Total); err != nil {
+			return res, err
+		}
+	}
+
+	return res, nil
+}
+
+// debit - атомарно списывает монеты за покупку; нехватка монет проверяется тем же UPDATE
+func (u *Usecase) debit(ctx context.Context, tx pgx.Tx, userID string, amount int64) error {
+	err := u.repoUser.DebitUserCoins(ctx, tx, userID, amount)
+	if errors.Is(err, models.ErrNotEnoughCoins) {
+		return ErrNotEnoughCoins
+	}
+
+	return err
+}
+
+// charge - проводит оплату заказа по журналу: монеты уходят со счёта пользователя магазину
+func (u *Usecase) charge(ctx context.Context, tx pgx.Tx, userID, orderID string, amount int64) error {
+	if amount == 0 {
+		return nil
+	}
+
+	entry := models.NewLedgerEntry(uuid.New().String(), models.EntryPurchase, orderID,
+		models.UserAccount(userID), models.AccountShop, amount)
+
+	return u.repoLedger.PostEntry(ctx, tx, entry)
+}
+
+// resolve - находит позицию и вариант строки покупки и считает цену с учётом акций
+func (u *Usecase) resolve(ctx context.Context, tx pgx.Tx, line models.PurchaseLine, now time.Time) (purchaseItem, error) {
+	p, err := u.lookup(ctx, tx, line)
+	if err != nil {
+		return purchaseItem{}, err
+	}
+
+	if err = u.applyPromotion(ctx, tx, &p, now); err != nil {
+		return purchaseItem{}, err
+	}
+
+	return p, nil
+}
+
+// lookup - находит позицию и вариант строки покупки и проверяет доступность и запас
+func (u *Usecase) lookup(ctx context.Context, tx pgx.Tx, line models.PurchaseLine) (purchaseItem, error) {
+	catalogItem, err := u.repoCatalog.GetItemByName(ctx, tx, line.Item)
+	if err != nil {
+		return purchaseItem{}, err
+	}
+
+	if catalogItem.Hidden || catalogItem.Retired {
+		return purchaseItem{}, models.ErrItemNotAvailable
+	}
+
+	p := purchaseItem{item: catalogItem, price: catalogItem.Price}
+	if line.Variant == "" {
+		if catalogItem.Stock != nil && *catalogItem.Stock < line.Quantity {
+			return purchaseItem{}, models.ErrSoldOut
+		}
+	} else {
+		variant, err := u.repoCatalog.GetVariantBySKU(ctx, tx, line.Variant)
+		if err != nil {
+			return purchaseItem{}, err
+		}
+		if variant.ItemID != catalogItem.ID {
+			return purchaseItem{}, models.ErrVariantNotFound
+		}
+		if variant.Stock != nil && *variant.Stock < line.Quantity {
+			return purchaseItem{}, models.ErrSoldOut
+		}
+		p.variant = &variant
+		p.price = variant.PriceFor(catalogItem)
+	}
+
+	return p, nil
+}
+
+// checkQuota - проверяет квоты лимитированных позиций; строки разных вариантов одной позиции
+// считаются вместе. Пользователь блокируется, чтобы параллельные покупки не обошли квоту
+func (u *Usecase) checkQuota(ctx context.Context, tx pgx.Tx, userID string, items []purchaseItem,
+	lines []models.PurchaseLine, now time.Time) error {
+	var limited []models.CatalogItem
+	wanted := make(map[string]int64)
+	for i, p := range items {
+		if p.item.PurchaseLimit == nil {
+			continue
+		}
+		if _, ok := wanted[p.item.ID]; !ok {
+			limited = append(limited, p.item)
+		}
+		wanted[p.item.ID] += lines[i].Quantity
+	}
+
+	if len(limited) == 0 {
+		return nil
+	}
+
+	if err := u.repoUser.LockUser(ctx, tx, userID); err != nil {
+		return err
+	}
+
+	for _, item := range limited {
+		purchased, err := u.repoOrder.CountUserPurchases(ctx, tx, userID, item.ID, item.QuotaPeriodStart(now))
+		if err != nil {
 			return err
 		}
+
+		owned, err := u.repoInventory.CountUserItems(ctx, tx, userID, item.Name)
+		if err != nil {
+			return err
+		}
+
+		if left := *item.QuotaLeft(purchased, owned); wanted[item.ID] > left {
+			return fmt.Errorf("%w: %d of %d left for '%s'", models.ErrQuotaExceeded, left, *item.PurchaseLimit, item.Name)
+		}
 	}
 
-	newQuantity := quantity + 1
-	if err = u.repoInventory.UpdateInventoryItem(ctx, tx, userID, item, newQuantity); err != nil {
+	return nil
+}
+
+// redeemCoupon - проверяет промокод под блокировкой, раскладывает скидку по подходящим строкам и гасит код.
+// Процентная скидка считается от каждой строки, фиксированная расходуется по строкам по порядку
+func (u *Usecase) redeemCoupon(ctx context.Context, tx pgx.Tx, userID, code string, items []purchaseItem,
+	lines []models.PurchaseLine, total int64, now time.Time) (int64, error) {
+	c, err := u.repoCoupon.LockCoupon(ctx, tx, code)
+	if err != nil {
+		return 0, err
+	}
+
+	switch {
+	case c.Expired(now):
+		return 0, models.ErrCouponExpired
+	case c.Redeemed >= c.MaxRedemptions:
+		return 0, models.ErrCouponExhausted
+	case total < c.MinSpend:
+		return 0, models.ErrCouponMinSpend
+	}
+
+	used, err := u.repoCoupon.HasRedeemed(ctx, tx, code, userID)
+	if err != nil {
+		return 0, err
+	}
+	if used {
+		return 0, models.ErrCouponAlreadyUsed
+	}
+
+	var applied bool
+	var discount int64
+	remaining := c.Amount
+	for i := range items {
+		if !c.Eligible(items[i].item.Name) {
+			continue
+		}
+		applied = true
+
+		lineTotal := items[i].price * lines[i].Quantity
+		if c.Percent > 0 {
+			items[i].couponDiscount = lineTotal * c.Percent / 100
+		} else {
+			items[i].couponDiscount = min(remaining, lineTotal)
+			remaining -= items[i].couponDiscount
+		}
+		discount += items[i].couponDiscount
+	}
+
+	if !applied {
+		return 0, models.ErrCouponNotApplicable
+	}
+
+	if err = u.repoCoupon.IncrementRedemptions(ctx, tx, code); err != nil {
+		return 0, err
+	}
+
+	err = u.repoCoupon.InsertRedemption(ctx, tx, models.CouponRedemption{
+		ID:       uuid.New().String(),
+		Code:     code,
+		UserID:   userID,
+		Discount: discount,
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return discount, nil
+}
+
+// couponCode - промокод записывается только в строки, на которые он подействовал
+func (p purchaseItem) couponCode(code string) string {
+	if p.couponDiscount == 0 {
+		return ""
+	}
+	return code
+}
+
+// applyPromotion - выбирает из действующих акций самую выгодную для покупателя; акции не суммируются
+func (u *Usecase) applyPromotion(ctx context.Context, tx pgx.Tx, p *purchaseItem, now time.Time) error {
+	promotions, err := u.repoPromotion.GetActivePromotions(ctx, tx, p.item.ID, p.item.Category, now)
+	if err != nil {
 		return err
 	}
 
+	for _, promo := range promotions {
+		if discount := promo.Discount(p.price); discount > p.discount {
+			p.discount = discount
+			p.promotionID = promo.ID
+		}
+	}
+	p.price -= p.discount
+
 	return nil
 }
+
+// takeStock - списывает запас варианта или базовой позиции; у варианта запас ведётся отдельно от позиции
+func (u *Usecase) takeStock(ctx context.Context, tx pgx.Tx, userID string, p purchaseItem, quantity int64) error {
+	movement := models.StockMovement{
+		ID:     uuid.New().String(),
+		ItemID: p.item.ID,
+		Delta:  -quantity,
+		Reason: models.StockReasonPurchase,
+		UserID: userID,
+	}
+
+	switch {
+	case p.variant != nil:
+		if p.variant.Stock == nil {
+			return nil
+		}
+		if err := u.repoCatalog.DecrementVariantStock(ctx, tx, p.variant.SKU, quantity); err != nil {
+			return err
+		}
+		movement.Variant = p.variant.SKU
+
+	case p.item.Stock != nil:
+		if err := u.repoCatalog.DecrementStock(ctx, tx, p.item.ID, quantity); err != nil {
+			return err
+		}
+
+	default:
+		return nil
+	}
+
+	return u.repoCatalog.InsertStockMovement(ctx, tx, movement)
+}
+
+// addToInventory - прибавляет купленное к инвентарю относительным upsert'ом, поэтому покупка не затирает
+// подарок той же позиции, пришедший пользователю параллельно
+func (u *Usecase) addToInventory(ctx context.Context, tx pgx.Tx, userID, item, variant string, count int64) error {
+	return u.repoInventory.AddInventoryItem(ctx, tx, uuid.New().String(), userID, item, variant, count)
+}
@@ -0,0 +1,152 @@
+package cart_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+
+	"AvitoTask/internal/models"
+	"AvitoTask/internal/usecase/cart"
+	"AvitoTask/internal/usecase/cart/mocks"
+)
+
+func TestAddItem_NotAvailable(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockCart := mocks.NewMockcart(ctrl)
+	mockCatalog := mocks.NewMockcatalog(ctrl)
+	mockTx := mocks.NewMockTx(ctrl)
+
+	mockCart.EXPECT().BeginTx(ctx).Return(mockTx, nil)
+	mockCatalog.EXPECT().GetItemByName(ctx, mockTx, "cup").Return(models.CatalogItem{ID: "item-1", Name: "cup", Retired: true}, nil)
+	mockTx.EXPECT().Rollback(ctx).Return(nil)
+
+	uc := cart.NewUsecase(mockCart, mockCatalog)
+	_, err := uc.AddItem(ctx, "user123", "cup", "", 1)
+	if !errors.Is(err, models.ErrItemNotAvailable) {
+		t.Errorf("expected error %v, got %v", models.ErrItemNotAvailable, err)
+	}
+}
+
+func TestAddItem_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockCart := mocks.NewMockcart(ctrl)
+	mockCatalog := mocks.NewMockcatalog(ctrl)
+	mockTx := mocks.NewMockTx(ctrl)
+
+	mockCart.EXPECT().BeginTx(ctx).Return(mockTx, nil)
+	mockCatalog.EXPECT().GetItemByName(ctx, mockTx, "pen").Return(models.CatalogItem{ID: "item-1", Name: "pen", Price: 10}, nil)
+	mockCart.EXPECT().AddItem(ctx, mockTx, "user123", "item-1", "", int64(5)).Return(nil)
+	mockCart.EXPECT().GetCart(ctx, mockTx, "user123").Return([]models.CartLine{
+		{Item: "pen", Price: 10, Quantity: 5},
+		{Item: "cup", Price: 20, Quantity: 1},
+	}, nil)
+	mockTx.EXPECT().Commit(ctx).Return(nil)
+
+	uc := cart.NewUsecase(mockCart, mockCatalog)
+	res, err := uc.AddItem(ctx, "user123", "pen", "", 5)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if res.Total != 70 {
+		t.Errorf("expected total 70, got %d", res.Total)
+	}
+}
+
+func TestAddItem_Variant(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockCart := mocks.NewMockcart(ctrl)
+	mockCatalog := mocks.NewMockcatalog(ctrl)
+	mockTx := mocks.NewMockTx(ctrl)
+
+	mockCart.EXPECT().BeginTx(ctx).Return(mockTx, nil)
+	mockCatalog.EXPECT().GetItemByName(ctx, mockTx, "hoody").Return(models.CatalogItem{ID: "item-1", Name: "hoody", Price: 300}, nil)
+	mockCatalog.EXPECT().GetVariantBySKU(ctx, mockTx, "hoody-m-grey").Return(models.ItemVariant{ItemID: "item-1", SKU: "hoody-m-grey"}, nil)
+	mockCart.EXPECT().AddItem(ctx, mockTx, "user123", "item-1", "hoody-m-grey", int64(1)).Return(nil)
+	mockCart.EXPECT().GetCart(ctx, mockTx, "user123").Return([]models.CartLine{
+		{Item: "hoody", Variant: "hoody-m-grey", Price: 350, Quantity: 1},
+	}, nil)
+	mockTx.EXPECT().Commit(ctx).Return(nil)
+
+	uc := cart.NewUsecase(mockCart, mockCatalog)
+	res, err := uc.AddItem(ctx, "user123", "hoody", "hoody-m-grey", 1)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(res.Lines) != 1 || res.Lines[0].Variant != "hoody-m-grey" || res.Total != 350 {
+		t.Errorf("unexpected cart %+v", res)
+	}
+}
+
+func TestAddItem_VariantOfAnotherItem(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockCart := mocks.NewMockcart(ctrl)
+	mockCatalog := mocks.NewMockcatalog(ctrl)
+	mockTx := mocks.NewMockTx(ctrl)
+
+	mockCart.EXPECT().BeginTx(ctx).Return(mockTx, nil)
+	mockCatalog.EXPECT().GetItemByName(ctx, mockTx, "hoody").Return(models.CatalogItem{ID: "item-1", Name: "hoody", Price: 300}, nil)
+	mockCatalog.EXPECT().GetVariantBySKU(ctx, mockTx, "pen-blue").Return(models.ItemVariant{ItemID: "item-2", SKU: "pen-blue"}, nil)
+	mockTx.EXPECT().Rollback(ctx).Return(nil)
+
+	uc := cart.NewUsecase(mockCart, mockCatalog)
+	_, err := uc.AddItem(ctx, "user123", "hoody", "pen-blue", 1)
+	if !errors.Is(err, models.ErrVariantNotFound) {
+		t.Errorf("expected error %v, got %v", models.ErrVariantNotFound, err)
+	}
+}
+
+func TestRemoveItem_NotInCart(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockCart := mocks.NewMockcart(ctrl)
+	mockCatalog := mocks.NewMockcatalog(ctrl)
+	mockTx := mocks.NewMockTx(ctrl)
+
+	mockCart.EXPECT().BeginTx(ctx).Return(mockTx, nil)
+	mockCatalog.EXPECT().GetItemByName(ctx, mockTx, "pen").Return(models.CatalogItem{ID: "item-1", Name: "pen"}, nil)
+	mockCart.EXPECT().RemoveItem(ctx, mockTx, "user123", "item-1", "").Return(models.ErrNotInCart)
+	mockTx.EXPECT().Rollback(ctx).Return(nil)
+
+	uc := cart.NewUsecase(mockCart, mockCatalog)
+	_, err := uc.RemoveItem(ctx, "user123", "pen", "")
+	if !errors.Is(err, models.ErrNotInCart) {
+		t.Errorf("expected error %v, got %v", models.ErrNotInCart, err)
+	}
+}
+
+func TestGetCart_Error(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockCart := mocks.NewMockcart(ctrl)
+	mockCatalog := mocks.NewMockcatalog(ctrl)
+	mockTx := mocks.NewMockTx(ctrl)
+
+	cartErr := errors.New("query failed")
+	mockCart.EXPECT().BeginTx(ctx).Return(mockTx, nil)
+	mockCart.EXPECT().GetCart(ctx, mockTx, "user123").Return(nil, cartErr)
+	mockTx.EXPECT().Rollback(ctx).Return(nil)
+
+	uc := cart.NewUsecase(mockCart, mockCatalog)
+	_, err := uc.GetCart(ctx, "user123")
+	if !errors.Is(err, cartErr) {
+		t.Errorf("expected error %v, got %v", cartErr, err)
+	}
+}
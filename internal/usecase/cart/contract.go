@@ -0,0 +1,23 @@
+//go:generate mockgen -source=contract.go -destination=mocks/mock.go -package=mocks $GOPACKAGE
+//go:generate mockgen -destination=mocks/mock_tx.go -package=mocks github.com/jackc/pgx/v5 Tx
+package cart
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+
+	"AvitoTask/internal/models"
+)
+
+type cart interface {
+	BeginTx(ctx context.Context) (pgx.Tx, error)
+	AddItem(ctx context.Context, tx pgx.Tx, userID, itemID, variant string, quantity int64) error
+	RemoveItem(ctx context.Context, tx pgx.Tx, userID, itemID, variant string) error
+	GetCart(ctx context.Context, tx pgx.Tx, userID string) ([]models.CartLine, error)
+}
+
+type catalog interface {
+	GetItemByName(ctx context.Context, tx pgx.Tx, name string) (models.CatalogItem, error)
+	GetVariantBySKU(ctx context.Context, tx pgx.Tx, sku string) (models.ItemVariant, error)
+}
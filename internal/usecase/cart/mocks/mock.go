@@ -0,0 +1,148 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: contract.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	models "AvitoTask/internal/models"
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	pgx "github.com/jackc/pgx/v5"
+)
+
+// Mockcart is a mock of cart interface.
+type Mockcart struct {
+	ctrl     *gomock.Controller
+	recorder *MockcartMockRecorder
+}
+
+// MockcartMockRecorder is the mock recorder for Mockcart.
+type MockcartMockRecorder struct {
+	mock *Mockcart
+}
+
+// NewMockcart creates a new mock instance.
+func NewMockcart(ctrl *gomock.Controller) *Mockcart {
+	mock := &Mockcart{ctrl: ctrl}
+	mock.recorder = &MockcartMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mockcart) EXPECT() *MockcartMockRecorder {
+	return m.recorder
+}
+
+// AddItem mocks base method.
+func (m *Mockcart) AddItem(ctx context.Context, tx pgx.Tx, userID, itemID, variant string, quantity int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddItem", ctx, tx, userID, itemID, variant, quantity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddItem indicates an expected call of AddItem.
+func (mr *MockcartMockRecorder) AddItem(ctx, tx, userID, itemID, variant, quantity interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddItem", reflect.TypeOf((*Mockcart)(nil).AddItem), ctx, tx, userID, itemID, variant, quantity)
+}
+
+// BeginTx mocks base method.
+func (m *Mockcart) BeginTx(ctx context.Context) (pgx.Tx, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BeginTx", ctx)
+	ret0, _ := ret[0].(pgx.Tx)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BeginTx indicates an expected call of BeginTx.
+func (mr *MockcartMockRecorder) BeginTx(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BeginTx", reflect.TypeOf((*Mockcart)(nil).BeginTx), ctx)
+}
+
+// GetCart mocks base method.
+func (m *Mockcart) GetCart(ctx context.Context, tx pgx.Tx, userID string) ([]models.CartLine, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCart", ctx, tx, userID)
+	ret0, _ := ret[0].([]models.CartLine)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCart indicates an expected call of GetCart.
+func (mr *MockcartMockRecorder) GetCart(ctx, tx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCart", reflect.TypeOf((*Mockcart)(nil).GetCart), ctx, tx, userID)
+}
+
+// RemoveItem mocks base method.
+func (m *Mockcart) RemoveItem(ctx context.Context, tx pgx.Tx, userID, itemID, variant string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveItem", ctx, tx, userID, itemID, variant)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveItem indicates an expected call of RemoveItem.
+func (mr *MockcartMockRecorder) RemoveItem(ctx, tx, userID, itemID, variant interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveItem", reflect.TypeOf((*Mockcart)(nil).RemoveItem), ctx, tx, userID, itemID, variant)
+}
+
+// Mockcatalog is a mock of catalog interface.
+type Mockcatalog struct {
+	ctrl     *gomock.Controller
+	recorder *MockcatalogMockRecorder
+}
+
+// MockcatalogMockRecorder is the mock recorder for Mockcatalog.
+type MockcatalogMockRecorder struct {
+	mock *Mockcatalog
+}
+
+// NewMockcatalog creates a new mock instance.
+func NewMockcatalog(ctrl *gomock.Controller) *Mockcatalog {
+	mock := &Mockcatalog{ctrl: ctrl}
+	mock.recorder = &MockcatalogMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mockcatalog) EXPECT() *MockcatalogMockRecorder {
+	return m.recorder
+}
+
+// GetItemByName mocks base method.
+func (m *Mockcatalog) GetItemByName(ctx context.Context, tx pgx.Tx, name string) (models.CatalogItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetItemByName", ctx, tx, name)
+	ret0, _ := ret[0].(models.CatalogItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetItemByName indicates an expected call of GetItemByName.
+func (mr *MockcatalogMockRecorder) GetItemByName(ctx, tx, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetItemByName", reflect.TypeOf((*Mockcatalog)(nil).GetItemByName), ctx, tx, name)
+}
+
+// GetVariantBySKU mocks base method.
+func (m *Mockcatalog) GetVariantBySKU(ctx context.Context, tx pgx.Tx, sku string) (models.ItemVariant, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetVariantBySKU", ctx, tx, sku)
+	ret0, _ := ret[0].(models.ItemVariant)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetVariantBySKU indicates an expected call of GetVariantBySKU.
+func (mr *MockcatalogMockRecorder) GetVariantBySKU(ctx, tx, sku interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVariantBySKU", reflect.TypeOf((*Mockcatalog)(nil).GetVariantBySKU), ctx, tx, sku)
+}
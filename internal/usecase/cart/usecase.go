@@ -0,0 +1,121 @@
+package cart
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+
+	"AvitoTask/internal/models"
+)
+
+type Usecase struct {
+	repoCart    cart
+	repoCatalog catalog
+}
+
+func NewUsecase(c cart, ct catalog) *Usecase {
+	return &Usecase{
+		repoCart:    c,
+		repoCatalog: ct,
+	}
+}
+
+// AddItem - кладёт позицию в корзину; variant - SKU варианта, пустая строка означает базовую позицию
+func (u *Usecase) AddItem(ctx context.Context, userID, item, variant string, quantity int64) (res models.Cart, err error) {
+	tx, err := u.repoCart.BeginTx(ctx)
+	if err != nil {
+		return res, fmt.Errorf("failed to begin tx: %w", err)
+	}
+
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback(ctx)
+		} else {
+			err = tx.Commit(ctx)
+		}
+	}()
+
+	catalogItem, err := u.repoCatalog.GetItemByName(ctx, tx, item)
+	if err != nil {
+		return res, err
+	}
+
+	if !catalogItem.Available() {
+		err = models.ErrItemNotAvailable
+		return res, err
+	}
+
+	if variant != "" {
+		v, err := u.repoCatalog.GetVariantBySKU(ctx, tx, variant)
+		if err != nil {
+			return res, err
+		}
+		if v.ItemID != catalogItem.ID {
+			return res, models.ErrVariantNotFound
+		}
+	}
+
+	if err = u.repoCart.AddItem(ctx, tx, userID, catalogItem.ID, variant, quantity); err != nil {
+		return res, err
+	}
+
+	return u.cart(ctx, tx, userID)
+}
+
+func (u *Usecase) RemoveItem(ctx context.Context, userID, item, variant string) (res models.Cart, err error) {
+	tx, err := u.repoCart.BeginTx(ctx)
+	if err != nil {
+		return res, fmt.Errorf("failed to begin tx: %w", err)
+	}
+
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback(ctx)
+		} else {
+			err = tx.Commit(ctx)
+		}
+	}()
+
+	catalogItem, err := u.repoCatalog.GetItemByName(ctx, tx, item)
+	if err != nil {
+		return res, err
+	}
+
+	if err = u.repoCart.RemoveItem(ctx, tx, userID, catalogItem.ID, variant); err != nil {
+		return res, err
+	}
+
+	return u.cart(ctx, tx, userID)
+}
+
+func (u *Usecase) GetCart(ctx context.Context, userID string) (res models.Cart, err error) {
+	tx, err := u.repoCart.BeginTx(ctx)
+	if err != nil {
+		return res, fmt.Errorf("failed to begin tx: %w", err)
+	}
+
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback(ctx)
+		} else {
+			err = tx.Commit(ctx)
+		}
+	}()
+
+	return u.cart(ctx, tx, userID)
+}
+
+func (u *Usecase) cart(ctx context.Context, tx pgx.Tx, userID string) (models.Cart, error) {
+	lines, err := u.repoCart.GetCart(ctx, tx, userID)
+	if err != nil {
+		return models.Cart{}, err
+	}
+
+	res := models.Cart{Lines: lines}
+	for _, line := range lines {
+		res.Total += line.Price * line.Quantity
+	}
+
+	return res, nil
+}
@@ -0,0 +1,471 @@
+package catalog_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+
+	"AvitoTask/internal/models"
+	"AvitoTask/internal/usecase/catalog"
+	"AvitoTask/internal/usecase/catalog/mocks"
+)
+
+func TestCreateItem_AlreadyExists(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockCatalog := mocks.NewMockcatalog(ctrl)
+	mockUser := mocks.NewMockuser(ctrl)
+	mockTx := mocks.NewMockTx(ctrl)
+
+	mockCatalog.EXPECT().BeginTx(ctx).Return(mockTx, nil)
+	mockCatalog.EXPECT().GetItemByName(ctx, mockTx, "cup").Return(models.CatalogItem{Name: "cup"}, nil)
+	mockTx.EXPECT().Rollback(ctx).Return(nil)
+
+	uc := catalog.NewUsecase(mockCatalog, mockUser, nil, nil, nil)
+	_, err := uc.CreateItem(ctx, "admin", models.CatalogItem{Name: "cup", Price: 20})
+	if !errors.Is(err, catalog.ErrItemExists) {
+		t.Errorf("expected error %v, got %v", catalog.ErrItemExists, err)
+	}
+}
+
+func TestCreateItem_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockCatalog := mocks.NewMockcatalog(ctrl)
+	mockUser := mocks.NewMockuser(ctrl)
+	mockTx := mocks.NewMockTx(ctrl)
+
+	mockCatalog.EXPECT().BeginTx(ctx).Return(mockTx, nil)
+	mockCatalog.EXPECT().GetItemByName(ctx, mockTx, "sticker").Return(models.CatalogItem{}, models.ErrItemNotFound)
+	mockCatalog.EXPECT().InsertItem(ctx, mockTx, gomock.Any()).Return(nil)
+	mockCatalog.EXPECT().InsertItemVersion(ctx, mockTx, gomock.Any(), gomock.Any(), "admin").Return(nil)
+	mockTx.EXPECT().Commit(ctx).Return(nil)
+
+	uc := catalog.NewUsecase(mockCatalog, mockUser, nil, nil, nil)
+	item, err := uc.CreateItem(ctx, "admin", models.CatalogItem{Name: "sticker", Price: 5})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if item.Name != "sticker" || item.Price != 5 || item.Version != 1 || item.Category != models.DefaultCategory {
+		t.Errorf("unexpected item %+v", item)
+	}
+}
+
+func TestCreateItem_InsertError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockCatalog := mocks.NewMockcatalog(ctrl)
+	mockUser := mocks.NewMockuser(ctrl)
+	mockTx := mocks.NewMockTx(ctrl)
+
+	insertErr := errors.New("insert failed")
+	mockCatalog.EXPECT().BeginTx(ctx).Return(mockTx, nil)
+	mockCatalog.EXPECT().GetItemByName(ctx, mockTx, "sticker").Return(models.CatalogItem{}, models.ErrItemNotFound)
+	mockCatalog.EXPECT().InsertItem(ctx, mockTx, gomock.Any()).Return(insertErr)
+	mockTx.EXPECT().Rollback(ctx).Return(nil)
+
+	uc := catalog.NewUsecase(mockCatalog, mockUser, nil, nil, nil)
+	_, err := uc.CreateItem(ctx, "admin", models.CatalogItem{Name: "sticker", Price: 5})
+	if !errors.Is(err, insertErr) {
+		t.Errorf("expected error %v, got %v", insertErr, err)
+	}
+}
+
+func TestCreateItem_ConcurrentDuplicate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockCatalog := mocks.NewMockcatalog(ctrl)
+	mockTx := mocks.NewMockTx(ctrl)
+
+	// позицию с тем же именем создали между проверкой и вставкой
+	mockCatalog.EXPECT().BeginTx(ctx).Return(mockTx, nil)
+	mockCatalog.EXPECT().GetItemByName(ctx, mockTx, "sticker").Return(models.CatalogItem{}, models.ErrItemNotFound)
+	mockCatalog.EXPECT().InsertItem(ctx, mockTx, gomock.Any()).
+		Return(fmt.Errorf("failed to insert catalog item 'sticker': %w", &pgconn.PgError{Code: "23505"}))
+	mockTx.EXPECT().Rollback(ctx).Return(nil)
+
+	uc := catalog.NewUsecase(mockCatalog, nil, nil, nil, nil)
+	_, err := uc.CreateItem(ctx, "admin", models.CatalogItem{Name: "sticker", Price: 5})
+	if !errors.Is(err, catalog.ErrItemExists) {
+		t.Errorf("expected error %v, got %v", catalog.ErrItemExists, err)
+	}
+}
+
+func TestRepriceItem_BumpsVersion(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockCatalog := mocks.NewMockcatalog(ctrl)
+	mockUser := mocks.NewMockuser(ctrl)
+	mockTx := mocks.NewMockTx(ctrl)
+
+	current := models.CatalogItem{ID: "item-1", Name: "cup", Price: 20, Version: 3}
+	expected := current
+	expected.Price = 25
+	expected.Version = 4
+
+	mockCatalog.EXPECT().BeginTx(ctx).Return(mockTx, nil)
+	mockCatalog.EXPECT().LockItemByName(ctx, mockTx, "cup").Return(current, nil)
+	mockCatalog.EXPECT().UpdateItem(ctx, mockTx, expected).Return(nil)
+	mockCatalog.EXPECT().InsertItemVersion(ctx, mockTx, gomock.Any(), expected, "admin").Return(nil)
+	mockTx.EXPECT().Commit(ctx).Return(nil)
+
+	uc := catalog.NewUsecase(mockCatalog, mockUser, nil, nil, nil)
+	item, err := uc.RepriceItem(ctx, "admin", "cup", 25)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if item != expected {
+		t.Errorf("expected %+v, got %+v", expected, item)
+	}
+}
+
+func TestSetItemHidden_NotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockCatalog := mocks.NewMockcatalog(ctrl)
+	mockUser := mocks.NewMockuser(ctrl)
+	mockTx := mocks.NewMockTx(ctrl)
+
+	mockCatalog.EXPECT().BeginTx(ctx).Return(mockTx, nil)
+	mockCatalog.EXPECT().LockItemByName(ctx, mockTx, "cup").Return(models.CatalogItem{}, models.ErrItemNotFound)
+	mockTx.EXPECT().Rollback(ctx).Return(nil)
+
+	uc := catalog.NewUsecase(mockCatalog, mockUser, nil, nil, nil)
+	_, err := uc.SetItemHidden(ctx, "admin", "cup", true)
+	if !errors.Is(err, models.ErrItemNotFound) {
+		t.Errorf("expected error %v, got %v", models.ErrItemNotFound, err)
+	}
+}
+
+func TestRetireItem_AlreadyRetired(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockCatalog := mocks.NewMockcatalog(ctrl)
+	mockUser := mocks.NewMockuser(ctrl)
+	mockTx := mocks.NewMockTx(ctrl)
+
+	mockCatalog.EXPECT().BeginTx(ctx).Return(mockTx, nil)
+	mockCatalog.EXPECT().LockItemByName(ctx, mockTx, "cup").Return(models.CatalogItem{Name: "cup", Retired: true}, nil)
+	mockTx.EXPECT().Rollback(ctx).Return(nil)
+
+	uc := catalog.NewUsecase(mockCatalog, mockUser, nil, nil, nil)
+	_, err := uc.RetireItem(ctx, "admin", "cup")
+	if !errors.Is(err, catalog.ErrItemRetired) {
+		t.Errorf("expected error %v, got %v", catalog.ErrItemRetired, err)
+	}
+}
+
+func TestListItems_CanAfford(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockCatalog := mocks.NewMockcatalog(ctrl)
+	mockUser := mocks.NewMockuser(ctrl)
+	mockTx := mocks.NewMockTx(ctrl)
+
+	filter := models.CatalogFilter{Category: "clothes", SortBy: models.SortByPrice, Limit: 2}
+	items := []models.CatalogItem{
+		{Name: "socks", Category: "clothes", Price: 10},
+		{Name: "hoody", Category: "clothes", Price: 300},
+	}
+
+	mockCatalog.EXPECT().BeginTx(ctx).Return(mockTx, nil)
+	mockUser.EXPECT().GetUserCoins(ctx, mockTx, "user123").Return(int64(100), nil)
+	mockCatalog.EXPECT().ListItems(ctx, mockTx, filter).Return(items, int64(4), nil)
+	mockTx.EXPECT().Commit(ctx).Return(nil)
+
+	uc := catalog.NewUsecase(mockCatalog, mockUser, nil, nil, nil)
+	res, total, err := uc.ListItems(ctx, "user123", filter)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if total != 4 {
+		t.Errorf("expected total 4, got %d", total)
+	}
+	if len(res) != 2 || !res[0].CanAfford || res[1].CanAfford {
+		t.Errorf("unexpected affordability: %+v", res)
+	}
+}
+
+func TestListItems_GetUserCoinsError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockCatalog := mocks.NewMockcatalog(ctrl)
+	mockUser := mocks.NewMockuser(ctrl)
+	mockTx := mocks.NewMockTx(ctrl)
+
+	coinsErr := errors.New("no coins")
+	mockCatalog.EXPECT().BeginTx(ctx).Return(mockTx, nil)
+	mockUser.EXPECT().GetUserCoins(ctx, mockTx, "user123").Return(int64(0), coinsErr)
+	mockTx.EXPECT().Rollback(ctx).Return(nil)
+
+	uc := catalog.NewUsecase(mockCatalog, mockUser, nil, nil, nil)
+	_, _, err := uc.ListItems(ctx, "user123", models.CatalogFilter{})
+	if !errors.Is(err, coinsErr) {
+		t.Errorf("expected error %v, got %v", coinsErr, err)
+	}
+}
+
+func TestRestock_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockCatalog := mocks.NewMockcatalog(ctrl)
+	mockUser := mocks.NewMockuser(ctrl)
+	mockTx := mocks.NewMockTx(ctrl)
+
+	stock := int64(3)
+	current := models.CatalogItem{ID: "item-1", Name: "pink-hoody", Price: 500, Stock: &stock}
+
+	mockCatalog.EXPECT().BeginTx(ctx).Return(mockTx, nil)
+	mockCatalog.EXPECT().LockItemByName(ctx, mockTx, "pink-hoody").Return(current, nil)
+	mockCatalog.EXPECT().AddStock(ctx, mockTx, "item-1", int64(10)).Return(int64(13), nil)
+	mockCatalog.EXPECT().InsertStockMovement(ctx, mockTx, gomock.Any()).DoAndReturn(
+		func(_ context.Context, _ pgx.Tx, m models.StockMovement) error {
+			if m.Delta != 10 || m.Reason != models.StockReasonRestock || m.UserID != "admin" {
+				t.Errorf("unexpected stock movement %+v", m)
+			}
+			return nil
+		})
+	mockTx.EXPECT().Commit(ctx).Return(nil)
+
+	uc := catalog.NewUsecase(mockCatalog, mockUser, nil, nil, nil)
+	item, err := uc.Restock(ctx, "admin", "pink-hoody", 10)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if item.Stock == nil || *item.Stock != 13 {
+		t.Errorf("expected stock 13, got %v", item.Stock)
+	}
+}
+
+func TestRestock_UnlimitedItem(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockCatalog := mocks.NewMockcatalog(ctrl)
+	mockTx := mocks.NewMockTx(ctrl)
+
+	// Stock == nil - запас не ограничен, пополнение не должно сделать его конечным
+	current := models.CatalogItem{ID: "item-1", Name: "pink-hoody", Price: 500}
+
+	mockCatalog.EXPECT().BeginTx(ctx).Return(mockTx, nil)
+	mockCatalog.EXPECT().LockItemByName(ctx, mockTx, "pink-hoody").Return(current, nil)
+	mockTx.EXPECT().Rollback(ctx).Return(nil)
+
+	uc := catalog.NewUsecase(mockCatalog, nil, nil, nil, nil)
+	if _, err := uc.Restock(ctx, "admin", "pink-hoody", 10); !errors.Is(err, catalog.ErrItemUnlimited) {
+		t.Fatalf("expected ErrItemUnlimited, got %v", err)
+	}
+}
+
+func TestRestock_Retired(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockCatalog := mocks.NewMockcatalog(ctrl)
+	mockUser := mocks.NewMockuser(ctrl)
+	mockTx := mocks.NewMockTx(ctrl)
+
+	mockCatalog.EXPECT().BeginTx(ctx).Return(mockTx, nil)
+	mockCatalog.EXPECT().LockItemByName(ctx, mockTx, "cup").Return(models.CatalogItem{Name: "cup", Retired: true}, nil)
+	mockTx.EXPECT().Rollback(ctx).Return(nil)
+
+	uc := catalog.NewUsecase(mockCatalog, mockUser, nil, nil, nil)
+	_, err := uc.Restock(ctx, "admin", "cup", 5)
+	if !errors.Is(err, catalog.ErrItemRetired) {
+		t.Errorf("expected error %v, got %v", catalog.ErrItemRetired, err)
+	}
+}
+
+func TestCreateVariant_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockCatalog := mocks.NewMockcatalog(ctrl)
+	mockUser := mocks.NewMockuser(ctrl)
+	mockTx := mocks.NewMockTx(ctrl)
+
+	price := int64(90)
+	mockCatalog.EXPECT().BeginTx(ctx).Return(mockTx, nil)
+	mockCatalog.EXPECT().LockItemByName(ctx, mockTx, "t-shirt").Return(models.CatalogItem{ID: "item-1", Name: "t-shirt", Price: 80}, nil)
+	mockCatalog.EXPECT().GetVariantBySKU(ctx, mockTx, "t-shirt-xl-white").Return(models.ItemVariant{}, models.ErrVariantNotFound)
+	mockCatalog.EXPECT().InsertVariant(ctx, mockTx, gomock.Any()).Return(nil)
+	mockTx.EXPECT().Commit(ctx).Return(nil)
+
+	uc := catalog.NewUsecase(mockCatalog, mockUser, nil, nil, nil)
+	variant, err := uc.CreateVariant(ctx, "t-shirt", models.ItemVariant{SKU: "t-shirt-xl-white", Size: "XL", Color: "white", Price: &price})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if variant.ItemID != "item-1" || variant.SKU != "t-shirt-xl-white" || variant.ID == "" {
+		t.Errorf("unexpected variant %+v", variant)
+	}
+}
+
+func TestCreateVariant_SKUTaken(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockCatalog := mocks.NewMockcatalog(ctrl)
+	mockUser := mocks.NewMockuser(ctrl)
+	mockTx := mocks.NewMockTx(ctrl)
+
+	mockCatalog.EXPECT().BeginTx(ctx).Return(mockTx, nil)
+	mockCatalog.EXPECT().LockItemByName(ctx, mockTx, "hoody").Return(models.CatalogItem{ID: "item-2", Name: "hoody"}, nil)
+	mockCatalog.EXPECT().GetVariantBySKU(ctx, mockTx, "hoody-m").Return(models.ItemVariant{SKU: "hoody-m"}, nil)
+	mockTx.EXPECT().Rollback(ctx).Return(nil)
+
+	uc := catalog.NewUsecase(mockCatalog, mockUser, nil, nil, nil)
+	_, err := uc.CreateVariant(ctx, "hoody", models.ItemVariant{SKU: "hoody-m", Size: "M"})
+	if !errors.Is(err, catalog.ErrVariantExists) {
+		t.Errorf("expected error %v, got %v", catalog.ErrVariantExists, err)
+	}
+}
+
+func TestListItems_QuotaLeft(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockCatalog := mocks.NewMockcatalog(ctrl)
+	mockUser := mocks.NewMockuser(ctrl)
+	mockOrder := mocks.NewMockorder(ctrl)
+	mockInventory := mocks.NewMockinventory(ctrl)
+	mockTx := mocks.NewMockTx(ctrl)
+
+	now := time.Date(2024, time.May, 17, 12, 0, 0, 0, time.UTC)
+	limit := int64(3)
+	items := []models.CatalogItem{
+		{ID: "item-1", Name: "socks", Price: 10},
+		{ID: "item-2", Name: "pink-hoody", Price: 500, PurchaseLimit: &limit, LimitPeriod: models.LimitPeriodQuarter},
+	}
+
+	mockCatalog.EXPECT().BeginTx(ctx).Return(mockTx, nil)
+	mockUser.EXPECT().GetUserCoins(ctx, mockTx, "user123").Return(int64(1000), nil)
+	mockCatalog.EXPECT().ListItems(ctx, mockTx, models.CatalogFilter{}).Return(items, int64(2), nil)
+	mockOrder.EXPECT().CountUserPurchases(ctx, mockTx, "user123", "item-2", time.Date(2024, time.April, 1, 0, 0, 0, 0, time.UTC)).
+		Return(int64(1), nil)
+	mockInventory.EXPECT().CountUserItems(ctx, mockTx, "user123", "pink-hoody").Return(int64(2), nil)
+	mockTx.EXPECT().Commit(ctx).Return(nil)
+
+	uc := catalog.NewUsecase(mockCatalog, mockUser, mockOrder, mockInventory, nil)
+	uc.Now = func() time.Time { return now }
+	res, _, err := uc.ListItems(ctx, "user123", models.CatalogFilter{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if res[0].QuotaLeft != nil {
+		t.Errorf("expected no quota for unlimited item, got %d", *res[0].QuotaLeft)
+	}
+	if res[1].QuotaLeft == nil || *res[1].QuotaLeft != 2 {
+		t.Errorf("expected 2 left for pink-hoody, got %v", res[1].QuotaLeft)
+	}
+}
+
+func TestSetPurchaseLimit_Invalid(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockCatalog := mocks.NewMockcatalog(ctrl)
+	mockUser := mocks.NewMockuser(ctrl)
+
+	limit := int64(0)
+	uc := catalog.NewUsecase(mockCatalog, mockUser, nil, nil, nil)
+	if _, err := uc.SetPurchaseLimit(ctx, "admin", "cup", &limit, ""); !errors.Is(err, catalog.ErrInvalidQuota) {
+		t.Errorf("expected error %v, got %v", catalog.ErrInvalidQuota, err)
+	}
+
+	limit = 1
+	if _, err := uc.SetPurchaseLimit(ctx, "admin", "cup", &limit, "week"); !errors.Is(err, catalog.ErrInvalidQuota) {
+		t.Errorf("expected error %v, got %v", catalog.ErrInvalidQuota, err)
+	}
+}
+
+func TestRepriceItem_PriceDropNotifiesWishlist(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockCatalog := mocks.NewMockcatalog(ctrl)
+	mockNotify := mocks.NewMocknotification(ctrl)
+	mockTx := mocks.NewMockTx(ctrl)
+
+	current := models.CatalogItem{ID: "item-1", Name: "cup", Price: 20, Version: 3}
+
+	mockCatalog.EXPECT().BeginTx(ctx).Return(mockTx, nil)
+	mockCatalog.EXPECT().LockItemByName(ctx, mockTx, "cup").Return(current, nil)
+	mockCatalog.EXPECT().UpdateItem(ctx, mockTx, gomock.Any()).Return(nil)
+	mockCatalog.EXPECT().InsertItemVersion(ctx, mockTx, gomock.Any(), gomock.Any(), "admin").Return(nil)
+	mockNotify.EXPECT().QueueWishlistNotifications(ctx, mockTx, models.Notification{
+		Kind:   models.NotificationPriceDrop,
+		ItemID: "item-1",
+		Detail: "cup price dropped from 20 to 15",
+	}, "").Return(int64(2), nil)
+	mockTx.EXPECT().Commit(ctx).Return(nil)
+
+	uc := catalog.NewUsecase(mockCatalog, nil, nil, nil, mockNotify)
+	if _, err := uc.RepriceItem(ctx, "admin", "cup", 15); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestRestock_SoldOutNotifiesWishlist(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockCatalog := mocks.NewMockcatalog(ctrl)
+	mockNotify := mocks.NewMocknotification(ctrl)
+	mockTx := mocks.NewMockTx(ctrl)
+
+	stock := int64(0)
+	current := models.CatalogItem{ID: "item-1", Name: "pink-hoody", Price: 500, Stock: &stock}
+
+	mockCatalog.EXPECT().BeginTx(ctx).Return(mockTx, nil)
+	mockCatalog.EXPECT().LockItemByName(ctx, mockTx, "pink-hoody").Return(current, nil)
+	mockCatalog.EXPECT().AddStock(ctx, mockTx, "item-1", int64(5)).Return(int64(5), nil)
+	mockCatalog.EXPECT().InsertStockMovement(ctx, mockTx, gomock.Any()).Return(nil)
+	mockNotify.EXPECT().QueueWishlistNotifications(ctx, mockTx, gomock.Any(), "").DoAndReturn(
+		func(_ context.Context, _ pgx.Tx, n models.Notification, _ string) (int64, error) {
+			if n.Kind != models.NotificationBackInStock || n.ItemID != "item-1" {
+				t.Errorf("unexpected notification %+v", n)
+			}
+			return 1, nil
+		})
+	mockTx.EXPECT().Commit(ctx).Return(nil)
+
+	uc := catalog.NewUsecase(mockCatalog, nil, nil, nil, mockNotify)
+	if _, err := uc.Restock(ctx, "admin", "pink-hoody", 5); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
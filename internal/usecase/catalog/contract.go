@@ -0,0 +1,45 @@
+//go:generate mockgen -source=contract.go -destination=mocks/mock.go -package=mocks $GOPACKAGE
+//go:generate mockgen -destination=mocks/mock_tx.go -package=mocks github.com/jackc/pgx/v5 Tx
+package catalog
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+
+	"AvitoTask/internal/models"
+)
+
+type catalog interface {
+	BeginTx(ctx context.Context) (pgx.Tx, error)
+	GetItemByName(ctx context.Context, tx pgx.Tx, name string) (models.CatalogItem, error)
+	LockItemByName(ctx context.Context, tx pgx.Tx, name string) (models.CatalogItem, error)
+	InsertItem(ctx context.Context, tx pgx.Tx, item models.CatalogItem) error
+	UpdateItem(ctx context.Context, tx pgx.Tx, item models.CatalogItem) error
+	InsertItemVersion(ctx context.Context, tx pgx.Tx, id string, item models.CatalogItem, changedBy string) error
+	GetItemVersions(ctx context.Context, tx pgx.Tx, itemID string) ([]models.CatalogItemVersion, error)
+	ListItems(ctx context.Context, tx pgx.Tx, filter models.CatalogFilter) ([]models.CatalogItem, int64, error)
+	AddStock(ctx context.Context, tx pgx.Tx, itemID string, quantity int64) (int64, error)
+	InsertStockMovement(ctx context.Context, tx pgx.Tx, m models.StockMovement) error
+	GetStockMovements(ctx context.Context, tx pgx.Tx, itemID string) ([]models.StockMovement, error)
+	InsertVariant(ctx context.Context, tx pgx.Tx, v models.ItemVariant) error
+	GetVariantBySKU(ctx context.Context, tx pgx.Tx, sku string) (models.ItemVariant, error)
+	GetItemVariants(ctx context.Context, tx pgx.Tx, itemID string) ([]models.ItemVariant, error)
+}
+
+type user interface {
+	GetUserCoins(ctx context.Context, tx pgx.Tx, userID string) (int64, error)
+}
+
+type order interface {
+	CountUserPurchases(ctx context.Context, tx pgx.Tx, userID, itemID string, since time.Time) (int64, error)
+}
+
+type inventory interface {
+	CountUserItems(ctx context.Context, tx pgx.Tx, userID, itemType string) (int64, error)
+}
+
+type notification interface {
+	QueueWishlistNotifications(ctx context.Context, tx pgx.Tx, n models.Notification, category string) (int64, error)
+}
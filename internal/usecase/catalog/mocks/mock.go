@@ -0,0 +1,396 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: contract.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	models "AvitoTask/internal/models"
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+	pgx "github.com/jackc/pgx/v5"
+)
+
+// Mockcatalog is a mock of catalog interface.
+type Mockcatalog struct {
+	ctrl     *gomock.Controller
+	recorder *MockcatalogMockRecorder
+}
+
+// MockcatalogMockRecorder is the mock recorder for Mockcatalog.
+type MockcatalogMockRecorder struct {
+	mock *Mockcatalog
+}
+
+// NewMockcatalog creates a new mock instance.
+func NewMockcatalog(ctrl *gomock.Controller) *Mockcatalog {
+	mock := &Mockcatalog{ctrl: ctrl}
+	mock.recorder = &MockcatalogMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mockcatalog) EXPECT() *MockcatalogMockRecorder {
+	return m.recorder
+}
+
+// AddStock mocks base method.
+func (m *Mockcatalog) AddStock(ctx context.Context, tx pgx.Tx, itemID string, quantity int64) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddStock", ctx, tx, itemID, quantity)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddStock indicates an expected call of AddStock.
+func (mr *MockcatalogMockRecorder) AddStock(ctx, tx, itemID, quantity interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddStock", reflect.TypeOf((*Mockcatalog)(nil).AddStock), ctx, tx, itemID, quantity)
+}
+
+// BeginTx mocks base method.
+func (m *Mockcatalog) BeginTx(ctx context.Context) (pgx.Tx, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BeginTx", ctx)
+	ret0, _ := ret[0].(pgx.Tx)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BeginTx indicates an expected call of BeginTx.
+func (mr *MockcatalogMockRecorder) BeginTx(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BeginTx", reflect.TypeOf((*Mockcatalog)(nil).BeginTx), ctx)
+}
+
+// GetItemByName mocks base method.
+func (m *Mockcatalog) GetItemByName(ctx context.Context, tx pgx.Tx, name string) (models.CatalogItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetItemByName", ctx, tx, name)
+	ret0, _ := ret[0].(models.CatalogItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetItemByName indicates an expected call of GetItemByName.
+func (mr *MockcatalogMockRecorder) GetItemByName(ctx, tx, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetItemByName", reflect.TypeOf((*Mockcatalog)(nil).GetItemByName), ctx, tx, name)
+}
+
+// GetItemVariants mocks base method.
+func (m *Mockcatalog) GetItemVariants(ctx context.Context, tx pgx.Tx, itemID string) ([]models.ItemVariant, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetItemVariants", ctx, tx, itemID)
+	ret0, _ := ret[0].([]models.ItemVariant)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetItemVariants indicates an expected call of GetItemVariants.
+func (mr *MockcatalogMockRecorder) GetItemVariants(ctx, tx, itemID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetItemVariants", reflect.TypeOf((*Mockcatalog)(nil).GetItemVariants), ctx, tx, itemID)
+}
+
+// GetItemVersions mocks base method.
+func (m *Mockcatalog) GetItemVersions(ctx context.Context, tx pgx.Tx, itemID string) ([]models.CatalogItemVersion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetItemVersions", ctx, tx, itemID)
+	ret0, _ := ret[0].([]models.CatalogItemVersion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetItemVersions indicates an expected call of GetItemVersions.
+func (mr *MockcatalogMockRecorder) GetItemVersions(ctx, tx, itemID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetItemVersions", reflect.TypeOf((*Mockcatalog)(nil).GetItemVersions), ctx, tx, itemID)
+}
+
+// GetStockMovements mocks base method.
+func (m *Mockcatalog) GetStockMovements(ctx context.Context, tx pgx.Tx, itemID string) ([]models.StockMovement, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStockMovements", ctx, tx, itemID)
+	ret0, _ := ret[0].([]models.StockMovement)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStockMovements indicates an expected call of GetStockMovements.
+func (mr *MockcatalogMockRecorder) GetStockMovements(ctx, tx, itemID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStockMovements", reflect.TypeOf((*Mockcatalog)(nil).GetStockMovements), ctx, tx, itemID)
+}
+
+// GetVariantBySKU mocks base method.
+func (m *Mockcatalog) GetVariantBySKU(ctx context.Context, tx pgx.Tx, sku string) (models.ItemVariant, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetVariantBySKU", ctx, tx, sku)
+	ret0, _ := ret[0].(models.ItemVariant)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetVariantBySKU indicates an expected call of GetVariantBySKU.
+func (mr *MockcatalogMockRecorder) GetVariantBySKU(ctx, tx, sku interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVariantBySKU", reflect.TypeOf((*Mockcatalog)(nil).GetVariantBySKU), ctx, tx, sku)
+}
+
+// InsertItem mocks base method.
+func (m *Mockcatalog) InsertItem(ctx context.Context, tx pgx.Tx, item models.CatalogItem) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertItem", ctx, tx, item)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InsertItem indicates an expected call of InsertItem.
+func (mr *MockcatalogMockRecorder) InsertItem(ctx, tx, item interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertItem", reflect.TypeOf((*Mockcatalog)(nil).InsertItem), ctx, tx, item)
+}
+
+// InsertItemVersion mocks base method.
+func (m *Mockcatalog) InsertItemVersion(ctx context.Context, tx pgx.Tx, id string, item models.CatalogItem, changedBy string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertItemVersion", ctx, tx, id, item, changedBy)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InsertItemVersion indicates an expected call of InsertItemVersion.
+func (mr *MockcatalogMockRecorder) InsertItemVersion(ctx, tx, id, item, changedBy interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertItemVersion", reflect.TypeOf((*Mockcatalog)(nil).InsertItemVersion), ctx, tx, id, item, changedBy)
+}
+
+// InsertStockMovement mocks base method.
+func (m_2 *Mockcatalog) InsertStockMovement(ctx context.Context, tx pgx.Tx, m models.StockMovement) error {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "InsertStockMovement", ctx, tx, m)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InsertStockMovement indicates an expected call of InsertStockMovement.
+func (mr *MockcatalogMockRecorder) InsertStockMovement(ctx, tx, m interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertStockMovement", reflect.TypeOf((*Mockcatalog)(nil).InsertStockMovement), ctx, tx, m)
+}
+
+// InsertVariant mocks base method.
+func (m *Mockcatalog) InsertVariant(ctx context.Context, tx pgx.Tx, v models.ItemVariant) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertVariant", ctx, tx, v)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InsertVariant indicates an expected call of InsertVariant.
+func (mr *MockcatalogMockRecorder) InsertVariant(ctx, tx, v interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertVariant", reflect.TypeOf((*Mockcatalog)(nil).InsertVariant), ctx, tx, v)
+}
+
+// ListItems mocks base method.
+func (m *Mockcatalog) ListItems(ctx context.Context, tx pgx.Tx, filter models.CatalogFilter) ([]models.CatalogItem, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListItems", ctx, tx, filter)
+	ret0, _ := ret[0].([]models.CatalogItem)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListItems indicates an expected call of ListItems.
+func (mr *MockcatalogMockRecorder) ListItems(ctx, tx, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListItems", reflect.TypeOf((*Mockcatalog)(nil).ListItems), ctx, tx, filter)
+}
+
+// LockItemByName mocks base method.
+func (m *Mockcatalog) LockItemByName(ctx context.Context, tx pgx.Tx, name string) (models.CatalogItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LockItemByName", ctx, tx, name)
+	ret0, _ := ret[0].(models.CatalogItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LockItemByName indicates an expected call of LockItemByName.
+func (mr *MockcatalogMockRecorder) LockItemByName(ctx, tx, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LockItemByName", reflect.TypeOf((*Mockcatalog)(nil).LockItemByName), ctx, tx, name)
+}
+
+// UpdateItem mocks base method.
+func (m *Mockcatalog) UpdateItem(ctx context.Context, tx pgx.Tx, item models.CatalogItem) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateItem", ctx, tx, item)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateItem indicates an expected call of UpdateItem.
+func (mr *MockcatalogMockRecorder) UpdateItem(ctx, tx, item interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateItem", reflect.TypeOf((*Mockcatalog)(nil).UpdateItem), ctx, tx, item)
+}
+
+// Mockuser is a mock of user interface.
+type Mockuser struct {
+	ctrl     *gomock.Controller
+	recorder *MockuserMockRecorder
+}
+
+// MockuserMockRecorder is the mock recorder for Mockuser.
+type MockuserMockRecorder struct {
+	mock *Mockuser
+}
+
+// NewMockuser creates a new mock instance.
+func NewMockuser(ctrl *gomock.Controller) *Mockuser {
+	mock := &Mockuser{ctrl: ctrl}
+	mock.recorder = &MockuserMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mockuser) EXPECT() *MockuserMockRecorder {
+	return m.recorder
+}
+
+// GetUserCoins mocks base method.
+func (m *Mockuser) GetUserCoins(ctx context.Context, tx pgx.Tx, userID string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserCoins", ctx, tx, userID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserCoins indicates an expected call of GetUserCoins.
+func (mr *MockuserMockRecorder) GetUserCoins(ctx, tx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserCoins", reflect.TypeOf((*Mockuser)(nil).GetUserCoins), ctx, tx, userID)
+}
+
+// Mockorder is a mock of order interface.
+type Mockorder struct {
+	ctrl     *gomock.Controller
+	recorder *MockorderMockRecorder
+}
+
+// MockorderMockRecorder is the mock recorder for Mockorder.
+type MockorderMockRecorder struct {
+	mock *Mockorder
+}
+
+// NewMockorder creates a new mock instance.
+func NewMockorder(ctrl *gomock.Controller) *Mockorder {
+	mock := &Mockorder{ctrl: ctrl}
+	mock.recorder = &MockorderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mockorder) EXPECT() *MockorderMockRecorder {
+	return m.recorder
+}
+
+// CountUserPurchases mocks base method.
+func (m *Mockorder) CountUserPurchases(ctx context.Context, tx pgx.Tx, userID, itemID string, since time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountUserPurchases", ctx, tx, userID, itemID, since)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountUserPurchases indicates an expected call of CountUserPurchases.
+func (mr *MockorderMockRecorder) CountUserPurchases(ctx, tx, userID, itemID, since interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountUserPurchases", reflect.TypeOf((*Mockorder)(nil).CountUserPurchases), ctx, tx, userID, itemID, since)
+}
+
+// Mockinventory is a mock of inventory interface.
+type Mockinventory struct {
+	ctrl     *gomock.Controller
+	recorder *MockinventoryMockRecorder
+}
+
+// MockinventoryMockRecorder is the mock recorder for Mockinventory.
+type MockinventoryMockRecorder struct {
+	mock *Mockinventory
+}
+
+// NewMockinventory creates a new mock instance.
+func NewMockinventory(ctrl *gomock.Controller) *Mockinventory {
+	mock := &Mockinventory{ctrl: ctrl}
+	mock.recorder = &MockinventoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mockinventory) EXPECT() *MockinventoryMockRecorder {
+	return m.recorder
+}
+
+// CountUserItems mocks base method.
+func (m *Mockinventory) CountUserItems(ctx context.Context, tx pgx.Tx, userID, itemType string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountUserItems", ctx, tx, userID, itemType)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountUserItems indicates an expected call of CountUserItems.
+func (mr *MockinventoryMockRecorder) CountUserItems(ctx, tx, userID, itemType interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountUserItems", reflect.TypeOf((*Mockinventory)(nil).CountUserItems), ctx, tx, userID, itemType)
+}
+
+// Mocknotification is a mock of notification interface.
+type Mocknotification struct {
+	ctrl     *gomock.Controller
+	recorder *MocknotificationMockRecorder
+}
+
+// MocknotificationMockRecorder is the mock recorder for Mocknotification.
+type MocknotificationMockRecorder struct {
+	mock *Mocknotification
+}
+
+// NewMocknotification creates a new mock instance.
+func NewMocknotification(ctrl *gomock.Controller) *Mocknotification {
+	mock := &Mocknotification{ctrl: ctrl}
+	mock.recorder = &MocknotificationMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mocknotification) EXPECT() *MocknotificationMockRecorder {
+	return m.recorder
+}
+
+// QueueWishlistNotifications mocks base method.
+func (m *Mocknotification) QueueWishlistNotifications(ctx context.Context, tx pgx.Tx, n models.Notification, category string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QueueWishlistNotifications", ctx, tx, n, category)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// QueueWishlistNotifications indicates an expected call of QueueWishlistNotifications.
+func (mr *MocknotificationMockRecorder) QueueWishlistNotifications(ctx, tx, n, category interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueueWishlistNotifications", reflect.TypeOf((*Mocknotification)(nil).QueueWishlistNotifications), ctx, tx, n, category)
+}
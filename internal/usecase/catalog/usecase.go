@@ -0,0 +1,445 @@
+package catalog
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+
+	"AvitoTask/internal/models"
+	"AvitoTask/internal/utils"
+)
+
+var (
+	ErrItemExists    = errors.New("item already exists in catalog")
+	ErrItemRetired   = errors.New("item is retired and cannot be changed")
+	ErrItemUnlimited = errors.New("item has unlimited stock and cannot be restocked")
+
+	ErrVariantExists = errors.New("variant with this sku already exists")
+
+	ErrInvalidQuota = errors.New("purchase limit must be positive and period one of month, quarter, year or empty")
+)
+
+type Usecase struct {
+	repo          catalog
+	repoUser      user
+	repoOrder     order
+	repoInventory inventory
+	repoNotify    notification
+	Now           func() time.Time
+}
+
+func NewUsecase(repo catalog, repoUser user, repoOrder order, repoInventory inventory, repoNotify notification) *Usecase {
+	return &Usecase{
+		repo:          repo,
+		repoUser:      repoUser,
+		repoOrder:     repoOrder,
+		repoInventory: repoInventory,
+		repoNotify:    repoNotify,
+		Now: func() time.Time {
+			return time.Now().UTC()
+		},
+	}
+}
+
+func (u *Usecase) ListItems(ctx context.Context, userID string, filter models.CatalogFilter) (res []models.CatalogListItem, total int64, err error) {
+	tx, err := u.repo.BeginTx(ctx)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to begin tx: %w", err)
+	}
+
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback(ctx)
+		} else {
+			err = tx.Commit(ctx)
+		}
+	}()
+
+	coins, err := u.repoUser.GetUserCoins(ctx, tx, userID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	items, total, err := u.repo.ListItems(ctx, tx, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	res = make([]models.CatalogListItem, 0, len(items))
+	for _, it := range items {
+		quotaLeft, err := u.quotaLeft(ctx, tx, userID, it)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		res = append(res, models.CatalogListItem{
+			Name:      it.Name,
+			Category:  it.Category,
+			Price:     it.Price,
+			Stock:     it.Stock,
+			QuotaLeft: quotaLeft,
+			Available: it.Available(),
+			CanAfford: it.Available() && coins >= it.Price,
+		})
+	}
+
+	return res, total, nil
+}
+
+// quotaLeft - остаток квоты пользователя по позиции; nil, если позиция не лимитирована
+func (u *Usecase) quotaLeft(ctx context.Context, tx pgx.Tx, userID string, item models.CatalogItem) (*int64, error) {
+	if item.PurchaseLimit == nil {
+		return nil, nil
+	}
+
+	purchased, err := u.repoOrder.CountUserPurchases(ctx, tx, userID, item.ID, item.QuotaPeriodStart(u.Now()))
+	if err != nil {
+		return nil, err
+	}
+
+	owned, err := u.repoInventory.CountUserItems(ctx, tx, userID, item.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	return item.QuotaLeft(purchased, owned), nil
+}
+
+func (u *Usecase) CreateItem(ctx context.Context, adminID string, draft models.CatalogItem) (item models.CatalogItem, err error) {
+	tx, err := u.repo.BeginTx(ctx)
+	if err != nil {
+		return item, fmt.Errorf("failed to begin tx: %w", err)
+	}
+
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback(ctx)
+		} else {
+			err = tx.Commit(ctx)
+		}
+	}()
+
+	// быстрая проверка; параллельное создание с тем же именем ловит уникальный ключ при вставке
+	_, err = u.repo.GetItemByName(ctx, tx, draft.Name)
+	if err == nil {
+		err = ErrItemExists
+		return item, err
+	}
+	if !errors.Is(err, models.ErrItemNotFound) {
+		return item, err
+	}
+
+	if !validQuota(draft.PurchaseLimit, draft.LimitPeriod) {
+		err = ErrInvalidQuota
+		return item, err
+	}
+
+	item = models.CatalogItem{
+		ID:            uuid.New().String(),
+		Name:          draft.Name,
+		Category:      draft.Category,
+		Price:         draft.Price,
+		Stock:         draft.Stock,
+		PurchaseLimit: draft.PurchaseLimit,
+		LimitPeriod:   draft.LimitPeriod,
+		Version:       1,
+	}
+	if item.Category == "" {
+		item.Category = models.DefaultCategory
+	}
+	if err = u.repo.InsertItem(ctx, tx, item); err != nil {
+		if utils.IsUniqueViolation(err) {
+			err = ErrItemExists
+		}
+		return item, err
+	}
+	if err = u.repo.InsertItemVersion(ctx, tx, uuid.New().String(), item, adminID); err != nil {
+		return item, err
+	}
+
+	return item, nil
+}
+
+func (u *Usecase) RepriceItem(ctx context.Context, adminID, name string, price int64) (models.CatalogItem, error) {
+	return u.change(ctx, adminID, name, func(item *models.CatalogItem) error {
+		item.Price = price
+		return nil
+	})
+}
+
+func (u *Usecase) SetItemHidden(ctx context.Context, adminID, name string, hidden bool) (models.CatalogItem, error) {
+	return u.change(ctx, adminID, name, func(item *models.CatalogItem) error {
+		item.Hidden = hidden
+		return nil
+	})
+}
+
+// SetPurchaseLimit - задаёт квоту на покупку позиции одним пользователем; limit == nil снимает квоту
+func (u *Usecase) SetPurchaseLimit(ctx context.Context, adminID, name string, limit *int64, period string) (models.CatalogItem, error) {
+	if !validQuota(limit, period) {
+		return models.CatalogItem{}, ErrInvalidQuota
+	}
+
+	return u.change(ctx, adminID, name, func(item *models.CatalogItem) error {
+		item.PurchaseLimit = limit
+		item.LimitPeriod = period
+		if limit == nil {
+			item.LimitPeriod = ""
+		}
+		return nil
+	})
+}
+
+func validQuota(limit *int64, period string) bool {
+	if limit != nil && *limit <= 0 {
+		return false
+	}
+
+	switch period {
+	case "", models.LimitPeriodMonth, models.LimitPeriodQuarter, models.LimitPeriodYear:
+		return true
+	default:
+		return false
+	}
+}
+
+func (u *Usecase) RetireItem(ctx context.Context, adminID, name string) (models.CatalogItem, error) {
+	return u.change(ctx, adminID, name, func(item *models.CatalogItem) error {
+		item.Retired = true
+		return nil
+	})
+}
+
+func (u *Usecase) Restock(ctx context.Context, adminID, name string, quantity int64) (item models.CatalogItem, err error) {
+	tx, err := u.repo.BeginTx(ctx)
+	if err != nil {
+		return item, fmt.Errorf("failed to begin tx: %w", err)
+	}
+
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback(ctx)
+		} else {
+			err = tx.Commit(ctx)
+		}
+	}()
+
+	item, err = u.repo.LockItemByName(ctx, tx, name)
+	if err != nil {
+		return item, err
+	}
+
+	if item.Retired {
+		err = ErrItemRetired
+		return item, err
+	}
+	if item.Stock == nil {
+		err = ErrItemUnlimited
+		return item, err
+	}
+
+	soldOut := item.Stock != nil && *item.Stock <= 0
+
+	stock, err := u.repo.AddStock(ctx, tx, item.ID, quantity)
+	if err != nil {
+		return item, err
+	}
+	item.Stock = &stock
+
+	err = u.repo.InsertStockMovement(ctx, tx, models.StockMovement{
+		ID:     uuid.New().String(),
+		ItemID: item.ID,
+		Delta:  quantity,
+		Reason: models.StockReasonRestock,
+		UserID: adminID,
+	})
+	if err != nil {
+		return item, err
+	}
+
+	if soldOut && stock > 0 {
+		_, err = u.repoNotify.QueueWishlistNotifications(ctx, tx, models.Notification{
+			Kind:   models.NotificationBackInStock,
+			ItemID: item.ID,
+			Detail: fmt.Sprintf("%s is back in stock", item.Name),
+		}, "")
+		if err != nil {
+			return item, err
+		}
+	}
+
+	return item, nil
+}
+
+func (u *Usecase) GetStockMovements(ctx context.Context, name string) (movements []models.StockMovement, err error) {
+	tx, err := u.repo.BeginTx(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin tx: %w", err)
+	}
+
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback(ctx)
+		} else {
+			err = tx.Commit(ctx)
+		}
+	}()
+
+	item, err := u.repo.GetItemByName(ctx, tx, name)
+	if err != nil {
+		return nil, err
+	}
+
+	return u.repo.GetStockMovements(ctx, tx, item.ID)
+}
+
+func (u *Usecase) GetItemVersions(ctx context.Context, name string) (versions []models.CatalogItemVersion, err error) {
+	tx, err := u.repo.BeginTx(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin tx: %w", err)
+	}
+
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback(ctx)
+		} else {
+			err = tx.Commit(ctx)
+		}
+	}()
+
+	item, err := u.repo.GetItemByName(ctx, tx, name)
+	if err != nil {
+		return nil, err
+	}
+
+	return u.repo.GetItemVersions(ctx, tx, item.ID)
+}
+
+// CreateVariant - добавляет вариант (размер, цвет) к позиции каталога
+func (u *Usecase) CreateVariant(ctx context.Context, name string, draft models.ItemVariant) (variant models.ItemVariant, err error) {
+	tx, err := u.repo.BeginTx(ctx)
+	if err != nil {
+		return variant, fmt.Errorf("failed to begin tx: %w", err)
+	}
+
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback(ctx)
+		} else {
+			err = tx.Commit(ctx)
+		}
+	}()
+
+	item, err := u.repo.LockItemByName(ctx, tx, name)
+	if err != nil {
+		return variant, err
+	}
+
+	if item.Retired {
+		err = ErrItemRetired
+		return variant, err
+	}
+
+	_, err = u.repo.GetVariantBySKU(ctx, tx, draft.SKU)
+	if err == nil {
+		err = ErrVariantExists
+		return variant, err
+	}
+	if !errors.Is(err, models.ErrVariantNotFound) {
+		return variant, err
+	}
+
+	variant = models.ItemVariant{
+		ID:     uuid.New().String(),
+		ItemID: item.ID,
+		SKU:    draft.SKU,
+		Size:   draft.Size,
+		Color:  draft.Color,
+		Price:  draft.Price,
+		Stock:  draft.Stock,
+	}
+	if err = u.repo.InsertVariant(ctx, tx, variant); err != nil {
+		return variant, err
+	}
+
+	return variant, nil
+}
+
+func (u *Usecase) GetItemVariants(ctx context.Context, name string) (variants []models.ItemVariant, err error) {
+	tx, err := u.repo.BeginTx(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin tx: %w", err)
+	}
+
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback(ctx)
+		} else {
+			err = tx.Commit(ctx)
+		}
+	}()
+
+	item, err := u.repo.GetItemByName(ctx, tx, name)
+	if err != nil {
+		return nil, err
+	}
+
+	return u.repo.GetItemVariants(ctx, tx, item.ID)
+}
+
+// change - блокирует позицию каталога, применяет apply и сохраняет новую версию;
+// при снижении цены уведомляет тех, у кого позиция в списке желаний
+func (u *Usecase) change(ctx context.Context, adminID, name string, apply func(item *models.CatalogItem) error) (item models.CatalogItem, err error) {
+	tx, err := u.repo.BeginTx(ctx)
+	if err != nil {
+		return item, fmt.Errorf("failed to begin tx: %w", err)
+	}
+
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback(ctx)
+		} else {
+			err = tx.Commit(ctx)
+		}
+	}()
+
+	item, err = u.repo.LockItemByName(ctx, tx, name)
+	if err != nil {
+		return item, err
+	}
+
+	if item.Retired {
+		err = ErrItemRetired
+		return item, err
+	}
+
+	oldPrice := item.Price
+	if err = apply(&item); err != nil {
+		return item, err
+	}
+	item.Version++
+
+	if err = u.repo.UpdateItem(ctx, tx, item); err != nil {
+		return item, err
+	}
+	if err = u.repo.InsertItemVersion(ctx, tx, uuid.New().String(), item, adminID); err != nil {
+		return item, err
+	}
+
+	if item.Price < oldPrice {
+		_, err = u.repoNotify.QueueWishlistNotifications(ctx, tx, models.Notification{
+			Kind:   models.NotificationPriceDrop,
+			ItemID: item.ID,
+			Detail: fmt.Sprintf("%s price dropped from %d to %d", item.Name, oldPrice, item.Price),
+		}, "")
+		if err != nil {
+			return item, err
+		}
+	}
+
+	return item, nil
+}
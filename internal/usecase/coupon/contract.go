@@ -0,0 +1,22 @@
+//go:generate mockgen -source=contract.go -destination=mocks/mock.go -package=mocks $GOPACKAGE
+//go:generate mockgen -destination=mocks/mock_tx.go -package=mocks github.com/jackc/pgx/v5 Tx
+package coupon
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+
+	"AvitoTask/internal/models"
+)
+
+type coupon interface {
+	BeginTx(ctx context.Context) (pgx.Tx, error)
+	InsertCoupon(ctx context.Context, tx pgx.Tx, c models.Coupon) error
+	GetBatchCoupons(ctx context.Context, tx pgx.Tx, batchID string) ([]models.Coupon, error)
+	GetRedemptions(ctx context.Context, tx pgx.Tx, code string) ([]models.CouponRedemption, error)
+}
+
+type catalog interface {
+	GetItemByName(ctx context.Context, tx pgx.Tx, name string) (models.CatalogItem, error)
+}
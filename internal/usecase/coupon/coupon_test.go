@@ -0,0 +1,95 @@
+package coupon_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+
+	"AvitoTask/internal/models"
+	"AvitoTask/internal/usecase/coupon"
+	"AvitoTask/internal/usecase/coupon/mocks"
+)
+
+func TestGenerateBatch_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockCoupon := mocks.NewMockcoupon(ctrl)
+	mockCatalog := mocks.NewMockcatalog(ctrl)
+	mockTx := mocks.NewMockTx(ctrl)
+
+	mockCoupon.EXPECT().BeginTx(ctx).Return(mockTx, nil)
+	mockCatalog.EXPECT().GetItemByName(ctx, mockTx, "book").Return(models.CatalogItem{ID: "item-1", Name: "book"}, nil)
+	mockCoupon.EXPECT().InsertCoupon(ctx, mockTx, gomock.Any()).Return(nil).Times(3)
+	mockTx.EXPECT().Commit(ctx).Return(nil)
+
+	uc := coupon.NewUsecase(mockCoupon, mockCatalog)
+	coupons, err := uc.GenerateBatch(ctx, "admin", "HACK-", 3, models.Coupon{
+		Percent:        20,
+		MaxRedemptions: 1,
+		Items:          []string{"book"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(coupons) != 3 {
+		t.Fatalf("expected 3 coupons, got %d", len(coupons))
+	}
+
+	seen := make(map[string]bool)
+	for _, c := range coupons {
+		if !strings.HasPrefix(c.Code, "HACK-") || seen[c.Code] {
+			t.Errorf("unexpected code %q", c.Code)
+		}
+		seen[c.Code] = true
+		if c.BatchID != coupons[0].BatchID {
+			t.Errorf("coupons of one batch must share batch id")
+		}
+	}
+}
+
+func TestGenerateBatch_UnknownItem(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockCoupon := mocks.NewMockcoupon(ctrl)
+	mockCatalog := mocks.NewMockcatalog(ctrl)
+	mockTx := mocks.NewMockTx(ctrl)
+
+	mockCoupon.EXPECT().BeginTx(ctx).Return(mockTx, nil)
+	mockCatalog.EXPECT().GetItemByName(ctx, mockTx, "yacht").Return(models.CatalogItem{}, models.ErrItemNotFound)
+	mockTx.EXPECT().Rollback(ctx).Return(nil)
+
+	uc := coupon.NewUsecase(mockCoupon, mockCatalog)
+	_, err := uc.GenerateBatch(ctx, "admin", "", 1, models.Coupon{Amount: 10, MaxRedemptions: 1, Items: []string{"yacht"}})
+	if !errors.Is(err, models.ErrItemNotFound) {
+		t.Errorf("expected error %v, got %v", models.ErrItemNotFound, err)
+	}
+}
+
+func TestGenerateBatch_InvalidTemplate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	now := time.Date(2025, 2, 10, 12, 0, 0, 0, time.UTC)
+	past := now.Add(-time.Hour)
+
+	uc := coupon.NewUsecase(mocks.NewMockcoupon(ctrl), mocks.NewMockcatalog(ctrl))
+	uc.Now = func() time.Time { return now }
+
+	_, err := uc.GenerateBatch(context.Background(), "admin", "", 1, models.Coupon{Percent: 10, Amount: 10, MaxRedemptions: 1})
+	if !errors.Is(err, coupon.ErrInvalidDiscount) {
+		t.Errorf("expected error %v, got %v", coupon.ErrInvalidDiscount, err)
+	}
+
+	_, err = uc.GenerateBatch(context.Background(), "admin", "", 1, models.Coupon{Amount: 10, MaxRedemptions: 1, ExpiresAt: &past})
+	if !errors.Is(err, coupon.ErrInvalidExpiry) {
+		t.Errorf("expected error %v, got %v", coupon.ErrInvalidExpiry, err)
+	}
+}
@@ -0,0 +1,134 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: contract.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	models "AvitoTask/internal/models"
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	pgx "github.com/jackc/pgx/v5"
+)
+
+// Mockcoupon is a mock of coupon interface.
+type Mockcoupon struct {
+	ctrl     *gomock.Controller
+	recorder *MockcouponMockRecorder
+}
+
+// MockcouponMockRecorder is the mock recorder for Mockcoupon.
+type MockcouponMockRecorder struct {
+	mock *Mockcoupon
+}
+
+// NewMockcoupon creates a new mock instance.
+func NewMockcoupon(ctrl *gomock.Controller) *Mockcoupon {
+	mock := &Mockcoupon{ctrl: ctrl}
+	mock.recorder = &MockcouponMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mockcoupon) EXPECT() *MockcouponMockRecorder {
+	return m.recorder
+}
+
+// BeginTx mocks base method.
+func (m *Mockcoupon) BeginTx(ctx context.Context) (pgx.Tx, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BeginTx", ctx)
+	ret0, _ := ret[0].(pgx.Tx)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BeginTx indicates an expected call of BeginTx.
+func (mr *MockcouponMockRecorder) BeginTx(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BeginTx", reflect.TypeOf((*Mockcoupon)(nil).BeginTx), ctx)
+}
+
+// GetBatchCoupons mocks base method.
+func (m *Mockcoupon) GetBatchCoupons(ctx context.Context, tx pgx.Tx, batchID string) ([]models.Coupon, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBatchCoupons", ctx, tx, batchID)
+	ret0, _ := ret[0].([]models.Coupon)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBatchCoupons indicates an expected call of GetBatchCoupons.
+func (mr *MockcouponMockRecorder) GetBatchCoupons(ctx, tx, batchID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBatchCoupons", reflect.TypeOf((*Mockcoupon)(nil).GetBatchCoupons), ctx, tx, batchID)
+}
+
+// GetRedemptions mocks base method.
+func (m *Mockcoupon) GetRedemptions(ctx context.Context, tx pgx.Tx, code string) ([]models.CouponRedemption, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRedemptions", ctx, tx, code)
+	ret0, _ := ret[0].([]models.CouponRedemption)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRedemptions indicates an expected call of GetRedemptions.
+func (mr *MockcouponMockRecorder) GetRedemptions(ctx, tx, code interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRedemptions", reflect.TypeOf((*Mockcoupon)(nil).GetRedemptions), ctx, tx, code)
+}
+
+// InsertCoupon mocks base method.
+func (m *Mockcoupon) InsertCoupon(ctx context.Context, tx pgx.Tx, c models.Coupon) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertCoupon", ctx, tx, c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InsertCoupon indicates an expected call of InsertCoupon.
+func (mr *MockcouponMockRecorder) InsertCoupon(ctx, tx, c interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertCoupon", reflect.TypeOf((*Mockcoupon)(nil).InsertCoupon), ctx, tx, c)
+}
+
+// Mockcatalog is a mock of catalog interface.
+type Mockcatalog struct {
+	ctrl     *gomock.Controller
+	recorder *MockcatalogMockRecorder
+}
+
+// MockcatalogMockRecorder is the mock recorder for Mockcatalog.
+type MockcatalogMockRecorder struct {
+	mock *Mockcatalog
+}
+
+// NewMockcatalog creates a new mock instance.
+func NewMockcatalog(ctrl *gomock.Controller) *Mockcatalog {
+	mock := &Mockcatalog{ctrl: ctrl}
+	mock.recorder = &MockcatalogMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mockcatalog) EXPECT() *MockcatalogMockRecorder {
+	return m.recorder
+}
+
+// GetItemByName mocks base method.
+func (m *Mockcatalog) GetItemByName(ctx context.Context, tx pgx.Tx, name string) (models.CatalogItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetItemByName", ctx, tx, name)
+	ret0, _ := ret[0].(models.CatalogItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetItemByName indicates an expected call of GetItemByName.
+func (mr *MockcatalogMockRecorder) GetItemByName(ctx, tx, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetItemByName", reflect.TypeOf((*Mockcatalog)(nil).GetItemByName), ctx, tx, name)
+}
@@ -0,0 +1,147 @@
+package coupon
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base32"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"AvitoTask/internal/models"
+)
+
+// codeBytes - 5 случайных байт дают 8 символов base32
+const codeBytes = 5
+
+var (
+	ErrInvalidDiscount = errors.New("coupon must set either a percent or a fixed amount")
+	ErrInvalidExpiry   = errors.New("coupon expiry must be in the future")
+)
+
+type Usecase struct {
+	repo        coupon
+	repoCatalog catalog
+	Now         func() time.Time
+}
+
+func NewUsecase(c coupon, ct catalog) *Usecase {
+	return &Usecase{
+		repo:        c,
+		repoCatalog: ct,
+		Now: func() time.Time {
+			return time.Now().UTC()
+		},
+	}
+}
+
+// GenerateBatch - выпускает count кодов с параметрами template; код - prefix плюс случайный суффикс
+func (u *Usecase) GenerateBatch(ctx context.Context, adminID, prefix string, count int, template models.Coupon) (coupons []models.Coupon, err error) {
+	if (template.Percent == 0) == (template.Amount == 0) {
+		return nil, ErrInvalidDiscount
+	}
+	if template.ExpiresAt != nil && !template.ExpiresAt.After(u.Now()) {
+		return nil, ErrInvalidExpiry
+	}
+
+	tx, err := u.repo.BeginTx(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin tx: %w", err)
+	}
+
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback(ctx)
+		} else {
+			err = tx.Commit(ctx)
+		}
+	}()
+
+	items := make([]string, 0, len(template.Items))
+	for _, name := range template.Items {
+		item, err := u.repoCatalog.GetItemByName(ctx, tx, name)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item.Name)
+	}
+
+	var expiresAt *time.Time
+	if template.ExpiresAt != nil {
+		t := template.ExpiresAt.UTC()
+		expiresAt = &t
+	}
+
+	batchID := uuid.New().String()
+	coupons = make([]models.Coupon, 0, count)
+	for range count {
+		code, err := generateCode(prefix)
+		if err != nil {
+			return nil, err
+		}
+
+		c := models.Coupon{
+			Code:           code,
+			BatchID:        batchID,
+			Percent:        template.Percent,
+			Amount:         template.Amount,
+			MaxRedemptions: template.MaxRedemptions,
+			MinSpend:       template.MinSpend,
+			Items:          items,
+			ExpiresAt:      expiresAt,
+			CreatedBy:      adminID,
+			CreatedAt:      u.Now(),
+		}
+		if err = u.repo.InsertCoupon(ctx, tx, c); err != nil {
+			return nil, err
+		}
+		coupons = append(coupons, c)
+	}
+
+	return coupons, nil
+}
+
+func (u *Usecase) GetBatch(ctx context.Context, batchID string) (coupons []models.Coupon, err error) {
+	tx, err := u.repo.BeginTx(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin tx: %w", err)
+	}
+
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback(ctx)
+		} else {
+			err = tx.Commit(ctx)
+		}
+	}()
+
+	return u.repo.GetBatchCoupons(ctx, tx, batchID)
+}
+
+func (u *Usecase) GetRedemptions(ctx context.Context, code string) (redemptions []models.CouponRedemption, err error) {
+	tx, err := u.repo.BeginTx(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin tx: %w", err)
+	}
+
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback(ctx)
+		} else {
+			err = tx.Commit(ctx)
+		}
+	}()
+
+	return u.repo.GetRedemptions(ctx, tx, code)
+}
+
+func generateCode(prefix string) (string, error) {
+	b := make([]byte, codeBytes)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate coupon code: %w", err)
+	}
+
+	return prefix + base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString(b), nil
+}
@@ -17,10 +17,22 @@ type user interface {
 
 type inventory interface {
 	BeginTx(ctx context.Context) (pgx.Tx, error)
-	GetInventoryItem(ctx context.Context, tx pgx.Tx, userID, itemType string) (int64, error)
+	GetInventoryItem(ctx context.Context, tx pgx.Tx, userID, itemType, variant string) (int64, error)
 	GetUserInventory(ctx context.Context, tx pgx.Tx, userID string) ([]models.InventoryItem, error)
 }
 
 type transaction interface {
-	GetUserTransactions(ctx context.Context, tx pgx.Tx, userID string) ([]models.TransactionItem, error)
+	GetUserTransactions(ctx context.Context, tx pgx.Tx, userID, category string) ([]models.TransactionItem, error)
+}
+
+type order interface {
+	GetUserOrders(ctx context.Context, tx pgx.Tx, userID string, limit, offset int64) ([]models.Order, int64, error)
+}
+
+type itemTransfer interface {
+	GetUserItemTransfers(ctx context.Context, tx pgx.Tx, userID string) ([]models.ItemTransfer, error)
+}
+
+type wishlist interface {
+	GetWishlist(ctx context.Context, tx pgx.Tx, userID string) ([]models.WishlistEntry, error)
 }
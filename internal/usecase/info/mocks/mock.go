@@ -105,18 +105,18 @@ func (mr *MockinventoryMockRecorder) BeginTx(ctx interface{}) *gomock.Call {
 }
 
 // GetInventoryItem mocks base method.
-func (m *Mockinventory) GetInventoryItem(ctx context.Context, tx pgx.Tx, userID, itemType string) (int64, error) {
+func (m *Mockinventory) GetInventoryItem(ctx context.Context, tx pgx.Tx, userID, itemType, variant string) (int64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetInventoryItem", ctx, tx, userID, itemType)
+	ret := m.ctrl.Call(m, "GetInventoryItem", ctx, tx, userID, itemType, variant)
 	ret0, _ := ret[0].(int64)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetInventoryItem indicates an expected call of GetInventoryItem.
-func (mr *MockinventoryMockRecorder) GetInventoryItem(ctx, tx, userID, itemType interface{}) *gomock.Call {
+func (mr *MockinventoryMockRecorder) GetInventoryItem(ctx, tx, userID, itemType, variant interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInventoryItem", reflect.TypeOf((*Mockinventory)(nil).GetInventoryItem), ctx, tx, userID, itemType)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInventoryItem", reflect.TypeOf((*Mockinventory)(nil).GetInventoryItem), ctx, tx, userID, itemType, variant)
 }
 
 // GetUserInventory mocks base method.
@@ -158,16 +158,131 @@ func (m *Mocktransaction) EXPECT() *MocktransactionMockRecorder {
 }
 
 // GetUserTransactions mocks base method.
-func (m *Mocktransaction) GetUserTransactions(ctx context.Context, tx pgx.Tx, userID string) ([]models.TransactionItem, error) {
+func (m *Mocktransaction) GetUserTransactions(ctx context.Context, tx pgx.Tx, userID, category string) ([]models.TransactionItem, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetUserTransactions", ctx, tx, userID)
+	ret := m.ctrl.Call(m, "GetUserTransactions", ctx, tx, userID, category)
 	ret0, _ := ret[0].([]models.TransactionItem)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetUserTransactions indicates an expected call of GetUserTransactions.
-func (mr *MocktransactionMockRecorder) GetUserTransactions(ctx, tx, userID interface{}) *gomock.Call {
+func (mr *MocktransactionMockRecorder) GetUserTransactions(ctx, tx, userID, category interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserTransactions", reflect.TypeOf((*Mocktransaction)(nil).GetUserTransactions), ctx, tx, userID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserTransactions", reflect.TypeOf((*Mocktransaction)(nil).GetUserTransactions), ctx, tx, userID, category)
+}
+
+// Mockorder is a mock of order interface.
+type Mockorder struct {
+	ctrl     *gomock.Controller
+	recorder *MockorderMockRecorder
+}
+
+// MockorderMockRecorder is the mock recorder for Mockorder.
+type MockorderMockRecorder struct {
+	mock *Mockorder
+}
+
+// NewMockorder creates a new mock instance.
+func NewMockorder(ctrl *gomock.Controller) *Mockorder {
+	mock := &Mockorder{ctrl: ctrl}
+	mock.recorder = &MockorderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mockorder) EXPECT() *MockorderMockRecorder {
+	return m.recorder
+}
+
+// GetUserOrders mocks base method.
+func (m *Mockorder) GetUserOrders(ctx context.Context, tx pgx.Tx, userID string, limit, offset int64) ([]models.Order, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserOrders", ctx, tx, userID, limit, offset)
+	ret0, _ := ret[0].([]models.Order)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetUserOrders indicates an expected call of GetUserOrders.
+func (mr *MockorderMockRecorder) GetUserOrders(ctx, tx, userID, limit, offset interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserOrders", reflect.TypeOf((*Mockorder)(nil).GetUserOrders), ctx, tx, userID, limit, offset)
+}
+
+// MockitemTransfer is a mock of itemTransfer interface.
+type MockitemTransfer struct {
+	ctrl     *gomock.Controller
+	recorder *MockitemTransferMockRecorder
+}
+
+// MockitemTransferMockRecorder is the mock recorder for MockitemTransfer.
+type MockitemTransferMockRecorder struct {
+	mock *MockitemTransfer
+}
+
+// NewMockitemTransfer creates a new mock instance.
+func NewMockitemTransfer(ctrl *gomock.Controller) *MockitemTransfer {
+	mock := &MockitemTransfer{ctrl: ctrl}
+	mock.recorder = &MockitemTransferMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockitemTransfer) EXPECT() *MockitemTransferMockRecorder {
+	return m.recorder
+}
+
+// GetUserItemTransfers mocks base method.
+func (m *MockitemTransfer) GetUserItemTransfers(ctx context.Context, tx pgx.Tx, userID string) ([]models.ItemTransfer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserItemTransfers", ctx, tx, userID)
+	ret0, _ := ret[0].([]models.ItemTransfer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserItemTransfers indicates an expected call of GetUserItemTransfers.
+func (mr *MockitemTransferMockRecorder) GetUserItemTransfers(ctx, tx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserItemTransfers", reflect.TypeOf((*MockitemTransfer)(nil).GetUserItemTransfers), ctx, tx, userID)
+}
+
+// Mockwishlist is a mock of wishlist interface.
+type Mockwishlist struct {
+	ctrl     *gomock.Controller
+	recorder *MockwishlistMockRecorder
+}
+
+// MockwishlistMockRecorder is the mock recorder for Mockwishlist.
+type MockwishlistMockRecorder struct {
+	mock *Mockwishlist
+}
+
+// NewMockwishlist creates a new mock instance.
+func NewMockwishlist(ctrl *gomock.Controller) *Mockwishlist {
+	mock := &Mockwishlist{ctrl: ctrl}
+	mock.recorder = &MockwishlistMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mockwishlist) EXPECT() *MockwishlistMockRecorder {
+	return m.recorder
+}
+
+// GetWishlist mocks base method.
+func (m *Mockwishlist) GetWishlist(ctx context.Context, tx pgx.Tx, userID string) ([]models.WishlistEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWishlist", ctx, tx, userID)
+	ret0, _ := ret[0].([]models.WishlistEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWishlist indicates an expected call of GetWishlist.
+func (mr *MockwishlistMockRecorder) GetWishlist(ctx, tx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWishlist", reflect.TypeOf((*Mockwishlist)(nil).GetWishlist), ctx, tx, userID)
 }
@@ -13,19 +13,27 @@ type Usecase struct {
 	repoUser        user
 	repoInfo        inventory
 	repoTransaction transaction
+	repoOrder       order
+	repoItem        itemTransfer
+	repoWishlist    wishlist
 	TX              func(ctx context.Context) (pgx.Tx, error)
 }
 
-func New(repoUser user, repo inventory, t transaction) *Usecase {
+func New(repoUser user, repo inventory, t transaction, o order, it itemTransfer, w wishlist) *Usecase {
 	return &Usecase{
 		repoUser:        repoUser,
 		repoInfo:        repo,
 		repoTransaction: t,
+		repoOrder:       o,
+		repoItem:        it,
+		repoWishlist:    w,
 		TX:              repo.BeginTx,
 	}
 }
 
-func (uc *Usecase) GetInfo(ctx context.Context, userID string) (username string, res models.InfoResponse, err error) {
+// GetInfo - баланс, инвентарь и истории пользователя; непустая category оставляет в истории
+// переводов только переводы этой категории
+func (uc *Usecase) GetInfo(ctx context.Context, userID, category string) (username string, res models.InfoResponse, err error) {
 	var tx pgx.Tx
 	tx, err = uc.TX(ctx)
 	if err != nil {
@@ -53,23 +61,34 @@ func (uc *Usecase) GetInfo(ctx context.Context, userID string) (username string,
 	for _, it := range items {
 		res.Inventory = append(res.Inventory, models.InventoryItem{
 			ItemType: it.ItemType,
+			Variant:  it.Variant,
 			Quantity: it.Quantity,
 		})
 	}
 
-	txs, err := uc.repoTransaction.GetUserTransactions(ctx, tx, userID)
+	txs, err := uc.repoTransaction.GetUserTransactions(ctx, tx, userID, category)
 	if err != nil {
 		return "", res, err
 	}
-	for _, t := range txs {
-		res.Transactions = append(res.Transactions, models.TransactionItem{
-			FromUserID:   t.FromUserID,
-			ToUserID:     t.ToUserID,
-			Amount:       t.Amount,
-			CreatedAt:    t.CreatedAt,
-			FromUsername: t.FromUsername,
-			ToUserName:   t.ToUserName,
-		})
+	res.Transactions = append(res.Transactions, txs...)
+
+	orders, _, err := uc.repoOrder.GetUserOrders(ctx, tx, userID, 0, 0)
+	if err != nil {
+		return "", res, err
+	}
+	res.Orders = orders
+
+	res.ItemHistory, err = uc.repoItem.GetUserItemTransfers(ctx, tx, userID)
+	if err != nil {
+		return "", res, err
+	}
+
+	wished, err := uc.repoWishlist.GetWishlist(ctx, tx, userID)
+	if err != nil {
+		return "", res, err
+	}
+	for _, e := range wished {
+		res.Wishlist = append(res.Wishlist, e.WithBalance(res.Coins))
 	}
 
 	return userFrom.Username, res, nil
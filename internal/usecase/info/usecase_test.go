@@ -29,9 +29,12 @@ func TestGetInfo_Success(t *testing.T) {
 	mockUser := mocks.NewMockuser(ctrl)
 	mockInventory := mocks.NewMockinventory(ctrl)
 	mockTransaction := mocks.NewMocktransaction(ctrl)
+	mockOrder := mocks.NewMockorder(ctrl)
+	mockItem := mocks.NewMockitemTransfer(ctrl)
+	mockWishlist := mocks.NewMockwishlist(ctrl)
 	mockTx := mocks.NewMockTx(ctrl)
 
-	uc := info.New(mockUser, mockInventory, mockTransaction)
+	uc := info.New(mockUser, mockInventory, mockTransaction, mockOrder, mockItem, mockWishlist)
 	uc.TX = func(ctx context.Context) (pgx.Tx, error) {
 		return mockTx, nil
 	}
@@ -39,16 +42,28 @@ func TestGetInfo_Success(t *testing.T) {
 	expectedCoins := int64(100)
 	expectedInventory := []models.InventoryItem{
 		{ItemType: "sword", Quantity: 1},
-		{ItemType: "shield", Quantity: 2},
+		{ItemType: "shield", Variant: "shield-red", Quantity: 2},
 	}
 	expectedTransactions := []models.TransactionItem{
 		{
 			FromUserID: "user123",
 			ToUserID:   "user456",
 			Amount:     50,
+			Message:    "thanks for the review",
+			Category:   models.TransferThanks,
 			CreatedAt:  time.Now(),
 		},
 	}
+	expectedOrders := []models.Order{
+		{ID: "order-1", Item: "sword", Quantity: 1, UnitPrice: 80, Total: 80},
+	}
+	expectedGifts := []models.ItemTransfer{
+		{FromUserID: "user456", ToUserID: "user123", ItemType: "shield", Quantity: 1},
+	}
+	wished := []models.WishlistEntry{
+		{ItemID: "item-1", Item: "pink-hoody", Price: 500, Available: true},
+		{ItemID: "item-2", Item: "cup", Price: 20, Available: true},
+	}
 
 	mockUser.
 		EXPECT().
@@ -60,15 +75,27 @@ func TestGetInfo_Success(t *testing.T) {
 		Return(expectedInventory, nil)
 	mockTransaction.
 		EXPECT().
-		GetUserTransactions(ctx, mockTx, userID).
+		GetUserTransactions(ctx, mockTx, userID, models.TransferThanks).
 		Return(expectedTransactions, nil)
+	mockOrder.
+		EXPECT().
+		GetUserOrders(ctx, mockTx, userID, int64(0), int64(0)).
+		Return(expectedOrders, int64(len(expectedOrders)), nil)
+	mockItem.
+		EXPECT().
+		GetUserItemTransfers(ctx, mockTx, userID).
+		Return(expectedGifts, nil)
+	mockWishlist.
+		EXPECT().
+		GetWishlist(ctx, mockTx, userID).
+		Return(wished, nil)
 
 	mockTx.
 		EXPECT().
 		Commit(ctx).
 		Return(nil)
 
-	_, res, err := uc.GetInfo(ctx, userID)
+	_, res, err := uc.GetInfo(ctx, userID, models.TransferThanks)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -79,9 +106,77 @@ func TestGetInfo_Success(t *testing.T) {
 	if len(res.Inventory) != len(expectedInventory) {
 		t.Errorf("expected inventory length %d, got %d", len(expectedInventory), len(res.Inventory))
 	}
+	if len(res.Inventory) == len(expectedInventory) && res.Inventory[1].Variant != "shield-red" {
+		t.Errorf("expected variant shield-red, got %q", res.Inventory[1].Variant)
+	}
 	if len(res.Transactions) != len(expectedTransactions) {
 		t.Errorf("expected transactions length %d, got %d", len(expectedTransactions), len(res.Transactions))
 	}
+	if len(res.Transactions) == 1 && (res.Transactions[0].Message != "thanks for the review" ||
+		res.Transactions[0].Category != models.TransferThanks) {
+		t.Errorf("unexpected transaction %+v", res.Transactions[0])
+	}
+	if len(res.Orders) != len(expectedOrders) {
+		t.Errorf("expected orders length %d, got %d", len(expectedOrders), len(res.Orders))
+	}
+	if len(res.ItemHistory) != len(expectedGifts) {
+		t.Errorf("expected item history length %d, got %d", len(expectedGifts), len(res.ItemHistory))
+	}
+	if len(res.Wishlist) != 2 || res.Wishlist[0].MissingCoins != 400 || res.Wishlist[1].MissingCoins != 0 {
+		t.Errorf("unexpected wishlist %+v", res.Wishlist)
+	}
+}
+
+func TestGetInfo_KeepsTransferState(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	userID := "user123"
+
+	mockUser := mocks.NewMockuser(ctrl)
+	mockInventory := mocks.NewMockinventory(ctrl)
+	mockTransaction := mocks.NewMocktransaction(ctrl)
+	mockOrder := mocks.NewMockorder(ctrl)
+	mockItem := mocks.NewMockitemTransfer(ctrl)
+	mockWishlist := mocks.NewMockwishlist(ctrl)
+	mockTx := mocks.NewMockTx(ctrl)
+
+	uc := info.New(mockUser, mockInventory, mockTransaction, mockOrder, mockItem, mockWishlist)
+	uc.TX = func(ctx context.Context) (pgx.Tx, error) {
+		return mockTx, nil
+	}
+
+	expiresAt := time.Date(2025, 3, 4, 12, 0, 0, 0, time.UTC)
+	pending := models.TransactionItem{
+		ID:         "tx-1",
+		FromUserID: "user456",
+		ToUserID:   userID,
+		Amount:     30,
+		Status:     models.TransferPending,
+		ExpiresAt:  &expiresAt,
+	}
+
+	mockUser.EXPECT().GetUserById(ctx, mockTx, userID).Return(models.User{ID: userID, Username: userID}, nil)
+	mockInventory.EXPECT().GetUserInventory(ctx, mockTx, userID).Return(nil, nil)
+	mockTransaction.EXPECT().GetUserTransactions(ctx, mockTx, userID, "").Return([]models.TransactionItem{pending}, nil)
+	mockOrder.EXPECT().GetUserOrders(ctx, mockTx, userID, int64(0), int64(0)).Return(nil, int64(0), nil)
+	mockItem.EXPECT().GetUserItemTransfers(ctx, mockTx, userID).Return(nil, nil)
+	mockWishlist.EXPECT().GetWishlist(ctx, mockTx, userID).Return(nil, nil)
+	mockTx.EXPECT().Commit(ctx).Return(nil)
+
+	_, res, err := uc.GetInfo(ctx, userID, "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(res.Transactions) != 1 {
+		t.Fatalf("expected 1 transaction, got %d", len(res.Transactions))
+	}
+	got := res.Transactions[0]
+	if got.ID != "tx-1" || got.Status != models.TransferPending || got.ExpiresAt == nil || !got.ExpiresAt.Equal(expiresAt) {
+		t.Errorf("unexpected transaction %+v", got)
+	}
 }
 
 func TestGetInfo_TXError(t *testing.T) {
@@ -94,14 +189,17 @@ func TestGetInfo_TXError(t *testing.T) {
 	mockUser := mocks.NewMockuser(ctrl)
 	mockInventory := mocks.NewMockinventory(ctrl)
 	mockTransaction := mocks.NewMocktransaction(ctrl)
+	mockOrder := mocks.NewMockorder(ctrl)
+	mockItem := mocks.NewMockitemTransfer(ctrl)
+	mockWishlist := mocks.NewMockwishlist(ctrl)
 
-	uc := info.New(mockUser, mockInventory, mockTransaction)
+	uc := info.New(mockUser, mockInventory, mockTransaction, mockOrder, mockItem, mockWishlist)
 	expectedErr := errors.New("begin tx error")
 	uc.TX = func(ctx context.Context) (pgx.Tx, error) {
 		return nil, expectedErr
 	}
 
-	_, _, err := uc.GetInfo(ctx, userID)
+	_, _, err := uc.GetInfo(ctx, userID, "")
 	if err == nil {
 		t.Fatalf("expected error, got nil")
 	}
@@ -125,9 +223,12 @@ func TestGetInfo_GetUserCoinsError(t *testing.T) {
 	mockUser := mocks.NewMockuser(ctrl)
 	mockInventory := mocks.NewMockinventory(ctrl)
 	mockTransaction := mocks.NewMocktransaction(ctrl)
+	mockOrder := mocks.NewMockorder(ctrl)
+	mockItem := mocks.NewMockitemTransfer(ctrl)
+	mockWishlist := mocks.NewMockwishlist(ctrl)
 	mockTx := mocks.NewMockTx(ctrl)
 
-	uc := info.New(mockUser, mockInventory, mockTransaction)
+	uc := info.New(mockUser, mockInventory, mockTransaction, mockOrder, mockItem, mockWishlist)
 	uc.TX = func(ctx context.Context) (pgx.Tx, error) {
 		return mockTx, nil
 	}
@@ -141,7 +242,7 @@ func TestGetInfo_GetUserCoinsError(t *testing.T) {
 		Rollback(ctx).
 		Return(nil)
 
-	_, _, err := uc.GetInfo(ctx, userID)
+	_, _, err := uc.GetInfo(ctx, userID, "")
 	if err == nil {
 		t.Fatalf("expected error, got nil")
 	}
@@ -165,9 +266,12 @@ func TestGetInfo_GetUserInventoryError(t *testing.T) {
 	mockUser := mocks.NewMockuser(ctrl)
 	mockInventory := mocks.NewMockinventory(ctrl)
 	mockTransaction := mocks.NewMocktransaction(ctrl)
+	mockOrder := mocks.NewMockorder(ctrl)
+	mockItem := mocks.NewMockitemTransfer(ctrl)
+	mockWishlist := mocks.NewMockwishlist(ctrl)
 	mockTx := mocks.NewMockTx(ctrl)
 
-	uc := info.New(mockUser, mockInventory, mockTransaction)
+	uc := info.New(mockUser, mockInventory, mockTransaction, mockOrder, mockItem, mockWishlist)
 	uc.TX = func(ctx context.Context) (pgx.Tx, error) {
 		return mockTx, nil
 	}
@@ -189,7 +293,7 @@ func TestGetInfo_GetUserInventoryError(t *testing.T) {
 		Rollback(ctx).
 		Return(nil)
 
-	_, res, err := uc.GetInfo(ctx, userID)
+	_, res, err := uc.GetInfo(ctx, userID, "")
 	if err == nil {
 		t.Fatalf("expected error, got nil")
 	}
@@ -219,9 +323,12 @@ func TestGetInfo_GetUserTransactionsError(t *testing.T) {
 	mockUser := mocks.NewMockuser(ctrl)
 	mockInventory := mocks.NewMockinventory(ctrl)
 	mockTransaction := mocks.NewMocktransaction(ctrl)
+	mockOrder := mocks.NewMockorder(ctrl)
+	mockItem := mocks.NewMockitemTransfer(ctrl)
+	mockWishlist := mocks.NewMockwishlist(ctrl)
 	mockTx := mocks.NewMockTx(ctrl)
 
-	uc := info.New(mockUser, mockInventory, mockTransaction)
+	uc := info.New(mockUser, mockInventory, mockTransaction, mockOrder, mockItem, mockWishlist)
 	uc.TX = func(ctx context.Context) (pgx.Tx, error) {
 		return mockTx, nil
 	}
@@ -242,14 +349,14 @@ func TestGetInfo_GetUserTransactionsError(t *testing.T) {
 		Return(expectedInventory, nil)
 	mockTransaction.
 		EXPECT().
-		GetUserTransactions(ctx, mockTx, userID).
+		GetUserTransactions(ctx, mockTx, userID, "").
 		Return(nil, expectedErr)
 	mockTx.
 		EXPECT().
 		Rollback(ctx).
 		Return(nil)
 
-	_, res, err := uc.GetInfo(ctx, userID)
+	_, res, err := uc.GetInfo(ctx, userID, "")
 	if err == nil {
 		t.Fatalf("expected error, got nil")
 	}
@@ -0,0 +1,17 @@
+//go:generate mockgen -source=contract.go -destination=mocks/mock.go -package=mocks $GOPACKAGE
+//go:generate mockgen -destination=mocks/mock_tx.go -package=mocks github.com/jackc/pgx/v5 Tx
+package ledger
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+
+	"AvitoTask/internal/models"
+)
+
+type ledger interface {
+	BeginTx(ctx context.Context) (pgx.Tx, error)
+	GetAccountBalance(ctx context.Context, tx pgx.Tx, account string) (int64, error)
+	GetBalanceMismatches(ctx context.Context, tx pgx.Tx) ([]models.BalanceMismatch, error)
+}
@@ -0,0 +1,42 @@
+package ledger_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+
+	"AvitoTask/internal/models"
+	"AvitoTask/internal/usecase/ledger"
+	"AvitoTask/internal/usecase/ledger/mocks"
+)
+
+func TestReconcile_ReportsMismatches(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockLedger := mocks.NewMockledger(ctrl)
+	mockTx := mocks.NewMockTx(ctrl)
+
+	mismatch := models.BalanceMismatch{UserID: "user123", Username: "alice", Coins: 900, Ledger: 1000}
+
+	mockLedger.EXPECT().BeginTx(ctx).Return(mockTx, nil)
+	mockLedger.EXPECT().GetAccountBalance(ctx, mockTx, models.AccountShop).Return(int64(300), nil)
+	mockLedger.EXPECT().GetAccountBalance(ctx, mockTx, models.AccountIssuance).Return(int64(-2000), nil)
+	mockLedger.EXPECT().GetAccountBalance(ctx, mockTx, models.AccountEscrow).Return(int64(40), nil)
+	mockLedger.EXPECT().GetBalanceMismatches(ctx, mockTx).Return([]models.BalanceMismatch{mismatch}, nil)
+	mockTx.EXPECT().Commit(ctx).Return(nil)
+
+	uc := ledger.NewUsecase(mockLedger)
+	report, err := uc.Reconcile(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if report.Shop != 300 || report.Issuance != -2000 || report.Escrow != 40 {
+		t.Errorf("unexpected system balances %+v", report)
+	}
+	if len(report.Mismatches) != 1 || report.Mismatches[0] != mismatch {
+		t.Errorf("unexpected mismatches %+v", report.Mismatches)
+	}
+}
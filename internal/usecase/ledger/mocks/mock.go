@@ -0,0 +1,82 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: contract.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	models "AvitoTask/internal/models"
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	pgx "github.com/jackc/pgx/v5"
+)
+
+// Mockledger is a mock of ledger interface.
+type Mockledger struct {
+	ctrl     *gomock.Controller
+	recorder *MockledgerMockRecorder
+}
+
+// MockledgerMockRecorder is the mock recorder for Mockledger.
+type MockledgerMockRecorder struct {
+	mock *Mockledger
+}
+
+// NewMockledger creates a new mock instance.
+func NewMockledger(ctrl *gomock.Controller) *Mockledger {
+	mock := &Mockledger{ctrl: ctrl}
+	mock.recorder = &MockledgerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mockledger) EXPECT() *MockledgerMockRecorder {
+	return m.recorder
+}
+
+// BeginTx mocks base method.
+func (m *Mockledger) BeginTx(ctx context.Context) (pgx.Tx, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BeginTx", ctx)
+	ret0, _ := ret[0].(pgx.Tx)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BeginTx indicates an expected call of BeginTx.
+func (mr *MockledgerMockRecorder) BeginTx(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BeginTx", reflect.TypeOf((*Mockledger)(nil).BeginTx), ctx)
+}
+
+// GetAccountBalance mocks base method.
+func (m *Mockledger) GetAccountBalance(ctx context.Context, tx pgx.Tx, account string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAccountBalance", ctx, tx, account)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAccountBalance indicates an expected call of GetAccountBalance.
+func (mr *MockledgerMockRecorder) GetAccountBalance(ctx, tx, account interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccountBalance", reflect.TypeOf((*Mockledger)(nil).GetAccountBalance), ctx, tx, account)
+}
+
+// GetBalanceMismatches mocks base method.
+func (m *Mockledger) GetBalanceMismatches(ctx context.Context, tx pgx.Tx) ([]models.BalanceMismatch, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBalanceMismatches", ctx, tx)
+	ret0, _ := ret[0].([]models.BalanceMismatch)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBalanceMismatches indicates an expected call of GetBalanceMismatches.
+func (mr *MockledgerMockRecorder) GetBalanceMismatches(ctx, tx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBalanceMismatches", reflect.TypeOf((*Mockledger)(nil).GetBalanceMismatches), ctx, tx)
+}
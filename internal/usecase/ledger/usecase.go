@@ -0,0 +1,52 @@
+package ledger
+
+import (
+	"context"
+	"fmt"
+
+	"AvitoTask/internal/models"
+)
+
+type Usecase struct {
+	repo ledger
+}
+
+func NewUsecase(l ledger) *Usecase {
+	return &Usecase{
+		repo: l,
+	}
+}
+
+// Reconcile - сверяет сохранённые балансы пользователей с журналом. Сумма всех счетов
+// журнала всегда нулевая, поэтому монеты пользователей плюс магазин и эскроу равны минус эмиссии
+func (u *Usecase) Reconcile(ctx context.Context) (report models.LedgerReport, err error) {
+	tx, err := u.repo.BeginTx(ctx)
+	if err != nil {
+		return report, fmt.Errorf("failed to begin tx: %w", err)
+	}
+
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback(ctx)
+		} else {
+			err = tx.Commit(ctx)
+		}
+	}()
+
+	if report.Shop, err = u.repo.GetAccountBalance(ctx, tx, models.AccountShop); err != nil {
+		return report, err
+	}
+	if report.Issuance, err = u.repo.GetAccountBalance(ctx, tx, models.AccountIssuance); err != nil {
+		return report, err
+	}
+	if report.Escrow, err = u.repo.GetAccountBalance(ctx, tx, models.AccountEscrow); err != nil {
+		return report, err
+	}
+
+	report.Mismatches, err = u.repo.GetBalanceMismatches(ctx, tx)
+	if err != nil {
+		return report, err
+	}
+
+	return report, nil
+}
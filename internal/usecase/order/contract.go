@@ -0,0 +1,50 @@
+//go:generate mockgen -source=contract.go -destination=mocks/mock.go -package=mocks $GOPACKAGE
+//go:generate mockgen -destination=mocks/mock_tx.go -package=mocks github.com/jackc/pgx/v5 Tx
+package order
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+
+	"AvitoTask/internal/models"
+)
+
+type order interface {
+	BeginTx(ctx context.Context) (pgx.Tx, error)
+	GetUserOrders(ctx context.Context, tx pgx.Tx, userID string, limit, offset int64) ([]models.Order, int64, error)
+	LockOrder(ctx context.Context, tx pgx.Tx, orderID string) (models.Order, error)
+	HasRefund(ctx context.Context, tx pgx.Tx, orderID string) (bool, error)
+	InsertOrder(ctx context.Context, tx pgx.Tx, o models.Order) error
+	UpdateOrderStatus(ctx context.Context, tx pgx.Tx, orderID, status string) error
+	InsertStatusChange(ctx context.Context, tx pgx.Tx, c models.OrderStatusChange) error
+	GetOrdersByStatus(ctx context.Context, tx pgx.Tx, status string, limit, offset int64) ([]models.Order, int64, error)
+	CountCouponPurchases(ctx context.Context, tx pgx.Tx, userID, code, exceptOrderID string) (int64, error)
+}
+
+type user interface {
+	CreditUserCoins(ctx context.Context, tx pgx.Tx, userID string, amount int64) error
+}
+
+type inventory interface {
+	TakeInventoryItem(ctx context.Context, tx pgx.Tx, userID, itemType, variant string, quantity int64) error
+}
+
+type bundle interface {
+	GetBundleItems(ctx context.Context, tx pgx.Tx, bundleID string) ([]models.BundleItem, error)
+}
+
+type ledger interface {
+	PostEntry(ctx context.Context, tx pgx.Tx, e models.LedgerEntry) error
+}
+
+type coupon interface {
+	LockCoupon(ctx context.Context, tx pgx.Tx, code string) (models.Coupon, error)
+	ReleaseRedemption(ctx context.Context, tx pgx.Tx, code, userID string) error
+}
+
+type catalog interface {
+	ReturnStock(ctx context.Context, tx pgx.Tx, itemID string, quantity int64) (bool, error)
+	ReturnVariantStock(ctx context.Context, tx pgx.Tx, sku string, quantity int64) (bool, error)
+	InsertStockMovement(ctx context.Context, tx pgx.Tx, m models.StockMovement) error
+}
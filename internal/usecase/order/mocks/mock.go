@@ -0,0 +1,439 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: contract.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	models "AvitoTask/internal/models"
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	pgx "github.com/jackc/pgx/v5"
+)
+
+// Mockorder is a mock of order interface.
+type Mockorder struct {
+	ctrl     *gomock.Controller
+	recorder *MockorderMockRecorder
+}
+
+// MockorderMockRecorder is the mock recorder for Mockorder.
+type MockorderMockRecorder struct {
+	mock *Mockorder
+}
+
+// NewMockorder creates a new mock instance.
+func NewMockorder(ctrl *gomock.Controller) *Mockorder {
+	mock := &Mockorder{ctrl: ctrl}
+	mock.recorder = &MockorderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mockorder) EXPECT() *MockorderMockRecorder {
+	return m.recorder
+}
+
+// BeginTx mocks base method.
+func (m *Mockorder) BeginTx(ctx context.Context) (pgx.Tx, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BeginTx", ctx)
+	ret0, _ := ret[0].(pgx.Tx)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BeginTx indicates an expected call of BeginTx.
+func (mr *MockorderMockRecorder) BeginTx(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BeginTx", reflect.TypeOf((*Mockorder)(nil).BeginTx), ctx)
+}
+
+// CountCouponPurchases mocks base method.
+func (m *Mockorder) CountCouponPurchases(ctx context.Context, tx pgx.Tx, userID, code, exceptOrderID string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountCouponPurchases", ctx, tx, userID, code, exceptOrderID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountCouponPurchases indicates an expected call of CountCouponPurchases.
+func (mr *MockorderMockRecorder) CountCouponPurchases(ctx, tx, userID, code, exceptOrderID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountCouponPurchases", reflect.TypeOf((*Mockorder)(nil).CountCouponPurchases), ctx, tx, userID, code, exceptOrderID)
+}
+
+// GetOrdersByStatus mocks base method.
+func (m *Mockorder) GetOrdersByStatus(ctx context.Context, tx pgx.Tx, status string, limit, offset int64) ([]models.Order, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrdersByStatus", ctx, tx, status, limit, offset)
+	ret0, _ := ret[0].([]models.Order)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetOrdersByStatus indicates an expected call of GetOrdersByStatus.
+func (mr *MockorderMockRecorder) GetOrdersByStatus(ctx, tx, status, limit, offset interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrdersByStatus", reflect.TypeOf((*Mockorder)(nil).GetOrdersByStatus), ctx, tx, status, limit, offset)
+}
+
+// GetUserOrders mocks base method.
+func (m *Mockorder) GetUserOrders(ctx context.Context, tx pgx.Tx, userID string, limit, offset int64) ([]models.Order, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserOrders", ctx, tx, userID, limit, offset)
+	ret0, _ := ret[0].([]models.Order)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetUserOrders indicates an expected call of GetUserOrders.
+func (mr *MockorderMockRecorder) GetUserOrders(ctx, tx, userID, limit, offset interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserOrders", reflect.TypeOf((*Mockorder)(nil).GetUserOrders), ctx, tx, userID, limit, offset)
+}
+
+// HasRefund mocks base method.
+func (m *Mockorder) HasRefund(ctx context.Context, tx pgx.Tx, orderID string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HasRefund", ctx, tx, orderID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HasRefund indicates an expected call of HasRefund.
+func (mr *MockorderMockRecorder) HasRefund(ctx, tx, orderID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HasRefund", reflect.TypeOf((*Mockorder)(nil).HasRefund), ctx, tx, orderID)
+}
+
+// InsertOrder mocks base method.
+func (m *Mockorder) InsertOrder(ctx context.Context, tx pgx.Tx, o models.Order) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertOrder", ctx, tx, o)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InsertOrder indicates an expected call of InsertOrder.
+func (mr *MockorderMockRecorder) InsertOrder(ctx, tx, o interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertOrder", reflect.TypeOf((*Mockorder)(nil).InsertOrder), ctx, tx, o)
+}
+
+// InsertStatusChange mocks base method.
+func (m *Mockorder) InsertStatusChange(ctx context.Context, tx pgx.Tx, c models.OrderStatusChange) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertStatusChange", ctx, tx, c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InsertStatusChange indicates an expected call of InsertStatusChange.
+func (mr *MockorderMockRecorder) InsertStatusChange(ctx, tx, c interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertStatusChange", reflect.TypeOf((*Mockorder)(nil).InsertStatusChange), ctx, tx, c)
+}
+
+// LockOrder mocks base method.
+func (m *Mockorder) LockOrder(ctx context.Context, tx pgx.Tx, orderID string) (models.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LockOrder", ctx, tx, orderID)
+	ret0, _ := ret[0].(models.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LockOrder indicates an expected call of LockOrder.
+func (mr *MockorderMockRecorder) LockOrder(ctx, tx, orderID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LockOrder", reflect.TypeOf((*Mockorder)(nil).LockOrder), ctx, tx, orderID)
+}
+
+// UpdateOrderStatus mocks base method.
+func (m *Mockorder) UpdateOrderStatus(ctx context.Context, tx pgx.Tx, orderID, status string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateOrderStatus", ctx, tx, orderID, status)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateOrderStatus indicates an expected call of UpdateOrderStatus.
+func (mr *MockorderMockRecorder) UpdateOrderStatus(ctx, tx, orderID, status interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateOrderStatus", reflect.TypeOf((*Mockorder)(nil).UpdateOrderStatus), ctx, tx, orderID, status)
+}
+
+// Mockuser is a mock of user interface.
+type Mockuser struct {
+	ctrl     *gomock.Controller
+	recorder *MockuserMockRecorder
+}
+
+// MockuserMockRecorder is the mock recorder for Mockuser.
+type MockuserMockRecorder struct {
+	mock *Mockuser
+}
+
+// NewMockuser creates a new mock instance.
+func NewMockuser(ctrl *gomock.Controller) *Mockuser {
+	mock := &Mockuser{ctrl: ctrl}
+	mock.recorder = &MockuserMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mockuser) EXPECT() *MockuserMockRecorder {
+	return m.recorder
+}
+
+// CreditUserCoins mocks base method.
+func (m *Mockuser) CreditUserCoins(ctx context.Context, tx pgx.Tx, userID string, amount int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreditUserCoins", ctx, tx, userID, amount)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreditUserCoins indicates an expected call of CreditUserCoins.
+func (mr *MockuserMockRecorder) CreditUserCoins(ctx, tx, userID, amount interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreditUserCoins", reflect.TypeOf((*Mockuser)(nil).CreditUserCoins), ctx, tx, userID, amount)
+}
+
+// Mockinventory is a mock of inventory interface.
+type Mockinventory struct {
+	ctrl     *gomock.Controller
+	recorder *MockinventoryMockRecorder
+}
+
+// MockinventoryMockRecorder is the mock recorder for Mockinventory.
+type MockinventoryMockRecorder struct {
+	mock *Mockinventory
+}
+
+// NewMockinventory creates a new mock instance.
+func NewMockinventory(ctrl *gomock.Controller) *Mockinventory {
+	mock := &Mockinventory{ctrl: ctrl}
+	mock.recorder = &MockinventoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mockinventory) EXPECT() *MockinventoryMockRecorder {
+	return m.recorder
+}
+
+// TakeInventoryItem mocks base method.
+func (m *Mockinventory) TakeInventoryItem(ctx context.Context, tx pgx.Tx, userID, itemType, variant string, quantity int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TakeInventoryItem", ctx, tx, userID, itemType, variant, quantity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// TakeInventoryItem indicates an expected call of TakeInventoryItem.
+func (mr *MockinventoryMockRecorder) TakeInventoryItem(ctx, tx, userID, itemType, variant, quantity interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TakeInventoryItem", reflect.TypeOf((*Mockinventory)(nil).TakeInventoryItem), ctx, tx, userID, itemType, variant, quantity)
+}
+
+// Mockbundle is a mock of bundle interface.
+type Mockbundle struct {
+	ctrl     *gomock.Controller
+	recorder *MockbundleMockRecorder
+}
+
+// MockbundleMockRecorder is the mock recorder for Mockbundle.
+type MockbundleMockRecorder struct {
+	mock *Mockbundle
+}
+
+// NewMockbundle creates a new mock instance.
+func NewMockbundle(ctrl *gomock.Controller) *Mockbundle {
+	mock := &Mockbundle{ctrl: ctrl}
+	mock.recorder = &MockbundleMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mockbundle) EXPECT() *MockbundleMockRecorder {
+	return m.recorder
+}
+
+// GetBundleItems mocks base method.
+func (m *Mockbundle) GetBundleItems(ctx context.Context, tx pgx.Tx, bundleID string) ([]models.BundleItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBundleItems", ctx, tx, bundleID)
+	ret0, _ := ret[0].([]models.BundleItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBundleItems indicates an expected call of GetBundleItems.
+func (mr *MockbundleMockRecorder) GetBundleItems(ctx, tx, bundleID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBundleItems", reflect.TypeOf((*Mockbundle)(nil).GetBundleItems), ctx, tx, bundleID)
+}
+
+// Mockledger is a mock of ledger interface.
+type Mockledger struct {
+	ctrl     *gomock.Controller
+	recorder *MockledgerMockRecorder
+}
+
+// MockledgerMockRecorder is the mock recorder for Mockledger.
+type MockledgerMockRecorder struct {
+	mock *Mockledger
+}
+
+// NewMockledger creates a new mock instance.
+func NewMockledger(ctrl *gomock.Controller) *Mockledger {
+	mock := &Mockledger{ctrl: ctrl}
+	mock.recorder = &MockledgerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mockledger) EXPECT() *MockledgerMockRecorder {
+	return m.recorder
+}
+
+// PostEntry mocks base method.
+func (m *Mockledger) PostEntry(ctx context.Context, tx pgx.Tx, e models.LedgerEntry) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PostEntry", ctx, tx, e)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PostEntry indicates an expected call of PostEntry.
+func (mr *MockledgerMockRecorder) PostEntry(ctx, tx, e interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PostEntry", reflect.TypeOf((*Mockledger)(nil).PostEntry), ctx, tx, e)
+}
+
+// Mockcoupon is a mock of coupon interface.
+type Mockcoupon struct {
+	ctrl     *gomock.Controller
+	recorder *MockcouponMockRecorder
+}
+
+// MockcouponMockRecorder is the mock recorder for Mockcoupon.
+type MockcouponMockRecorder struct {
+	mock *Mockcoupon
+}
+
+// NewMockcoupon creates a new mock instance.
+func NewMockcoupon(ctrl *gomock.Controller) *Mockcoupon {
+	mock := &Mockcoupon{ctrl: ctrl}
+	mock.recorder = &MockcouponMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mockcoupon) EXPECT() *MockcouponMockRecorder {
+	return m.recorder
+}
+
+// LockCoupon mocks base method.
+func (m *Mockcoupon) LockCoupon(ctx context.Context, tx pgx.Tx, code string) (models.Coupon, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LockCoupon", ctx, tx, code)
+	ret0, _ := ret[0].(models.Coupon)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LockCoupon indicates an expected call of LockCoupon.
+func (mr *MockcouponMockRecorder) LockCoupon(ctx, tx, code interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LockCoupon", reflect.TypeOf((*Mockcoupon)(nil).LockCoupon), ctx, tx, code)
+}
+
+// ReleaseRedemption mocks base method.
+func (m *Mockcoupon) ReleaseRedemption(ctx context.Context, tx pgx.Tx, code, userID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReleaseRedemption", ctx, tx, code, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReleaseRedemption indicates an expected call of ReleaseRedemption.
+func (mr *MockcouponMockRecorder) ReleaseRedemption(ctx, tx, code, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReleaseRedemption", reflect.TypeOf((*Mockcoupon)(nil).ReleaseRedemption), ctx, tx, code, userID)
+}
+
+// Mockcatalog is a mock of catalog interface.
+type Mockcatalog struct {
+	ctrl     *gomock.Controller
+	recorder *MockcatalogMockRecorder
+}
+
+// MockcatalogMockRecorder is the mock recorder for Mockcatalog.
+type MockcatalogMockRecorder struct {
+	mock *Mockcatalog
+}
+
+// NewMockcatalog creates a new mock instance.
+func NewMockcatalog(ctrl *gomock.Controller) *Mockcatalog {
+	mock := &Mockcatalog{ctrl: ctrl}
+	mock.recorder = &MockcatalogMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mockcatalog) EXPECT() *MockcatalogMockRecorder {
+	return m.recorder
+}
+
+// InsertStockMovement mocks base method.
+func (m_2 *Mockcatalog) InsertStockMovement(ctx context.Context, tx pgx.Tx, m models.StockMovement) error {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "InsertStockMovement", ctx, tx, m)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InsertStockMovement indicates an expected call of InsertStockMovement.
+func (mr *MockcatalogMockRecorder) InsertStockMovement(ctx, tx, m interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertStockMovement", reflect.TypeOf((*Mockcatalog)(nil).InsertStockMovement), ctx, tx, m)
+}
+
+// ReturnStock mocks base method.
+func (m *Mockcatalog) ReturnStock(ctx context.Context, tx pgx.Tx, itemID string, quantity int64) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReturnStock", ctx, tx, itemID, quantity)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReturnStock indicates an expected call of ReturnStock.
+func (mr *MockcatalogMockRecorder) ReturnStock(ctx, tx, itemID, quantity interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReturnStock", reflect.TypeOf((*Mockcatalog)(nil).ReturnStock), ctx, tx, itemID, quantity)
+}
+
+// ReturnVariantStock mocks base method.
+func (m *Mockcatalog) ReturnVariantStock(ctx context.Context, tx pgx.Tx, sku string, quantity int64) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReturnVariantStock", ctx, tx, sku, quantity)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReturnVariantStock indicates an expected call of ReturnVariantStock.
+func (mr *MockcatalogMockRecorder) ReturnVariantStock(ctx, tx, sku, quantity interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReturnVariantStock", reflect.TypeOf((*Mockcatalog)(nil).ReturnVariantStock), ctx, tx, sku, quantity)
+}
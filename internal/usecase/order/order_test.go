@@ -0,0 +1,529 @@
+package order_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/jackc/pgx/v5"
+
+	"AvitoTask/internal/models"
+	"AvitoTask/internal/usecase/order"
+	"AvitoTask/internal/usecase/order/mocks"
+)
+
+func TestListOrders_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockOrder := mocks.NewMockorder(ctrl)
+	mockUser := mocks.NewMockuser(ctrl)
+	mockInventory := mocks.NewMockinventory(ctrl)
+	mockCatalog := mocks.NewMockcatalog(ctrl)
+	mockTx := mocks.NewMockTx(ctrl)
+
+	expected := []models.Order{{ID: "order-1", Item: "cup", Quantity: 1, UnitPrice: 20, Total: 20}}
+	mockOrder.EXPECT().BeginTx(ctx).Return(mockTx, nil)
+	mockOrder.EXPECT().GetUserOrders(ctx, mockTx, "user123", int64(10), int64(20)).Return(expected, int64(21), nil)
+	mockTx.EXPECT().Commit(ctx).Return(nil)
+
+	uc := order.NewUsecase(mockOrder, mockUser, mockInventory, mockCatalog, nil, acceptLedger(ctrl), nil, time.Hour)
+	orders, total, err := uc.ListOrders(ctx, "user123", 10, 20)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if total != 21 || len(orders) != 1 {
+		t.Errorf("unexpected result: total=%d orders=%v", total, orders)
+	}
+}
+
+func TestListOrders_QueryError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockOrder := mocks.NewMockorder(ctrl)
+	mockUser := mocks.NewMockuser(ctrl)
+	mockInventory := mocks.NewMockinventory(ctrl)
+	mockCatalog := mocks.NewMockcatalog(ctrl)
+	mockTx := mocks.NewMockTx(ctrl)
+
+	queryErr := errors.New("query failed")
+	mockOrder.EXPECT().BeginTx(ctx).Return(mockTx, nil)
+	mockOrder.EXPECT().GetUserOrders(ctx, mockTx, "user123", int64(10), int64(0)).Return(nil, int64(0), queryErr)
+	mockTx.EXPECT().Rollback(ctx).Return(nil)
+
+	uc := order.NewUsecase(mockOrder, mockUser, mockInventory, mockCatalog, nil, acceptLedger(ctrl), nil, time.Hour)
+	_, _, err := uc.ListOrders(ctx, "user123", 10, 0)
+	if !errors.Is(err, queryErr) {
+		t.Errorf("expected error %v, got %v", queryErr, err)
+	}
+}
+
+func newPurchase(createdAt time.Time) models.Order {
+	return models.Order{
+		ID:        "order-1",
+		Kind:      models.OrderKindPurchase,
+		UserID:    "user123",
+		ItemID:    "item-1",
+		Item:      "hoody",
+		Quantity:  1,
+		UnitPrice: 300,
+		Total:     300,
+		CreatedAt: createdAt,
+	}
+}
+
+// acceptLedger - журнал, который принимает любые записи; для тестов, где проводки не проверяются
+func acceptLedger(ctrl *gomock.Controller) *mocks.Mockledger {
+	l := mocks.NewMockledger(ctrl)
+	l.EXPECT().PostEntry(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+	return l
+}
+
+func TestReturnOrder_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockOrder := mocks.NewMockorder(ctrl)
+	mockUser := mocks.NewMockuser(ctrl)
+	mockInventory := mocks.NewMockinventory(ctrl)
+	mockCatalog := mocks.NewMockcatalog(ctrl)
+	mockLedger := mocks.NewMockledger(ctrl)
+	mockTx := mocks.NewMockTx(ctrl)
+
+	now := time.Date(2025, 2, 10, 12, 0, 0, 0, time.UTC)
+	purchase := newPurchase(now.Add(-30 * time.Minute))
+
+	mockOrder.EXPECT().BeginTx(ctx).Return(mockTx, nil)
+	mockOrder.EXPECT().LockOrder(ctx, mockTx, "order-1").Return(purchase, nil)
+	mockOrder.EXPECT().HasRefund(ctx, mockTx, "order-1").Return(false, nil)
+	mockInventory.EXPECT().TakeInventoryItem(ctx, mockTx, "user123", "hoody", "", int64(1)).Return(nil)
+	mockUser.EXPECT().CreditUserCoins(ctx, mockTx, "user123", int64(300)).Return(nil)
+	mockCatalog.EXPECT().ReturnStock(ctx, mockTx, "item-1", int64(1)).Return(true, nil)
+	mockCatalog.EXPECT().InsertStockMovement(ctx, mockTx, gomock.Any()).Return(nil)
+	mockOrder.EXPECT().InsertOrder(ctx, mockTx, gomock.Any()).DoAndReturn(
+		func(_ context.Context, _ pgx.Tx, o models.Order) error {
+			if o.Kind != models.OrderKindRefund || o.RefundOf != "order-1" || o.Total != 300 {
+				t.Errorf("unexpected refund entry %+v", o)
+			}
+			return nil
+		})
+	mockLedger.EXPECT().PostEntry(ctx, mockTx, gomock.Any()).DoAndReturn(
+		func(_ context.Context, _ pgx.Tx, e models.LedgerEntry) error {
+			if e.Kind != models.EntryRefund || !e.Balanced() ||
+				e.Postings[0] != (models.Posting{Account: models.AccountShop, Amount: -300}) ||
+				e.Postings[1] != (models.Posting{Account: "user:user123", Amount: 300}) {
+				t.Errorf("unexpected ledger entry %+v", e)
+			}
+			return nil
+		})
+	mockTx.EXPECT().Commit(ctx).Return(nil)
+
+	uc := order.NewUsecase(mockOrder, mockUser, mockInventory, mockCatalog, nil, mockLedger, nil, time.Hour)
+	uc.Now = func() time.Time { return now }
+	refund, err := uc.ReturnOrder(ctx, "user123", "order-1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if refund.RefundOf != "order-1" {
+		t.Errorf("expected refund linked to order-1, got %q", refund.RefundOf)
+	}
+}
+
+func TestReturnOrder_VariantReturnsVariantStock(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockOrder := mocks.NewMockorder(ctrl)
+	mockUser := mocks.NewMockuser(ctrl)
+	mockInventory := mocks.NewMockinventory(ctrl)
+	mockCatalog := mocks.NewMockcatalog(ctrl)
+	mockTx := mocks.NewMockTx(ctrl)
+
+	now := time.Date(2025, 2, 10, 12, 0, 0, 0, time.UTC)
+	purchase := newPurchase(now.Add(-30 * time.Minute))
+	purchase.Variant = "hoody-m-grey"
+
+	mockOrder.EXPECT().BeginTx(ctx).Return(mockTx, nil)
+	mockOrder.EXPECT().LockOrder(ctx, mockTx, "order-1").Return(purchase, nil)
+	mockOrder.EXPECT().HasRefund(ctx, mockTx, "order-1").Return(false, nil)
+	mockInventory.EXPECT().TakeInventoryItem(ctx, mockTx, "user123", "hoody", "hoody-m-grey", int64(1)).Return(nil)
+	mockUser.EXPECT().CreditUserCoins(ctx, mockTx, "user123", int64(300)).Return(nil)
+	mockCatalog.EXPECT().ReturnVariantStock(ctx, mockTx, "hoody-m-grey", int64(1)).Return(false, nil)
+	mockOrder.EXPECT().InsertOrder(ctx, mockTx, gomock.Any()).DoAndReturn(
+		func(_ context.Context, _ pgx.Tx, o models.Order) error {
+			if o.Variant != "hoody-m-grey" {
+				t.Errorf("expected refund of variant hoody-m-grey, got %+v", o)
+			}
+			return nil
+		})
+	mockTx.EXPECT().Commit(ctx).Return(nil)
+
+	uc := order.NewUsecase(mockOrder, mockUser, mockInventory, mockCatalog, nil, acceptLedger(ctrl), nil, time.Hour)
+	uc.Now = func() time.Time { return now }
+	if _, err := uc.ReturnOrder(ctx, "user123", "order-1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestReturnOrder_WindowExpired(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockOrder := mocks.NewMockorder(ctrl)
+	mockUser := mocks.NewMockuser(ctrl)
+	mockInventory := mocks.NewMockinventory(ctrl)
+	mockCatalog := mocks.NewMockcatalog(ctrl)
+	mockTx := mocks.NewMockTx(ctrl)
+
+	now := time.Date(2025, 2, 10, 12, 0, 0, 0, time.UTC)
+
+	mockOrder.EXPECT().BeginTx(ctx).Return(mockTx, nil)
+	mockOrder.EXPECT().LockOrder(ctx, mockTx, "order-1").Return(newPurchase(now.Add(-2*time.Hour)), nil)
+	mockTx.EXPECT().Rollback(ctx).Return(nil)
+
+	uc := order.NewUsecase(mockOrder, mockUser, mockInventory, mockCatalog, nil, acceptLedger(ctrl), nil, time.Hour)
+	uc.Now = func() time.Time { return now }
+	_, err := uc.ReturnOrder(ctx, "user123", "order-1")
+	if !errors.Is(err, order.ErrReturnWindowExpired) {
+		t.Errorf("expected error %v, got %v", order.ErrReturnWindowExpired, err)
+	}
+}
+
+func TestReturnOrder_AlreadyRefunded(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockOrder := mocks.NewMockorder(ctrl)
+	mockUser := mocks.NewMockuser(ctrl)
+	mockInventory := mocks.NewMockinventory(ctrl)
+	mockCatalog := mocks.NewMockcatalog(ctrl)
+	mockTx := mocks.NewMockTx(ctrl)
+
+	now := time.Date(2025, 2, 10, 12, 0, 0, 0, time.UTC)
+
+	mockOrder.EXPECT().BeginTx(ctx).Return(mockTx, nil)
+	mockOrder.EXPECT().LockOrder(ctx, mockTx, "order-1").Return(newPurchase(now), nil)
+	mockOrder.EXPECT().HasRefund(ctx, mockTx, "order-1").Return(true, nil)
+	mockTx.EXPECT().Rollback(ctx).Return(nil)
+
+	uc := order.NewUsecase(mockOrder, mockUser, mockInventory, mockCatalog, nil, acceptLedger(ctrl), nil, time.Hour)
+	uc.Now = func() time.Time { return now }
+	_, err := uc.ReturnOrder(ctx, "user123", "order-1")
+	if !errors.Is(err, order.ErrAlreadyRefunded) {
+		t.Errorf("expected error %v, got %v", order.ErrAlreadyRefunded, err)
+	}
+}
+
+func TestReturnOrder_ForeignOrder(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockOrder := mocks.NewMockorder(ctrl)
+	mockUser := mocks.NewMockuser(ctrl)
+	mockInventory := mocks.NewMockinventory(ctrl)
+	mockCatalog := mocks.NewMockcatalog(ctrl)
+	mockTx := mocks.NewMockTx(ctrl)
+
+	mockOrder.EXPECT().BeginTx(ctx).Return(mockTx, nil)
+	mockOrder.EXPECT().LockOrder(ctx, mockTx, "order-1").Return(newPurchase(time.Now()), nil)
+	mockTx.EXPECT().Rollback(ctx).Return(nil)
+
+	uc := order.NewUsecase(mockOrder, mockUser, mockInventory, mockCatalog, nil, acceptLedger(ctrl), nil, time.Hour)
+	_, err := uc.ReturnOrder(ctx, "someone-else", "order-1")
+	if !errors.Is(err, models.ErrOrderNotFound) {
+		t.Errorf("expected error %v, got %v", models.ErrOrderNotFound, err)
+	}
+}
+
+func TestReturnOrder_ItemGivenAway(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockOrder := mocks.NewMockorder(ctrl)
+	mockUser := mocks.NewMockuser(ctrl)
+	mockInventory := mocks.NewMockinventory(ctrl)
+	mockCatalog := mocks.NewMockcatalog(ctrl)
+	mockTx := mocks.NewMockTx(ctrl)
+
+	now := time.Date(2025, 2, 10, 12, 0, 0, 0, time.UTC)
+
+	mockOrder.EXPECT().BeginTx(ctx).Return(mockTx, nil)
+	mockOrder.EXPECT().LockOrder(ctx, mockTx, "order-1").Return(newPurchase(now), nil)
+	mockOrder.EXPECT().HasRefund(ctx, mockTx, "order-1").Return(false, nil)
+	mockInventory.EXPECT().TakeInventoryItem(ctx, mockTx, "user123", "hoody", "", int64(1)).Return(models.ErrNotEnoughItems)
+	mockTx.EXPECT().Rollback(ctx).Return(nil)
+
+	uc := order.NewUsecase(mockOrder, mockUser, mockInventory, mockCatalog, nil, acceptLedger(ctrl), nil, time.Hour)
+	uc.Now = func() time.Time { return now }
+	_, err := uc.ReturnOrder(ctx, "user123", "order-1")
+	if !errors.Is(err, order.ErrItemNoLongerOwned) {
+		t.Errorf("expected error %v, got %v", order.ErrItemNoLongerOwned, err)
+	}
+}
+
+func TestMoveOrder_Ready(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockOrder := mocks.NewMockorder(ctrl)
+	mockUser := mocks.NewMockuser(ctrl)
+	mockInventory := mocks.NewMockinventory(ctrl)
+	mockCatalog := mocks.NewMockcatalog(ctrl)
+	mockTx := mocks.NewMockTx(ctrl)
+
+	purchase := newPurchase(time.Now())
+	purchase.Status = models.OrderStatusPlaced
+
+	mockOrder.EXPECT().BeginTx(ctx).Return(mockTx, nil)
+	mockOrder.EXPECT().LockOrder(ctx, mockTx, "order-1").Return(purchase, nil)
+	mockOrder.EXPECT().UpdateOrderStatus(ctx, mockTx, "order-1", models.OrderStatusReady).Return(nil)
+	mockOrder.EXPECT().InsertStatusChange(ctx, mockTx, gomock.Any()).DoAndReturn(
+		func(_ context.Context, _ pgx.Tx, c models.OrderStatusChange) error {
+			if c.OrderID != "order-1" || c.Status != models.OrderStatusReady || c.ChangedBy != "staff-1" {
+				t.Errorf("unexpected status change %+v", c)
+			}
+			return nil
+		})
+	mockTx.EXPECT().Commit(ctx).Return(nil)
+
+	uc := order.NewUsecase(mockOrder, mockUser, mockInventory, mockCatalog, nil, acceptLedger(ctrl), nil, time.Hour)
+	o, err := uc.MoveOrder(ctx, "staff-1", "order-1", models.OrderStatusReady)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if o.Status != models.OrderStatusReady {
+		t.Errorf("expected status %s, got %s", models.OrderStatusReady, o.Status)
+	}
+}
+
+func TestMoveOrder_CancelRefunds(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockOrder := mocks.NewMockorder(ctrl)
+	mockUser := mocks.NewMockuser(ctrl)
+	mockInventory := mocks.NewMockinventory(ctrl)
+	mockCatalog := mocks.NewMockcatalog(ctrl)
+	mockTx := mocks.NewMockTx(ctrl)
+
+	// отмена не зависит от окна возврата
+	purchase := newPurchase(time.Now().Add(-30 * 24 * time.Hour))
+	purchase.Status = models.OrderStatusReady
+
+	mockOrder.EXPECT().BeginTx(ctx).Return(mockTx, nil)
+	mockOrder.EXPECT().LockOrder(ctx, mockTx, "order-1").Return(purchase, nil)
+	mockOrder.EXPECT().HasRefund(ctx, mockTx, "order-1").Return(false, nil)
+	mockInventory.EXPECT().TakeInventoryItem(ctx, mockTx, "user123", "hoody", "", int64(1)).Return(nil)
+	mockUser.EXPECT().CreditUserCoins(ctx, mockTx, "user123", int64(300)).Return(nil)
+	mockCatalog.EXPECT().ReturnStock(ctx, mockTx, "item-1", int64(1)).Return(false, nil)
+	mockOrder.EXPECT().InsertOrder(ctx, mockTx, gomock.Any()).Return(nil)
+	mockOrder.EXPECT().UpdateOrderStatus(ctx, mockTx, "order-1", models.OrderStatusCancelled).Return(nil)
+	mockOrder.EXPECT().InsertStatusChange(ctx, mockTx, gomock.Any()).Return(nil)
+	mockTx.EXPECT().Commit(ctx).Return(nil)
+
+	uc := order.NewUsecase(mockOrder, mockUser, mockInventory, mockCatalog, nil, acceptLedger(ctrl), nil, time.Hour)
+	if _, err := uc.MoveOrder(ctx, "staff-1", "order-1", models.OrderStatusCancelled); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestReturnOrder_ReleasesCoupon(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockOrder := mocks.NewMockorder(ctrl)
+	mockUser := mocks.NewMockuser(ctrl)
+	mockInventory := mocks.NewMockinventory(ctrl)
+	mockCatalog := mocks.NewMockcatalog(ctrl)
+	mockCoupon := mocks.NewMockcoupon(ctrl)
+	mockTx := mocks.NewMockTx(ctrl)
+
+	now := time.Date(2025, 2, 10, 12, 0, 0, 0, time.UTC)
+	purchase := newPurchase(now.Add(-30 * time.Minute))
+	purchase.CouponCode, purchase.CouponDiscount, purchase.Total = "WELCOME", 50, 250
+
+	mockOrder.EXPECT().BeginTx(ctx).Return(mockTx, nil)
+	mockOrder.EXPECT().LockOrder(ctx, mockTx, "order-1").Return(purchase, nil)
+	mockOrder.EXPECT().HasRefund(ctx, mockTx, "order-1").Return(false, nil)
+	gomock.InOrder(
+		mockCoupon.EXPECT().LockCoupon(ctx, mockTx, "WELCOME").Return(models.Coupon{Code: "WELCOME"}, nil),
+		mockOrder.EXPECT().CountCouponPurchases(ctx, mockTx, "user123", "WELCOME", "order-1").Return(int64(0), nil),
+		mockCoupon.EXPECT().ReleaseRedemption(ctx, mockTx, "WELCOME", "user123").Return(nil),
+	)
+	mockInventory.EXPECT().TakeInventoryItem(ctx, mockTx, "user123", "hoody", "", int64(1)).Return(nil)
+	mockUser.EXPECT().CreditUserCoins(ctx, mockTx, "user123", int64(250)).Return(nil)
+	mockCatalog.EXPECT().ReturnStock(ctx, mockTx, "item-1", int64(1)).Return(false, nil)
+	mockOrder.EXPECT().InsertOrder(ctx, mockTx, gomock.Any()).Return(nil)
+	mockTx.EXPECT().Commit(ctx).Return(nil)
+
+	uc := order.NewUsecase(mockOrder, mockUser, mockInventory, mockCatalog, nil, acceptLedger(ctrl), mockCoupon, time.Hour)
+	uc.Now = func() time.Time { return now }
+	if _, err := uc.ReturnOrder(ctx, "user123", "order-1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestMoveOrder_CancelKeepsCouponOfRemainingCartLines(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockOrder := mocks.NewMockorder(ctrl)
+	mockUser := mocks.NewMockuser(ctrl)
+	mockInventory := mocks.NewMockinventory(ctrl)
+	mockCatalog := mocks.NewMockcatalog(ctrl)
+	mockCoupon := mocks.NewMockcoupon(ctrl)
+	mockTx := mocks.NewMockTx(ctrl)
+
+	purchase := newPurchase(time.Now())
+	purchase.Status = models.OrderStatusPlaced
+	purchase.CouponCode, purchase.CouponDiscount, purchase.Total = "WELCOME", 50, 250
+
+	mockOrder.EXPECT().BeginTx(ctx).Return(mockTx, nil)
+	mockOrder.EXPECT().LockOrder(ctx, mockTx, "order-1").Return(purchase, nil)
+	mockOrder.EXPECT().HasRefund(ctx, mockTx, "order-1").Return(false, nil)
+	mockCoupon.EXPECT().LockCoupon(ctx, mockTx, "WELCOME").Return(models.Coupon{Code: "WELCOME"}, nil)
+	// другая строка того же заказа корзины ещё не возвращена
+	mockOrder.EXPECT().CountCouponPurchases(ctx, mockTx, "user123", "WELCOME", "order-1").Return(int64(1), nil)
+	mockInventory.EXPECT().TakeInventoryItem(ctx, mockTx, "user123", "hoody", "", int64(1)).Return(nil)
+	mockUser.EXPECT().CreditUserCoins(ctx, mockTx, "user123", int64(250)).Return(nil)
+	mockCatalog.EXPECT().ReturnStock(ctx, mockTx, "item-1", int64(1)).Return(false, nil)
+	mockOrder.EXPECT().InsertOrder(ctx, mockTx, gomock.Any()).Return(nil)
+	mockOrder.EXPECT().UpdateOrderStatus(ctx, mockTx, "order-1", models.OrderStatusCancelled).Return(nil)
+	mockOrder.EXPECT().InsertStatusChange(ctx, mockTx, gomock.Any()).Return(nil)
+	mockTx.EXPECT().Commit(ctx).Return(nil)
+
+	uc := order.NewUsecase(mockOrder, mockUser, mockInventory, mockCatalog, nil, acceptLedger(ctrl), mockCoupon, time.Hour)
+	if _, err := uc.MoveOrder(ctx, "staff-1", "order-1", models.OrderStatusCancelled); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestMoveOrder_InvalidTransition(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockOrder := mocks.NewMockorder(ctrl)
+	mockUser := mocks.NewMockuser(ctrl)
+	mockInventory := mocks.NewMockinventory(ctrl)
+	mockCatalog := mocks.NewMockcatalog(ctrl)
+	mockTx := mocks.NewMockTx(ctrl)
+
+	purchase := newPurchase(time.Now())
+	purchase.Status = models.OrderStatusHandedOver
+
+	mockOrder.EXPECT().BeginTx(ctx).Return(mockTx, nil)
+	mockOrder.EXPECT().LockOrder(ctx, mockTx, "order-1").Return(purchase, nil)
+	mockTx.EXPECT().Rollback(ctx).Return(nil)
+
+	uc := order.NewUsecase(mockOrder, mockUser, mockInventory, mockCatalog, nil, acceptLedger(ctrl), nil, time.Hour)
+	_, err := uc.MoveOrder(ctx, "staff-1", "order-1", models.OrderStatusCancelled)
+	if !errors.Is(err, order.ErrInvalidTransition) {
+		t.Errorf("expected error %v, got %v", order.ErrInvalidTransition, err)
+	}
+}
+
+func TestReturnOrder_BundleReturnedAsOneUnit(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockOrder := mocks.NewMockorder(ctrl)
+	mockUser := mocks.NewMockuser(ctrl)
+	mockInventory := mocks.NewMockinventory(ctrl)
+	mockCatalog := mocks.NewMockcatalog(ctrl)
+	mockBundle := mocks.NewMockbundle(ctrl)
+	mockTx := mocks.NewMockTx(ctrl)
+
+	now := time.Date(2025, 2, 10, 12, 0, 0, 0, time.UTC)
+	purchase := models.Order{
+		ID:        "order-1",
+		Kind:      models.OrderKindPurchase,
+		UserID:    "user123",
+		BundleID:  "bundle-1",
+		Item:      "welcome-kit",
+		Quantity:  1,
+		UnitPrice: 100,
+		Total:     100,
+		CreatedAt: now.Add(-time.Minute),
+	}
+	parts := []models.BundleItem{
+		{ItemID: "item-1", Item: "t-shirt", Quantity: 1},
+		{ItemID: "item-2", Item: "pen", Variant: "pen-blue", Quantity: 2},
+	}
+
+	mockOrder.EXPECT().BeginTx(ctx).Return(mockTx, nil)
+	mockOrder.EXPECT().LockOrder(ctx, mockTx, "order-1").Return(purchase, nil)
+	mockOrder.EXPECT().HasRefund(ctx, mockTx, "order-1").Return(false, nil)
+	mockBundle.EXPECT().GetBundleItems(ctx, mockTx, "bundle-1").Return(parts, nil)
+	mockInventory.EXPECT().TakeInventoryItem(ctx, mockTx, "user123", "t-shirt", "", int64(1)).Return(nil)
+	mockInventory.EXPECT().TakeInventoryItem(ctx, mockTx, "user123", "pen", "pen-blue", int64(2)).Return(nil)
+	mockUser.EXPECT().CreditUserCoins(ctx, mockTx, "user123", int64(100)).Return(nil)
+	mockCatalog.EXPECT().ReturnStock(ctx, mockTx, "item-1", int64(1)).Return(false, nil)
+	mockCatalog.EXPECT().ReturnVariantStock(ctx, mockTx, "pen-blue", int64(2)).Return(true, nil)
+	mockCatalog.EXPECT().InsertStockMovement(ctx, mockTx, gomock.Any()).Return(nil)
+	mockOrder.EXPECT().InsertOrder(ctx, mockTx, gomock.Any()).DoAndReturn(
+		func(_ context.Context, _ pgx.Tx, o models.Order) error {
+			if o.BundleID != "bundle-1" || o.RefundOf != "order-1" || o.Total != 100 {
+				t.Errorf("unexpected refund entry %+v", o)
+			}
+			return nil
+		})
+	mockTx.EXPECT().Commit(ctx).Return(nil)
+
+	uc := order.NewUsecase(mockOrder, mockUser, mockInventory, mockCatalog, mockBundle, acceptLedger(ctrl), nil, time.Hour)
+	uc.Now = func() time.Time { return now }
+	if _, err := uc.ReturnOrder(ctx, "user123", "order-1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestReturnOrder_BundlePartGivenAway(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockOrder := mocks.NewMockorder(ctrl)
+	mockUser := mocks.NewMockuser(ctrl)
+	mockInventory := mocks.NewMockinventory(ctrl)
+	mockCatalog := mocks.NewMockcatalog(ctrl)
+	mockBundle := mocks.NewMockbundle(ctrl)
+	mockTx := mocks.NewMockTx(ctrl)
+
+	now := time.Date(2025, 2, 10, 12, 0, 0, 0, time.UTC)
+	purchase := newPurchase(now.Add(-time.Minute))
+	purchase.BundleID = "bundle-1"
+
+	mockOrder.EXPECT().BeginTx(ctx).Return(mockTx, nil)
+	mockOrder.EXPECT().LockOrder(ctx, mockTx, "order-1").Return(purchase, nil)
+	mockOrder.EXPECT().HasRefund(ctx, mockTx, "order-1").Return(false, nil)
+	mockBundle.EXPECT().GetBundleItems(ctx, mockTx, "bundle-1").Return([]models.BundleItem{
+		{ItemID: "item-1", Item: "t-shirt", Quantity: 1},
+		{ItemID: "item-2", Item: "pen", Quantity: 2},
+	}, nil)
+	mockInventory.EXPECT().TakeInventoryItem(ctx, mockTx, "user123", "t-shirt", "", int64(1)).Return(nil)
+	mockInventory.EXPECT().TakeInventoryItem(ctx, mockTx, "user123", "pen", "", int64(2)).Return(models.ErrNotEnoughItems)
+	mockTx.EXPECT().Rollback(ctx).Return(nil)
+
+	uc := order.NewUsecase(mockOrder, mockUser, mockInventory, mockCatalog, mockBundle, acceptLedger(ctrl), nil, time.Hour)
+	uc.Now = func() time.Time { return now }
+	_, err := uc.ReturnOrder(ctx, "user123", "order-1")
+	if !errors.Is(err, order.ErrItemNoLongerOwned) {
+		t.Errorf("expected error %v, got %v", order.ErrItemNoLongerOwned, err)
+	}
+}
@@ -0,0 +1,352 @@
+package order
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+
+	"AvitoTask/internal/models"
+)
+
+var (
+	ErrNotRefundable       = errors.New("only purchases can be returned")
+	ErrAlreadyRefunded     = errors.New("order has already been returned")
+	ErrReturnWindowExpired = errors.New("return window for this order has expired")
+	ErrItemNoLongerOwned   = errors.New("returned items are no longer in user inventory")
+
+	ErrNoFulfillment     = errors.New("only purchases go through fulfillment")
+	ErrInvalidTransition = errors.New("order cannot be moved to this status")
+)
+
+type Usecase struct {
+	repoOrder     order
+	repoUser      user
+	repoInventory inventory
+	repoCatalog   catalog
+	repoBundle    bundle
+	repoLedger    ledger
+	repoCoupon    coupon
+	refundWindow  time.Duration
+	Now           func() time.Time
+}
+
+func NewUsecase(o order, u user, i inventory, c catalog, b bundle, l ledger, cp coupon, refundWindow time.Duration) *Usecase {
+	return &Usecase{
+		repoOrder:     o,
+		repoUser:      u,
+		repoInventory: i,
+		repoCatalog:   c,
+		repoBundle:    b,
+		repoLedger:    l,
+		repoCoupon:    cp,
+		refundWindow:  refundWindow,
+		Now: func() time.Time {
+			return time.Now().UTC()
+		},
+	}
+}
+
+func (u *Usecase) ListOrders(ctx context.Context, userID string, limit, offset int64) (orders []models.Order, total int64, err error) {
+	tx, err := u.repoOrder.BeginTx(ctx)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to begin tx: %w", err)
+	}
+
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback(ctx)
+		} else {
+			err = tx.Commit(ctx)
+		}
+	}()
+
+	return u.repoOrder.GetUserOrders(ctx, tx, userID, limit, offset)
+}
+
+// ReturnOrder - отменяет покупку: забирает товар из инвентаря, возвращает монеты и пишет в журнал заказов запись возврата
+func (u *Usecase) ReturnOrder(ctx context.Context, userID, orderID string) (refund models.Order, err error) {
+	tx, err := u.repoOrder.BeginTx(ctx)
+	if err != nil {
+		return refund, fmt.Errorf("failed to begin tx: %w", err)
+	}
+
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback(ctx)
+		} else {
+			err = tx.Commit(ctx)
+		}
+	}()
+
+	purchase, err := u.repoOrder.LockOrder(ctx, tx, orderID)
+	if err != nil {
+		return refund, err
+	}
+
+	if purchase.UserID != userID {
+		err = models.ErrOrderNotFound
+		return refund, err
+	}
+
+	if err = u.checkRefundable(ctx, tx, purchase); err != nil {
+		return refund, err
+	}
+
+	refund, err = u.refund(ctx, tx, purchase)
+	if err != nil {
+		return refund, err
+	}
+
+	// невыданный заказ убирается из очереди выдачи
+	if purchase.Pending() {
+		if err = u.setStatus(ctx, tx, purchase.ID, "", models.OrderStatusCancelled); err != nil {
+			return refund, err
+		}
+	}
+
+	return refund, nil
+}
+
+// ListQueue - заказы в статусе status для стойки выдачи, от старых к новым
+func (u *Usecase) ListQueue(ctx context.Context, status string, limit, offset int64) (orders []models.Order, total int64, err error) {
+	tx, err := u.repoOrder.BeginTx(ctx)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to begin tx: %w", err)
+	}
+
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback(ctx)
+		} else {
+			err = tx.Commit(ctx)
+		}
+	}()
+
+	return u.repoOrder.GetOrdersByStatus(ctx, tx, status, limit, offset)
+}
+
+// MoveOrder - переводит покупку в следующий статус выдачи; отмена до выдачи сразу оформляет возврат
+func (u *Usecase) MoveOrder(ctx context.Context, staffID, orderID, status string) (purchase models.Order, err error) {
+	tx, err := u.repoOrder.BeginTx(ctx)
+	if err != nil {
+		return purchase, fmt.Errorf("failed to begin tx: %w", err)
+	}
+
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback(ctx)
+		} else {
+			err = tx.Commit(ctx)
+		}
+	}()
+
+	purchase, err = u.repoOrder.LockOrder(ctx, tx, orderID)
+	if err != nil {
+		return purchase, err
+	}
+
+	if purchase.Kind != models.OrderKindPurchase {
+		err = ErrNoFulfillment
+		return purchase, err
+	}
+
+	if !purchase.CanMoveTo(status) {
+		err = fmt.Errorf("%w: %s -> %s", ErrInvalidTransition, purchase.Status, status)
+		return purchase, err
+	}
+
+	if status == models.OrderStatusCancelled {
+		var refunded bool
+		refunded, err = u.repoOrder.HasRefund(ctx, tx, purchase.ID)
+		if err != nil {
+			return purchase, err
+		}
+		if !refunded {
+			if _, err = u.refund(ctx, tx, purchase); err != nil {
+				return purchase, err
+			}
+		}
+	}
+
+	if err = u.setStatus(ctx, tx, purchase.ID, staffID, status); err != nil {
+		return purchase, err
+	}
+	purchase.Status = status
+
+	return purchase, nil
+}
+
+func (u *Usecase) setStatus(ctx context.Context, tx pgx.Tx, orderID, changedBy, status string) error {
+	if err := u.repoOrder.UpdateOrderStatus(ctx, tx, orderID, status); err != nil {
+		return err
+	}
+
+	return u.repoOrder.InsertStatusChange(ctx, tx, models.OrderStatusChange{
+		ID:        uuid.New().String(),
+		OrderID:   orderID,
+		Status:    status,
+		ChangedBy: changedBy,
+	})
+}
+
+func (u *Usecase) checkRefundable(ctx context.Context, tx pgx.Tx, purchase models.Order) error {
+	if purchase.Kind != models.OrderKindPurchase {
+		return ErrNotRefundable
+	}
+
+	if u.Now().Sub(purchase.CreatedAt) > u.refundWindow {
+		return ErrReturnWindowExpired
+	}
+
+	refunded, err := u.repoOrder.HasRefund(ctx, tx, purchase.ID)
+	if err != nil {
+		return err
+	}
+	if refunded {
+		return ErrAlreadyRefunded
+	}
+
+	return nil
+}
+
+// refund - возвращает покупку целиком: набор возвращается только вместе со всеми своими позициями
+func (u *Usecase) refund(ctx context.Context, tx pgx.Tx, purchase models.Order) (models.Order, error) {
+	parts, err := u.parts(ctx, tx, purchase)
+	if err != nil {
+		return models.Order{}, err
+	}
+
+	if err = u.releaseCoupon(ctx, tx, purchase); err != nil {
+		return models.Order{}, err
+	}
+
+	for _, part := range parts {
+		if err = u.takeBack(ctx, tx, purchase.UserID, part); err != nil {
+			return models.Order{}, err
+		}
+	}
+
+	if err = u.repoUser.CreditUserCoins(ctx, tx, purchase.UserID, purchase.Total); err != nil {
+		return models.Order{}, err
+	}
+
+	for _, part := range parts {
+		if err = u.restock(ctx, tx, purchase.UserID, part); err != nil {
+			return models.Order{}, err
+		}
+	}
+
+	refund := models.Order{
+		ID:             uuid.New().String(),
+		Kind:           models.OrderKindRefund,
+		RefundOf:       purchase.ID,
+		UserID:         purchase.UserID,
+		ItemID:         purchase.ItemID,
+		BundleID:       purchase.BundleID,
+		Item:           purchase.Item,
+		Variant:        purchase.Variant,
+		Quantity:       purchase.Quantity,
+		UnitPrice:      purchase.UnitPrice,
+		Discount:       purchase.Discount,
+		PromotionID:    purchase.PromotionID,
+		CouponCode:     purchase.CouponCode,
+		CouponDiscount: purchase.CouponDiscount,
+		Total:          purchase.Total,
+		CreatedAt:      u.Now(),
+	}
+	if err = u.repoOrder.InsertOrder(ctx, tx, refund); err != nil {
+		return models.Order{}, err
+	}
+
+	if refund.Total > 0 {
+		entry := models.NewLedgerEntry(uuid.New().String(), models.EntryRefund, refund.ID,
+			models.AccountShop, models.UserAccount(refund.UserID), refund.Total)
+		if err = u.repoLedger.PostEntry(ctx, tx, entry); err != nil {
+			return models.Order{}, err
+		}
+	}
+
+	return refund, nil
+}
+
+// releaseCoupon - возврат последней покупки, оплаченной с промокодом, отменяет его погашение. Купон блокируется,
+// чтобы параллельные возвраты строк одного заказа корзины видели друг друга
+func (u *Usecase) releaseCoupon(ctx context.Context, tx pgx.Tx, purchase models.Order) error {
+	if purchase.CouponCode == "" {
+		return nil
+	}
+
+	if _, err := u.repoCoupon.LockCoupon(ctx, tx, purchase.CouponCode); err != nil {
+		return err
+	}
+
+	left, err := u.repoOrder.CountCouponPurchases(ctx, tx, purchase.UserID, purchase.CouponCode, purchase.ID)
+	if err != nil {
+		return err
+	}
+	if left > 0 {
+		return nil
+	}
+
+	return u.repoCoupon.ReleaseRedemption(ctx, tx, purchase.CouponCode, purchase.UserID)
+}
+
+// parts - позиции, из которых состоит покупка; у обычного заказа это одна позиция
+func (u *Usecase) parts(ctx context.Context, tx pgx.Tx, purchase models.Order) ([]models.BundleItem, error) {
+	if purchase.BundleID == "" {
+		return []models.BundleItem{{
+			ItemID:   purchase.ItemID,
+			Item:     purchase.Item,
+			Variant:  purchase.Variant,
+			Quantity: purchase.Quantity,
+		}}, nil
+	}
+
+	parts, err := u.repoBundle.GetBundleItems(ctx, tx, purchase.BundleID)
+	if err != nil {
+		return nil, err
+	}
+	for i := range parts {
+		parts[i].Quantity *= purchase.Quantity
+	}
+
+	return parts, nil
+}
+
+// takeBack - забирает позицию из инвентаря покупателя; списание условное, поэтому два параллельных
+// возврата одной и той же позиции не пройдут оба, если у покупателя осталась только одна
+func (u *Usecase) takeBack(ctx context.Context, tx pgx.Tx, userID string, part models.BundleItem) error {
+	err := u.repoInventory.TakeInventoryItem(ctx, tx, userID, part.Item, part.Variant, part.Quantity)
+	if errors.Is(err, models.ErrNotEnoughItems) {
+		return ErrItemNoLongerOwned
+	}
+
+	return err
+}
+
+// restock - возвращает позицию в запас, если запас у неё ограничен
+func (u *Usecase) restock(ctx context.Context, tx pgx.Tx, userID string, part models.BundleItem) error {
+	var returned bool
+	var err error
+	if part.Variant != "" {
+		returned, err = u.repoCatalog.ReturnVariantStock(ctx, tx, part.Variant, part.Quantity)
+	} else {
+		returned, err = u.repoCatalog.ReturnStock(ctx, tx, part.ItemID, part.Quantity)
+	}
+	if err != nil || !returned {
+		return err
+	}
+
+	return u.repoCatalog.InsertStockMovement(ctx, tx, models.StockMovement{
+		ID:      uuid.New().String(),
+		ItemID:  part.ItemID,
+		Variant: part.Variant,
+		Delta:   part.Quantity,
+		Reason:  models.StockReasonRefund,
+		UserID:  userID,
+	})
+}
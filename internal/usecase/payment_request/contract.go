@@ -0,0 +1,31 @@
+//go:generate mockgen -source=contract.go -destination=mocks/mock.go -package=mocks $GOPACKAGE
+//go:generate mockgen -destination=mocks/mock_tx.go -package=mocks github.com/jackc/pgx/v5 Tx
+package payment_request
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+
+	"AvitoTask/internal/models"
+)
+
+type request interface {
+	BeginTx(ctx context.Context) (pgx.Tx, error)
+	InsertRequest(ctx context.Context, tx pgx.Tx, req models.PaymentRequest) error
+	LockRequest(ctx context.Context, tx pgx.Tx, id string) (models.PaymentRequest, error)
+	GetIncomingRequests(ctx context.Context, tx pgx.Tx, userID string) ([]models.PaymentRequest, error)
+	GetOutgoingRequests(ctx context.Context, tx pgx.Tx, userID string) ([]models.PaymentRequest, error)
+	ResolveRequest(ctx context.Context, tx pgx.Tx, id, status, transactionID string) error
+	ExpireRequests(ctx context.Context, tx pgx.Tx, now time.Time) (int64, error)
+}
+
+type user interface {
+	GetUserById(ctx context.Context, tx pgx.Tx, userID string) (models.User, error)
+	GetUserByLoginWithTx(ctx context.Context, tx pgx.Tx, login string) (models.User, error)
+}
+
+type sender interface {
+	SendCoinTx(ctx context.Context, tx pgx.Tx, fromUser, toUser string, amount int64, message, category string) (models.Transfer, error)
+}
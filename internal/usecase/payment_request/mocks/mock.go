@@ -0,0 +1,232 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: contract.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	models "AvitoTask/internal/models"
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+	pgx "github.com/jackc/pgx/v5"
+)
+
+// Mockrequest is a mock of request interface.
+type Mockrequest struct {
+	ctrl     *gomock.Controller
+	recorder *MockrequestMockRecorder
+}
+
+// MockrequestMockRecorder is the mock recorder for Mockrequest.
+type MockrequestMockRecorder struct {
+	mock *Mockrequest
+}
+
+// NewMockrequest creates a new mock instance.
+func NewMockrequest(ctrl *gomock.Controller) *Mockrequest {
+	mock := &Mockrequest{ctrl: ctrl}
+	mock.recorder = &MockrequestMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mockrequest) EXPECT() *MockrequestMockRecorder {
+	return m.recorder
+}
+
+// BeginTx mocks base method.
+func (m *Mockrequest) BeginTx(ctx context.Context) (pgx.Tx, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BeginTx", ctx)
+	ret0, _ := ret[0].(pgx.Tx)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BeginTx indicates an expected call of BeginTx.
+func (mr *MockrequestMockRecorder) BeginTx(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BeginTx", reflect.TypeOf((*Mockrequest)(nil).BeginTx), ctx)
+}
+
+// ExpireRequests mocks base method.
+func (m *Mockrequest) ExpireRequests(ctx context.Context, tx pgx.Tx, now time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExpireRequests", ctx, tx, now)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExpireRequests indicates an expected call of ExpireRequests.
+func (mr *MockrequestMockRecorder) ExpireRequests(ctx, tx, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExpireRequests", reflect.TypeOf((*Mockrequest)(nil).ExpireRequests), ctx, tx, now)
+}
+
+// GetIncomingRequests mocks base method.
+func (m *Mockrequest) GetIncomingRequests(ctx context.Context, tx pgx.Tx, userID string) ([]models.PaymentRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetIncomingRequests", ctx, tx, userID)
+	ret0, _ := ret[0].([]models.PaymentRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetIncomingRequests indicates an expected call of GetIncomingRequests.
+func (mr *MockrequestMockRecorder) GetIncomingRequests(ctx, tx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetIncomingRequests", reflect.TypeOf((*Mockrequest)(nil).GetIncomingRequests), ctx, tx, userID)
+}
+
+// GetOutgoingRequests mocks base method.
+func (m *Mockrequest) GetOutgoingRequests(ctx context.Context, tx pgx.Tx, userID string) ([]models.PaymentRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOutgoingRequests", ctx, tx, userID)
+	ret0, _ := ret[0].([]models.PaymentRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOutgoingRequests indicates an expected call of GetOutgoingRequests.
+func (mr *MockrequestMockRecorder) GetOutgoingRequests(ctx, tx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOutgoingRequests", reflect.TypeOf((*Mockrequest)(nil).GetOutgoingRequests), ctx, tx, userID)
+}
+
+// InsertRequest mocks base method.
+func (m *Mockrequest) InsertRequest(ctx context.Context, tx pgx.Tx, req models.PaymentRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertRequest", ctx, tx, req)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InsertRequest indicates an expected call of InsertRequest.
+func (mr *MockrequestMockRecorder) InsertRequest(ctx, tx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertRequest", reflect.TypeOf((*Mockrequest)(nil).InsertRequest), ctx, tx, req)
+}
+
+// LockRequest mocks base method.
+func (m *Mockrequest) LockRequest(ctx context.Context, tx pgx.Tx, id string) (models.PaymentRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LockRequest", ctx, tx, id)
+	ret0, _ := ret[0].(models.PaymentRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LockRequest indicates an expected call of LockRequest.
+func (mr *MockrequestMockRecorder) LockRequest(ctx, tx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LockRequest", reflect.TypeOf((*Mockrequest)(nil).LockRequest), ctx, tx, id)
+}
+
+// ResolveRequest mocks base method.
+func (m *Mockrequest) ResolveRequest(ctx context.Context, tx pgx.Tx, id, status, transactionID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResolveRequest", ctx, tx, id, status, transactionID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ResolveRequest indicates an expected call of ResolveRequest.
+func (mr *MockrequestMockRecorder) ResolveRequest(ctx, tx, id, status, transactionID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResolveRequest", reflect.TypeOf((*Mockrequest)(nil).ResolveRequest), ctx, tx, id, status, transactionID)
+}
+
+// Mockuser is a mock of user interface.
+type Mockuser struct {
+	ctrl     *gomock.Controller
+	recorder *MockuserMockRecorder
+}
+
+// MockuserMockRecorder is the mock recorder for Mockuser.
+type MockuserMockRecorder struct {
+	mock *Mockuser
+}
+
+// NewMockuser creates a new mock instance.
+func NewMockuser(ctrl *gomock.Controller) *Mockuser {
+	mock := &Mockuser{ctrl: ctrl}
+	mock.recorder = &MockuserMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mockuser) EXPECT() *MockuserMockRecorder {
+	return m.recorder
+}
+
+// GetUserById mocks base method.
+func (m *Mockuser) GetUserById(ctx context.Context, tx pgx.Tx, userID string) (models.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserById", ctx, tx, userID)
+	ret0, _ := ret[0].(models.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserById indicates an expected call of GetUserById.
+func (mr *MockuserMockRecorder) GetUserById(ctx, tx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserById", reflect.TypeOf((*Mockuser)(nil).GetUserById), ctx, tx, userID)
+}
+
+// GetUserByLoginWithTx mocks base method.
+func (m *Mockuser) GetUserByLoginWithTx(ctx context.Context, tx pgx.Tx, login string) (models.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserByLoginWithTx", ctx, tx, login)
+	ret0, _ := ret[0].(models.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserByLoginWithTx indicates an expected call of GetUserByLoginWithTx.
+func (mr *MockuserMockRecorder) GetUserByLoginWithTx(ctx, tx, login interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByLoginWithTx", reflect.TypeOf((*Mockuser)(nil).GetUserByLoginWithTx), ctx, tx, login)
+}
+
+// Mocksender is a mock of sender interface.
+type Mocksender struct {
+	ctrl     *gomock.Controller
+	recorder *MocksenderMockRecorder
+}
+
+// MocksenderMockRecorder is the mock recorder for Mocksender.
+type MocksenderMockRecorder struct {
+	mock *Mocksender
+}
+
+// NewMocksender creates a new mock instance.
+func NewMocksender(ctrl *gomock.Controller) *Mocksender {
+	mock := &Mocksender{ctrl: ctrl}
+	mock.recorder = &MocksenderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mocksender) EXPECT() *MocksenderMockRecorder {
+	return m.recorder
+}
+
+// SendCoinTx mocks base method.
+func (m *Mocksender) SendCoinTx(ctx context.Context, tx pgx.Tx, fromUser, toUser string, amount int64, message, category string) (models.Transfer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendCoinTx", ctx, tx, fromUser, toUser, amount, message, category)
+	ret0, _ := ret[0].(models.Transfer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SendCoinTx indicates an expected call of SendCoinTx.
+func (mr *MocksenderMockRecorder) SendCoinTx(ctx, tx, fromUser, toUser, amount, message, category interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendCoinTx", reflect.TypeOf((*Mocksender)(nil).SendCoinTx), ctx, tx, fromUser, toUser, amount, message, category)
+}
@@ -0,0 +1,263 @@
+package payment_request_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/jackc/pgx/v5"
+
+	"AvitoTask/internal/models"
+	"AvitoTask/internal/usecase/payment_request"
+	"AvitoTask/internal/usecase/payment_request/mocks"
+	"AvitoTask/internal/usecase/send_coin"
+)
+
+var now = time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+
+func openRequest() models.PaymentRequest {
+	return models.PaymentRequest{
+		ID:            "req-1",
+		RequesterID:   "user1",
+		RequesterName: "alice",
+		PayerID:       "user2",
+		PayerName:     "bob",
+		Amount:        30,
+		Note:          "pizza",
+		Status:        models.PaymentRequestOpen,
+		ExpiresAt:     now.Add(time.Hour),
+	}
+}
+
+func newUsecase(ctrl *gomock.Controller) (*payment_request.Usecase, *mocks.Mockrequest, *mocks.Mockuser, *mocks.Mocksender, *mocks.MockTx) {
+	mockRequest := mocks.NewMockrequest(ctrl)
+	mockUser := mocks.NewMockuser(ctrl)
+	mockSender := mocks.NewMocksender(ctrl)
+	mockTx := mocks.NewMockTx(ctrl)
+
+	uc := payment_request.NewUsecase(mockRequest, mockUser, mockSender, 24*time.Hour)
+	uc.Now = func() time.Time { return now }
+
+	return uc, mockRequest, mockUser, mockSender, mockTx
+}
+
+func TestCreate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	uc, mockRequest, mockUser, _, mockTx := newUsecase(ctrl)
+
+	mockRequest.EXPECT().BeginTx(ctx).Return(mockTx, nil)
+	mockUser.EXPECT().GetUserById(ctx, mockTx, "user1").Return(models.User{ID: "user1", Username: "alice"}, nil)
+	mockUser.EXPECT().GetUserByLoginWithTx(ctx, mockTx, "bob").Return(models.User{ID: "user2", Username: "bob"}, nil)
+	mockRequest.EXPECT().InsertRequest(ctx, mockTx, gomock.Any()).DoAndReturn(
+		func(_ context.Context, _ pgx.Tx, req models.PaymentRequest) error {
+			if req.RequesterID != "user1" || req.PayerID != "user2" || req.Amount != 30 ||
+				req.Status != models.PaymentRequestOpen || !req.ExpiresAt.Equal(now.Add(24*time.Hour)) {
+				t.Errorf("unexpected request %+v", req)
+			}
+			return nil
+		})
+	mockTx.EXPECT().Commit(ctx).Return(nil)
+
+	req, err := uc.Create(ctx, "user1", "bob", 30, "pizza")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if req.ID == "" || req.PayerName != "bob" {
+		t.Errorf("unexpected request %+v", req)
+	}
+}
+
+func TestCreate_Rejected(t *testing.T) {
+	tests := []struct {
+		name    string
+		payer   string
+		lookup  error
+		wantErr error
+	}{
+		{name: "same user", payer: "alice", wantErr: payment_request.ErrSameUser},
+		{name: "unknown payer", payer: "ghost", lookup: pgx.ErrNoRows, wantErr: payment_request.ErrPayerNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			ctx := context.Background()
+			uc, mockRequest, mockUser, _, mockTx := newUsecase(ctrl)
+
+			mockRequest.EXPECT().BeginTx(ctx).Return(mockTx, nil)
+			mockUser.EXPECT().GetUserById(ctx, mockTx, "user1").Return(models.User{ID: "user1", Username: "alice"}, nil)
+			if tt.lookup != nil {
+				mockUser.EXPECT().GetUserByLoginWithTx(ctx, mockTx, tt.payer).Return(models.User{}, tt.lookup)
+			}
+			mockTx.EXPECT().Rollback(ctx).Return(nil)
+
+			if _, err := uc.Create(ctx, "user1", tt.payer, 30, ""); !errors.Is(err, tt.wantErr) {
+				t.Fatalf("expected %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestPay_LinksTransaction(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	uc, mockRequest, _, mockSender, mockTx := newUsecase(ctrl)
+
+	mockRequest.EXPECT().BeginTx(ctx).Return(mockTx, nil)
+	mockRequest.EXPECT().LockRequest(ctx, mockTx, "req-1").Return(openRequest(), nil)
+	mockSender.EXPECT().SendCoinTx(ctx, mockTx, "user2", "alice", int64(30), "pizza", "").Return(models.Transfer{ID: "tr-1"}, nil)
+	mockRequest.EXPECT().ResolveRequest(ctx, mockTx, "req-1", models.PaymentRequestPaid, "tr-1").Return(nil)
+	mockTx.EXPECT().Commit(ctx).Return(nil)
+
+	req, err := uc.Pay(ctx, "user2", "req-1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if req.Status != models.PaymentRequestPaid || req.TransactionID != "tr-1" {
+		t.Errorf("unexpected request %+v", req)
+	}
+}
+
+func TestPay_TransferFailedKeepsRequestOpen(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	uc, mockRequest, _, mockSender, mockTx := newUsecase(ctrl)
+
+	mockRequest.EXPECT().BeginTx(ctx).Return(mockTx, nil)
+	mockRequest.EXPECT().LockRequest(ctx, mockTx, "req-1").Return(openRequest(), nil)
+	mockSender.EXPECT().SendCoinTx(ctx, mockTx, "user2", "alice", int64(30), "pizza", "").
+		Return(models.Transfer{}, send_coin.ErrNotEnoughCoins)
+	mockTx.EXPECT().Rollback(ctx).Return(nil)
+
+	if _, err := uc.Pay(ctx, "user2", "req-1"); !errors.Is(err, send_coin.ErrNotEnoughCoins) {
+		t.Fatalf("expected ErrNotEnoughCoins, got %v", err)
+	}
+}
+
+func TestAnswer(t *testing.T) {
+	paid := openRequest()
+	paid.Status = models.PaymentRequestPaid
+
+	tests := []struct {
+		name       string
+		answer     func(uc *payment_request.Usecase, ctx context.Context) (models.PaymentRequest, error)
+		locked     models.PaymentRequest
+		now        time.Time
+		wantStatus string
+		wantErr    error
+	}{
+		{
+			name: "payer declines",
+			answer: func(uc *payment_request.Usecase, ctx context.Context) (models.PaymentRequest, error) {
+				return uc.Decline(ctx, "user2", "req-1")
+			},
+			locked:     openRequest(),
+			now:        now,
+			wantStatus: models.PaymentRequestDeclined,
+		},
+		{
+			name: "requester cancels",
+			answer: func(uc *payment_request.Usecase, ctx context.Context) (models.PaymentRequest, error) {
+				return uc.Cancel(ctx, "user1", "req-1")
+			},
+			locked:     openRequest(),
+			now:        now,
+			wantStatus: models.PaymentRequestCancelled,
+		},
+		{
+			name: "requester cannot decline",
+			answer: func(uc *payment_request.Usecase, ctx context.Context) (models.PaymentRequest, error) {
+				return uc.Decline(ctx, "user1", "req-1")
+			},
+			locked:  openRequest(),
+			now:     now,
+			wantErr: models.ErrPaymentRequestNotFound,
+		},
+		{
+			name: "payer cannot cancel",
+			answer: func(uc *payment_request.Usecase, ctx context.Context) (models.PaymentRequest, error) {
+				return uc.Cancel(ctx, "user2", "req-1")
+			},
+			locked:  openRequest(),
+			now:     now,
+			wantErr: models.ErrPaymentRequestNotFound,
+		},
+		{
+			name: "already paid",
+			answer: func(uc *payment_request.Usecase, ctx context.Context) (models.PaymentRequest, error) {
+				return uc.Pay(ctx, "user2", "req-1")
+			},
+			locked:  paid,
+			now:     now,
+			wantErr: payment_request.ErrRequestClosed,
+		},
+		{
+			name: "expired",
+			answer: func(uc *payment_request.Usecase, ctx context.Context) (models.PaymentRequest, error) {
+				return uc.Pay(ctx, "user2", "req-1")
+			},
+			locked:  openRequest(),
+			now:     now.Add(2 * time.Hour),
+			wantErr: payment_request.ErrRequestExpired,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			ctx := context.Background()
+			uc, mockRequest, _, _, mockTx := newUsecase(ctrl)
+			uc.Now = func() time.Time { return tt.now }
+
+			mockRequest.EXPECT().BeginTx(ctx).Return(mockTx, nil)
+			mockRequest.EXPECT().LockRequest(ctx, mockTx, "req-1").Return(tt.locked, nil)
+			if tt.wantErr == nil {
+				mockRequest.EXPECT().ResolveRequest(ctx, mockTx, "req-1", tt.wantStatus, "").Return(nil)
+				mockTx.EXPECT().Commit(ctx).Return(nil)
+			} else {
+				mockTx.EXPECT().Rollback(ctx).Return(nil)
+			}
+
+			req, err := tt.answer(uc, ctx)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("expected %v, got %v", tt.wantErr, err)
+			}
+			if tt.wantErr == nil && req.Status != tt.wantStatus {
+				t.Errorf("expected status %s, got %s", tt.wantStatus, req.Status)
+			}
+		})
+	}
+}
+
+func TestExpireRequests(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	uc, mockRequest, _, _, mockTx := newUsecase(ctrl)
+
+	mockRequest.EXPECT().BeginTx(ctx).Return(mockTx, nil)
+	mockRequest.EXPECT().ExpireRequests(ctx, mockTx, now).Return(int64(2), nil)
+	mockTx.EXPECT().Commit(ctx).Return(nil)
+
+	expired, err := uc.ExpireRequests(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if expired != 2 {
+		t.Errorf("expected 2 expired requests, got %d", expired)
+	}
+}
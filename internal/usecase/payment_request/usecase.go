@@ -0,0 +1,246 @@
+package payment_request
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+
+	"AvitoTask/internal/models"
+	"AvitoTask/internal/utils"
+)
+
+// conflictAttempts - сколько раз ответ на запрос повторяется при конфликте его перевода с другими транзакциями
+const conflictAttempts = 3
+
+var (
+	ErrSameUser       = errors.New("cannot request coins from yourself")
+	ErrPayerNotFound  = errors.New("user to request coins from does not exist")
+	ErrRequestClosed  = errors.New("payment request is already paid, declined, cancelled or expired")
+	ErrRequestExpired = errors.New("payment request has expired")
+)
+
+type Usecase struct {
+	repo     request
+	repoUser user
+	sender   sender
+	ttl      time.Duration
+	Now      func() time.Time
+}
+
+func NewUsecase(r request, u user, snd sender, ttl time.Duration) *Usecase {
+	return &Usecase{
+		repo:     r,
+		repoUser: u,
+		sender:   snd,
+		ttl:      ttl,
+		Now: func() time.Time {
+			return time.Now().UTC()
+		},
+	}
+}
+
+// Create - запрос монет у пользователя payer; запрос открыт до оплаты, отказа, отмены или истечения срока
+func (u *Usecase) Create(ctx context.Context, userID, payer string, amount int64, note string) (req models.PaymentRequest, err error) {
+	tx, err := u.repo.BeginTx(ctx)
+	if err != nil {
+		return req, fmt.Errorf("failed to begin tx: %w", err)
+	}
+
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback(ctx)
+		} else {
+			err = tx.Commit(ctx)
+		}
+	}()
+
+	from, err := u.repoUser.GetUserById(ctx, tx, userID)
+	if err != nil {
+		return req, err
+	}
+	if from.Username == payer {
+		err = ErrSameUser
+		return req, err
+	}
+
+	to, err := u.repoUser.GetUserByLoginWithTx(ctx, tx, payer)
+	if errors.Is(err, pgx.ErrNoRows) {
+		err = ErrPayerNotFound
+		return req, err
+	}
+	if err != nil {
+		return req, err
+	}
+
+	now := u.Now()
+	req = models.PaymentRequest{
+		ID:            uuid.New().String(),
+		RequesterID:   from.ID,
+		RequesterName: from.Username,
+		PayerID:       to.ID,
+		PayerName:     to.Username,
+		Amount:        amount,
+		Note:          note,
+		Status:        models.PaymentRequestOpen,
+		ExpiresAt:     now.Add(u.ttl),
+		CreatedAt:     now,
+	}
+	if err = u.repo.InsertRequest(ctx, tx, req); err != nil {
+		return req, err
+	}
+
+	return req, nil
+}
+
+// Incoming - открытые запросы, которые ждут оплаты от пользователя
+func (u *Usecase) Incoming(ctx context.Context, userID string) ([]models.PaymentRequest, error) {
+	return u.list(ctx, userID, u.repo.GetIncomingRequests)
+}
+
+// Outgoing - запросы, отправленные пользователем, во всех статусах
+func (u *Usecase) Outgoing(ctx context.Context, userID string) ([]models.PaymentRequest, error) {
+	return u.list(ctx, userID, u.repo.GetOutgoingRequests)
+}
+
+func (u *Usecase) list(ctx context.Context, userID string, get func(ctx context.Context, tx pgx.Tx, userID string) ([]models.PaymentRequest, error)) (res []models.PaymentRequest, err error) {
+	tx, err := u.repo.BeginTx(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin tx: %w", err)
+	}
+
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback(ctx)
+		} else {
+			err = tx.Commit(ctx)
+		}
+	}()
+
+	return get(ctx, tx, userID)
+}
+
+// Pay - плательщик оплачивает запрос обычным переводом; перевод привязывается к запросу
+func (u *Usecase) Pay(ctx context.Context, userID, id string) (models.PaymentRequest, error) {
+	return u.answer(ctx, id, func(req models.PaymentRequest) bool { return req.PayerID == userID },
+		func(ctx context.Context, tx pgx.Tx, req *models.PaymentRequest) error {
+			// перевод идёт в транзакции, держащей блокировку запроса, поэтому списание и статус paid
+			// фиксируются вместе, а повторная оплата увидит уже закрытый запрос
+			t, err := u.sender.SendCoinTx(ctx, tx, req.PayerID, req.RequesterName, req.Amount, req.Note, "")
+			if err != nil {
+				return err
+			}
+
+			req.Status, req.TransactionID = models.PaymentRequestPaid, t.ID
+			return u.repo.ResolveRequest(ctx, tx, req.ID, req.Status, req.TransactionID)
+		})
+}
+
+// Decline - плательщик отказывается платить по запросу
+func (u *Usecase) Decline(ctx context.Context, userID, id string) (models.PaymentRequest, error) {
+	return u.answer(ctx, id, func(req models.PaymentRequest) bool { return req.PayerID == userID },
+		u.close(models.PaymentRequestDeclined))
+}
+
+// Cancel - автор отзывает свой запрос
+func (u *Usecase) Cancel(ctx context.Context, userID, id string) (models.PaymentRequest, error) {
+	return u.answer(ctx, id, func(req models.PaymentRequest) bool { return req.RequesterID == userID },
+		u.close(models.PaymentRequestCancelled))
+}
+
+func (u *Usecase) close(status string) func(ctx context.Context, tx pgx.Tx, req *models.PaymentRequest) error {
+	return func(ctx context.Context, tx pgx.Tx, req *models.PaymentRequest) error {
+		req.Status = status
+		return u.repo.ResolveRequest(ctx, tx, req.ID, status, "")
+	}
+}
+
+// answer - блокирует открытый запрос и применяет к нему apply. Запрос, к которому пользователь
+// не имеет отношения (allowed), выглядит как несуществующий
+func (u *Usecase) answer(ctx context.Context, id string, allowed func(req models.PaymentRequest) bool,
+	apply func(ctx context.Context, tx pgx.Tx, req *models.PaymentRequest) error) (req models.PaymentRequest, err error) {
+	err = utils.RetryOnConflict(ctx, conflictAttempts, func() error {
+		req, err = u.answerOnce(ctx, id, allowed, apply)
+		return err
+	})
+
+	return req, err
+}
+
+func (u *Usecase) answerOnce(ctx context.Context, id string, allowed func(req models.PaymentRequest) bool,
+	apply func(ctx context.Context, tx pgx.Tx, req *models.PaymentRequest) error) (req models.PaymentRequest, err error) {
+	tx, err := u.repo.BeginTx(ctx)
+	if err != nil {
+		return req, fmt.Errorf("failed to begin tx: %w", err)
+	}
+
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback(ctx)
+		} else {
+			err = tx.Commit(ctx)
+		}
+	}()
+
+	req, err = u.repo.LockRequest(ctx, tx, id)
+	if err != nil {
+		return req, err
+	}
+	if !allowed(req) {
+		err = models.ErrPaymentRequestNotFound
+		return models.PaymentRequest{}, err
+	}
+	if req.Status != models.PaymentRequestOpen {
+		err = ErrRequestClosed
+		return req, err
+	}
+	if !u.Now().Before(req.ExpiresAt) {
+		err = ErrRequestExpired
+		return req, err
+	}
+
+	if err = apply(ctx, tx, &req); err != nil {
+		return req, err
+	}
+
+	return req, nil
+}
+
+// ExpireRequests - закрывает запросы, которые никто не оплатил в срок
+func (u *Usecase) ExpireRequests(ctx context.Context) (expired int64, err error) {
+	tx, err := u.repo.BeginTx(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin tx: %w", err)
+	}
+
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback(ctx)
+		} else {
+			err = tx.Commit(ctx)
+		}
+	}()
+
+	return u.repo.ExpireRequests(ctx, tx, u.Now())
+}
+
+// RunExpiry - раз в interval закрывает просроченные запросы, пока не отменён ctx
+func (u *Usecase) RunExpiry(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := u.ExpireRequests(ctx); err != nil {
+				log.Printf("payment requests: %v", err)
+			}
+		}
+	}
+}
@@ -0,0 +1,27 @@
+//go:generate mockgen -source=contract.go -destination=mocks/mock.go -package=mocks $GOPACKAGE
+//go:generate mockgen -destination=mocks/mock_tx.go -package=mocks github.com/jackc/pgx/v5 Tx
+package promotion
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+
+	"AvitoTask/internal/models"
+)
+
+type promotion interface {
+	BeginTx(ctx context.Context) (pgx.Tx, error)
+	InsertPromotion(ctx context.Context, tx pgx.Tx, p models.Promotion) error
+	ListPromotions(ctx context.Context, tx pgx.Tx) ([]models.Promotion, error)
+	EndPromotion(ctx context.Context, tx pgx.Tx, id string, at time.Time) error
+}
+
+type catalog interface {
+	GetItemByName(ctx context.Context, tx pgx.Tx, name string) (models.CatalogItem, error)
+}
+
+type notification interface {
+	QueueWishlistNotifications(ctx context.Context, tx pgx.Tx, n models.Notification, category string) (int64, error)
+}
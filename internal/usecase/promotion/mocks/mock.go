@@ -0,0 +1,172 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: contract.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	models "AvitoTask/internal/models"
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+	pgx "github.com/jackc/pgx/v5"
+)
+
+// Mockpromotion is a mock of promotion interface.
+type Mockpromotion struct {
+	ctrl     *gomock.Controller
+	recorder *MockpromotionMockRecorder
+}
+
+// MockpromotionMockRecorder is the mock recorder for Mockpromotion.
+type MockpromotionMockRecorder struct {
+	mock *Mockpromotion
+}
+
+// NewMockpromotion creates a new mock instance.
+func NewMockpromotion(ctrl *gomock.Controller) *Mockpromotion {
+	mock := &Mockpromotion{ctrl: ctrl}
+	mock.recorder = &MockpromotionMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mockpromotion) EXPECT() *MockpromotionMockRecorder {
+	return m.recorder
+}
+
+// BeginTx mocks base method.
+func (m *Mockpromotion) BeginTx(ctx context.Context) (pgx.Tx, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BeginTx", ctx)
+	ret0, _ := ret[0].(pgx.Tx)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BeginTx indicates an expected call of BeginTx.
+func (mr *MockpromotionMockRecorder) BeginTx(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BeginTx", reflect.TypeOf((*Mockpromotion)(nil).BeginTx), ctx)
+}
+
+// EndPromotion mocks base method.
+func (m *Mockpromotion) EndPromotion(ctx context.Context, tx pgx.Tx, id string, at time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EndPromotion", ctx, tx, id, at)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// EndPromotion indicates an expected call of EndPromotion.
+func (mr *MockpromotionMockRecorder) EndPromotion(ctx, tx, id, at interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EndPromotion", reflect.TypeOf((*Mockpromotion)(nil).EndPromotion), ctx, tx, id, at)
+}
+
+// InsertPromotion mocks base method.
+func (m *Mockpromotion) InsertPromotion(ctx context.Context, tx pgx.Tx, p models.Promotion) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertPromotion", ctx, tx, p)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InsertPromotion indicates an expected call of InsertPromotion.
+func (mr *MockpromotionMockRecorder) InsertPromotion(ctx, tx, p interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertPromotion", reflect.TypeOf((*Mockpromotion)(nil).InsertPromotion), ctx, tx, p)
+}
+
+// ListPromotions mocks base method.
+func (m *Mockpromotion) ListPromotions(ctx context.Context, tx pgx.Tx) ([]models.Promotion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPromotions", ctx, tx)
+	ret0, _ := ret[0].([]models.Promotion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPromotions indicates an expected call of ListPromotions.
+func (mr *MockpromotionMockRecorder) ListPromotions(ctx, tx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPromotions", reflect.TypeOf((*Mockpromotion)(nil).ListPromotions), ctx, tx)
+}
+
+// Mockcatalog is a mock of catalog interface.
+type Mockcatalog struct {
+	ctrl     *gomock.Controller
+	recorder *MockcatalogMockRecorder
+}
+
+// MockcatalogMockRecorder is the mock recorder for Mockcatalog.
+type MockcatalogMockRecorder struct {
+	mock *Mockcatalog
+}
+
+// NewMockcatalog creates a new mock instance.
+func NewMockcatalog(ctrl *gomock.Controller) *Mockcatalog {
+	mock := &Mockcatalog{ctrl: ctrl}
+	mock.recorder = &MockcatalogMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mockcatalog) EXPECT() *MockcatalogMockRecorder {
+	return m.recorder
+}
+
+// GetItemByName mocks base method.
+func (m *Mockcatalog) GetItemByName(ctx context.Context, tx pgx.Tx, name string) (models.CatalogItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetItemByName", ctx, tx, name)
+	ret0, _ := ret[0].(models.CatalogItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetItemByName indicates an expected call of GetItemByName.
+func (mr *MockcatalogMockRecorder) GetItemByName(ctx, tx, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetItemByName", reflect.TypeOf((*Mockcatalog)(nil).GetItemByName), ctx, tx, name)
+}
+
+// Mocknotification is a mock of notification interface.
+type Mocknotification struct {
+	ctrl     *gomock.Controller
+	recorder *MocknotificationMockRecorder
+}
+
+// MocknotificationMockRecorder is the mock recorder for Mocknotification.
+type MocknotificationMockRecorder struct {
+	mock *Mocknotification
+}
+
+// NewMocknotification creates a new mock instance.
+func NewMocknotification(ctrl *gomock.Controller) *Mocknotification {
+	mock := &Mocknotification{ctrl: ctrl}
+	mock.recorder = &MocknotificationMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mocknotification) EXPECT() *MocknotificationMockRecorder {
+	return m.recorder
+}
+
+// QueueWishlistNotifications mocks base method.
+func (m *Mocknotification) QueueWishlistNotifications(ctx context.Context, tx pgx.Tx, n models.Notification, category string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QueueWishlistNotifications", ctx, tx, n, category)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// QueueWishlistNotifications indicates an expected call of QueueWishlistNotifications.
+func (mr *MocknotificationMockRecorder) QueueWishlistNotifications(ctx, tx, n, category interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueueWishlistNotifications", reflect.TypeOf((*Mocknotification)(nil).QueueWishlistNotifications), ctx, tx, n, category)
+}
@@ -0,0 +1,121 @@
+package promotion_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+
+	"AvitoTask/internal/models"
+	"AvitoTask/internal/usecase/promotion"
+	"AvitoTask/internal/usecase/promotion/mocks"
+)
+
+func TestCreatePromotion_ItemSuccess(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockPromotion := mocks.NewMockpromotion(ctrl)
+	mockCatalog := mocks.NewMockcatalog(ctrl)
+	mockNotify := mocks.NewMocknotification(ctrl)
+	mockTx := mocks.NewMockTx(ctrl)
+
+	now := time.Date(2025, 2, 10, 12, 0, 0, 0, time.UTC)
+
+	mockPromotion.EXPECT().BeginTx(ctx).Return(mockTx, nil)
+	mockCatalog.EXPECT().GetItemByName(ctx, mockTx, "book").Return(models.CatalogItem{ID: "item-1", Name: "book"}, nil)
+	mockPromotion.EXPECT().InsertPromotion(ctx, mockTx, gomock.Any()).Return(nil)
+	mockNotify.EXPECT().QueueWishlistNotifications(ctx, mockTx, models.Notification{
+		Kind:   models.NotificationPriceDrop,
+		ItemID: "item-1",
+		Detail: "hackathon week: 50% off from 2025-02-10 to 2025-02-17",
+	}, "").Return(int64(3), nil)
+	mockTx.EXPECT().Commit(ctx).Return(nil)
+
+	uc := promotion.NewUsecase(mockPromotion, mockCatalog, mockNotify)
+	uc.Now = func() time.Time { return now }
+	p, err := uc.CreatePromotion(ctx, "admin", models.Promotion{
+		Name:     "hackathon week",
+		Item:     "book",
+		Percent:  50,
+		StartsAt: now,
+		EndsAt:   now.Add(7 * 24 * time.Hour),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if p.ItemID != "item-1" || p.ID == "" || p.CreatedBy != "admin" {
+		t.Errorf("unexpected promotion %+v", p)
+	}
+}
+
+func TestCreatePromotion_Invalid(t *testing.T) {
+	now := time.Date(2025, 2, 10, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name  string
+		draft models.Promotion
+		want  error
+	}{
+		{
+			name:  "no target",
+			draft: models.Promotion{Percent: 10, StartsAt: now, EndsAt: now.Add(time.Hour)},
+			want:  promotion.ErrInvalidTarget,
+		},
+		{
+			name:  "item and category",
+			draft: models.Promotion{Item: "book", Category: "merch", Percent: 10, StartsAt: now, EndsAt: now.Add(time.Hour)},
+			want:  promotion.ErrInvalidTarget,
+		},
+		{
+			name:  "percent and amount",
+			draft: models.Promotion{Category: "merch", Percent: 10, Amount: 5, StartsAt: now, EndsAt: now.Add(time.Hour)},
+			want:  promotion.ErrInvalidAmount,
+		},
+		{
+			name:  "already over",
+			draft: models.Promotion{Category: "merch", Amount: 5, StartsAt: now.Add(-2 * time.Hour), EndsAt: now.Add(-time.Hour)},
+			want:  promotion.ErrInvalidPeriod,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			uc := promotion.NewUsecase(mocks.NewMockpromotion(ctrl), mocks.NewMockcatalog(ctrl), nil)
+			uc.Now = func() time.Time { return now }
+			_, err := uc.CreatePromotion(context.Background(), "admin", tt.draft)
+			if !errors.Is(err, tt.want) {
+				t.Errorf("expected error %v, got %v", tt.want, err)
+			}
+		})
+	}
+}
+
+func TestEndPromotion_NotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockPromotion := mocks.NewMockpromotion(ctrl)
+	mockCatalog := mocks.NewMockcatalog(ctrl)
+	mockTx := mocks.NewMockTx(ctrl)
+
+	now := time.Date(2025, 2, 10, 12, 0, 0, 0, time.UTC)
+
+	mockPromotion.EXPECT().BeginTx(ctx).Return(mockTx, nil)
+	mockPromotion.EXPECT().EndPromotion(ctx, mockTx, "promo-1", now).Return(models.ErrPromotionNotFound)
+	mockTx.EXPECT().Rollback(ctx).Return(nil)
+
+	uc := promotion.NewUsecase(mockPromotion, mockCatalog, nil)
+	uc.Now = func() time.Time { return now }
+	err := uc.EndPromotion(ctx, "promo-1")
+	if !errors.Is(err, models.ErrPromotionNotFound) {
+		t.Errorf("expected error %v, got %v", models.ErrPromotionNotFound, err)
+	}
+}
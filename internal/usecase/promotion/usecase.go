@@ -0,0 +1,142 @@
+package promotion
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"AvitoTask/internal/models"
+)
+
+var (
+	ErrInvalidPeriod = errors.New("promotion must end after it starts and not in the past")
+	ErrInvalidTarget = errors.New("promotion must target either an item or a category")
+	ErrInvalidAmount = errors.New("promotion must set either a percent or a fixed amount")
+)
+
+type Usecase struct {
+	repo        promotion
+	repoCatalog catalog
+	repoNotify  notification
+	Now         func() time.Time
+}
+
+func NewUsecase(p promotion, c catalog, n notification) *Usecase {
+	return &Usecase{
+		repo:        p,
+		repoCatalog: c,
+		repoNotify:  n,
+		Now: func() time.Time {
+			return time.Now().UTC()
+		},
+	}
+}
+
+// CreatePromotion - заводит акцию; позиция в draft задаётся именем (draft.Item).
+// Всем, у кого в списке желаний есть подходящие позиции, уходит уведомление о снижении цены
+func (u *Usecase) CreatePromotion(ctx context.Context, adminID string, draft models.Promotion) (p models.Promotion, err error) {
+	if (draft.Item == "") == (draft.Category == "") {
+		return p, ErrInvalidTarget
+	}
+	if (draft.Percent == 0) == (draft.Amount == 0) {
+		return p, ErrInvalidAmount
+	}
+	if !draft.EndsAt.After(draft.StartsAt) || !draft.EndsAt.After(u.Now()) {
+		return p, ErrInvalidPeriod
+	}
+
+	tx, err := u.repo.BeginTx(ctx)
+	if err != nil {
+		return p, fmt.Errorf("failed to begin tx: %w", err)
+	}
+
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback(ctx)
+		} else {
+			err = tx.Commit(ctx)
+		}
+	}()
+
+	p = models.Promotion{
+		ID:        uuid.New().String(),
+		Name:      draft.Name,
+		Category:  draft.Category,
+		Percent:   draft.Percent,
+		Amount:    draft.Amount,
+		StartsAt:  draft.StartsAt.UTC(),
+		EndsAt:    draft.EndsAt.UTC(),
+		CreatedBy: adminID,
+		CreatedAt: u.Now(),
+	}
+
+	if draft.Item != "" {
+		item, err := u.repoCatalog.GetItemByName(ctx, tx, draft.Item)
+		if err != nil {
+			return p, err
+		}
+		p.ItemID = item.ID
+		p.Item = item.Name
+	}
+
+	if err = u.repo.InsertPromotion(ctx, tx, p); err != nil {
+		return p, err
+	}
+
+	_, err = u.repoNotify.QueueWishlistNotifications(ctx, tx, models.Notification{
+		Kind:   models.NotificationPriceDrop,
+		ItemID: p.ItemID,
+		Detail: promotionDetail(p),
+	}, p.Category)
+	if err != nil {
+		return p, err
+	}
+
+	return p, nil
+}
+
+func promotionDetail(p models.Promotion) string {
+	discount := fmt.Sprintf("%d coins", p.Amount)
+	if p.Percent > 0 {
+		discount = fmt.Sprintf("%d%%", p.Percent)
+	}
+	return fmt.Sprintf("%s: %s off from %s to %s", p.Name, discount,
+		p.StartsAt.Format(time.DateOnly), p.EndsAt.Format(time.DateOnly))
+}
+
+func (u *Usecase) ListPromotions(ctx context.Context) (promotions []models.Promotion, err error) {
+	tx, err := u.repo.BeginTx(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin tx: %w", err)
+	}
+
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback(ctx)
+		} else {
+			err = tx.Commit(ctx)
+		}
+	}()
+
+	return u.repo.ListPromotions(ctx, tx)
+}
+
+func (u *Usecase) EndPromotion(ctx context.Context, id string) (err error) {
+	tx, err := u.repo.BeginTx(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin tx: %w", err)
+	}
+
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback(ctx)
+		} else {
+			err = tx.Commit(ctx)
+		}
+	}()
+
+	return u.repo.EndPromotion(ctx, tx, id, u.Now())
+}
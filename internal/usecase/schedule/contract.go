@@ -0,0 +1,32 @@
+//go:generate mockgen -source=contract.go -destination=mocks/mock.go -package=mocks $GOPACKAGE
+//go:generate mockgen -destination=mocks/mock_tx.go -package=mocks github.com/jackc/pgx/v5 Tx
+package schedule
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+
+	"AvitoTask/internal/models"
+)
+
+type schedule interface {
+	BeginTx(ctx context.Context) (pgx.Tx, error)
+	InsertSchedule(ctx context.Context, tx pgx.Tx, s models.ScheduledTransfer) error
+	UpdateSchedule(ctx context.Context, tx pgx.Tx, s models.ScheduledTransfer) error
+	LockSchedule(ctx context.Context, tx pgx.Tx, id string) (models.ScheduledTransfer, error)
+	GetUserSchedules(ctx context.Context, tx pgx.Tx, userID string) ([]models.ScheduledTransfer, error)
+	LockDueSchedules(ctx context.Context, tx pgx.Tx, now time.Time, limit int64) ([]models.ScheduledTransfer, error)
+	InsertRun(ctx context.Context, tx pgx.Tx, run models.ScheduledRun) error
+	GetRuns(ctx context.Context, tx pgx.Tx, scheduleID string) ([]models.ScheduledRun, error)
+}
+
+type user interface {
+	GetUserById(ctx context.Context, tx pgx.Tx, userID string) (models.User, error)
+	GetUserByLoginWithTx(ctx context.Context, tx pgx.Tx, login string) (models.User, error)
+}
+
+type sender interface {
+	SendCoin(ctx context.Context, fromUser, toUser string, amount int64, message, category string) (models.Transfer, error)
+}
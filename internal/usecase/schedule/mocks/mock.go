@@ -0,0 +1,246 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: contract.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	models "AvitoTask/internal/models"
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+	pgx "github.com/jackc/pgx/v5"
+)
+
+// Mockschedule is a mock of schedule interface.
+type Mockschedule struct {
+	ctrl     *gomock.Controller
+	recorder *MockscheduleMockRecorder
+}
+
+// MockscheduleMockRecorder is the mock recorder for Mockschedule.
+type MockscheduleMockRecorder struct {
+	mock *Mockschedule
+}
+
+// NewMockschedule creates a new mock instance.
+func NewMockschedule(ctrl *gomock.Controller) *Mockschedule {
+	mock := &Mockschedule{ctrl: ctrl}
+	mock.recorder = &MockscheduleMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mockschedule) EXPECT() *MockscheduleMockRecorder {
+	return m.recorder
+}
+
+// BeginTx mocks base method.
+func (m *Mockschedule) BeginTx(ctx context.Context) (pgx.Tx, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BeginTx", ctx)
+	ret0, _ := ret[0].(pgx.Tx)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BeginTx indicates an expected call of BeginTx.
+func (mr *MockscheduleMockRecorder) BeginTx(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BeginTx", reflect.TypeOf((*Mockschedule)(nil).BeginTx), ctx)
+}
+
+// GetRuns mocks base method.
+func (m *Mockschedule) GetRuns(ctx context.Context, tx pgx.Tx, scheduleID string) ([]models.ScheduledRun, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRuns", ctx, tx, scheduleID)
+	ret0, _ := ret[0].([]models.ScheduledRun)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRuns indicates an expected call of GetRuns.
+func (mr *MockscheduleMockRecorder) GetRuns(ctx, tx, scheduleID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRuns", reflect.TypeOf((*Mockschedule)(nil).GetRuns), ctx, tx, scheduleID)
+}
+
+// GetUserSchedules mocks base method.
+func (m *Mockschedule) GetUserSchedules(ctx context.Context, tx pgx.Tx, userID string) ([]models.ScheduledTransfer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserSchedules", ctx, tx, userID)
+	ret0, _ := ret[0].([]models.ScheduledTransfer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserSchedules indicates an expected call of GetUserSchedules.
+func (mr *MockscheduleMockRecorder) GetUserSchedules(ctx, tx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserSchedules", reflect.TypeOf((*Mockschedule)(nil).GetUserSchedules), ctx, tx, userID)
+}
+
+// InsertRun mocks base method.
+func (m *Mockschedule) InsertRun(ctx context.Context, tx pgx.Tx, run models.ScheduledRun) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertRun", ctx, tx, run)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InsertRun indicates an expected call of InsertRun.
+func (mr *MockscheduleMockRecorder) InsertRun(ctx, tx, run interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertRun", reflect.TypeOf((*Mockschedule)(nil).InsertRun), ctx, tx, run)
+}
+
+// InsertSchedule mocks base method.
+func (m *Mockschedule) InsertSchedule(ctx context.Context, tx pgx.Tx, s models.ScheduledTransfer) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertSchedule", ctx, tx, s)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InsertSchedule indicates an expected call of InsertSchedule.
+func (mr *MockscheduleMockRecorder) InsertSchedule(ctx, tx, s interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertSchedule", reflect.TypeOf((*Mockschedule)(nil).InsertSchedule), ctx, tx, s)
+}
+
+// LockDueSchedules mocks base method.
+func (m *Mockschedule) LockDueSchedules(ctx context.Context, tx pgx.Tx, now time.Time, limit int64) ([]models.ScheduledTransfer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LockDueSchedules", ctx, tx, now, limit)
+	ret0, _ := ret[0].([]models.ScheduledTransfer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LockDueSchedules indicates an expected call of LockDueSchedules.
+func (mr *MockscheduleMockRecorder) LockDueSchedules(ctx, tx, now, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LockDueSchedules", reflect.TypeOf((*Mockschedule)(nil).LockDueSchedules), ctx, tx, now, limit)
+}
+
+// LockSchedule mocks base method.
+func (m *Mockschedule) LockSchedule(ctx context.Context, tx pgx.Tx, id string) (models.ScheduledTransfer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LockSchedule", ctx, tx, id)
+	ret0, _ := ret[0].(models.ScheduledTransfer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LockSchedule indicates an expected call of LockSchedule.
+func (mr *MockscheduleMockRecorder) LockSchedule(ctx, tx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LockSchedule", reflect.TypeOf((*Mockschedule)(nil).LockSchedule), ctx, tx, id)
+}
+
+// UpdateSchedule mocks base method.
+func (m *Mockschedule) UpdateSchedule(ctx context.Context, tx pgx.Tx, s models.ScheduledTransfer) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateSchedule", ctx, tx, s)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateSchedule indicates an expected call of UpdateSchedule.
+func (mr *MockscheduleMockRecorder) UpdateSchedule(ctx, tx, s interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSchedule", reflect.TypeOf((*Mockschedule)(nil).UpdateSchedule), ctx, tx, s)
+}
+
+// Mockuser is a mock of user interface.
+type Mockuser struct {
+	ctrl     *gomock.Controller
+	recorder *MockuserMockRecorder
+}
+
+// MockuserMockRecorder is the mock recorder for Mockuser.
+type MockuserMockRecorder struct {
+	mock *Mockuser
+}
+
+// NewMockuser creates a new mock instance.
+func NewMockuser(ctrl *gomock.Controller) *Mockuser {
+	mock := &Mockuser{ctrl: ctrl}
+	mock.recorder = &MockuserMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mockuser) EXPECT() *MockuserMockRecorder {
+	return m.recorder
+}
+
+// GetUserById mocks base method.
+func (m *Mockuser) GetUserById(ctx context.Context, tx pgx.Tx, userID string) (models.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserById", ctx, tx, userID)
+	ret0, _ := ret[0].(models.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserById indicates an expected call of GetUserById.
+func (mr *MockuserMockRecorder) GetUserById(ctx, tx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserById", reflect.TypeOf((*Mockuser)(nil).GetUserById), ctx, tx, userID)
+}
+
+// GetUserByLoginWithTx mocks base method.
+func (m *Mockuser) GetUserByLoginWithTx(ctx context.Context, tx pgx.Tx, login string) (models.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserByLoginWithTx", ctx, tx, login)
+	ret0, _ := ret[0].(models.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserByLoginWithTx indicates an expected call of GetUserByLoginWithTx.
+func (mr *MockuserMockRecorder) GetUserByLoginWithTx(ctx, tx, login interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByLoginWithTx", reflect.TypeOf((*Mockuser)(nil).GetUserByLoginWithTx), ctx, tx, login)
+}
+
+// Mocksender is a mock of sender interface.
+type Mocksender struct {
+	ctrl     *gomock.Controller
+	recorder *MocksenderMockRecorder
+}
+
+// MocksenderMockRecorder is the mock recorder for Mocksender.
+type MocksenderMockRecorder struct {
+	mock *Mocksender
+}
+
+// NewMocksender creates a new mock instance.
+func NewMocksender(ctrl *gomock.Controller) *Mocksender {
+	mock := &Mocksender{ctrl: ctrl}
+	mock.recorder = &MocksenderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mocksender) EXPECT() *MocksenderMockRecorder {
+	return m.recorder
+}
+
+// SendCoin mocks base method.
+func (m *Mocksender) SendCoin(ctx context.Context, fromUser, toUser string, amount int64, message, category string) (models.Transfer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendCoin", ctx, fromUser, toUser, amount, message, category)
+	ret0, _ := ret[0].(models.Transfer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SendCoin indicates an expected call of SendCoin.
+func (mr *MocksenderMockRecorder) SendCoin(ctx, fromUser, toUser, amount, message, category interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendCoin", reflect.TypeOf((*Mocksender)(nil).SendCoin), ctx, fromUser, toUser, amount, message, category)
+}
@@ -0,0 +1,303 @@
+package schedule_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/jackc/pgx/v5"
+
+	"AvitoTask/internal/models"
+	"AvitoTask/internal/usecase/schedule"
+	"AvitoTask/internal/usecase/schedule/mocks"
+	"AvitoTask/internal/usecase/send_coin"
+	"AvitoTask/internal/utils"
+)
+
+var now = time.Date(2025, 3, 10, 9, 0, 0, 0, time.UTC)
+
+func newUsecase(s *mocks.Mockschedule, u *mocks.Mockuser, snd *mocks.Mocksender) *schedule.Usecase {
+	uc := schedule.NewUsecase(s, u, snd, time.Hour, 3)
+	uc.Now = func() time.Time { return now }
+	return uc
+}
+
+func TestCreate_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockSchedule := mocks.NewMockschedule(ctrl)
+	mockUser := mocks.NewMockuser(ctrl)
+	mockTx := mocks.NewMockTx(ctrl)
+
+	monday := now.Add(7 * 24 * time.Hour)
+
+	mockSchedule.EXPECT().BeginTx(ctx).Return(mockTx, nil)
+	mockUser.EXPECT().GetUserById(ctx, mockTx, "user1").Return(models.User{ID: "user1", Username: "alice"}, nil)
+	mockUser.EXPECT().GetUserByLoginWithTx(ctx, mockTx, "mentor").Return(models.User{ID: "user2", Username: "mentor"}, nil)
+	mockSchedule.EXPECT().InsertSchedule(ctx, mockTx, gomock.Any()).DoAndReturn(
+		func(_ context.Context, _ pgx.Tx, s models.ScheduledTransfer) error {
+			if s.Status != models.ScheduleActive || s.OnFailure != models.OnFailureRetry ||
+				!s.RunAt.Equal(monday) || !s.DueAt.Equal(monday) || s.Repeat != models.RepeatWeekly {
+				t.Errorf("unexpected schedule %+v", s)
+			}
+			return nil
+		})
+	mockTx.EXPECT().Commit(ctx).Return(nil)
+
+	uc := newUsecase(mockSchedule, mockUser, mocks.NewMocksender(ctrl))
+	_, err := uc.Create(ctx, "user1", models.ScheduledTransfer{
+		ToUser: "mentor",
+		Amount: 10,
+		RunAt:  monday,
+		Repeat: models.RepeatWeekly,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestCreate_Validation(t *testing.T) {
+	tests := []struct {
+		name  string
+		draft models.ScheduledTransfer
+		want  error
+	}{
+		{"in the past", models.ScheduledTransfer{ToUser: "bob", Amount: 10, RunAt: now.Add(-time.Minute)}, schedule.ErrRunAtInPast},
+		{"unknown repeat", models.ScheduledTransfer{ToUser: "bob", Amount: 10, RunAt: now.Add(time.Hour), Repeat: "hourly"}, schedule.ErrInvalidSchedule},
+		{"unknown policy", models.ScheduledTransfer{ToUser: "bob", Amount: 10, RunAt: now.Add(time.Hour), OnFailure: "panic"}, schedule.ErrInvalidSchedule},
+		{"unknown category", models.ScheduledTransfer{ToUser: "bob", Amount: 10, RunAt: now.Add(time.Hour), Category: "bribe"}, schedule.ErrUnknownCategory},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			uc := newUsecase(mocks.NewMockschedule(ctrl), mocks.NewMockuser(ctrl), mocks.NewMocksender(ctrl))
+			_, err := uc.Create(context.Background(), "user1", tt.draft)
+			if !errors.Is(err, tt.want) {
+				t.Errorf("expected error %v, got %v", tt.want, err)
+			}
+		})
+	}
+}
+
+func TestCreate_RecipientNotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockSchedule := mocks.NewMockschedule(ctrl)
+	mockUser := mocks.NewMockuser(ctrl)
+	mockTx := mocks.NewMockTx(ctrl)
+
+	mockSchedule.EXPECT().BeginTx(ctx).Return(mockTx, nil)
+	mockUser.EXPECT().GetUserById(ctx, mockTx, "user1").Return(models.User{ID: "user1", Username: "alice"}, nil)
+	mockUser.EXPECT().GetUserByLoginWithTx(ctx, mockTx, "ghost").Return(models.User{}, fmt.Errorf("failed to scan user: %w", pgx.ErrNoRows))
+	mockTx.EXPECT().Rollback(ctx).Return(nil)
+
+	uc := newUsecase(mockSchedule, mockUser, mocks.NewMocksender(ctrl))
+	_, err := uc.Create(ctx, "user1", models.ScheduledTransfer{ToUser: "ghost", Amount: 10, RunAt: now.Add(time.Hour)})
+	if !errors.Is(err, schedule.ErrRecipientNotFound) {
+		t.Errorf("expected error %v, got %v", schedule.ErrRecipientNotFound, err)
+	}
+}
+
+func TestRunDue_SendsAndMovesToNextOccurrence(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockSchedule := mocks.NewMockschedule(ctrl)
+	mockSender := mocks.NewMocksender(ctrl)
+	mockTx := mocks.NewMockTx(ctrl)
+
+	planned := now.Add(-time.Minute)
+	s := models.ScheduledTransfer{
+		ID: "sched-1", UserID: "user1", ToUser: "mentor", Amount: 10, Category: models.TransferThanks,
+		Repeat: models.RepeatWeekly, OnFailure: models.OnFailureRetry, Status: models.ScheduleActive,
+		RunAt: planned, DueAt: planned,
+	}
+
+	mockSchedule.EXPECT().BeginTx(ctx).Return(mockTx, nil)
+	mockSchedule.EXPECT().LockDueSchedules(ctx, mockTx, now, gomock.Any()).Return([]models.ScheduledTransfer{s}, nil)
+	mockSender.EXPECT().SendCoin(gomock.Any(), "user1", "mentor", int64(10), "", models.TransferThanks).DoAndReturn(
+		func(ctx context.Context, _, _ string, _ int64, _, _ string) (models.Transfer, error) {
+			key, ok := utils.IdempotencyKeyFrom(ctx)
+			if want := s.IdempotencyKey(); !ok || key.Key != want.Key || key.RequestHash != want.RequestHash {
+				t.Errorf("expected idempotency key of the run, got %+v", key)
+			}
+			return models.Transfer{ID: "tr-1"}, nil
+		})
+	mockSchedule.EXPECT().InsertRun(ctx, mockTx, gomock.Any()).DoAndReturn(
+		func(_ context.Context, _ pgx.Tx, run models.ScheduledRun) error {
+			if run.Status != models.RunSent || !run.RunAt.Equal(planned) {
+				t.Errorf("unexpected run %+v", run)
+			}
+			return nil
+		})
+	mockSchedule.EXPECT().UpdateSchedule(ctx, mockTx, gomock.Any()).DoAndReturn(
+		func(_ context.Context, _ pgx.Tx, updated models.ScheduledTransfer) error {
+			next := planned.AddDate(0, 0, 7)
+			if updated.Status != models.ScheduleActive || !updated.RunAt.Equal(next) || !updated.DueAt.Equal(next) {
+				t.Errorf("unexpected schedule after run %+v", updated)
+			}
+			return nil
+		})
+	mockTx.EXPECT().Commit(ctx).Return(nil)
+
+	uc := newUsecase(mockSchedule, mocks.NewMockuser(ctrl), mockSender)
+	n, err := uc.RunDue(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if n != 1 {
+		t.Errorf("expected 1 processed run, got %d", n)
+	}
+}
+
+func TestRunDue_FailurePolicy(t *testing.T) {
+	planned := now.Add(-time.Minute)
+
+	tests := []struct {
+		name     string
+		schedule models.ScheduledTransfer
+		check    func(t *testing.T, s models.ScheduledTransfer)
+	}{
+		{
+			name: "retry is postponed",
+			schedule: models.ScheduledTransfer{ID: "sched-1", UserID: "user1", ToUser: "bob", Amount: 10,
+				OnFailure: models.OnFailureRetry, Status: models.ScheduleActive, RunAt: planned, DueAt: planned},
+			check: func(t *testing.T, s models.ScheduledTransfer) {
+				if s.Status != models.ScheduleActive || s.Attempts != 1 || !s.DueAt.Equal(now.Add(time.Hour)) ||
+					!s.RunAt.Equal(planned) || s.LastError == "" {
+					t.Errorf("unexpected schedule %+v", s)
+				}
+			},
+		},
+		{
+			name: "retries exhausted",
+			schedule: models.ScheduledTransfer{ID: "sched-1", UserID: "user1", ToUser: "bob", Amount: 10, Attempts: 2,
+				OnFailure: models.OnFailureRetry, Status: models.ScheduleActive, RunAt: planned, DueAt: planned},
+			check: func(t *testing.T, s models.ScheduledTransfer) {
+				if s.Status != models.ScheduleFailed || s.Attempts != 0 {
+					t.Errorf("unexpected schedule %+v", s)
+				}
+			},
+		},
+		{
+			name: "recurring run is skipped",
+			schedule: models.ScheduledTransfer{ID: "sched-1", UserID: "user1", ToUser: "bob", Amount: 10, Repeat: models.RepeatDaily,
+				OnFailure: models.OnFailureSkip, Status: models.ScheduleActive, RunAt: planned, DueAt: planned},
+			check: func(t *testing.T, s models.ScheduledTransfer) {
+				next := planned.AddDate(0, 0, 1)
+				if s.Status != models.ScheduleActive || !s.RunAt.Equal(next) || !s.DueAt.Equal(next) || s.LastError == "" {
+					t.Errorf("unexpected schedule %+v", s)
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			ctx := context.Background()
+			mockSchedule := mocks.NewMockschedule(ctrl)
+			mockSender := mocks.NewMocksender(ctrl)
+			mockTx := mocks.NewMockTx(ctrl)
+
+			mockSchedule.EXPECT().BeginTx(ctx).Return(mockTx, nil)
+			mockSchedule.EXPECT().LockDueSchedules(ctx, mockTx, now, gomock.Any()).Return([]models.ScheduledTransfer{tt.schedule}, nil)
+			mockSender.EXPECT().SendCoin(gomock.Any(), "user1", "bob", int64(10), "", "").Return(models.Transfer{}, send_coin.ErrNotEnoughCoins)
+			mockSchedule.EXPECT().InsertRun(ctx, mockTx, gomock.Any()).DoAndReturn(
+				func(_ context.Context, _ pgx.Tx, run models.ScheduledRun) error {
+					if run.Status != models.RunFailed || run.Error != send_coin.ErrNotEnoughCoins.Error() {
+						t.Errorf("unexpected run %+v", run)
+					}
+					return nil
+				})
+			mockSchedule.EXPECT().UpdateSchedule(ctx, mockTx, gomock.Any()).DoAndReturn(
+				func(_ context.Context, _ pgx.Tx, s models.ScheduledTransfer) error {
+					tt.check(t, s)
+					return nil
+				})
+			mockTx.EXPECT().Commit(ctx).Return(nil)
+
+			uc := newUsecase(mockSchedule, mocks.NewMockuser(ctrl), mockSender)
+			if _, err := uc.RunDue(ctx); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestPause_OtherUsersSchedule(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockSchedule := mocks.NewMockschedule(ctrl)
+	mockTx := mocks.NewMockTx(ctrl)
+
+	mockSchedule.EXPECT().BeginTx(ctx).Return(mockTx, nil)
+	mockSchedule.EXPECT().LockSchedule(ctx, mockTx, "sched-1").Return(models.ScheduledTransfer{ID: "sched-1", UserID: "user2", Status: models.ScheduleActive}, nil)
+	mockTx.EXPECT().Rollback(ctx).Return(nil)
+
+	uc := newUsecase(mockSchedule, mocks.NewMockuser(ctrl), mocks.NewMocksender(ctrl))
+	_, err := uc.Pause(ctx, "user1", "sched-1")
+	if !errors.Is(err, models.ErrScheduleNotFound) {
+		t.Errorf("expected error %v, got %v", models.ErrScheduleNotFound, err)
+	}
+}
+
+func TestCancel_AlreadyDone(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockSchedule := mocks.NewMockschedule(ctrl)
+	mockTx := mocks.NewMockTx(ctrl)
+
+	mockSchedule.EXPECT().BeginTx(ctx).Return(mockTx, nil)
+	mockSchedule.EXPECT().LockSchedule(ctx, mockTx, "sched-1").Return(models.ScheduledTransfer{ID: "sched-1", UserID: "user1", Status: models.ScheduleDone}, nil)
+	mockTx.EXPECT().Rollback(ctx).Return(nil)
+
+	uc := newUsecase(mockSchedule, mocks.NewMockuser(ctrl), mocks.NewMocksender(ctrl))
+	_, err := uc.Cancel(ctx, "user1", "sched-1")
+	if !errors.Is(err, schedule.ErrScheduleClosed) {
+		t.Errorf("expected error %v, got %v", schedule.ErrScheduleClosed, err)
+	}
+}
+
+func TestResume_SkipsMissedOccurrences(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockSchedule := mocks.NewMockschedule(ctrl)
+	mockTx := mocks.NewMockTx(ctrl)
+
+	planned := now.AddDate(0, 0, -15)
+	mockSchedule.EXPECT().BeginTx(ctx).Return(mockTx, nil)
+	mockSchedule.EXPECT().LockSchedule(ctx, mockTx, "sched-1").Return(models.ScheduledTransfer{
+		ID: "sched-1", UserID: "user1", Status: models.SchedulePaused, Repeat: models.RepeatWeekly, RunAt: planned, DueAt: planned,
+	}, nil)
+	mockSchedule.EXPECT().UpdateSchedule(ctx, mockTx, gomock.Any()).Return(nil)
+	mockTx.EXPECT().Commit(ctx).Return(nil)
+
+	uc := newUsecase(mockSchedule, mocks.NewMockuser(ctrl), mocks.NewMocksender(ctrl))
+	res, err := uc.Resume(ctx, "user1", "sched-1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := planned.AddDate(0, 0, 21); res.Status != models.ScheduleActive || !res.RunAt.Equal(want) {
+		t.Errorf("expected active schedule at %v, got %+v", want, res)
+	}
+}
@@ -0,0 +1,296 @@
+package schedule
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+
+	"AvitoTask/internal/models"
+)
+
+// dueBatch - сколько наступивших запусков планировщик обрабатывает за одну транзакцию
+const dueBatch = 50
+
+var (
+	ErrInvalidSchedule   = errors.New("repeat must be daily, weekly, monthly or empty and onFailure retry or skip")
+	ErrRunAtInPast       = errors.New("scheduled time must be in the future")
+	ErrUnknownCategory   = errors.New("unknown transfer category")
+	ErrSameUser          = errors.New("cannot schedule a transfer to yourself")
+	ErrRecipientNotFound = errors.New("recipient does not exist")
+	ErrScheduleClosed    = errors.New("scheduled transfer is already cancelled or finished")
+)
+
+type Usecase struct {
+	repo        schedule
+	repoUser    user
+	sender      sender
+	retryDelay  time.Duration
+	maxAttempts int
+	Now         func() time.Time
+}
+
+func NewUsecase(s schedule, u user, snd sender, retryDelay time.Duration, maxAttempts int) *Usecase {
+	return &Usecase{
+		repo:        s,
+		repoUser:    u,
+		sender:      snd,
+		retryDelay:  retryDelay,
+		maxAttempts: maxAttempts,
+		Now: func() time.Time {
+			return time.Now().UTC()
+		},
+	}
+}
+
+// Create - планирует перевод на draft.RunAt; при непустом draft.Repeat перевод повторяется
+func (u *Usecase) Create(ctx context.Context, userID string, draft models.ScheduledTransfer) (s models.ScheduledTransfer, err error) {
+	if draft.OnFailure == "" {
+		draft.OnFailure = models.OnFailureRetry
+	}
+	if !models.ValidRepeat(draft.Repeat) || !models.ValidOnFailure(draft.OnFailure) {
+		return s, ErrInvalidSchedule
+	}
+	if !models.ValidTransferCategory(draft.Category) {
+		return s, ErrUnknownCategory
+	}
+	if !draft.RunAt.After(u.Now()) {
+		return s, ErrRunAtInPast
+	}
+
+	tx, err := u.repo.BeginTx(ctx)
+	if err != nil {
+		return s, fmt.Errorf("failed to begin tx: %w", err)
+	}
+
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback(ctx)
+		} else {
+			err = tx.Commit(ctx)
+		}
+	}()
+
+	from, err := u.repoUser.GetUserById(ctx, tx, userID)
+	if err != nil {
+		return s, err
+	}
+	if from.Username == draft.ToUser {
+		err = ErrSameUser
+		return s, err
+	}
+
+	_, err = u.repoUser.GetUserByLoginWithTx(ctx, tx, draft.ToUser)
+	if errors.Is(err, pgx.ErrNoRows) {
+		err = ErrRecipientNotFound
+		return s, err
+	}
+	if err != nil {
+		return s, err
+	}
+
+	runAt := draft.RunAt.UTC()
+	s = models.ScheduledTransfer{
+		ID:        uuid.New().String(),
+		UserID:    userID,
+		ToUser:    draft.ToUser,
+		Amount:    draft.Amount,
+		Message:   draft.Message,
+		Category:  draft.Category,
+		Repeat:    draft.Repeat,
+		OnFailure: draft.OnFailure,
+		Status:    models.ScheduleActive,
+		RunAt:     runAt,
+		DueAt:     runAt,
+	}
+	if err = u.repo.InsertSchedule(ctx, tx, s); err != nil {
+		return s, err
+	}
+
+	return s, nil
+}
+
+func (u *Usecase) List(ctx context.Context, userID string) (res []models.ScheduledTransfer, err error) {
+	tx, err := u.repo.BeginTx(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin tx: %w", err)
+	}
+
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback(ctx)
+		} else {
+			err = tx.Commit(ctx)
+		}
+	}()
+
+	return u.repo.GetUserSchedules(ctx, tx, userID)
+}
+
+// Runs - история запусков расписания пользователя
+func (u *Usecase) Runs(ctx context.Context, userID, id string) (res []models.ScheduledRun, err error) {
+	tx, err := u.repo.BeginTx(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin tx: %w", err)
+	}
+
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback(ctx)
+		} else {
+			err = tx.Commit(ctx)
+		}
+	}()
+
+	if _, err = u.own(ctx, tx, userID, id); err != nil {
+		return nil, err
+	}
+
+	return u.repo.GetRuns(ctx, tx, id)
+}
+
+func (u *Usecase) Pause(ctx context.Context, userID, id string) (models.ScheduledTransfer, error) {
+	return u.change(ctx, userID, id, func(s *models.ScheduledTransfer) {
+		s.Status = models.SchedulePaused
+	})
+}
+
+// Resume - возобновляет расписание; запуски повторяющегося перевода, пропущенные на паузе, не выполняются
+func (u *Usecase) Resume(ctx context.Context, userID, id string) (models.ScheduledTransfer, error) {
+	return u.change(ctx, userID, id, func(s *models.ScheduledTransfer) {
+		s.Status = models.ScheduleActive
+		if s.Repeat != "" && s.RunAt.Before(u.Now()) {
+			s.RunAt = s.NextAfter(u.Now())
+			s.DueAt = s.RunAt
+			s.Attempts = 0
+		}
+	})
+}
+
+func (u *Usecase) Cancel(ctx context.Context, userID, id string) (models.ScheduledTransfer, error) {
+	return u.change(ctx, userID, id, func(s *models.ScheduledTransfer) {
+		s.Status = models.ScheduleCancelled
+	})
+}
+
+func (u *Usecase) change(ctx context.Context, userID, id string, apply func(s *models.ScheduledTransfer)) (s models.ScheduledTransfer, err error) {
+	tx, err := u.repo.BeginTx(ctx)
+	if err != nil {
+		return s, fmt.Errorf("failed to begin tx: %w", err)
+	}
+
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback(ctx)
+		} else {
+			err = tx.Commit(ctx)
+		}
+	}()
+
+	s, err = u.own(ctx, tx, userID, id)
+	if err != nil {
+		return s, err
+	}
+
+	if s.Closed() {
+		err = ErrScheduleClosed
+		return s, err
+	}
+
+	apply(&s)
+	if err = u.repo.UpdateSchedule(ctx, tx, s); err != nil {
+		return s, err
+	}
+
+	return s, nil
+}
+
+// own - блокирует расписание; чужое расписание выглядит как несуществующее
+func (u *Usecase) own(ctx context.Context, tx pgx.Tx, userID, id string) (models.ScheduledTransfer, error) {
+	s, err := u.repo.LockSchedule(ctx, tx, id)
+	if err != nil {
+		return s, err
+	}
+	if s.UserID != userID {
+		return models.ScheduledTransfer{}, models.ErrScheduleNotFound
+	}
+	return s, nil
+}
+
+// Run - планировщик: раз в interval выполняет наступившие запуски, пока не отменён ctx
+func (u *Usecase) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := u.RunDue(ctx); err != nil {
+				log.Printf("scheduled transfers: %v", err)
+			}
+		}
+	}
+}
+
+// RunDue - выполняет наступившие запуски через SendCoin и сохраняет их результат. Неудачный
+// перевод не прерывает обработку остальных: он записывается в историю запусков и обрабатывается
+// по политике расписания
+func (u *Usecase) RunDue(ctx context.Context) (processed int, err error) {
+	tx, err := u.repo.BeginTx(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin tx: %w", err)
+	}
+
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback(ctx)
+		} else {
+			err = tx.Commit(ctx)
+		}
+	}()
+
+	now := u.Now()
+	due, err := u.repo.LockDueSchedules(ctx, tx, now, dueBatch)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, s := range due {
+		if err = u.run(ctx, tx, s, now); err != nil {
+			return processed, err
+		}
+		processed++
+	}
+
+	return processed, nil
+}
+
+func (u *Usecase) run(ctx context.Context, tx pgx.Tx, s models.ScheduledTransfer, now time.Time) error {
+	// перевод идёт в своей транзакции; ключ идемпотентности не даст выполнить его повторно,
+	// если эта транзакция с результатом запуска откатится
+	sendCtx := context.WithValue(ctx, models.IdempotencyKeyLocal, s.IdempotencyKey())
+	_, sendErr := u.sender.SendCoin(sendCtx, s.UserID, s.ToUser, s.Amount, s.Message, s.Category)
+
+	run := models.ScheduledRun{
+		ID:         uuid.New().String(),
+		ScheduleID: s.ID,
+		RunAt:      s.RunAt,
+		ExecutedAt: now,
+		Status:     models.RunSent,
+	}
+	if sendErr != nil {
+		run.Status = models.RunFailed
+		run.Error = sendErr.Error()
+	}
+	if err := u.repo.InsertRun(ctx, tx, run); err != nil {
+		return err
+	}
+
+	return u.repo.UpdateSchedule(ctx, tx, s.Advance(now, sendErr, u.retryDelay, u.maxAttempts))
+}
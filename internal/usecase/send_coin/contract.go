@@ -4,6 +4,7 @@ package send_coin
 
 import (
 	"context"
+	"time"
 
 	"github.com/jackc/pgx/v5"
 
@@ -15,9 +16,30 @@ type user interface {
 	GetUserById(ctx context.Context, tx pgx.Tx, userID string) (models.User, error)
 	GetUserByLoginWithTx(ctx context.Context, tx pgx.Tx, login string) (models.User, error)
 	IsUserExists(ctx context.Context, user models.User) (bool, error)
-	UpdateUserCoins(ctx context.Context, tx pgx.Tx, userID string, newCoins int64) error
+	DebitUserCoins(ctx context.Context, tx pgx.Tx, userID string, amount int64) error
+	CreditUserCoins(ctx context.Context, tx pgx.Tx, userID string, amount int64) error
 }
 
 type transaction interface {
-	InsertTransaction(ctx context.Context, tx pgx.Tx, id, fromUserID, toUserID string, amount int64) error
+	InsertTransaction(ctx context.Context, tx pgx.Tx, t models.Transfer) error
+	GetSentTotals(ctx context.Context, tx pgx.Tx, userID, toUserID string, since time.Time) (models.TransferUsage, error)
+	GetPendingTransfers(ctx context.Context, tx pgx.Tx, userID string) ([]models.TransactionItem, error)
+	LockTransaction(ctx context.Context, tx pgx.Tx, id string) (models.Transfer, error)
+	LockExpiredTransfers(ctx context.Context, tx pgx.Tx, now time.Time, limit int64) ([]models.Transfer, error)
+	ResolveTransaction(ctx context.Context, tx pgx.Tx, id, status string) error
+}
+
+type limit interface {
+	GetUserLimits(ctx context.Context, tx pgx.Tx, userID string) (map[string]models.LimitPolicy, error)
+	SetUserLimit(ctx context.Context, tx pgx.Tx, userID, period string, p models.LimitPolicy, setBy string) error
+	DeleteUserLimit(ctx context.Context, tx pgx.Tx, userID, period string) error
+}
+
+type ledger interface {
+	PostEntry(ctx context.Context, tx pgx.Tx, e models.LedgerEntry) error
+}
+
+type idempotency interface {
+	ClaimKey(ctx context.Context, tx pgx.Tx, k models.IdempotencyKey) (models.IdempotencyKey, error)
+	SaveResponse(ctx context.Context, tx pgx.Tx, k models.IdempotencyKey) error
 }
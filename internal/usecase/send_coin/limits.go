@@ -0,0 +1,160 @@
+package send_coin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+
+	"AvitoTask/internal/models"
+)
+
+var limitPeriods = []string{models.LimitDaily, models.LimitMonthly}
+
+var ErrUnknownLimitPeriod = errors.New("limit period must be daily or monthly")
+
+// checkLimits - проверяет, что переводы credits укладываются в ограничения отправителя. Вызывается после
+// списания: строка отправителя уже заблокирована, поэтому его параллельные переводы проверяются по очереди
+// и каждый видит суммы предыдущих
+func (u *Usecase) checkLimits(ctx context.Context, tx pgx.Tx, fromID string, credits map[string]int64) error {
+	personal, err := u.repoLimits.GetUserLimits(ctx, tx, fromID)
+	if err != nil {
+		return err
+	}
+	limits := u.limits.Override(personal)
+
+	now := u.Now()
+	for _, period := range limitPeriods {
+		policy := limits.Policy(period)
+		if policy == (models.LimitPolicy{}) {
+			continue
+		}
+		since := models.LimitPeriodStart(period, now)
+
+		var total int64
+		for _, amount := range credits {
+			total += amount
+		}
+		used, err := u.repoTransaction.GetSentTotals(ctx, tx, fromID, "", since)
+		if err != nil {
+			return err
+		}
+		if err = policy.Check(period, used, total, int64(len(credits))); err != nil {
+			return err
+		}
+
+		if policy.RecipientAmount == 0 {
+			continue
+		}
+		for toID, amount := range credits {
+			used, err := u.repoTransaction.GetSentTotals(ctx, tx, fromID, toID, since)
+			if err != nil {
+				return err
+			}
+			if err = policy.CheckRecipient(period, used, amount); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// Limits - ограничения пользователя на текущие день и месяц и сколько из них уже израсходовано;
+// при непустом toUser в Used.RecipientAmount - сколько отправлено этому получателю
+func (u *Usecase) Limits(ctx context.Context, userID, toUser string) (res []models.PeriodLimits, err error) {
+	tx, err := u.repoUser.BeginTx(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback(ctx)
+		} else {
+			err = tx.Commit(ctx)
+		}
+	}()
+
+	var toID string
+	if toUser != "" {
+		toData, err := u.repoUser.GetUserByLoginWithTx(ctx, tx, toUser)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrRecipientNotFound
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to get user by login: %w", err)
+		}
+		toID = toData.ID
+	}
+
+	personal, err := u.repoLimits.GetUserLimits(ctx, tx, userID)
+	if err != nil {
+		return nil, err
+	}
+	limits := u.limits.Override(personal)
+
+	now := u.Now()
+	for _, period := range limitPeriods {
+		since := models.LimitPeriodStart(period, now)
+		used, err := u.repoTransaction.GetSentTotals(ctx, tx, userID, toID, since)
+		if err != nil {
+			return nil, err
+		}
+		_, own := personal[period]
+		res = append(res, models.PeriodLimits{
+			Period:   period,
+			Since:    since,
+			Policy:   limits.Policy(period),
+			Personal: own,
+			Used:     used,
+		})
+	}
+
+	return res, nil
+}
+
+// SetUserLimit - задаёт пользователю username личные ограничения периода period вместо общих;
+// нулевое поле политики снимает ограничение
+func (u *Usecase) SetUserLimit(ctx context.Context, adminID, username, period string, p models.LimitPolicy) error {
+	return u.changeUserLimit(ctx, username, period, func(tx pgx.Tx, userID string) error {
+		return u.repoLimits.SetUserLimit(ctx, tx, userID, period, p, adminID)
+	})
+}
+
+// ResetUserLimit - возвращает пользователю username общие ограничения периода period
+func (u *Usecase) ResetUserLimit(ctx context.Context, username, period string) error {
+	return u.changeUserLimit(ctx, username, period, func(tx pgx.Tx, userID string) error {
+		return u.repoLimits.DeleteUserLimit(ctx, tx, userID, period)
+	})
+}
+
+func (u *Usecase) changeUserLimit(ctx context.Context, username, period string, change func(tx pgx.Tx, userID string) error) (err error) {
+	if !models.ValidLimitPeriod(period) {
+		return ErrUnknownLimitPeriod
+	}
+
+	tx, err := u.repoUser.BeginTx(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback(ctx)
+		} else {
+			err = tx.Commit(ctx)
+		}
+	}()
+
+	userData, err := u.repoUser.GetUserByLoginWithTx(ctx, tx, username)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return models.ErrUserNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get user by login: %w", err)
+	}
+
+	return change(tx, userData.ID)
+}
@@ -0,0 +1,268 @@
+package send_coin_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/jackc/pgx/v5"
+
+	"AvitoTask/internal/models"
+	"AvitoTask/internal/usecase/send_coin"
+	"AvitoTask/internal/usecase/send_coin/mocks"
+)
+
+var (
+	limitsNow  = time.Date(2025, 3, 17, 15, 30, 0, 0, time.UTC)
+	dayStart   = time.Date(2025, 3, 17, 0, 0, 0, 0, time.UTC)
+	monthStart = time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+)
+
+// noOverrides - у пользователя нет личных ограничений, действует общая политика
+func noOverrides(ctrl *gomock.Controller) *mocks.Mocklimit {
+	l := mocks.NewMocklimit(ctrl)
+	l.EXPECT().GetUserLimits(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil).AnyTimes()
+	return l
+}
+
+func TestSendCoin_Limits(t *testing.T) {
+	tests := []struct {
+		name      string
+		limits    models.TransferLimits
+		personal  map[string]models.LimitPolicy
+		daily     models.TransferUsage
+		recipient models.TransferUsage
+		monthly   models.TransferUsage
+		wantErr   error
+	}{
+		{
+			name:    "within limits",
+			limits:  models.TransferLimits{Daily: models.LimitPolicy{Amount: 500, Count: 5}, Monthly: models.LimitPolicy{Amount: 3000}},
+			daily:   models.TransferUsage{Amount: 400, Count: 4},
+			monthly: models.TransferUsage{Amount: 2900, Count: 40},
+		},
+		{
+			name:    "daily amount exceeded",
+			limits:  models.TransferLimits{Daily: models.LimitPolicy{Amount: 500}},
+			daily:   models.TransferUsage{Amount: 401, Count: 1},
+			wantErr: models.ErrTransferLimitExceeded,
+		},
+		{
+			name:    "daily count exceeded",
+			limits:  models.TransferLimits{Daily: models.LimitPolicy{Count: 5}},
+			daily:   models.TransferUsage{Amount: 50, Count: 5},
+			wantErr: models.ErrTransferLimitExceeded,
+		},
+		{
+			name:      "recipient amount exceeded",
+			limits:    models.TransferLimits{Daily: models.LimitPolicy{RecipientAmount: 150}},
+			daily:     models.TransferUsage{Amount: 300, Count: 3},
+			recipient: models.TransferUsage{Amount: 60, Count: 1, RecipientAmount: 60},
+			wantErr:   models.ErrTransferLimitExceeded,
+		},
+		{
+			name:    "monthly amount exceeded",
+			limits:  models.TransferLimits{Monthly: models.LimitPolicy{Amount: 3000}},
+			monthly: models.TransferUsage{Amount: 2950, Count: 30},
+			wantErr: models.ErrTransferLimitExceeded,
+		},
+		{
+			name:     "personal limit raises global",
+			limits:   models.TransferLimits{Daily: models.LimitPolicy{Amount: 500}},
+			personal: map[string]models.LimitPolicy{models.LimitDaily: {Amount: 1000}},
+			daily:    models.TransferUsage{Amount: 450, Count: 3},
+		},
+		{
+			name:     "personal limit without global",
+			personal: map[string]models.LimitPolicy{models.LimitMonthly: {Count: 10}},
+			monthly:  models.TransferUsage{Amount: 100, Count: 10},
+			wantErr:  models.ErrTransferLimitExceeded,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			ctx := context.Background()
+			mockUser := mocks.NewMockuser(ctrl)
+			mockTx := mocks.NewMockTx(ctrl)
+			mockTransaction := mocks.NewMocktransaction(ctrl)
+			mockLedger := mocks.NewMockledger(ctrl)
+			mockLimit := mocks.NewMocklimit(ctrl)
+			limits := tt.limits.Override(tt.personal)
+
+			mockUser.EXPECT().BeginTx(ctx).Return(mockTx, nil)
+			mockUser.EXPECT().GetUserById(ctx, mockTx, "user123").Return(models.User{ID: "user123", Username: "alice"}, nil)
+			mockUser.EXPECT().GetUserByLoginWithTx(ctx, mockTx, "bob").Return(models.User{ID: "user456", Username: "bob"}, nil)
+			mockUser.EXPECT().DebitUserCoins(ctx, mockTx, "user123", int64(100)).Return(nil)
+			mockUser.EXPECT().CreditUserCoins(ctx, mockTx, "user456", int64(100)).Return(nil)
+			mockLimit.EXPECT().GetUserLimits(ctx, mockTx, "user123").Return(tt.personal, nil)
+			if limits.Daily != (models.LimitPolicy{}) {
+				mockTransaction.EXPECT().GetSentTotals(ctx, mockTx, "user123", "", dayStart).Return(tt.daily, nil)
+			}
+			if limits.Daily.RecipientAmount > 0 {
+				mockTransaction.EXPECT().GetSentTotals(ctx, mockTx, "user123", "user456", dayStart).Return(tt.recipient, nil)
+			}
+			if limits.Monthly != (models.LimitPolicy{}) {
+				mockTransaction.EXPECT().GetSentTotals(ctx, mockTx, "user123", "", monthStart).Return(tt.monthly, nil)
+			}
+			if tt.wantErr == nil {
+				mockTransaction.EXPECT().InsertTransaction(ctx, mockTx, transfer("user123", "user456")).Return(nil)
+				mockLedger.EXPECT().PostEntry(ctx, mockTx, gomock.Any()).Return(nil)
+				mockTx.EXPECT().Commit(ctx).Return(nil)
+			} else {
+				mockTx.EXPECT().Rollback(ctx).Return(nil)
+			}
+
+			uc := send_coin.NewUsecase(mockUser, mockTransaction, mockLedger, nil, mockLimit, time.Hour, tt.limits)
+			uc.Now = func() time.Time { return limitsNow }
+
+			if _, err := uc.SendCoin(ctx, "user123", "bob", 100, "", ""); !errors.Is(err, tt.wantErr) {
+				t.Fatalf("expected %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestSendBatch_LimitCountsEveryRecipient(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockUser := mocks.NewMockuser(ctrl)
+	mockTx := mocks.NewMockTx(ctrl)
+	mockTransaction := mocks.NewMocktransaction(ctrl)
+
+	mockUser.EXPECT().BeginTx(ctx).Return(mockTx, nil)
+	mockUser.EXPECT().GetUserById(ctx, mockTx, "user123").Return(models.User{ID: "user123", Username: "alice"}, nil)
+	mockUser.EXPECT().GetUserByLoginWithTx(ctx, mockTx, "bob").Return(models.User{ID: "user456", Username: "bob"}, nil)
+	mockUser.EXPECT().GetUserByLoginWithTx(ctx, mockTx, "carol").Return(models.User{ID: "user789", Username: "carol"}, nil)
+	mockUser.EXPECT().DebitUserCoins(ctx, mockTx, "user123", int64(20)).Return(nil)
+	mockUser.EXPECT().CreditUserCoins(ctx, mockTx, gomock.Any(), int64(10)).Return(nil).Times(2)
+	mockTransaction.EXPECT().GetSentTotals(ctx, mockTx, "user123", "", dayStart).Return(models.TransferUsage{Amount: 30, Count: 4}, nil)
+	mockTx.EXPECT().Rollback(ctx).Return(nil)
+
+	limits := models.TransferLimits{Daily: models.LimitPolicy{Count: 5}}
+	uc := send_coin.NewUsecase(mockUser, mockTransaction, mocks.NewMockledger(ctrl), nil, noOverrides(ctrl), time.Hour, limits)
+	uc.Now = func() time.Time { return limitsNow }
+
+	recipients := []models.BatchRecipient{{ToUser: "bob", Amount: 10}, {ToUser: "carol", Amount: 10}}
+	if _, err := uc.SendBatch(ctx, "user123", recipients, "", ""); !errors.Is(err, models.ErrTransferLimitExceeded) {
+		t.Fatalf("expected ErrTransferLimitExceeded, got %v", err)
+	}
+}
+
+func TestLimits_ReportsUsage(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockUser := mocks.NewMockuser(ctrl)
+	mockTx := mocks.NewMockTx(ctrl)
+	mockTransaction := mocks.NewMocktransaction(ctrl)
+
+	daily := models.TransferUsage{Amount: 120, Count: 2, RecipientAmount: 20}
+	monthly := models.TransferUsage{Amount: 900, Count: 11, RecipientAmount: 200}
+	mockUser.EXPECT().BeginTx(ctx).Return(mockTx, nil)
+	mockUser.EXPECT().GetUserByLoginWithTx(ctx, mockTx, "bob").Return(models.User{ID: "user456", Username: "bob"}, nil)
+	mockTransaction.EXPECT().GetSentTotals(ctx, mockTx, "user123", "user456", dayStart).Return(daily, nil)
+	mockTransaction.EXPECT().GetSentTotals(ctx, mockTx, "user123", "user456", monthStart).Return(monthly, nil)
+	mockTx.EXPECT().Commit(ctx).Return(nil)
+
+	limits := models.TransferLimits{Daily: models.LimitPolicy{Amount: 500}, Monthly: models.LimitPolicy{Amount: 3000, RecipientAmount: 1000}}
+	uc := send_coin.NewUsecase(mockUser, mockTransaction, nil, nil, noOverrides(ctrl), time.Hour, limits)
+	uc.Now = func() time.Time { return limitsNow }
+
+	res, err := uc.Limits(ctx, "user123", "bob")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(res) != 2 ||
+		res[0] != (models.PeriodLimits{Period: models.LimitDaily, Since: dayStart, Policy: limits.Daily, Used: daily}) ||
+		res[1] != (models.PeriodLimits{Period: models.LimitMonthly, Since: monthStart, Policy: limits.Monthly, Used: monthly}) {
+		t.Errorf("unexpected limits %+v", res)
+	}
+}
+
+func TestLimits_ReportsPersonalPolicy(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockUser := mocks.NewMockuser(ctrl)
+	mockTx := mocks.NewMockTx(ctrl)
+	mockTransaction := mocks.NewMocktransaction(ctrl)
+	mockLimit := mocks.NewMocklimit(ctrl)
+
+	personal := models.LimitPolicy{Amount: 5000}
+	mockUser.EXPECT().BeginTx(ctx).Return(mockTx, nil)
+	mockLimit.EXPECT().GetUserLimits(ctx, mockTx, "user123").Return(map[string]models.LimitPolicy{models.LimitMonthly: personal}, nil)
+	mockTransaction.EXPECT().GetSentTotals(ctx, mockTx, "user123", "", gomock.Any()).Return(models.TransferUsage{}, nil).Times(2)
+	mockTx.EXPECT().Commit(ctx).Return(nil)
+
+	limits := models.TransferLimits{Daily: models.LimitPolicy{Amount: 500}, Monthly: models.LimitPolicy{Amount: 3000}}
+	uc := send_coin.NewUsecase(mockUser, mockTransaction, nil, nil, mockLimit, time.Hour, limits)
+	uc.Now = func() time.Time { return limitsNow }
+
+	res, err := uc.Limits(ctx, "user123", "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(res) != 2 || res[0].Personal || res[0].Policy != limits.Daily || !res[1].Personal || res[1].Policy != personal {
+		t.Errorf("unexpected limits %+v", res)
+	}
+}
+
+func TestSetUserLimit_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockUser := mocks.NewMockuser(ctrl)
+	mockTx := mocks.NewMockTx(ctrl)
+	mockLimit := mocks.NewMocklimit(ctrl)
+
+	policy := models.LimitPolicy{Amount: 2000, Count: 20}
+	mockUser.EXPECT().BeginTx(ctx).Return(mockTx, nil)
+	mockUser.EXPECT().GetUserByLoginWithTx(ctx, mockTx, "bob").Return(models.User{ID: "user456", Username: "bob"}, nil)
+	mockLimit.EXPECT().SetUserLimit(ctx, mockTx, "user456", models.LimitDaily, policy, "admin").Return(nil)
+	mockTx.EXPECT().Commit(ctx).Return(nil)
+
+	uc := send_coin.NewUsecase(mockUser, nil, nil, nil, mockLimit, time.Hour, models.TransferLimits{})
+	if err := uc.SetUserLimit(ctx, "admin", "bob", models.LimitDaily, policy); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestSetUserLimit_UnknownPeriod(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	uc := send_coin.NewUsecase(mocks.NewMockuser(ctrl), nil, nil, nil, mocks.NewMocklimit(ctrl), time.Hour, models.TransferLimits{})
+	err := uc.SetUserLimit(context.Background(), "admin", "bob", "weekly", models.LimitPolicy{Amount: 100})
+	if !errors.Is(err, send_coin.ErrUnknownLimitPeriod) {
+		t.Fatalf("expected ErrUnknownLimitPeriod, got %v", err)
+	}
+}
+
+func TestResetUserLimit_UserNotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockUser := mocks.NewMockuser(ctrl)
+	mockTx := mocks.NewMockTx(ctrl)
+
+	mockUser.EXPECT().BeginTx(ctx).Return(mockTx, nil)
+	mockUser.EXPECT().GetUserByLoginWithTx(ctx, mockTx, "ghost").Return(models.User{}, pgx.ErrNoRows)
+	mockTx.EXPECT().Rollback(ctx).Return(nil)
+
+	uc := send_coin.NewUsecase(mockUser, nil, nil, nil, mocks.NewMocklimit(ctrl), time.Hour, models.TransferLimits{})
+	if err := uc.ResetUserLimit(ctx, "ghost", models.LimitMonthly); !errors.Is(err, models.ErrUserNotFound) {
+		t.Fatalf("expected ErrUserNotFound, got %v", err)
+	}
+}
@@ -8,6 +8,7 @@ import (
 	models "AvitoTask/internal/models"
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	gomock "github.com/golang/mock/gomock"
 	pgx "github.com/jackc/pgx/v5"
@@ -51,6 +52,34 @@ func (mr *MockuserMockRecorder) BeginTx(ctx interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BeginTx", reflect.TypeOf((*Mockuser)(nil).BeginTx), ctx)
 }
 
+// CreditUserCoins mocks base method.
+func (m *Mockuser) CreditUserCoins(ctx context.Context, tx pgx.Tx, userID string, amount int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreditUserCoins", ctx, tx, userID, amount)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreditUserCoins indicates an expected call of CreditUserCoins.
+func (mr *MockuserMockRecorder) CreditUserCoins(ctx, tx, userID, amount interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreditUserCoins", reflect.TypeOf((*Mockuser)(nil).CreditUserCoins), ctx, tx, userID, amount)
+}
+
+// DebitUserCoins mocks base method.
+func (m *Mockuser) DebitUserCoins(ctx context.Context, tx pgx.Tx, userID string, amount int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DebitUserCoins", ctx, tx, userID, amount)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DebitUserCoins indicates an expected call of DebitUserCoins.
+func (mr *MockuserMockRecorder) DebitUserCoins(ctx, tx, userID, amount interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DebitUserCoins", reflect.TypeOf((*Mockuser)(nil).DebitUserCoins), ctx, tx, userID, amount)
+}
+
 // GetUserById mocks base method.
 func (m *Mockuser) GetUserById(ctx context.Context, tx pgx.Tx, userID string) (models.User, error) {
 	m.ctrl.T.Helper()
@@ -96,20 +125,6 @@ func (mr *MockuserMockRecorder) IsUserExists(ctx, user interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsUserExists", reflect.TypeOf((*Mockuser)(nil).IsUserExists), ctx, user)
 }
 
-// UpdateUserCoins mocks base method.
-func (m *Mockuser) UpdateUserCoins(ctx context.Context, tx pgx.Tx, userID string, newCoins int64) error {
-	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateUserCoins", ctx, tx, userID, newCoins)
-	ret0, _ := ret[0].(error)
-	return ret0
-}
-
-// UpdateUserCoins indicates an expected call of UpdateUserCoins.
-func (mr *MockuserMockRecorder) UpdateUserCoins(ctx, tx, userID, newCoins interface{}) *gomock.Call {
-	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUserCoins", reflect.TypeOf((*Mockuser)(nil).UpdateUserCoins), ctx, tx, userID, newCoins)
-}
-
 // Mocktransaction is a mock of transaction interface.
 type Mocktransaction struct {
 	ctrl     *gomock.Controller
@@ -133,16 +148,245 @@ func (m *Mocktransaction) EXPECT() *MocktransactionMockRecorder {
 	return m.recorder
 }
 
+// GetPendingTransfers mocks base method.
+func (m *Mocktransaction) GetPendingTransfers(ctx context.Context, tx pgx.Tx, userID string) ([]models.TransactionItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPendingTransfers", ctx, tx, userID)
+	ret0, _ := ret[0].([]models.TransactionItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPendingTransfers indicates an expected call of GetPendingTransfers.
+func (mr *MocktransactionMockRecorder) GetPendingTransfers(ctx, tx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPendingTransfers", reflect.TypeOf((*Mocktransaction)(nil).GetPendingTransfers), ctx, tx, userID)
+}
+
+// GetSentTotals mocks base method.
+func (m *Mocktransaction) GetSentTotals(ctx context.Context, tx pgx.Tx, userID, toUserID string, since time.Time) (models.TransferUsage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSentTotals", ctx, tx, userID, toUserID, since)
+	ret0, _ := ret[0].(models.TransferUsage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSentTotals indicates an expected call of GetSentTotals.
+func (mr *MocktransactionMockRecorder) GetSentTotals(ctx, tx, userID, toUserID, since interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSentTotals", reflect.TypeOf((*Mocktransaction)(nil).GetSentTotals), ctx, tx, userID, toUserID, since)
+}
+
 // InsertTransaction mocks base method.
-func (m *Mocktransaction) InsertTransaction(ctx context.Context, tx pgx.Tx, id, fromUserID, toUserID string, amount int64) error {
+func (m *Mocktransaction) InsertTransaction(ctx context.Context, tx pgx.Tx, t models.Transfer) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "InsertTransaction", ctx, tx, id, fromUserID, toUserID, amount)
+	ret := m.ctrl.Call(m, "InsertTransaction", ctx, tx, t)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // InsertTransaction indicates an expected call of InsertTransaction.
-func (mr *MocktransactionMockRecorder) InsertTransaction(ctx, tx, id, fromUserID, toUserID, amount interface{}) *gomock.Call {
+func (mr *MocktransactionMockRecorder) InsertTransaction(ctx, tx, t interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertTransaction", reflect.TypeOf((*Mocktransaction)(nil).InsertTransaction), ctx, tx, t)
+}
+
+// LockExpiredTransfers mocks base method.
+func (m *Mocktransaction) LockExpiredTransfers(ctx context.Context, tx pgx.Tx, now time.Time, limit int64) ([]models.Transfer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LockExpiredTransfers", ctx, tx, now, limit)
+	ret0, _ := ret[0].([]models.Transfer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LockExpiredTransfers indicates an expected call of LockExpiredTransfers.
+func (mr *MocktransactionMockRecorder) LockExpiredTransfers(ctx, tx, now, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LockExpiredTransfers", reflect.TypeOf((*Mocktransaction)(nil).LockExpiredTransfers), ctx, tx, now, limit)
+}
+
+// LockTransaction mocks base method.
+func (m *Mocktransaction) LockTransaction(ctx context.Context, tx pgx.Tx, id string) (models.Transfer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LockTransaction", ctx, tx, id)
+	ret0, _ := ret[0].(models.Transfer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LockTransaction indicates an expected call of LockTransaction.
+func (mr *MocktransactionMockRecorder) LockTransaction(ctx, tx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LockTransaction", reflect.TypeOf((*Mocktransaction)(nil).LockTransaction), ctx, tx, id)
+}
+
+// ResolveTransaction mocks base method.
+func (m *Mocktransaction) ResolveTransaction(ctx context.Context, tx pgx.Tx, id, status string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResolveTransaction", ctx, tx, id, status)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ResolveTransaction indicates an expected call of ResolveTransaction.
+func (mr *MocktransactionMockRecorder) ResolveTransaction(ctx, tx, id, status interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResolveTransaction", reflect.TypeOf((*Mocktransaction)(nil).ResolveTransaction), ctx, tx, id, status)
+}
+
+// Mocklimit is a mock of limit interface.
+type Mocklimit struct {
+	ctrl     *gomock.Controller
+	recorder *MocklimitMockRecorder
+}
+
+// MocklimitMockRecorder is the mock recorder for Mocklimit.
+type MocklimitMockRecorder struct {
+	mock *Mocklimit
+}
+
+// NewMocklimit creates a new mock instance.
+func NewMocklimit(ctrl *gomock.Controller) *Mocklimit {
+	mock := &Mocklimit{ctrl: ctrl}
+	mock.recorder = &MocklimitMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mocklimit) EXPECT() *MocklimitMockRecorder {
+	return m.recorder
+}
+
+// DeleteUserLimit mocks base method.
+func (m *Mocklimit) DeleteUserLimit(ctx context.Context, tx pgx.Tx, userID, period string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteUserLimit", ctx, tx, userID, period)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteUserLimit indicates an expected call of DeleteUserLimit.
+func (mr *MocklimitMockRecorder) DeleteUserLimit(ctx, tx, userID, period interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUserLimit", reflect.TypeOf((*Mocklimit)(nil).DeleteUserLimit), ctx, tx, userID, period)
+}
+
+// GetUserLimits mocks base method.
+func (m *Mocklimit) GetUserLimits(ctx context.Context, tx pgx.Tx, userID string) (map[string]models.LimitPolicy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserLimits", ctx, tx, userID)
+	ret0, _ := ret[0].(map[string]models.LimitPolicy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserLimits indicates an expected call of GetUserLimits.
+func (mr *MocklimitMockRecorder) GetUserLimits(ctx, tx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserLimits", reflect.TypeOf((*Mocklimit)(nil).GetUserLimits), ctx, tx, userID)
+}
+
+// SetUserLimit mocks base method.
+func (m *Mocklimit) SetUserLimit(ctx context.Context, tx pgx.Tx, userID, period string, p models.LimitPolicy, setBy string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetUserLimit", ctx, tx, userID, period, p, setBy)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetUserLimit indicates an expected call of SetUserLimit.
+func (mr *MocklimitMockRecorder) SetUserLimit(ctx, tx, userID, period, p, setBy interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetUserLimit", reflect.TypeOf((*Mocklimit)(nil).SetUserLimit), ctx, tx, userID, period, p, setBy)
+}
+
+// Mockledger is a mock of ledger interface.
+type Mockledger struct {
+	ctrl     *gomock.Controller
+	recorder *MockledgerMockRecorder
+}
+
+// MockledgerMockRecorder is the mock recorder for Mockledger.
+type MockledgerMockRecorder struct {
+	mock *Mockledger
+}
+
+// NewMockledger creates a new mock instance.
+func NewMockledger(ctrl *gomock.Controller) *Mockledger {
+	mock := &Mockledger{ctrl: ctrl}
+	mock.recorder = &MockledgerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mockledger) EXPECT() *MockledgerMockRecorder {
+	return m.recorder
+}
+
+// PostEntry mocks base method.
+func (m *Mockledger) PostEntry(ctx context.Context, tx pgx.Tx, e models.LedgerEntry) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PostEntry", ctx, tx, e)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PostEntry indicates an expected call of PostEntry.
+func (mr *MockledgerMockRecorder) PostEntry(ctx, tx, e interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PostEntry", reflect.TypeOf((*Mockledger)(nil).PostEntry), ctx, tx, e)
+}
+
+// Mockidempotency is a mock of idempotency interface.
+type Mockidempotency struct {
+	ctrl     *gomock.Controller
+	recorder *MockidempotencyMockRecorder
+}
+
+// MockidempotencyMockRecorder is the mock recorder for Mockidempotency.
+type MockidempotencyMockRecorder struct {
+	mock *Mockidempotency
+}
+
+// NewMockidempotency creates a new mock instance.
+func NewMockidempotency(ctrl *gomock.Controller) *Mockidempotency {
+	mock := &Mockidempotency{ctrl: ctrl}
+	mock.recorder = &MockidempotencyMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mockidempotency) EXPECT() *MockidempotencyMockRecorder {
+	return m.recorder
+}
+
+// ClaimKey mocks base method.
+func (m *Mockidempotency) ClaimKey(ctx context.Context, tx pgx.Tx, k models.IdempotencyKey) (models.IdempotencyKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimKey", ctx, tx, k)
+	ret0, _ := ret[0].(models.IdempotencyKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimKey indicates an expected call of ClaimKey.
+func (mr *MockidempotencyMockRecorder) ClaimKey(ctx, tx, k interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimKey", reflect.TypeOf((*Mockidempotency)(nil).ClaimKey), ctx, tx, k)
+}
+
+// SaveResponse mocks base method.
+func (m *Mockidempotency) SaveResponse(ctx context.Context, tx pgx.Tx, k models.IdempotencyKey) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveResponse", ctx, tx, k)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveResponse indicates an expected call of SaveResponse.
+func (mr *MockidempotencyMockRecorder) SaveResponse(ctx, tx, k interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertTransaction", reflect.TypeOf((*Mocktransaction)(nil).InsertTransaction), ctx, tx, id, fromUserID, toUserID, amount)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveResponse", reflect.TypeOf((*Mockidempotency)(nil).SaveResponse), ctx, tx, k)
 }
@@ -0,0 +1,245 @@
+package send_coin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+
+	"AvitoTask/internal/models"
+	"AvitoTask/internal/utils"
+)
+
+// expireBatch - сколько просроченных отложенных переводов возвращается отправителям за одну транзакцию
+const expireBatch = 100
+
+var ErrPendingExpired = errors.New("pending transfer has expired")
+
+// SendPending - отложенный перевод: монеты списываются у отправителя на эскроу, но получателю
+// не зачисляются, пока он не примет перевод. Непринятый за pendingTimeout перевод возвращается отправителю
+func (u *Usecase) SendPending(ctx context.Context, fromUser, toUser string, amount int64, message, category string) (t models.Transfer, err error) {
+	if !models.ValidTransferCategory(category) {
+		return t, ErrUnknownCategory
+	}
+
+	err = utils.RetryOnConflict(ctx, conflictAttempts, func() error {
+		t, err = u.sendPending(ctx, fromUser, toUser, amount, message, category)
+		return err
+	})
+
+	return t, err
+}
+
+func (u *Usecase) sendPending(ctx context.Context, fromUser, toUser string, amount int64, message, category string) (t models.Transfer, err error) {
+	tx, err := u.repoUser.BeginTx(ctx)
+	if err != nil {
+		return t, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback(ctx)
+		} else {
+			err = tx.Commit(ctx)
+		}
+	}()
+
+	return utils.Idempotent(ctx, tx, u.repoIdempotency, func() (models.Transfer, error) {
+		return u.hold(ctx, tx, fromUser, toUser, amount, message, category)
+	})
+}
+
+func (u *Usecase) hold(ctx context.Context, tx pgx.Tx, fromUser, toUser string, amount int64, message, category string) (models.Transfer, error) {
+	fromData, err := u.repoUser.GetUserById(ctx, tx, fromUser)
+	if err != nil {
+		return models.Transfer{}, fmt.Errorf("failed to get user by id: %w", err)
+	}
+
+	if fromData.Username == toUser {
+		return models.Transfer{}, ErrSameUser
+	}
+
+	toData, err := u.repoUser.GetUserByLoginWithTx(ctx, tx, toUser)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return models.Transfer{}, ErrRecipientNotFound
+	}
+	if err != nil {
+		return models.Transfer{}, fmt.Errorf("failed to get user by id: %w", err)
+	}
+
+	err = u.repoUser.DebitUserCoins(ctx, tx, fromData.ID, amount)
+	if errors.Is(err, models.ErrNotEnoughCoins) {
+		return models.Transfer{}, ErrNotEnoughCoins
+	}
+	if err != nil {
+		return models.Transfer{}, fmt.Errorf("failed to update user coins: %w", err)
+	}
+	if err = u.checkLimits(ctx, tx, fromData.ID, map[string]int64{toData.ID: amount}); err != nil {
+		return models.Transfer{}, err
+	}
+
+	expiresAt := u.Now().Add(u.pendingTimeout)
+	t := models.Transfer{
+		ID:         uuid.New().String(),
+		FromUserID: fromData.ID,
+		ToUserID:   toData.ID,
+		Amount:     amount,
+		Message:    message,
+		Category:   category,
+		Status:     models.TransferPending,
+		ExpiresAt:  &expiresAt,
+	}
+	if err = u.repoTransaction.InsertTransaction(ctx, tx, t); err != nil {
+		return models.Transfer{}, fmt.Errorf("failed to insert transaction: %w", err)
+	}
+
+	entry := models.NewLedgerEntry(uuid.New().String(), models.EntryEscrowHold, t.ID,
+		models.UserAccount(fromData.ID), models.AccountEscrow, amount)
+	if err = u.repoLedger.PostEntry(ctx, tx, entry); err != nil {
+		return models.Transfer{}, fmt.Errorf("failed to post ledger entry: %w", err)
+	}
+
+	return t, nil
+}
+
+// PendingTransfers - входящие переводы пользователя, которые ждут его ответа
+func (u *Usecase) PendingTransfers(ctx context.Context, userID string) (res []models.TransactionItem, err error) {
+	tx, err := u.repoUser.BeginTx(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback(ctx)
+		} else {
+			err = tx.Commit(ctx)
+		}
+	}()
+
+	return u.repoTransaction.GetPendingTransfers(ctx, tx, userID)
+}
+
+// Accept - получатель принимает отложенный перевод, монеты переходят с эскроу на его счёт
+func (u *Usecase) Accept(ctx context.Context, userID, transferID string) error {
+	return u.answer(ctx, userID, transferID, models.TransferAccepted)
+}
+
+// Decline - получатель отклоняет отложенный перевод, монеты возвращаются отправителю
+func (u *Usecase) Decline(ctx context.Context, userID, transferID string) error {
+	return u.answer(ctx, userID, transferID, models.TransferDeclined)
+}
+
+func (u *Usecase) answer(ctx context.Context, userID, transferID, status string) error {
+	return utils.RetryOnConflict(ctx, conflictAttempts, func() (err error) {
+		tx, err := u.repoUser.BeginTx(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to begin transaction: %w", err)
+		}
+
+		defer func() {
+			if err != nil {
+				_ = tx.Rollback(ctx)
+			} else {
+				err = tx.Commit(ctx)
+			}
+		}()
+
+		t, err := u.repoTransaction.LockTransaction(ctx, tx, transferID)
+		if err != nil {
+			return err
+		}
+		// чужой перевод не отличается от несуществующего
+		if t.ToUserID != userID {
+			return models.ErrTransferNotFound
+		}
+		if t.Status != models.TransferPending {
+			return models.ErrTransferNotPending
+		}
+		if t.ExpiresAt != nil && !u.Now().Before(*t.ExpiresAt) {
+			return ErrPendingExpired
+		}
+
+		return u.resolve(ctx, tx, t, status)
+	})
+}
+
+// ExpirePending - возвращает отправителям отложенные переводы, срок ответа на которые истёк
+func (u *Usecase) ExpirePending(ctx context.Context) (expired int, err error) {
+	err = utils.RetryOnConflict(ctx, conflictAttempts, func() (err error) {
+		tx, err := u.repoUser.BeginTx(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to begin transaction: %w", err)
+		}
+
+		defer func() {
+			if err != nil {
+				_ = tx.Rollback(ctx)
+			} else {
+				err = tx.Commit(ctx)
+			}
+		}()
+
+		due, err := u.repoTransaction.LockExpiredTransfers(ctx, tx, u.Now(), expireBatch)
+		if err != nil {
+			return err
+		}
+
+		for _, t := range due {
+			if err = u.resolve(ctx, tx, t, models.TransferExpired); err != nil {
+				return err
+			}
+		}
+		expired = len(due)
+
+		return nil
+	})
+
+	return expired, err
+}
+
+// RunExpiry - периодически возвращает просроченные отложенные переводы, пока не отменён ctx
+func (u *Usecase) RunExpiry(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := u.ExpirePending(ctx); err != nil {
+				log.Printf("pending transfers: %v", err)
+			}
+		}
+	}
+}
+
+// resolve - снимает монеты отложенного перевода с эскроу: при принятии зачисляет их получателю,
+// иначе возвращает отправителю
+func (u *Usecase) resolve(ctx context.Context, tx pgx.Tx, t models.Transfer, status string) error {
+	to, kind := t.FromUserID, models.EntryEscrowReturn
+	if status == models.TransferAccepted {
+		to, kind = t.ToUserID, models.EntryEscrowRelease
+	}
+
+	if err := u.repoTransaction.ResolveTransaction(ctx, tx, t.ID, status); err != nil {
+		return err
+	}
+
+	if err := u.repoUser.CreditUserCoins(ctx, tx, to, t.Amount); err != nil {
+		return fmt.Errorf("failed to update user coins: %w", err)
+	}
+
+	entry := models.NewLedgerEntry(uuid.New().String(), kind, t.ID,
+		models.AccountEscrow, models.UserAccount(to), t.Amount)
+	if err := u.repoLedger.PostEntry(ctx, tx, entry); err != nil {
+		return fmt.Errorf("failed to post ledger entry: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,246 @@
+package send_coin_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/jackc/pgx/v5"
+
+	"AvitoTask/internal/models"
+	"AvitoTask/internal/usecase/send_coin"
+	"AvitoTask/internal/usecase/send_coin/mocks"
+)
+
+var pendingNow = time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+
+func pendingTransfer() models.Transfer {
+	expiresAt := pendingNow.Add(time.Hour)
+	return models.Transfer{
+		ID:         "tr-1",
+		FromUserID: "user123",
+		ToUserID:   "user456",
+		Amount:     40,
+		Status:     models.TransferPending,
+		ExpiresAt:  &expiresAt,
+	}
+}
+
+func expectEntry(t *testing.T, kind, from, to string, amount int64) func(context.Context, pgx.Tx, models.LedgerEntry) error {
+	return func(_ context.Context, _ pgx.Tx, e models.LedgerEntry) error {
+		if e.Kind != kind || !e.Balanced() {
+			t.Errorf("unexpected ledger entry %+v", e)
+		}
+		if e.Postings[0] != (models.Posting{Account: from, Amount: -amount}) ||
+			e.Postings[1] != (models.Posting{Account: to, Amount: amount}) {
+			t.Errorf("unexpected postings %+v", e.Postings)
+		}
+		return nil
+	}
+}
+
+func TestSendPending_HoldsCoinsInEscrow(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockUser := mocks.NewMockuser(ctrl)
+	mockTx := mocks.NewMockTx(ctrl)
+	mockTransaction := mocks.NewMocktransaction(ctrl)
+	mockLedger := mocks.NewMockledger(ctrl)
+
+	mockUser.EXPECT().BeginTx(ctx).Return(mockTx, nil)
+	mockUser.EXPECT().GetUserById(ctx, mockTx, "user123").Return(models.User{ID: "user123", Username: "alice"}, nil)
+	mockUser.EXPECT().GetUserByLoginWithTx(ctx, mockTx, "bob").Return(models.User{ID: "user456", Username: "bob"}, nil)
+	mockUser.EXPECT().DebitUserCoins(ctx, mockTx, "user123", int64(40)).Return(nil)
+	mockTransaction.EXPECT().InsertTransaction(ctx, mockTx, transfer("user123", "user456")).DoAndReturn(
+		func(_ context.Context, _ pgx.Tx, tr models.Transfer) error {
+			if tr.Status != models.TransferPending || tr.ExpiresAt == nil || !tr.ExpiresAt.Equal(pendingNow.Add(time.Hour)) {
+				t.Errorf("unexpected pending transfer %+v", tr)
+			}
+			return nil
+		})
+	mockLedger.EXPECT().PostEntry(ctx, mockTx, gomock.Any()).
+		DoAndReturn(expectEntry(t, models.EntryEscrowHold, "user:user123", models.AccountEscrow, 40))
+	mockTx.EXPECT().Commit(ctx).Return(nil)
+
+	uc := send_coin.NewUsecase(mockUser, mockTransaction, mockLedger, nil, noOverrides(ctrl), time.Hour, models.TransferLimits{})
+	uc.Now = func() time.Time { return pendingNow }
+
+	tr, err := uc.SendPending(ctx, "user123", "bob", 40, "", "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if tr.ID == "" || tr.Status != models.TransferPending {
+		t.Errorf("unexpected transfer %+v", tr)
+	}
+}
+
+func TestSendPending_NotEnoughCoins(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockUser := mocks.NewMockuser(ctrl)
+	mockTx := mocks.NewMockTx(ctrl)
+
+	mockUser.EXPECT().BeginTx(ctx).Return(mockTx, nil)
+	mockUser.EXPECT().GetUserById(ctx, mockTx, "user123").Return(models.User{ID: "user123", Username: "alice"}, nil)
+	mockUser.EXPECT().GetUserByLoginWithTx(ctx, mockTx, "bob").Return(models.User{ID: "user456", Username: "bob"}, nil)
+	mockUser.EXPECT().DebitUserCoins(ctx, mockTx, "user123", int64(40)).Return(models.ErrNotEnoughCoins)
+	mockTx.EXPECT().Rollback(ctx).Return(nil)
+
+	uc := send_coin.NewUsecase(mockUser, mocks.NewMocktransaction(ctrl), mocks.NewMockledger(ctrl), nil, noOverrides(ctrl), time.Hour, models.TransferLimits{})
+	if _, err := uc.SendPending(ctx, "user123", "bob", 40, "", ""); !errors.Is(err, send_coin.ErrNotEnoughCoins) {
+		t.Fatalf("expected ErrNotEnoughCoins, got %v", err)
+	}
+}
+
+func TestSendPending_RecipientNotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockUser := mocks.NewMockuser(ctrl)
+	mockTx := mocks.NewMockTx(ctrl)
+
+	mockUser.EXPECT().BeginTx(ctx).Return(mockTx, nil)
+	mockUser.EXPECT().GetUserById(ctx, mockTx, "user123").Return(models.User{ID: "user123", Username: "alice"}, nil)
+	mockUser.EXPECT().GetUserByLoginWithTx(ctx, mockTx, "ghost").Return(models.User{}, pgx.ErrNoRows)
+	mockTx.EXPECT().Rollback(ctx).Return(nil)
+
+	uc := send_coin.NewUsecase(mockUser, mocks.NewMocktransaction(ctrl), mocks.NewMockledger(ctrl), nil, noOverrides(ctrl), time.Hour, models.TransferLimits{})
+	if _, err := uc.SendPending(ctx, "user123", "ghost", 40, "", ""); !errors.Is(err, send_coin.ErrRecipientNotFound) {
+		t.Fatalf("expected ErrRecipientNotFound, got %v", err)
+	}
+}
+
+func TestAnswerPending(t *testing.T) {
+	tests := []struct {
+		name     string
+		answer   func(uc *send_coin.Usecase, ctx context.Context) error
+		status   string
+		kind     string
+		credited string
+	}{
+		{
+			name: "accept credits recipient",
+			answer: func(uc *send_coin.Usecase, ctx context.Context) error {
+				return uc.Accept(ctx, "user456", "tr-1")
+			},
+			status:   models.TransferAccepted,
+			kind:     models.EntryEscrowRelease,
+			credited: "user456",
+		},
+		{
+			name: "decline returns coins to sender",
+			answer: func(uc *send_coin.Usecase, ctx context.Context) error {
+				return uc.Decline(ctx, "user456", "tr-1")
+			},
+			status:   models.TransferDeclined,
+			kind:     models.EntryEscrowReturn,
+			credited: "user123",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			ctx := context.Background()
+			mockUser := mocks.NewMockuser(ctrl)
+			mockTx := mocks.NewMockTx(ctrl)
+			mockTransaction := mocks.NewMocktransaction(ctrl)
+			mockLedger := mocks.NewMockledger(ctrl)
+
+			mockUser.EXPECT().BeginTx(ctx).Return(mockTx, nil)
+			mockTransaction.EXPECT().LockTransaction(ctx, mockTx, "tr-1").Return(pendingTransfer(), nil)
+			mockTransaction.EXPECT().ResolveTransaction(ctx, mockTx, "tr-1", tt.status).Return(nil)
+			mockUser.EXPECT().CreditUserCoins(ctx, mockTx, tt.credited, int64(40)).Return(nil)
+			mockLedger.EXPECT().PostEntry(ctx, mockTx, gomock.Any()).
+				DoAndReturn(expectEntry(t, tt.kind, models.AccountEscrow, models.UserAccount(tt.credited), 40))
+			mockTx.EXPECT().Commit(ctx).Return(nil)
+
+			uc := send_coin.NewUsecase(mockUser, mockTransaction, mockLedger, nil, noOverrides(ctrl), time.Hour, models.TransferLimits{})
+			uc.Now = func() time.Time { return pendingNow }
+
+			if err := tt.answer(uc, ctx); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestAcceptPending_Rejected(t *testing.T) {
+	accepted := pendingTransfer()
+	accepted.Status = models.TransferAccepted
+
+	tests := []struct {
+		name    string
+		userID  string
+		now     time.Time
+		locked  models.Transfer
+		wantErr error
+	}{
+		{name: "not recipient", userID: "user123", now: pendingNow, locked: pendingTransfer(), wantErr: models.ErrTransferNotFound},
+		{name: "already answered", userID: "user456", now: pendingNow, locked: accepted, wantErr: models.ErrTransferNotPending},
+		{name: "expired", userID: "user456", now: pendingNow.Add(2 * time.Hour), locked: pendingTransfer(), wantErr: send_coin.ErrPendingExpired},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			ctx := context.Background()
+			mockUser := mocks.NewMockuser(ctrl)
+			mockTx := mocks.NewMockTx(ctrl)
+			mockTransaction := mocks.NewMocktransaction(ctrl)
+
+			mockUser.EXPECT().BeginTx(ctx).Return(mockTx, nil)
+			mockTransaction.EXPECT().LockTransaction(ctx, mockTx, "tr-1").Return(tt.locked, nil)
+			mockTx.EXPECT().Rollback(ctx).Return(nil)
+
+			uc := send_coin.NewUsecase(mockUser, mockTransaction, mocks.NewMockledger(ctrl), nil, noOverrides(ctrl), time.Hour, models.TransferLimits{})
+			uc.Now = func() time.Time { return tt.now }
+
+			if err := uc.Accept(ctx, tt.userID, "tr-1"); !errors.Is(err, tt.wantErr) {
+				t.Fatalf("expected %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestExpirePending_ReturnsCoinsToSender(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockUser := mocks.NewMockuser(ctrl)
+	mockTx := mocks.NewMockTx(ctrl)
+	mockTransaction := mocks.NewMocktransaction(ctrl)
+	mockLedger := mocks.NewMockledger(ctrl)
+
+	now := pendingNow.Add(2 * time.Hour)
+	mockUser.EXPECT().BeginTx(ctx).Return(mockTx, nil)
+	mockTransaction.EXPECT().LockExpiredTransfers(ctx, mockTx, now, gomock.Any()).Return([]models.Transfer{pendingTransfer()}, nil)
+	mockTransaction.EXPECT().ResolveTransaction(ctx, mockTx, "tr-1", models.TransferExpired).Return(nil)
+	mockUser.EXPECT().CreditUserCoins(ctx, mockTx, "user123", int64(40)).Return(nil)
+	mockLedger.EXPECT().PostEntry(ctx, mockTx, gomock.Any()).
+		DoAndReturn(expectEntry(t, models.EntryEscrowReturn, models.AccountEscrow, "user:user123", 40))
+	mockTx.EXPECT().Commit(ctx).Return(nil)
+
+	uc := send_coin.NewUsecase(mockUser, mockTransaction, mockLedger, nil, noOverrides(ctrl), time.Hour, models.TransferLimits{})
+	uc.Now = func() time.Time { return now }
+
+	expired, err := uc.ExpirePending(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if expired != 1 {
+		t.Errorf("expected 1 expired transfer, got %d", expired)
+	}
+}
@@ -2,11 +2,15 @@ package send_coin_test
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 
 	"AvitoTask/internal/models"
 	"AvitoTask/internal/usecase/send_coin"
@@ -28,8 +32,8 @@ func TestSendCoin_SameUser(t *testing.T) {
 	fromData := models.User{ID: "user123", Username: "user123", Coins: 100}
 	mockUser.EXPECT().GetUserById(gomock.Any(), gomock.Any(), gomock.Any()).Return(fromData, nil)
 
-	uc := send_coin.NewUsecase(mockUser, mockTransaction)
-	err := uc.SendCoin(ctx, "user123", "user123", 100)
+	uc := send_coin.NewUsecase(mockUser, mockTransaction, nil, nil, noOverrides(ctrl), time.Hour, models.TransferLimits{})
+	_, err := uc.SendCoin(ctx, "user123", "user123", 100, "", "")
 	if !errors.Is(err, send_coin.ErrSameUser) {
 		t.Errorf("expected error %v, got %v", send_coin.ErrSameUser, err)
 	}
@@ -46,8 +50,8 @@ func TestSendCoin_BeginTxError(t *testing.T) {
 	beginErr := errors.New("begin tx error")
 	mockUser.EXPECT().BeginTx(ctx).Return(nil, beginErr)
 
-	uc := send_coin.NewUsecase(mockUser, mockTransaction)
-	err := uc.SendCoin(ctx, "user123", "user456", 100)
+	uc := send_coin.NewUsecase(mockUser, mockTransaction, nil, nil, noOverrides(ctrl), time.Hour, models.TransferLimits{})
+	_, err := uc.SendCoin(ctx, "user123", "user456", 100, "", "")
 	expectedMsg := fmt.Sprintf("failed to begin transaction: %v", beginErr)
 	if err == nil || err.Error() != expectedMsg {
 		t.Errorf("expected error %q, got %v", expectedMsg, err)
@@ -70,8 +74,8 @@ func TestSendCoin_GetUserByIdError_FromUser(t *testing.T) {
 		Return(models.User{}, getUserErr)
 	mockTx.EXPECT().Rollback(ctx).Return(nil)
 
-	uc := send_coin.NewUsecase(mockUser, mockTransaction)
-	err := uc.SendCoin(ctx, "user123", "user456", 100)
+	uc := send_coin.NewUsecase(mockUser, mockTransaction, nil, nil, noOverrides(ctrl), time.Hour, models.TransferLimits{})
+	_, err := uc.SendCoin(ctx, "user123", "user456", 100, "", "")
 	expectedMsg := fmt.Sprintf("failed to get user by id: %v", getUserErr)
 	if err == nil || err.Error() != expectedMsg {
 		t.Errorf("expected error %q, got %v", expectedMsg, err)
@@ -96,14 +100,33 @@ func TestSendCoin_GetUserByIdError_ToUser(t *testing.T) {
 	mockUser.EXPECT().GetUserByLoginWithTx(ctx, mockTx, "user456").Return(models.User{}, getUserErr)
 	mockTx.EXPECT().Rollback(ctx).Return(nil)
 
-	uc := send_coin.NewUsecase(mockUser, mockTransaction)
-	err := uc.SendCoin(ctx, "user123", "user456", 100)
+	uc := send_coin.NewUsecase(mockUser, mockTransaction, nil, nil, noOverrides(ctrl), time.Hour, models.TransferLimits{})
+	_, err := uc.SendCoin(ctx, "user123", "user456", 100, "", "")
 	expectedMsg := fmt.Sprintf("failed to get user by id: %v", getUserErr)
 	if err == nil || err.Error() != expectedMsg {
 		t.Errorf("expected error %q, got %v", expectedMsg, err)
 	}
 }
 
+func TestSendCoin_RecipientNotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockUser := mocks.NewMockuser(ctrl)
+	mockTx := mocks.NewMockTx(ctrl)
+
+	mockUser.EXPECT().BeginTx(ctx).Return(mockTx, nil)
+	mockUser.EXPECT().GetUserById(ctx, mockTx, "user123").Return(models.User{ID: "user123", Username: "alice"}, nil)
+	mockUser.EXPECT().GetUserByLoginWithTx(ctx, mockTx, "ghost").Return(models.User{}, pgx.ErrNoRows)
+	mockTx.EXPECT().Rollback(ctx).Return(nil)
+
+	uc := send_coin.NewUsecase(mockUser, mocks.NewMocktransaction(ctrl), nil, nil, noOverrides(ctrl), time.Hour, models.TransferLimits{})
+	if _, err := uc.SendCoin(ctx, "user123", "ghost", 100, "", ""); !errors.Is(err, send_coin.ErrRecipientNotFound) {
+		t.Errorf("expected error %v, got %v", send_coin.ErrRecipientNotFound, err)
+	}
+}
+
 func TestSendCoin_NotEnoughCoins(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -118,16 +141,17 @@ func TestSendCoin_NotEnoughCoins(t *testing.T) {
 	toData := models.User{ID: "user456", Coins: 100}
 	mockUser.EXPECT().GetUserById(ctx, mockTx, "user123").Return(fromData, nil)
 	mockUser.EXPECT().GetUserByLoginWithTx(ctx, mockTx, "user456").Return(toData, nil)
+	mockUser.EXPECT().DebitUserCoins(ctx, mockTx, "user123", int64(100)).Return(models.ErrNotEnoughCoins)
 	mockTx.EXPECT().Rollback(ctx).Return(nil)
 
-	uc := send_coin.NewUsecase(mockUser, mockTransaction)
-	err := uc.SendCoin(ctx, "user123", "user456", 100)
+	uc := send_coin.NewUsecase(mockUser, mockTransaction, nil, nil, noOverrides(ctrl), time.Hour, models.TransferLimits{})
+	_, err := uc.SendCoin(ctx, "user123", "user456", 100, "", "")
 	if err == nil || !errors.Is(err, send_coin.ErrNotEnoughCoins) {
 		t.Errorf("expected error %v, got %v", send_coin.ErrNotEnoughCoins, err)
 	}
 }
 
-func TestSendCoin_UpdateUserCoinsError_FromUser(t *testing.T) {
+func TestSendCoin_DebitError(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
@@ -142,20 +166,19 @@ func TestSendCoin_UpdateUserCoinsError_FromUser(t *testing.T) {
 	mockUser.EXPECT().GetUserById(ctx, mockTx, "user123").Return(fromData, nil)
 	mockUser.EXPECT().GetUserByLoginWithTx(ctx, mockTx, "user456").Return(toData, nil)
 
-	newFromCoins := fromData.Coins - 100
 	updateErr := errors.New("update coins error")
-	mockUser.EXPECT().UpdateUserCoins(ctx, mockTx, "user123", newFromCoins).Return(updateErr)
+	mockUser.EXPECT().DebitUserCoins(ctx, mockTx, "user123", int64(100)).Return(updateErr)
 	mockTx.EXPECT().Rollback(ctx).Return(nil)
 
-	uc := send_coin.NewUsecase(mockUser, mockTransaction)
-	err := uc.SendCoin(ctx, "user123", "user456", 100)
+	uc := send_coin.NewUsecase(mockUser, mockTransaction, nil, nil, noOverrides(ctrl), time.Hour, models.TransferLimits{})
+	_, err := uc.SendCoin(ctx, "user123", "user456", 100, "", "")
 	expectedMsg := fmt.Sprintf("failed to update user coins: %v", updateErr)
 	if err == nil || err.Error() != expectedMsg {
 		t.Errorf("expected error %q, got %v", expectedMsg, err)
 	}
 }
 
-func TestSendCoin_UpdateUserCoinsError_ToUser(t *testing.T) {
+func TestSendCoin_CreditError(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
@@ -170,17 +193,14 @@ func TestSendCoin_UpdateUserCoinsError_ToUser(t *testing.T) {
 	mockUser.EXPECT().GetUserById(ctx, mockTx, "user123").Return(fromData, nil)
 	mockUser.EXPECT().GetUserByLoginWithTx(ctx, mockTx, "user456").Return(toData, nil)
 
-	newFromCoins := fromData.Coins - 100
-	newToCoins := toData.Coins + 100
-
-	mockUser.EXPECT().UpdateUserCoins(ctx, mockTx, "user123", newFromCoins).Return(nil)
+	mockUser.EXPECT().DebitUserCoins(ctx, mockTx, "user123", int64(100)).Return(nil)
 
 	updateErr := errors.New("update to coins error")
-	mockUser.EXPECT().UpdateUserCoins(ctx, mockTx, "user456", newToCoins).Return(updateErr)
+	mockUser.EXPECT().CreditUserCoins(ctx, mockTx, "user456", int64(100)).Return(updateErr)
 	mockTx.EXPECT().Rollback(ctx).Return(nil)
 
-	uc := send_coin.NewUsecase(mockUser, mockTransaction)
-	err := uc.SendCoin(ctx, "user123", "user456", 100)
+	uc := send_coin.NewUsecase(mockUser, mockTransaction, nil, nil, noOverrides(ctrl), time.Hour, models.TransferLimits{})
+	_, err := uc.SendCoin(ctx, "user123", "user456", 100, "", "")
 	expectedMsg := fmt.Sprintf("failed to update user coins: %v", updateErr)
 	if err == nil || err.Error() != expectedMsg {
 		t.Errorf("expected error %q, got %v", expectedMsg, err)
@@ -201,19 +221,17 @@ func TestSendCoin_InsertTransactionError(t *testing.T) {
 	toData := models.User{ID: "user456", Coins: 100}
 	mockUser.EXPECT().GetUserById(ctx, mockTx, "user123").Return(fromData, nil)
 	mockUser.EXPECT().GetUserByLoginWithTx(ctx, mockTx, "user456").Return(toData, nil)
-	newFromCoins := fromData.Coins - 100
-	newToCoins := toData.Coins + 100
-	mockUser.EXPECT().UpdateUserCoins(ctx, mockTx, "user123", newFromCoins).Return(nil)
-	mockUser.EXPECT().UpdateUserCoins(ctx, mockTx, "user456", newToCoins).Return(nil)
+	mockUser.EXPECT().DebitUserCoins(ctx, mockTx, "user123", int64(100)).Return(nil)
+	mockUser.EXPECT().CreditUserCoins(ctx, mockTx, "user456", int64(100)).Return(nil)
 
 	insertErr := errors.New("insert transaction error")
 	mockTransaction.EXPECT().
-		InsertTransaction(ctx, mockTx, gomock.Any(), "user123", "user456", gomock.Any()).
+		InsertTransaction(ctx, mockTx, transfer("user123", "user456")).
 		Return(insertErr)
 	mockTx.EXPECT().Rollback(ctx).Return(nil)
 
-	uc := send_coin.NewUsecase(mockUser, mockTransaction)
-	err := uc.SendCoin(ctx, "user123", "user456", 100)
+	uc := send_coin.NewUsecase(mockUser, mockTransaction, nil, nil, noOverrides(ctrl), time.Hour, models.TransferLimits{})
+	_, err := uc.SendCoin(ctx, "user123", "user456", 100, "", "")
 	expectedMsg := fmt.Sprintf("failed to insert transaction: %v", insertErr)
 	if err == nil || err.Error() != expectedMsg {
 		t.Errorf("expected error %q, got %v", expectedMsg, err)
@@ -228,6 +246,7 @@ func TestSendCoin_Success(t *testing.T) {
 	mockUser := mocks.NewMockuser(ctrl)
 	mockTx := mocks.NewMockTx(ctrl)
 	mockTransaction := mocks.NewMocktransaction(ctrl)
+	mockLedger := mocks.NewMockledger(ctrl)
 
 	mockUser.EXPECT().BeginTx(ctx).Return(mockTx, nil)
 	fromData := models.User{ID: "user123", Coins: 200}
@@ -235,18 +254,357 @@ func TestSendCoin_Success(t *testing.T) {
 	mockUser.EXPECT().GetUserById(ctx, mockTx, "user123").Return(fromData, nil)
 	mockUser.EXPECT().GetUserByLoginWithTx(ctx, mockTx, "user456").Return(toData, nil)
 
-	newFromCoins := fromData.Coins - 100
-	newToCoins := toData.Coins + 100
-	mockUser.EXPECT().UpdateUserCoins(ctx, mockTx, "user123", newFromCoins).Return(nil)
-	mockUser.EXPECT().UpdateUserCoins(ctx, mockTx, "user456", newToCoins).Return(nil)
+	mockUser.EXPECT().DebitUserCoins(ctx, mockTx, "user123", int64(100)).Return(nil)
+	mockUser.EXPECT().CreditUserCoins(ctx, mockTx, "user456", int64(100)).Return(nil)
 	mockTransaction.EXPECT().
-		InsertTransaction(ctx, mockTx, gomock.Any(), "user123", "user456", gomock.Any()).
+		InsertTransaction(ctx, mockTx, transfer("user123", "user456")).
 		Return(nil)
+	mockLedger.EXPECT().PostEntry(ctx, mockTx, gomock.Any()).DoAndReturn(
+		func(_ context.Context, _ pgx.Tx, e models.LedgerEntry) error {
+			if e.Kind != models.EntryTransfer || !e.Balanced() {
+				t.Errorf("unexpected ledger entry %+v", e)
+			}
+			if e.Postings[0] != (models.Posting{Account: "user:user123", Amount: -100}) ||
+				e.Postings[1] != (models.Posting{Account: "user:user456", Amount: 100}) {
+				t.Errorf("unexpected postings %+v", e.Postings)
+			}
+			return nil
+		})
 	mockTx.EXPECT().Commit(ctx).Return(nil)
 
-	uc := send_coin.NewUsecase(mockUser, mockTransaction)
-	err := uc.SendCoin(ctx, "user123", "user456", 100)
+	uc := send_coin.NewUsecase(mockUser, mockTransaction, mockLedger, nil, noOverrides(ctrl), time.Hour, models.TransferLimits{})
+	_, err := uc.SendCoin(ctx, "user123", "user456", 100, "", "")
 	if err != nil {
 		t.Errorf("expected no error, got %v", err)
 	}
 }
+
+func TestSendCoin_LocksUsersInIDOrder(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockUser := mocks.NewMockuser(ctrl)
+	mockTx := mocks.NewMockTx(ctrl)
+	mockTransaction := mocks.NewMocktransaction(ctrl)
+	mockLedger := mocks.NewMockledger(ctrl)
+
+	mockUser.EXPECT().BeginTx(ctx).Return(mockTx, nil)
+	mockUser.EXPECT().GetUserById(ctx, mockTx, "user456").Return(models.User{ID: "user456", Username: "bob"}, nil)
+	mockUser.EXPECT().GetUserByLoginWithTx(ctx, mockTx, "alice").Return(models.User{ID: "user123", Username: "alice"}, nil)
+	gomock.InOrder(
+		mockUser.EXPECT().CreditUserCoins(ctx, mockTx, "user123", int64(10)).Return(nil),
+		mockUser.EXPECT().DebitUserCoins(ctx, mockTx, "user456", int64(10)).Return(nil),
+	)
+	mockTransaction.EXPECT().InsertTransaction(ctx, mockTx, transfer("user456", "user123")).Return(nil)
+	mockLedger.EXPECT().PostEntry(ctx, mockTx, gomock.Any()).Return(nil)
+	mockTx.EXPECT().Commit(ctx).Return(nil)
+
+	uc := send_coin.NewUsecase(mockUser, mockTransaction, mockLedger, nil, noOverrides(ctrl), time.Hour, models.TransferLimits{})
+	if _, err := uc.SendCoin(ctx, "user456", "alice", 10, "", ""); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestSendCoin_RetriesDeadlock(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockUser := mocks.NewMockuser(ctrl)
+	mockTx := mocks.NewMockTx(ctrl)
+	mockTransaction := mocks.NewMocktransaction(ctrl)
+	mockLedger := mocks.NewMockledger(ctrl)
+
+	fromData := models.User{ID: "user123", Username: "alice"}
+	toData := models.User{ID: "user456", Username: "bob"}
+	deadlock := &pgconn.PgError{Code: "40P01"}
+
+	mockUser.EXPECT().BeginTx(ctx).Return(mockTx, nil).Times(2)
+	mockUser.EXPECT().GetUserById(ctx, mockTx, "user123").Return(fromData, nil).Times(2)
+	mockUser.EXPECT().GetUserByLoginWithTx(ctx, mockTx, "bob").Return(toData, nil).Times(2)
+	gomock.InOrder(
+		mockUser.EXPECT().DebitUserCoins(ctx, mockTx, "user123", int64(10)).Return(deadlock),
+		mockUser.EXPECT().DebitUserCoins(ctx, mockTx, "user123", int64(10)).Return(nil),
+	)
+	mockUser.EXPECT().CreditUserCoins(ctx, mockTx, "user456", int64(10)).Return(nil)
+	mockTransaction.EXPECT().InsertTransaction(ctx, mockTx, transfer("user123", "user456")).Return(nil)
+	mockLedger.EXPECT().PostEntry(ctx, mockTx, gomock.Any()).Return(nil)
+	mockTx.EXPECT().Rollback(ctx).Return(nil)
+	mockTx.EXPECT().Commit(ctx).Return(nil)
+
+	uc := send_coin.NewUsecase(mockUser, mockTransaction, mockLedger, nil, noOverrides(ctrl), time.Hour, models.TransferLimits{})
+	if _, err := uc.SendCoin(ctx, "user123", "bob", 10, "", ""); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestSendCoin_StoresIdempotencyKey(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	key := models.NewIdempotencyKey("user123", "retry-1", "POST", "/api/sendCoin", []byte(`{"toUser":"bob","amount":10}`))
+	ctx := context.WithValue(context.Background(), models.IdempotencyKeyLocal, key)
+	mockUser := mocks.NewMockuser(ctrl)
+	mockTx := mocks.NewMockTx(ctrl)
+	mockTransaction := mocks.NewMocktransaction(ctrl)
+	mockLedger := mocks.NewMockledger(ctrl)
+	mockIdempotency := mocks.NewMockidempotency(ctrl)
+
+	mockUser.EXPECT().BeginTx(ctx).Return(mockTx, nil)
+	mockIdempotency.EXPECT().ClaimKey(ctx, mockTx, key).Return(key, nil)
+	mockUser.EXPECT().GetUserById(ctx, mockTx, "user123").Return(models.User{ID: "user123", Username: "alice"}, nil)
+	mockUser.EXPECT().GetUserByLoginWithTx(ctx, mockTx, "bob").Return(models.User{ID: "user456", Username: "bob"}, nil)
+	mockUser.EXPECT().DebitUserCoins(ctx, mockTx, "user123", int64(10)).Return(nil)
+	mockUser.EXPECT().CreditUserCoins(ctx, mockTx, "user456", int64(10)).Return(nil)
+	mockTransaction.EXPECT().InsertTransaction(ctx, mockTx, transfer("user123", "user456")).Return(nil)
+	mockLedger.EXPECT().PostEntry(ctx, mockTx, gomock.Any()).Return(nil)
+	mockIdempotency.EXPECT().SaveResponse(ctx, mockTx, gomock.Any()).DoAndReturn(
+		func(_ context.Context, _ pgx.Tx, k models.IdempotencyKey) error {
+			var stored models.Transfer
+			if k.Key != "retry-1" || k.RequestHash != key.RequestHash ||
+				json.Unmarshal(k.Response, &stored) != nil || stored.ID == "" || stored.Amount != 10 {
+				t.Errorf("unexpected stored key %+v", k)
+			}
+			return nil
+		})
+	mockTx.EXPECT().Commit(ctx).Return(nil)
+
+	uc := send_coin.NewUsecase(mockUser, mockTransaction, mockLedger, mockIdempotency, noOverrides(ctrl), time.Hour, models.TransferLimits{})
+	if _, err := uc.SendCoin(ctx, "user123", "bob", 10, "", ""); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestSendCoin_ReplaysDuplicateRequest(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	key := models.NewIdempotencyKey("user123", "retry-1", "POST", "/api/sendCoin", []byte(`{"toUser":"bob","amount":10}`))
+	ctx := context.WithValue(context.Background(), models.IdempotencyKeyLocal, key)
+	mockUser := mocks.NewMockuser(ctrl)
+	mockTx := mocks.NewMockTx(ctrl)
+	mockIdempotency := mocks.NewMockidempotency(ctrl)
+
+	stored := key
+	stored.Response = []byte(`{"ID":"tr-1","Amount":10}`)
+
+	mockUser.EXPECT().BeginTx(ctx).Return(mockTx, nil)
+	mockIdempotency.EXPECT().ClaimKey(ctx, mockTx, key).Return(stored, nil)
+	mockTx.EXPECT().Commit(ctx).Return(nil)
+
+	uc := send_coin.NewUsecase(mockUser, mocks.NewMocktransaction(ctrl), mocks.NewMockledger(ctrl), mockIdempotency, noOverrides(ctrl), time.Hour, models.TransferLimits{})
+	tr, err := uc.SendCoin(ctx, "user123", "bob", 10, "", "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if tr.ID != "tr-1" {
+		t.Errorf("expected replayed transfer tr-1, got %+v", tr)
+	}
+}
+
+func TestSendCoin_RejectsReusedIdempotencyKey(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	key := models.NewIdempotencyKey("user123", "retry-1", "POST", "/api/sendCoin", []byte(`{"toUser":"bob","amount":20}`))
+	ctx := context.WithValue(context.Background(), models.IdempotencyKeyLocal, key)
+	mockUser := mocks.NewMockuser(ctrl)
+	mockTx := mocks.NewMockTx(ctrl)
+	mockIdempotency := mocks.NewMockidempotency(ctrl)
+
+	stored := models.NewIdempotencyKey("user123", "retry-1", "POST", "/api/sendCoin", []byte(`{"toUser":"bob","amount":10}`))
+	stored.Response = []byte("{}")
+
+	mockUser.EXPECT().BeginTx(ctx).Return(mockTx, nil)
+	mockIdempotency.EXPECT().ClaimKey(ctx, mockTx, key).Return(stored, nil)
+	mockTx.EXPECT().Rollback(ctx).Return(nil)
+
+	uc := send_coin.NewUsecase(mockUser, mocks.NewMocktransaction(ctrl), mocks.NewMockledger(ctrl), mockIdempotency, noOverrides(ctrl), time.Hour, models.TransferLimits{})
+	_, err := uc.SendCoin(ctx, "user123", "bob", 20, "", "")
+	if !errors.Is(err, models.ErrIdempotencyKeyReused) {
+		t.Errorf("expected error %v, got %v", models.ErrIdempotencyKeyReused, err)
+	}
+}
+
+// transferMatcher - сравнивает перевод по отправителю и получателю, id и сумму не проверяет
+type transferMatcher struct {
+	from, to string
+}
+
+func transfer(from, to string) gomock.Matcher {
+	return transferMatcher{from: from, to: to}
+}
+
+func (m transferMatcher) Matches(x interface{}) bool {
+	t, ok := x.(models.Transfer)
+	return ok && t.FromUserID == m.from && t.ToUserID == m.to
+}
+
+func (m transferMatcher) String() string {
+	return fmt.Sprintf("transfer from %s to %s", m.from, m.to)
+}
+
+func TestSendCoin_StoresMessageAndCategory(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockUser := mocks.NewMockuser(ctrl)
+	mockTx := mocks.NewMockTx(ctrl)
+	mockTransaction := mocks.NewMocktransaction(ctrl)
+	mockLedger := mocks.NewMockledger(ctrl)
+
+	mockUser.EXPECT().BeginTx(ctx).Return(mockTx, nil)
+	mockUser.EXPECT().GetUserById(ctx, mockTx, "user123").Return(models.User{ID: "user123", Username: "alice"}, nil)
+	mockUser.EXPECT().GetUserByLoginWithTx(ctx, mockTx, "bob").Return(models.User{ID: "user456", Username: "bob"}, nil)
+	mockUser.EXPECT().DebitUserCoins(ctx, mockTx, "user123", int64(10)).Return(nil)
+	mockUser.EXPECT().CreditUserCoins(ctx, mockTx, "user456", int64(10)).Return(nil)
+	mockTransaction.EXPECT().InsertTransaction(ctx, mockTx, gomock.Any()).DoAndReturn(
+		func(_ context.Context, _ pgx.Tx, tr models.Transfer) error {
+			if tr.Amount != 10 || tr.Message != "for the review" || tr.Category != models.TransferHelp {
+				t.Errorf("unexpected transfer %+v", tr)
+			}
+			return nil
+		})
+	mockLedger.EXPECT().PostEntry(ctx, mockTx, gomock.Any()).Return(nil)
+	mockTx.EXPECT().Commit(ctx).Return(nil)
+
+	uc := send_coin.NewUsecase(mockUser, mockTransaction, mockLedger, nil, noOverrides(ctrl), time.Hour, models.TransferLimits{})
+	if _, err := uc.SendCoin(ctx, "user123", "bob", 10, "for the review", models.TransferHelp); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestSendCoin_UnknownCategory(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	uc := send_coin.NewUsecase(mocks.NewMockuser(ctrl), mocks.NewMocktransaction(ctrl), nil, nil, noOverrides(ctrl), time.Hour, models.TransferLimits{})
+	_, err := uc.SendCoin(context.Background(), "user123", "bob", 10, "", "bribe")
+	if !errors.Is(err, send_coin.ErrUnknownCategory) {
+		t.Errorf("expected error %v, got %v", send_coin.ErrUnknownCategory, err)
+	}
+}
+
+func TestSendCoinTx_UsesCallerTransaction(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockUser := mocks.NewMockuser(ctrl)
+	mockTx := mocks.NewMockTx(ctrl)
+	mockTransaction := mocks.NewMocktransaction(ctrl)
+	mockLedger := mocks.NewMockledger(ctrl)
+
+	mockUser.EXPECT().GetUserById(ctx, mockTx, "user123").Return(models.User{ID: "user123", Username: "alice"}, nil)
+	mockUser.EXPECT().GetUserByLoginWithTx(ctx, mockTx, "bob").Return(models.User{ID: "user456", Username: "bob"}, nil)
+	mockUser.EXPECT().DebitUserCoins(ctx, mockTx, "user123", int64(10)).Return(nil)
+	mockUser.EXPECT().CreditUserCoins(ctx, mockTx, "user456", int64(10)).Return(nil)
+	mockTransaction.EXPECT().InsertTransaction(ctx, mockTx, gomock.Any()).Return(nil)
+	mockLedger.EXPECT().PostEntry(ctx, mockTx, gomock.Any()).Return(nil)
+
+	uc := send_coin.NewUsecase(mockUser, mockTransaction, mockLedger, nil, noOverrides(ctrl), time.Hour, models.TransferLimits{})
+	if _, err := uc.SendCoinTx(ctx, mockTx, "user123", "bob", 10, "", ""); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestSendBatch_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockUser := mocks.NewMockuser(ctrl)
+	mockTx := mocks.NewMockTx(ctrl)
+	mockTransaction := mocks.NewMocktransaction(ctrl)
+	mockLedger := mocks.NewMockledger(ctrl)
+
+	mockUser.EXPECT().BeginTx(ctx).Return(mockTx, nil)
+	mockUser.EXPECT().GetUserById(ctx, mockTx, "user2").Return(models.User{ID: "user2", Username: "lead"}, nil)
+	mockUser.EXPECT().GetUserByLoginWithTx(ctx, mockTx, "carol").Return(models.User{ID: "user3", Username: "carol"}, nil)
+	mockUser.EXPECT().GetUserByLoginWithTx(ctx, mockTx, "alice").Return(models.User{ID: "user1", Username: "alice"}, nil)
+	gomock.InOrder(
+		mockUser.EXPECT().CreditUserCoins(ctx, mockTx, "user1", int64(20)).Return(nil),
+		mockUser.EXPECT().DebitUserCoins(ctx, mockTx, "user2", int64(50)).Return(nil),
+		mockUser.EXPECT().CreditUserCoins(ctx, mockTx, "user3", int64(30)).Return(nil),
+	)
+	mockTransaction.EXPECT().InsertTransaction(ctx, mockTx, transfer("user2", "user3")).Return(nil)
+	mockTransaction.EXPECT().InsertTransaction(ctx, mockTx, transfer("user2", "user1")).Return(nil)
+	mockLedger.EXPECT().PostEntry(ctx, mockTx, gomock.Any()).Return(nil).Times(2)
+	mockTx.EXPECT().Commit(ctx).Return(nil)
+
+	uc := send_coin.NewUsecase(mockUser, mockTransaction, mockLedger, nil, noOverrides(ctrl), time.Hour, models.TransferLimits{})
+	res, err := uc.SendBatch(ctx, "user2", []models.BatchRecipient{
+		{ToUser: "carol", Amount: 30},
+		{ToUser: "alice", Amount: 20},
+	}, "great release", models.TransferTeamwork)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(res) != 2 || res[0].Status != models.BatchSent || res[1].Status != models.BatchSent ||
+		res[0].TransactionID == "" || res[0].TransactionID == res[1].TransactionID {
+		t.Errorf("unexpected results %+v", res)
+	}
+}
+
+func TestSendBatch_RejectsInvalidRecipients(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockUser := mocks.NewMockuser(ctrl)
+	mockTx := mocks.NewMockTx(ctrl)
+
+	mockUser.EXPECT().BeginTx(ctx).Return(mockTx, nil)
+	mockUser.EXPECT().GetUserById(ctx, mockTx, "user2").Return(models.User{ID: "user2", Username: "lead"}, nil)
+	mockUser.EXPECT().GetUserByLoginWithTx(ctx, mockTx, "alice").Return(models.User{ID: "user1", Username: "alice"}, nil)
+	mockUser.EXPECT().GetUserByLoginWithTx(ctx, mockTx, "ghost").Return(models.User{}, fmt.Errorf("failed to scan user: %w", pgx.ErrNoRows))
+	mockTx.EXPECT().Rollback(ctx).Return(nil)
+
+	uc := send_coin.NewUsecase(mockUser, mocks.NewMocktransaction(ctrl), mocks.NewMockledger(ctrl), nil, noOverrides(ctrl), time.Hour, models.TransferLimits{})
+	res, err := uc.SendBatch(ctx, "user2", []models.BatchRecipient{
+		{ToUser: "alice", Amount: 10},
+		{ToUser: "ghost", Amount: 10},
+		{ToUser: "lead", Amount: 10},
+		{ToUser: "alice", Amount: 5},
+	}, "", "")
+	if !errors.Is(err, send_coin.ErrBatchRejected) {
+		t.Fatalf("expected error %v, got %v", send_coin.ErrBatchRejected, err)
+	}
+
+	want := []string{models.BatchNotSent, models.BatchUnknownRecipient, models.BatchSameUser, models.BatchDuplicateRecipient}
+	if len(res) != len(want) {
+		t.Fatalf("expected %d results, got %+v", len(want), res)
+	}
+	for i, status := range want {
+		if res[i].Status != status {
+			t.Errorf("recipient %d: expected status %s, got %s", i, status, res[i].Status)
+		}
+	}
+}
+
+func TestSendBatch_NotEnoughCoinsForTotal(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockUser := mocks.NewMockuser(ctrl)
+	mockTx := mocks.NewMockTx(ctrl)
+
+	mockUser.EXPECT().BeginTx(ctx).Return(mockTx, nil)
+	mockUser.EXPECT().GetUserById(ctx, mockTx, "user1").Return(models.User{ID: "user1", Username: "lead"}, nil)
+	mockUser.EXPECT().GetUserByLoginWithTx(ctx, mockTx, "bob").Return(models.User{ID: "user2", Username: "bob"}, nil)
+	mockUser.EXPECT().GetUserByLoginWithTx(ctx, mockTx, "carol").Return(models.User{ID: "user3", Username: "carol"}, nil)
+	mockUser.EXPECT().DebitUserCoins(ctx, mockTx, "user1", int64(1200)).Return(models.ErrNotEnoughCoins)
+	mockTx.EXPECT().Rollback(ctx).Return(nil)
+
+	uc := send_coin.NewUsecase(mockUser, mocks.NewMocktransaction(ctrl), mocks.NewMockledger(ctrl), nil, noOverrides(ctrl), time.Hour, models.TransferLimits{})
+	_, err := uc.SendBatch(ctx, "user1", []models.BatchRecipient{
+		{ToUser: "bob", Amount: 600},
+		{ToUser: "carol", Amount: 600},
+	}, "", "")
+	if !errors.Is(err, send_coin.ErrNotEnoughCoins) {
+		t.Errorf("expected error %v, got %v", send_coin.ErrNotEnoughCoins, err)
+	}
+}
@@ -4,31 +4,85 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"slices"
+	"time"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+
+	"AvitoTask/internal/models"
+	"AvitoTask/internal/utils"
 )
 
+// conflictAttempts - сколько раз перевод повторяется при конфликте транзакций в базе
+const conflictAttempts = 3
+
 var (
-	ErrSameUser       = errors.New("cannot send coins to the same user")
-	ErrNotEnoughCoins = errors.New("user does not have enough coins to send")
+	ErrSameUser          = errors.New("cannot send coins to the same user")
+	ErrNotEnoughCoins    = errors.New("user does not have enough coins to send")
+	ErrUnknownCategory   = errors.New("unknown transfer category")
+	ErrRecipientNotFound = errors.New("recipient does not exist")
+
+	ErrEmptyBatch    = errors.New("batch transfer has no recipients")
+	ErrBatchRejected = errors.New("batch transfer rejected: some recipients are invalid")
 )
 
 type Usecase struct {
 	repoUser        user
 	repoTransaction transaction
+	repoLedger      ledger
+	repoIdempotency idempotency
+	repoLimits      limit
+	pendingTimeout  time.Duration
+	limits          models.TransferLimits
+	Now             func() time.Time
 }
 
-func NewUsecase(repoUser user, repoTransaction transaction) *Usecase {
+func NewUsecase(repoUser user, repoTransaction transaction, repoLedger ledger, repoIdempotency idempotency,
+	repoLimits limit, pendingTimeout time.Duration, limits models.TransferLimits) *Usecase {
 	return &Usecase{
 		repoUser:        repoUser,
 		repoTransaction: repoTransaction,
+		repoLedger:      repoLedger,
+		repoIdempotency: repoIdempotency,
+		repoLimits:      repoLimits,
+		pendingTimeout:  pendingTimeout,
+		limits:          limits,
+		Now: func() time.Time {
+			return time.Now().UTC()
+		},
+	}
+}
+
+// SendCoin - переводит монеты пользователю toUser и возвращает записанный перевод; message и category -
+// необязательные сообщение и категория перевода, они сохраняются вместе с ним и видны в истории
+func (u *Usecase) SendCoin(ctx context.Context, fromUser, toUser string, amount int64, message, category string) (t models.Transfer, err error) {
+	if !models.ValidTransferCategory(category) {
+		return t, ErrUnknownCategory
+	}
+
+	err = utils.RetryOnConflict(ctx, conflictAttempts, func() error {
+		t, err = u.sendCoin(ctx, fromUser, toUser, amount, message, category)
+		return err
+	})
+
+	return t, err
+}
+
+// SendCoinTx - выполняет перевод в транзакции вызывающего, чтобы он зафиксировался вместе с его изменениями.
+// Повтор при конфликте остаётся на вызывающем
+func (u *Usecase) SendCoinTx(ctx context.Context, tx pgx.Tx, fromUser, toUser string, amount int64, message, category string) (models.Transfer, error) {
+	if !models.ValidTransferCategory(category) {
+		return models.Transfer{}, ErrUnknownCategory
 	}
+
+	return u.transfer(ctx, tx, fromUser, toUser, amount, message, category)
 }
 
-func (u *Usecase) SendCoin(ctx context.Context, fromUser, toUser string, amount int64) (err error) {
+func (u *Usecase) sendCoin(ctx context.Context, fromUser, toUser string, amount int64, message, category string) (t models.Transfer, err error) {
 	tx, err := u.repoUser.BeginTx(ctx)
 	if err != nil {
-		return fmt.Errorf("failed to begin transaction: %w", err)
+		return t, fmt.Errorf("failed to begin transaction: %w", err)
 	}
 
 	defer func() {
@@ -39,37 +93,208 @@ func (u *Usecase) SendCoin(ctx context.Context, fromUser, toUser string, amount
 		}
 	}()
 
+	return utils.Idempotent(ctx, tx, u.repoIdempotency, func() (models.Transfer, error) {
+		return u.transfer(ctx, tx, fromUser, toUser, amount, message, category)
+	})
+}
+
+func (u *Usecase) transfer(ctx context.Context, tx pgx.Tx, fromUser, toUser string, amount int64, message, category string) (models.Transfer, error) {
 	fromData, err := u.repoUser.GetUserById(ctx, tx, fromUser)
 	if err != nil {
-		return fmt.Errorf("failed to get user by id: %w", err)
+		return models.Transfer{}, fmt.Errorf("failed to get user by id: %w", err)
 	}
 
 	if fromData.Username == toUser {
-		return ErrSameUser
+		return models.Transfer{}, ErrSameUser
 	}
 
 	toData, err := u.repoUser.GetUserByLoginWithTx(ctx, tx, toUser)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return models.Transfer{}, ErrRecipientNotFound
+	}
+	if err != nil {
+		return models.Transfer{}, fmt.Errorf("failed to get user by id: %w", err)
+	}
+
+	if err = u.move(ctx, tx, fromData.ID, toData.ID, amount); err != nil {
+		return models.Transfer{}, err
+	}
+	if err = u.checkLimits(ctx, tx, fromData.ID, map[string]int64{toData.ID: amount}); err != nil {
+		return models.Transfer{}, err
+	}
+
+	t := models.Transfer{
+		ID:         uuid.New().String(),
+		FromUserID: fromData.ID,
+		ToUserID:   toData.ID,
+		Amount:     amount,
+		Message:    message,
+		Category:   category,
+		Status:     models.TransferCompleted,
+	}
+	if err = u.repoTransaction.InsertTransaction(ctx, tx, t); err != nil {
+		return models.Transfer{}, fmt.Errorf("failed to insert transaction: %w", err)
+	}
+
+	entry := models.NewLedgerEntry(uuid.New().String(), models.EntryTransfer, t.ID,
+		models.UserAccount(fromData.ID), models.UserAccount(toData.ID), amount)
+	if err = u.repoLedger.PostEntry(ctx, tx, entry); err != nil {
+		return models.Transfer{}, fmt.Errorf("failed to post ledger entry: %w", err)
+	}
+
+	return t, nil
+}
+
+// SendBatch - переводит монеты нескольким получателям одной транзакцией: у отправителя списывается
+// общая сумма, на каждого получателя пишется свой перевод. Если хоть один получатель неизвестен,
+// совпадает с отправителем или повторяется, пакет отклоняется целиком с ErrBatchRejected,
+// а в результатах указано, что не так с каждым получателем
+func (u *Usecase) SendBatch(ctx context.Context, fromUser string, recipients []models.BatchRecipient, message, category string) (res []models.BatchResult, err error) {
+	if !models.ValidTransferCategory(category) {
+		return nil, ErrUnknownCategory
+	}
+	if len(recipients) == 0 {
+		return nil, ErrEmptyBatch
+	}
+
+	err = utils.RetryOnConflict(ctx, conflictAttempts, func() error {
+		res, err = u.sendBatch(ctx, fromUser, recipients, message, category)
+		return err
+	})
+
+	return res, err
+}
+
+func (u *Usecase) sendBatch(ctx context.Context, fromUser string, recipients []models.BatchRecipient, message, category string) (res []models.BatchResult, err error) {
+	tx, err := u.repoUser.BeginTx(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback(ctx)
+		} else {
+			err = tx.Commit(ctx)
+		}
+	}()
+
+	return utils.Idempotent(ctx, tx, u.repoIdempotency, func() ([]models.BatchResult, error) {
+		return u.batch(ctx, tx, fromUser, recipients, message, category)
+	})
+}
+
+func (u *Usecase) batch(ctx context.Context, tx pgx.Tx, fromUser string, recipients []models.BatchRecipient, message, category string) ([]models.BatchResult, error) {
+	fromData, err := u.repoUser.GetUserById(ctx, tx, fromUser)
 	if err != nil {
-		return fmt.Errorf("failed to get user by id: %w", err)
+		return nil, fmt.Errorf("failed to get user by id: %w", err)
+	}
+
+	results := make([]models.BatchResult, len(recipients))
+	toIDs := make([]string, len(recipients))
+	seen := make(map[string]bool, len(recipients))
+	rejected := false
+	var total int64
+
+	for i, r := range recipients {
+		results[i] = models.BatchResult{ToUser: r.ToUser, Amount: r.Amount, Status: models.BatchNotSent}
+
+		switch {
+		case r.ToUser == fromData.Username:
+			results[i].Status = models.BatchSameUser
+		case seen[r.ToUser]:
+			results[i].Status = models.BatchDuplicateRecipient
+		default:
+			toData, err := u.repoUser.GetUserByLoginWithTx(ctx, tx, r.ToUser)
+			if errors.Is(err, pgx.ErrNoRows) {
+				results[i].Status = models.BatchUnknownRecipient
+				break
+			}
+			if err != nil {
+				return nil, fmt.Errorf("failed to get user by login: %w", err)
+			}
+			toIDs[i] = toData.ID
+			total += r.Amount
+		}
+
+		seen[r.ToUser] = true
+		if results[i].Status != models.BatchNotSent {
+			rejected = true
+		}
 	}
 
-	if fromData.Coins < amount {
-		err = ErrNotEnoughCoins
-		return ErrNotEnoughCoins
+	if rejected {
+		return results, ErrBatchRejected
+	}
+
+	credits := make(map[string]int64, len(recipients))
+	for i, r := range recipients {
+		credits[toIDs[i]] = r.Amount
+	}
+	if err = u.settle(ctx, tx, fromData.ID, total, credits); err != nil {
+		return nil, err
 	}
+	if err = u.checkLimits(ctx, tx, fromData.ID, credits); err != nil {
+		return nil, err
+	}
+
+	for i, r := range recipients {
+		t := models.Transfer{
+			ID:         uuid.New().String(),
+			FromUserID: fromData.ID,
+			ToUserID:   toIDs[i],
+			Amount:     r.Amount,
+			Message:    message,
+			Category:   category,
+			Status:     models.TransferCompleted,
+		}
+		if err = u.repoTransaction.InsertTransaction(ctx, tx, t); err != nil {
+			return nil, fmt.Errorf("failed to insert transaction: %w", err)
+		}
 
-	newFromCoins := fromData.Coins - amount
-	newToCoins := toData.Coins + amount
+		entry := models.NewLedgerEntry(uuid.New().String(), models.EntryTransfer, t.ID,
+			models.UserAccount(fromData.ID), models.UserAccount(t.ToUserID), t.Amount)
+		if err = u.repoLedger.PostEntry(ctx, tx, entry); err != nil {
+			return nil, fmt.Errorf("failed to post ledger entry: %w", err)
+		}
 
-	if err = u.repoUser.UpdateUserCoins(ctx, tx, fromData.ID, newFromCoins); err != nil {
-		return fmt.Errorf("failed to update user coins: %w", err)
+		results[i].Status = models.BatchSent
+		results[i].TransactionID = t.ID
 	}
-	if err = u.repoUser.UpdateUserCoins(ctx, tx, toData.ID, newToCoins); err != nil {
-		return fmt.Errorf("failed to update user coins: %w", err)
+
+	return results, nil
+}
+
+// move - списывает монеты у отправителя и зачисляет одному получателю
+func (u *Usecase) move(ctx context.Context, tx pgx.Tx, fromID, toID string, amount int64) error {
+	return u.settle(ctx, tx, fromID, amount, map[string]int64{toID: amount})
+}
+
+// settle - списывает total у отправителя и зачисляет получателям их суммы из credits. Строки пользователей
+// обновляются в порядке их id, чтобы встречные переводы блокировали их в одном порядке и не ловили взаимоблокировку
+func (u *Usecase) settle(ctx context.Context, tx pgx.Tx, fromID string, total int64, credits map[string]int64) error {
+	ids := make([]string, 0, len(credits)+1)
+	ids = append(ids, fromID)
+	for id := range credits {
+		ids = append(ids, id)
 	}
+	slices.Sort(ids)
+
+	for _, id := range ids {
+		if id == fromID {
+			err := u.repoUser.DebitUserCoins(ctx, tx, fromID, total)
+			if errors.Is(err, models.ErrNotEnoughCoins) {
+				return ErrNotEnoughCoins
+			}
+			if err != nil {
+				return fmt.Errorf("failed to update user coins: %w", err)
+			}
+			continue
+		}
 
-	if err = u.repoTransaction.InsertTransaction(ctx, tx, uuid.New().String(), fromData.ID, toData.ID, amount); err != nil {
-		return fmt.Errorf("failed to insert transaction: %w", err)
+		if err := u.repoUser.CreditUserCoins(ctx, tx, id, credits[id]); err != nil {
+			return fmt.Errorf("failed to update user coins: %w", err)
+		}
 	}
 
 	return nil
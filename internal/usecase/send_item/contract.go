@@ -0,0 +1,31 @@
+//go:generate mockgen -source=contract.go -destination=mocks/mock.go -package=mocks $GOPACKAGE
+//go:generate mockgen -destination=mocks/mock_tx.go -package=mocks github.com/jackc/pgx/v5 Tx
+package send_item
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+
+	"AvitoTask/internal/models"
+)
+
+type user interface {
+	BeginTx(ctx context.Context) (pgx.Tx, error)
+	GetUserById(ctx context.Context, tx pgx.Tx, userID string) (models.User, error)
+	GetUserByLoginWithTx(ctx context.Context, tx pgx.Tx, login string) (models.User, error)
+}
+
+type inventory interface {
+	GetInventoryItem(ctx context.Context, tx pgx.Tx, userID, itemType, variant string) (int64, error)
+	TakeInventoryItem(ctx context.Context, tx pgx.Tx, userID, itemType, variant string, quantity int64) error
+	AddInventoryItem(ctx context.Context, tx pgx.Tx, id, userID, itemType, variant string, quantity int64) error
+}
+
+type itemTransfer interface {
+	InsertItemTransfer(ctx context.Context, tx pgx.Tx, id, fromUserID, toUserID, itemType, variant string, quantity int64) error
+}
+
+type order interface {
+	CountOpenOrderItems(ctx context.Context, tx pgx.Tx, userID, item, variant string) (int64, error)
+}
@@ -0,0 +1,223 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: contract.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	models "AvitoTask/internal/models"
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	pgx "github.com/jackc/pgx/v5"
+)
+
+// Mockuser is a mock of user interface.
+type Mockuser struct {
+	ctrl     *gomock.Controller
+	recorder *MockuserMockRecorder
+}
+
+// MockuserMockRecorder is the mock recorder for Mockuser.
+type MockuserMockRecorder struct {
+	mock *Mockuser
+}
+
+// NewMockuser creates a new mock instance.
+func NewMockuser(ctrl *gomock.Controller) *Mockuser {
+	mock := &Mockuser{ctrl: ctrl}
+	mock.recorder = &MockuserMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mockuser) EXPECT() *MockuserMockRecorder {
+	return m.recorder
+}
+
+// BeginTx mocks base method.
+func (m *Mockuser) BeginTx(ctx context.Context) (pgx.Tx, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BeginTx", ctx)
+	ret0, _ := ret[0].(pgx.Tx)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BeginTx indicates an expected call of BeginTx.
+func (mr *MockuserMockRecorder) BeginTx(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BeginTx", reflect.TypeOf((*Mockuser)(nil).BeginTx), ctx)
+}
+
+// GetUserById mocks base method.
+func (m *Mockuser) GetUserById(ctx context.Context, tx pgx.Tx, userID string) (models.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserById", ctx, tx, userID)
+	ret0, _ := ret[0].(models.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserById indicates an expected call of GetUserById.
+func (mr *MockuserMockRecorder) GetUserById(ctx, tx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserById", reflect.TypeOf((*Mockuser)(nil).GetUserById), ctx, tx, userID)
+}
+
+// GetUserByLoginWithTx mocks base method.
+func (m *Mockuser) GetUserByLoginWithTx(ctx context.Context, tx pgx.Tx, login string) (models.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserByLoginWithTx", ctx, tx, login)
+	ret0, _ := ret[0].(models.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserByLoginWithTx indicates an expected call of GetUserByLoginWithTx.
+func (mr *MockuserMockRecorder) GetUserByLoginWithTx(ctx, tx, login interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByLoginWithTx", reflect.TypeOf((*Mockuser)(nil).GetUserByLoginWithTx), ctx, tx, login)
+}
+
+// Mockinventory is a mock of inventory interface.
+type Mockinventory struct {
+	ctrl     *gomock.Controller
+	recorder *MockinventoryMockRecorder
+}
+
+// MockinventoryMockRecorder is the mock recorder for Mockinventory.
+type MockinventoryMockRecorder struct {
+	mock *Mockinventory
+}
+
+// NewMockinventory creates a new mock instance.
+func NewMockinventory(ctrl *gomock.Controller) *Mockinventory {
+	mock := &Mockinventory{ctrl: ctrl}
+	mock.recorder = &MockinventoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mockinventory) EXPECT() *MockinventoryMockRecorder {
+	return m.recorder
+}
+
+// AddInventoryItem mocks base method.
+func (m *Mockinventory) AddInventoryItem(ctx context.Context, tx pgx.Tx, id, userID, itemType, variant string, quantity int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddInventoryItem", ctx, tx, id, userID, itemType, variant, quantity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddInventoryItem indicates an expected call of AddInventoryItem.
+func (mr *MockinventoryMockRecorder) AddInventoryItem(ctx, tx, id, userID, itemType, variant, quantity interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddInventoryItem", reflect.TypeOf((*Mockinventory)(nil).AddInventoryItem), ctx, tx, id, userID, itemType, variant, quantity)
+}
+
+// GetInventoryItem mocks base method.
+func (m *Mockinventory) GetInventoryItem(ctx context.Context, tx pgx.Tx, userID, itemType, variant string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetInventoryItem", ctx, tx, userID, itemType, variant)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetInventoryItem indicates an expected call of GetInventoryItem.
+func (mr *MockinventoryMockRecorder) GetInventoryItem(ctx, tx, userID, itemType, variant interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInventoryItem", reflect.TypeOf((*Mockinventory)(nil).GetInventoryItem), ctx, tx, userID, itemType, variant)
+}
+
+// TakeInventoryItem mocks base method.
+func (m *Mockinventory) TakeInventoryItem(ctx context.Context, tx pgx.Tx, userID, itemType, variant string, quantity int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TakeInventoryItem", ctx, tx, userID, itemType, variant, quantity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// TakeInventoryItem indicates an expected call of TakeInventoryItem.
+func (mr *MockinventoryMockRecorder) TakeInventoryItem(ctx, tx, userID, itemType, variant, quantity interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TakeInventoryItem", reflect.TypeOf((*Mockinventory)(nil).TakeInventoryItem), ctx, tx, userID, itemType, variant, quantity)
+}
+
+// MockitemTransfer is a mock of itemTransfer interface.
+type MockitemTransfer struct {
+	ctrl     *gomock.Controller
+	recorder *MockitemTransferMockRecorder
+}
+
+// MockitemTransferMockRecorder is the mock recorder for MockitemTransfer.
+type MockitemTransferMockRecorder struct {
+	mock *MockitemTransfer
+}
+
+// NewMockitemTransfer creates a new mock instance.
+func NewMockitemTransfer(ctrl *gomock.Controller) *MockitemTransfer {
+	mock := &MockitemTransfer{ctrl: ctrl}
+	mock.recorder = &MockitemTransferMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockitemTransfer) EXPECT() *MockitemTransferMockRecorder {
+	return m.recorder
+}
+
+// InsertItemTransfer mocks base method.
+func (m *MockitemTransfer) InsertItemTransfer(ctx context.Context, tx pgx.Tx, id, fromUserID, toUserID, itemType, variant string, quantity int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertItemTransfer", ctx, tx, id, fromUserID, toUserID, itemType, variant, quantity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InsertItemTransfer indicates an expected call of InsertItemTransfer.
+func (mr *MockitemTransferMockRecorder) InsertItemTransfer(ctx, tx, id, fromUserID, toUserID, itemType, variant, quantity interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertItemTransfer", reflect.TypeOf((*MockitemTransfer)(nil).InsertItemTransfer), ctx, tx, id, fromUserID, toUserID, itemType, variant, quantity)
+}
+
+// Mockorder is a mock of order interface.
+type Mockorder struct {
+	ctrl     *gomock.Controller
+	recorder *MockorderMockRecorder
+}
+
+// MockorderMockRecorder is the mock recorder for Mockorder.
+type MockorderMockRecorder struct {
+	mock *Mockorder
+}
+
+// NewMockorder creates a new mock instance.
+func NewMockorder(ctrl *gomock.Controller) *Mockorder {
+	mock := &Mockorder{ctrl: ctrl}
+	mock.recorder = &MockorderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mockorder) EXPECT() *MockorderMockRecorder {
+	return m.recorder
+}
+
+// CountOpenOrderItems mocks base method.
+func (m *Mockorder) CountOpenOrderItems(ctx context.Context, tx pgx.Tx, userID, item, variant string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountOpenOrderItems", ctx, tx, userID, item, variant)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountOpenOrderItems indicates an expected call of CountOpenOrderItems.
+func (mr *MockorderMockRecorder) CountOpenOrderItems(ctx, tx, userID, item, variant interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountOpenOrderItems", reflect.TypeOf((*Mockorder)(nil).CountOpenOrderItems), ctx, tx, userID, item, variant)
+}
@@ -0,0 +1,155 @@
+package send_item_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/jackc/pgx/v5"
+
+	"AvitoTask/internal/models"
+	"AvitoTask/internal/usecase/send_item"
+	"AvitoTask/internal/usecase/send_item/mocks"
+)
+
+func TestSendItem_SameUser(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockUser := mocks.NewMockuser(ctrl)
+	mockInventory := mocks.NewMockinventory(ctrl)
+	mockItemTransfer := mocks.NewMockitemTransfer(ctrl)
+	mockTx := mocks.NewMockTx(ctrl)
+
+	mockUser.EXPECT().BeginTx(ctx).Return(mockTx, nil)
+	mockUser.EXPECT().GetUserById(ctx, mockTx, "user123").Return(models.User{ID: "user123", Username: "alice"}, nil)
+	mockTx.EXPECT().Rollback(ctx).Return(nil)
+
+	uc := send_item.NewUsecase(mockUser, mockInventory, mockItemTransfer, mocks.NewMockorder(ctrl))
+	err := uc.SendItem(ctx, "user123", "alice", "cup", "", 1)
+	if !errors.Is(err, send_item.ErrSameUser) {
+		t.Errorf("expected error %v, got %v", send_item.ErrSameUser, err)
+	}
+}
+
+func TestSendItem_BeginTxError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockUser := mocks.NewMockuser(ctrl)
+	mockInventory := mocks.NewMockinventory(ctrl)
+	mockItemTransfer := mocks.NewMockitemTransfer(ctrl)
+
+	beginErr := errors.New("begin tx error")
+	mockUser.EXPECT().BeginTx(ctx).Return(nil, beginErr)
+
+	uc := send_item.NewUsecase(mockUser, mockInventory, mockItemTransfer, mocks.NewMockorder(ctrl))
+	err := uc.SendItem(ctx, "user123", "bob", "cup", "", 1)
+	expectedMsg := fmt.Sprintf("failed to begin transaction: %v", beginErr)
+	if err == nil || err.Error() != expectedMsg {
+		t.Errorf("expected error %q, got %v", expectedMsg, err)
+	}
+}
+
+func TestSendItem_RecipientNotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockUser := mocks.NewMockuser(ctrl)
+	mockInventory := mocks.NewMockinventory(ctrl)
+	mockItemTransfer := mocks.NewMockitemTransfer(ctrl)
+	mockTx := mocks.NewMockTx(ctrl)
+
+	mockUser.EXPECT().BeginTx(ctx).Return(mockTx, nil)
+	mockUser.EXPECT().GetUserById(ctx, mockTx, "user123").Return(models.User{ID: "user123", Username: "alice"}, nil)
+	mockUser.EXPECT().GetUserByLoginWithTx(ctx, mockTx, "bob").Return(models.User{}, fmt.Errorf("failed to scan user: %w", pgx.ErrNoRows))
+	mockTx.EXPECT().Rollback(ctx).Return(nil)
+
+	uc := send_item.NewUsecase(mockUser, mockInventory, mockItemTransfer, mocks.NewMockorder(ctrl))
+	err := uc.SendItem(ctx, "user123", "bob", "cup", "", 1)
+	if !errors.Is(err, send_item.ErrRecipientNotFound) {
+		t.Errorf("expected error %v, got %v", send_item.ErrRecipientNotFound, err)
+	}
+}
+
+func TestSendItem_NotEnoughItems(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockUser := mocks.NewMockuser(ctrl)
+	mockInventory := mocks.NewMockinventory(ctrl)
+	mockItemTransfer := mocks.NewMockitemTransfer(ctrl)
+	mockTx := mocks.NewMockTx(ctrl)
+
+	mockUser.EXPECT().BeginTx(ctx).Return(mockTx, nil)
+	mockUser.EXPECT().GetUserById(ctx, mockTx, "user123").Return(models.User{ID: "user123", Username: "alice"}, nil)
+	mockUser.EXPECT().GetUserByLoginWithTx(ctx, mockTx, "bob").Return(models.User{ID: "user456", Username: "bob"}, nil)
+	mockInventory.EXPECT().TakeInventoryItem(ctx, mockTx, "user123", "cup", "", int64(2)).Return(models.ErrNotEnoughItems)
+	mockTx.EXPECT().Rollback(ctx).Return(nil)
+
+	uc := send_item.NewUsecase(mockUser, mockInventory, mockItemTransfer, mocks.NewMockorder(ctrl))
+	err := uc.SendItem(ctx, "user123", "bob", "cup", "", 2)
+	if !errors.Is(err, send_item.ErrNotEnoughItems) {
+		t.Errorf("expected error %v, got %v", send_item.ErrNotEnoughItems, err)
+	}
+}
+
+func TestSendItem_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockUser := mocks.NewMockuser(ctrl)
+	mockInventory := mocks.NewMockinventory(ctrl)
+	mockItemTransfer := mocks.NewMockitemTransfer(ctrl)
+	mockOrder := mocks.NewMockorder(ctrl)
+	mockTx := mocks.NewMockTx(ctrl)
+
+	mockUser.EXPECT().BeginTx(ctx).Return(mockTx, nil)
+	mockUser.EXPECT().GetUserById(ctx, mockTx, "user123").Return(models.User{ID: "user123", Username: "alice"}, nil)
+	mockUser.EXPECT().GetUserByLoginWithTx(ctx, mockTx, "bob").Return(models.User{ID: "user456", Username: "bob"}, nil)
+	mockInventory.EXPECT().TakeInventoryItem(ctx, mockTx, "user123", "cup", "", int64(2)).Return(nil)
+	mockInventory.EXPECT().GetInventoryItem(ctx, mockTx, "user123", "cup", "").Return(int64(1), nil)
+	mockOrder.EXPECT().CountOpenOrderItems(ctx, mockTx, "user123", "cup", "").Return(int64(1), nil)
+	mockInventory.EXPECT().AddInventoryItem(ctx, mockTx, gomock.Any(), "user456", "cup", "", int64(2)).Return(nil)
+	mockItemTransfer.EXPECT().InsertItemTransfer(ctx, mockTx, gomock.Any(), "user123", "user456", "cup", "", int64(2)).Return(nil)
+	mockTx.EXPECT().Commit(ctx).Return(nil)
+
+	uc := send_item.NewUsecase(mockUser, mockInventory, mockItemTransfer, mockOrder)
+	err := uc.SendItem(ctx, "user123", "bob", "cup", "", 2)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestSendItem_ItemsReservedByOpenOrder(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockUser := mocks.NewMockuser(ctrl)
+	mockInventory := mocks.NewMockinventory(ctrl)
+	mockItemTransfer := mocks.NewMockitemTransfer(ctrl)
+	mockOrder := mocks.NewMockorder(ctrl)
+	mockTx := mocks.NewMockTx(ctrl)
+
+	mockUser.EXPECT().BeginTx(ctx).Return(mockTx, nil)
+	mockUser.EXPECT().GetUserById(ctx, mockTx, "user123").Return(models.User{ID: "user123", Username: "alice"}, nil)
+	mockUser.EXPECT().GetUserByLoginWithTx(ctx, mockTx, "bob").Return(models.User{ID: "user456", Username: "bob"}, nil)
+	mockInventory.EXPECT().TakeInventoryItem(ctx, mockTx, "user123", "cup", "", int64(1)).Return(nil)
+	mockInventory.EXPECT().GetInventoryItem(ctx, mockTx, "user123", "cup", "").Return(int64(1), nil)
+	mockOrder.EXPECT().CountOpenOrderItems(ctx, mockTx, "user123", "cup", "").Return(int64(2), nil)
+	mockTx.EXPECT().Rollback(ctx).Return(nil)
+
+	uc := send_item.NewUsecase(mockUser, mockInventory, mockItemTransfer, mockOrder)
+	err := uc.SendItem(ctx, "user123", "bob", "cup", "", 1)
+	if !errors.Is(err, send_item.ErrItemsReserved) {
+		t.Errorf("expected error %v, got %v", send_item.ErrItemsReserved, err)
+	}
+}
@@ -0,0 +1,100 @@
+package send_item
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+
+	"AvitoTask/internal/models"
+)
+
+var (
+	ErrSameUser          = errors.New("cannot send items to the same user")
+	ErrRecipientNotFound = errors.New("recipient does not exist")
+	ErrNotEnoughItems    = errors.New("user does not have enough items to send")
+	ErrItemsReserved     = errors.New("items belong to orders that have not been handed over yet")
+)
+
+type Usecase struct {
+	repoUser         user
+	repoInventory    inventory
+	repoItemTransfer itemTransfer
+	repoOrder        order
+}
+
+func NewUsecase(repoUser user, repoInventory inventory, repoItemTransfer itemTransfer, repoOrder order) *Usecase {
+	return &Usecase{
+		repoUser:         repoUser,
+		repoInventory:    repoInventory,
+		repoItemTransfer: repoItemTransfer,
+		repoOrder:        repoOrder,
+	}
+}
+
+// SendItem - передаёт предметы другому пользователю; variant - SKU варианта, пустая строка означает базовую позицию
+func (u *Usecase) SendItem(ctx context.Context, fromUser, toUser, item, variant string, quantity int64) (err error) {
+	tx, err := u.repoUser.BeginTx(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback(ctx)
+		} else {
+			err = tx.Commit(ctx)
+		}
+	}()
+
+	fromData, err := u.repoUser.GetUserById(ctx, tx, fromUser)
+	if err != nil {
+		return fmt.Errorf("failed to get user by id: %w", err)
+	}
+
+	if fromData.Username == toUser {
+		return ErrSameUser
+	}
+
+	toData, err := u.repoUser.GetUserByLoginWithTx(ctx, tx, toUser)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return ErrRecipientNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get user by login: %w", err)
+	}
+
+	// списание условное и относительное: два параллельных подарка последней единицы не пройдут оба
+	err = u.repoInventory.TakeInventoryItem(ctx, tx, fromData.ID, item, variant, quantity)
+	if errors.Is(err, models.ErrNotEnoughItems) {
+		return ErrNotEnoughItems
+	}
+	if err != nil {
+		return fmt.Errorf("failed to update sender inventory: %w", err)
+	}
+
+	// единицы невыданных заказов остаются у покупателя: при отмене заказа они забираются обратно
+	left, err := u.repoInventory.GetInventoryItem(ctx, tx, fromData.ID, item, variant)
+	if err != nil {
+		return fmt.Errorf("failed to get sender inventory: %w", err)
+	}
+	reserved, err := u.repoOrder.CountOpenOrderItems(ctx, tx, fromData.ID, item, variant)
+	if err != nil {
+		return err
+	}
+	if left < reserved {
+		return ErrItemsReserved
+	}
+
+	if err = u.repoInventory.AddInventoryItem(ctx, tx, uuid.New().String(), toData.ID, item, variant, quantity); err != nil {
+		return fmt.Errorf("failed to update recipient inventory: %w", err)
+	}
+
+	if err = u.repoItemTransfer.InsertItemTransfer(ctx, tx, uuid.New().String(), fromData.ID, toData.ID, item, variant, quantity); err != nil {
+		return fmt.Errorf("failed to insert item transfer: %w", err)
+	}
+
+	return nil
+}
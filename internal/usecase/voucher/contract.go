@@ -0,0 +1,29 @@
+//go:generate mockgen -source=contract.go -destination=mocks/mock.go -package=mocks $GOPACKAGE
+//go:generate mockgen -destination=mocks/mock_tx.go -package=mocks github.com/jackc/pgx/v5 Tx
+package voucher
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+
+	"AvitoTask/internal/models"
+)
+
+type voucher interface {
+	BeginTx(ctx context.Context) (pgx.Tx, error)
+	IssueVoucher(ctx context.Context, tx pgx.Tx, v models.Voucher) (models.Voucher, error)
+	LockVoucher(ctx context.Context, tx pgx.Tx, id string) (models.Voucher, error)
+	ConsumeVoucher(ctx context.Context, tx pgx.Tx, id, staffID string) error
+}
+
+type order interface {
+	LockOrder(ctx context.Context, tx pgx.Tx, orderID string) (models.Order, error)
+	UpdateOrderStatus(ctx context.Context, tx pgx.Tx, orderID, status string) error
+	InsertStatusChange(ctx context.Context, tx pgx.Tx, c models.OrderStatusChange) error
+}
+
+type signer interface {
+	SignVoucher(v models.Voucher) (string, error)
+	ParseVoucher(token string) (models.Voucher, error)
+}
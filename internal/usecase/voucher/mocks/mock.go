@@ -0,0 +1,215 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: contract.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	models "AvitoTask/internal/models"
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	pgx "github.com/jackc/pgx/v5"
+)
+
+// Mockvoucher is a mock of voucher interface.
+type Mockvoucher struct {
+	ctrl     *gomock.Controller
+	recorder *MockvoucherMockRecorder
+}
+
+// MockvoucherMockRecorder is the mock recorder for Mockvoucher.
+type MockvoucherMockRecorder struct {
+	mock *Mockvoucher
+}
+
+// NewMockvoucher creates a new mock instance.
+func NewMockvoucher(ctrl *gomock.Controller) *Mockvoucher {
+	mock := &Mockvoucher{ctrl: ctrl}
+	mock.recorder = &MockvoucherMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mockvoucher) EXPECT() *MockvoucherMockRecorder {
+	return m.recorder
+}
+
+// BeginTx mocks base method.
+func (m *Mockvoucher) BeginTx(ctx context.Context) (pgx.Tx, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BeginTx", ctx)
+	ret0, _ := ret[0].(pgx.Tx)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BeginTx indicates an expected call of BeginTx.
+func (mr *MockvoucherMockRecorder) BeginTx(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BeginTx", reflect.TypeOf((*Mockvoucher)(nil).BeginTx), ctx)
+}
+
+// ConsumeVoucher mocks base method.
+func (m *Mockvoucher) ConsumeVoucher(ctx context.Context, tx pgx.Tx, id, staffID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConsumeVoucher", ctx, tx, id, staffID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ConsumeVoucher indicates an expected call of ConsumeVoucher.
+func (mr *MockvoucherMockRecorder) ConsumeVoucher(ctx, tx, id, staffID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConsumeVoucher", reflect.TypeOf((*Mockvoucher)(nil).ConsumeVoucher), ctx, tx, id, staffID)
+}
+
+// IssueVoucher mocks base method.
+func (m *Mockvoucher) IssueVoucher(ctx context.Context, tx pgx.Tx, v models.Voucher) (models.Voucher, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IssueVoucher", ctx, tx, v)
+	ret0, _ := ret[0].(models.Voucher)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IssueVoucher indicates an expected call of IssueVoucher.
+func (mr *MockvoucherMockRecorder) IssueVoucher(ctx, tx, v interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IssueVoucher", reflect.TypeOf((*Mockvoucher)(nil).IssueVoucher), ctx, tx, v)
+}
+
+// LockVoucher mocks base method.
+func (m *Mockvoucher) LockVoucher(ctx context.Context, tx pgx.Tx, id string) (models.Voucher, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LockVoucher", ctx, tx, id)
+	ret0, _ := ret[0].(models.Voucher)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LockVoucher indicates an expected call of LockVoucher.
+func (mr *MockvoucherMockRecorder) LockVoucher(ctx, tx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LockVoucher", reflect.TypeOf((*Mockvoucher)(nil).LockVoucher), ctx, tx, id)
+}
+
+// Mockorder is a mock of order interface.
+type Mockorder struct {
+	ctrl     *gomock.Controller
+	recorder *MockorderMockRecorder
+}
+
+// MockorderMockRecorder is the mock recorder for Mockorder.
+type MockorderMockRecorder struct {
+	mock *Mockorder
+}
+
+// NewMockorder creates a new mock instance.
+func NewMockorder(ctrl *gomock.Controller) *Mockorder {
+	mock := &Mockorder{ctrl: ctrl}
+	mock.recorder = &MockorderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mockorder) EXPECT() *MockorderMockRecorder {
+	return m.recorder
+}
+
+// InsertStatusChange mocks base method.
+func (m *Mockorder) InsertStatusChange(ctx context.Context, tx pgx.Tx, c models.OrderStatusChange) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertStatusChange", ctx, tx, c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InsertStatusChange indicates an expected call of InsertStatusChange.
+func (mr *MockorderMockRecorder) InsertStatusChange(ctx, tx, c interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertStatusChange", reflect.TypeOf((*Mockorder)(nil).InsertStatusChange), ctx, tx, c)
+}
+
+// LockOrder mocks base method.
+func (m *Mockorder) LockOrder(ctx context.Context, tx pgx.Tx, orderID string) (models.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LockOrder", ctx, tx, orderID)
+	ret0, _ := ret[0].(models.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LockOrder indicates an expected call of LockOrder.
+func (mr *MockorderMockRecorder) LockOrder(ctx, tx, orderID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LockOrder", reflect.TypeOf((*Mockorder)(nil).LockOrder), ctx, tx, orderID)
+}
+
+// UpdateOrderStatus mocks base method.
+func (m *Mockorder) UpdateOrderStatus(ctx context.Context, tx pgx.Tx, orderID, status string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateOrderStatus", ctx, tx, orderID, status)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateOrderStatus indicates an expected call of UpdateOrderStatus.
+func (mr *MockorderMockRecorder) UpdateOrderStatus(ctx, tx, orderID, status interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateOrderStatus", reflect.TypeOf((*Mockorder)(nil).UpdateOrderStatus), ctx, tx, orderID, status)
+}
+
+// Mocksigner is a mock of signer interface.
+type Mocksigner struct {
+	ctrl     *gomock.Controller
+	recorder *MocksignerMockRecorder
+}
+
+// MocksignerMockRecorder is the mock recorder for Mocksigner.
+type MocksignerMockRecorder struct {
+	mock *Mocksigner
+}
+
+// NewMocksigner creates a new mock instance.
+func NewMocksigner(ctrl *gomock.Controller) *Mocksigner {
+	mock := &Mocksigner{ctrl: ctrl}
+	mock.recorder = &MocksignerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mocksigner) EXPECT() *MocksignerMockRecorder {
+	return m.recorder
+}
+
+// ParseVoucher mocks base method.
+func (m *Mocksigner) ParseVoucher(token string) (models.Voucher, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ParseVoucher", token)
+	ret0, _ := ret[0].(models.Voucher)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ParseVoucher indicates an expected call of ParseVoucher.
+func (mr *MocksignerMockRecorder) ParseVoucher(token interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ParseVoucher", reflect.TypeOf((*Mocksigner)(nil).ParseVoucher), token)
+}
+
+// SignVoucher mocks base method.
+func (m *Mocksigner) SignVoucher(v models.Voucher) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SignVoucher", v)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SignVoucher indicates an expected call of SignVoucher.
+func (mr *MocksignerMockRecorder) SignVoucher(v interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SignVoucher", reflect.TypeOf((*Mocksigner)(nil).SignVoucher), v)
+}
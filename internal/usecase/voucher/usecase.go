@@ -0,0 +1,139 @@
+package voucher
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+
+	"AvitoTask/internal/models"
+)
+
+var ErrOrderNotReady = errors.New("order is not ready for pickup")
+
+type Usecase struct {
+	repoVoucher voucher
+	repoOrder   order
+	signer      signer
+}
+
+func NewUsecase(v voucher, o order, s signer) *Usecase {
+	return &Usecase{
+		repoVoucher: v,
+		repoOrder:   o,
+		signer:      s,
+	}
+}
+
+// IssueVoucher - подписанный талон на получение готового к выдаче заказа; повторный вызов возвращает тот же талон
+func (u *Usecase) IssueVoucher(ctx context.Context, userID, orderID string) (token string, err error) {
+	tx, err := u.repoVoucher.BeginTx(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to begin tx: %w", err)
+	}
+
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback(ctx)
+		} else {
+			err = tx.Commit(ctx)
+		}
+	}()
+
+	o, err := u.repoOrder.LockOrder(ctx, tx, orderID)
+	if err != nil {
+		return "", err
+	}
+
+	if o.UserID != userID {
+		err = models.ErrOrderNotFound
+		return "", err
+	}
+
+	if o.Status != models.OrderStatusReady {
+		err = ErrOrderNotReady
+		return "", err
+	}
+
+	v, err := u.repoVoucher.IssueVoucher(ctx, tx, models.Voucher{
+		ID:      uuid.New().String(),
+		OrderID: o.ID,
+		UserID:  o.UserID,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	v.Item = o.Item
+	v.Variant = o.Variant
+
+	return u.signer.SignVoucher(v)
+}
+
+// RedeemVoucher - проверяет подпись талона, гасит его и отмечает заказ выданным
+func (u *Usecase) RedeemVoucher(ctx context.Context, staffID, token string) (o models.Order, err error) {
+	claims, err := u.signer.ParseVoucher(token)
+	if err != nil {
+		return o, err
+	}
+
+	tx, err := u.repoVoucher.BeginTx(ctx)
+	if err != nil {
+		return o, fmt.Errorf("failed to begin tx: %w", err)
+	}
+
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback(ctx)
+		} else {
+			err = tx.Commit(ctx)
+		}
+	}()
+
+	v, err := u.repoVoucher.LockVoucher(ctx, tx, claims.ID)
+	if err != nil {
+		return o, err
+	}
+
+	if v.OrderID != claims.OrderID || v.UserID != claims.UserID {
+		err = models.ErrVoucherInvalid
+		return o, err
+	}
+
+	if v.Consumed() {
+		err = models.ErrVoucherConsumed
+		return o, err
+	}
+
+	o, err = u.repoOrder.LockOrder(ctx, tx, v.OrderID)
+	if err != nil {
+		return o, err
+	}
+
+	if o.Status != models.OrderStatusReady {
+		err = fmt.Errorf("%w: order is %s", ErrOrderNotReady, o.Status)
+		return o, err
+	}
+
+	if err = u.repoVoucher.ConsumeVoucher(ctx, tx, v.ID, staffID); err != nil {
+		return o, err
+	}
+
+	if err = u.repoOrder.UpdateOrderStatus(ctx, tx, o.ID, models.OrderStatusHandedOver); err != nil {
+		return o, err
+	}
+
+	err = u.repoOrder.InsertStatusChange(ctx, tx, models.OrderStatusChange{
+		ID:        uuid.New().String(),
+		OrderID:   o.ID,
+		Status:    models.OrderStatusHandedOver,
+		ChangedBy: staffID,
+	})
+	if err != nil {
+		return o, err
+	}
+	o.Status = models.OrderStatusHandedOver
+
+	return o, nil
+}
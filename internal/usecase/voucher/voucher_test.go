@@ -0,0 +1,138 @@
+package voucher_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+
+	"AvitoTask/internal/middleware/jwt"
+	"AvitoTask/internal/models"
+	"AvitoTask/internal/usecase/voucher"
+	"AvitoTask/internal/usecase/voucher/mocks"
+)
+
+func readyOrder() models.Order {
+	return models.Order{
+		ID:     "order-1",
+		Kind:   models.OrderKindPurchase,
+		UserID: "user123",
+		Item:   "hoody",
+		Status: models.OrderStatusReady,
+	}
+}
+
+func TestIssueVoucher_NotReady(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockVoucher := mocks.NewMockvoucher(ctrl)
+	mockOrder := mocks.NewMockorder(ctrl)
+	mockSigner := mocks.NewMocksigner(ctrl)
+	mockTx := mocks.NewMockTx(ctrl)
+
+	o := readyOrder()
+	o.Status = models.OrderStatusPlaced
+
+	mockVoucher.EXPECT().BeginTx(ctx).Return(mockTx, nil)
+	mockOrder.EXPECT().LockOrder(ctx, mockTx, "order-1").Return(o, nil)
+	mockTx.EXPECT().Rollback(ctx).Return(nil)
+
+	uc := voucher.NewUsecase(mockVoucher, mockOrder, mockSigner)
+	_, err := uc.IssueVoucher(ctx, "user123", "order-1")
+	if !errors.Is(err, voucher.ErrOrderNotReady) {
+		t.Errorf("expected error %v, got %v", voucher.ErrOrderNotReady, err)
+	}
+}
+
+func TestIssueAndRedeemVoucher_VerifiedByPublicKey(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockVoucher := mocks.NewMockvoucher(ctrl)
+	mockOrder := mocks.NewMockorder(ctrl)
+	mockTx := mocks.NewMockTx(ctrl)
+	signer := jwt.NewMiddleware("secret")
+
+	issued := models.Voucher{ID: "voucher-1", OrderID: "order-1", UserID: "user123", IssuedAt: time.Now().UTC()}
+
+	mockVoucher.EXPECT().BeginTx(ctx).Return(mockTx, nil).Times(2)
+	mockOrder.EXPECT().LockOrder(ctx, mockTx, "order-1").Return(readyOrder(), nil).Times(2)
+	mockVoucher.EXPECT().IssueVoucher(ctx, mockTx, gomock.Any()).Return(issued, nil)
+	mockVoucher.EXPECT().LockVoucher(ctx, mockTx, "voucher-1").Return(issued, nil)
+	mockVoucher.EXPECT().ConsumeVoucher(ctx, mockTx, "voucher-1", "staff-1").Return(nil)
+	mockOrder.EXPECT().UpdateOrderStatus(ctx, mockTx, "order-1", models.OrderStatusHandedOver).Return(nil)
+	mockOrder.EXPECT().InsertStatusChange(ctx, mockTx, gomock.Any()).Return(nil)
+	mockTx.EXPECT().Commit(ctx).Return(nil).Times(2)
+
+	uc := voucher.NewUsecase(mockVoucher, mockOrder, signer)
+	token, err := uc.IssueVoucher(ctx, "user123", "order-1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// сканер на стойке знает только открытый ключ
+	claims, err := jwt.ParseVoucher(token, signer.VoucherPublicKey())
+	if err != nil {
+		t.Fatalf("voucher is not verifiable by public key: %v", err)
+	}
+	if claims.OrderID != "order-1" || claims.UserID != "user123" || claims.Item != "hoody" {
+		t.Errorf("unexpected voucher claims %+v", claims)
+	}
+
+	o, err := uc.RedeemVoucher(ctx, "staff-1", token)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if o.Status != models.OrderStatusHandedOver {
+		t.Errorf("expected status %s, got %s", models.OrderStatusHandedOver, o.Status)
+	}
+}
+
+func TestRedeemVoucher_AlreadyConsumed(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockVoucher := mocks.NewMockvoucher(ctrl)
+	mockOrder := mocks.NewMockorder(ctrl)
+	mockSigner := mocks.NewMocksigner(ctrl)
+	mockTx := mocks.NewMockTx(ctrl)
+
+	consumedAt := time.Now().UTC()
+	v := models.Voucher{ID: "voucher-1", OrderID: "order-1", UserID: "user123", ConsumedAt: &consumedAt}
+
+	mockSigner.EXPECT().ParseVoucher("token").Return(v, nil)
+	mockVoucher.EXPECT().BeginTx(ctx).Return(mockTx, nil)
+	mockVoucher.EXPECT().LockVoucher(ctx, mockTx, "voucher-1").Return(v, nil)
+	mockTx.EXPECT().Rollback(ctx).Return(nil)
+
+	uc := voucher.NewUsecase(mockVoucher, mockOrder, mockSigner)
+	_, err := uc.RedeemVoucher(ctx, "staff-1", "token")
+	if !errors.Is(err, models.ErrVoucherConsumed) {
+		t.Errorf("expected error %v, got %v", models.ErrVoucherConsumed, err)
+	}
+}
+
+func TestRedeemVoucher_ForgedSignature(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockVoucher := mocks.NewMockvoucher(ctrl)
+	mockOrder := mocks.NewMockorder(ctrl)
+
+	token, err := jwt.NewMiddleware("other-secret").SignVoucher(models.Voucher{ID: "voucher-1", OrderID: "order-1"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	uc := voucher.NewUsecase(mockVoucher, mockOrder, jwt.NewMiddleware("secret"))
+	if _, err = uc.RedeemVoucher(ctx, "staff-1", token); !errors.Is(err, models.ErrVoucherInvalid) {
+		t.Errorf("expected error %v, got %v", models.ErrVoucherInvalid, err)
+	}
+}
@@ -0,0 +1,31 @@
+//go:generate mockgen -source=contract.go -destination=mocks/mock.go -package=mocks $GOPACKAGE
+//go:generate mockgen -destination=mocks/mock_tx.go -package=mocks github.com/jackc/pgx/v5 Tx
+package wishlist
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+
+	"AvitoTask/internal/models"
+)
+
+type wishlist interface {
+	BeginTx(ctx context.Context) (pgx.Tx, error)
+	AddToWishlist(ctx context.Context, tx pgx.Tx, userID, itemID string) error
+	RemoveFromWishlist(ctx context.Context, tx pgx.Tx, userID, itemID string) error
+	GetWishlist(ctx context.Context, tx pgx.Tx, userID string) ([]models.WishlistEntry, error)
+}
+
+type catalog interface {
+	GetItemByName(ctx context.Context, tx pgx.Tx, name string) (models.CatalogItem, error)
+}
+
+type user interface {
+	GetUserCoins(ctx context.Context, tx pgx.Tx, userID string) (int64, error)
+}
+
+type notification interface {
+	GetNotifications(ctx context.Context, tx pgx.Tx, userID string, unreadOnly bool) ([]models.Notification, error)
+	MarkNotificationsRead(ctx context.Context, tx pgx.Tx, userID string) (int64, error)
+}
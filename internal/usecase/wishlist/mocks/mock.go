@@ -0,0 +1,224 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: contract.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	models "AvitoTask/internal/models"
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	pgx "github.com/jackc/pgx/v5"
+)
+
+// Mockwishlist is a mock of wishlist interface.
+type Mockwishlist struct {
+	ctrl     *gomock.Controller
+	recorder *MockwishlistMockRecorder
+}
+
+// MockwishlistMockRecorder is the mock recorder for Mockwishlist.
+type MockwishlistMockRecorder struct {
+	mock *Mockwishlist
+}
+
+// NewMockwishlist creates a new mock instance.
+func NewMockwishlist(ctrl *gomock.Controller) *Mockwishlist {
+	mock := &Mockwishlist{ctrl: ctrl}
+	mock.recorder = &MockwishlistMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mockwishlist) EXPECT() *MockwishlistMockRecorder {
+	return m.recorder
+}
+
+// AddToWishlist mocks base method.
+func (m *Mockwishlist) AddToWishlist(ctx context.Context, tx pgx.Tx, userID, itemID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddToWishlist", ctx, tx, userID, itemID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddToWishlist indicates an expected call of AddToWishlist.
+func (mr *MockwishlistMockRecorder) AddToWishlist(ctx, tx, userID, itemID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddToWishlist", reflect.TypeOf((*Mockwishlist)(nil).AddToWishlist), ctx, tx, userID, itemID)
+}
+
+// BeginTx mocks base method.
+func (m *Mockwishlist) BeginTx(ctx context.Context) (pgx.Tx, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BeginTx", ctx)
+	ret0, _ := ret[0].(pgx.Tx)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BeginTx indicates an expected call of BeginTx.
+func (mr *MockwishlistMockRecorder) BeginTx(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BeginTx", reflect.TypeOf((*Mockwishlist)(nil).BeginTx), ctx)
+}
+
+// GetWishlist mocks base method.
+func (m *Mockwishlist) GetWishlist(ctx context.Context, tx pgx.Tx, userID string) ([]models.WishlistEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWishlist", ctx, tx, userID)
+	ret0, _ := ret[0].([]models.WishlistEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWishlist indicates an expected call of GetWishlist.
+func (mr *MockwishlistMockRecorder) GetWishlist(ctx, tx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWishlist", reflect.TypeOf((*Mockwishlist)(nil).GetWishlist), ctx, tx, userID)
+}
+
+// RemoveFromWishlist mocks base method.
+func (m *Mockwishlist) RemoveFromWishlist(ctx context.Context, tx pgx.Tx, userID, itemID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveFromWishlist", ctx, tx, userID, itemID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveFromWishlist indicates an expected call of RemoveFromWishlist.
+func (mr *MockwishlistMockRecorder) RemoveFromWishlist(ctx, tx, userID, itemID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveFromWishlist", reflect.TypeOf((*Mockwishlist)(nil).RemoveFromWishlist), ctx, tx, userID, itemID)
+}
+
+// Mockcatalog is a mock of catalog interface.
+type Mockcatalog struct {
+	ctrl     *gomock.Controller
+	recorder *MockcatalogMockRecorder
+}
+
+// MockcatalogMockRecorder is the mock recorder for Mockcatalog.
+type MockcatalogMockRecorder struct {
+	mock *Mockcatalog
+}
+
+// NewMockcatalog creates a new mock instance.
+func NewMockcatalog(ctrl *gomock.Controller) *Mockcatalog {
+	mock := &Mockcatalog{ctrl: ctrl}
+	mock.recorder = &MockcatalogMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mockcatalog) EXPECT() *MockcatalogMockRecorder {
+	return m.recorder
+}
+
+// GetItemByName mocks base method.
+func (m *Mockcatalog) GetItemByName(ctx context.Context, tx pgx.Tx, name string) (models.CatalogItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetItemByName", ctx, tx, name)
+	ret0, _ := ret[0].(models.CatalogItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetItemByName indicates an expected call of GetItemByName.
+func (mr *MockcatalogMockRecorder) GetItemByName(ctx, tx, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetItemByName", reflect.TypeOf((*Mockcatalog)(nil).GetItemByName), ctx, tx, name)
+}
+
+// Mockuser is a mock of user interface.
+type Mockuser struct {
+	ctrl     *gomock.Controller
+	recorder *MockuserMockRecorder
+}
+
+// MockuserMockRecorder is the mock recorder for Mockuser.
+type MockuserMockRecorder struct {
+	mock *Mockuser
+}
+
+// NewMockuser creates a new mock instance.
+func NewMockuser(ctrl *gomock.Controller) *Mockuser {
+	mock := &Mockuser{ctrl: ctrl}
+	mock.recorder = &MockuserMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mockuser) EXPECT() *MockuserMockRecorder {
+	return m.recorder
+}
+
+// GetUserCoins mocks base method.
+func (m *Mockuser) GetUserCoins(ctx context.Context, tx pgx.Tx, userID string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserCoins", ctx, tx, userID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserCoins indicates an expected call of GetUserCoins.
+func (mr *MockuserMockRecorder) GetUserCoins(ctx, tx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserCoins", reflect.TypeOf((*Mockuser)(nil).GetUserCoins), ctx, tx, userID)
+}
+
+// Mocknotification is a mock of notification interface.
+type Mocknotification struct {
+	ctrl     *gomock.Controller
+	recorder *MocknotificationMockRecorder
+}
+
+// MocknotificationMockRecorder is the mock recorder for Mocknotification.
+type MocknotificationMockRecorder struct {
+	mock *Mocknotification
+}
+
+// NewMocknotification creates a new mock instance.
+func NewMocknotification(ctrl *gomock.Controller) *Mocknotification {
+	mock := &Mocknotification{ctrl: ctrl}
+	mock.recorder = &MocknotificationMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mocknotification) EXPECT() *MocknotificationMockRecorder {
+	return m.recorder
+}
+
+// GetNotifications mocks base method.
+func (m *Mocknotification) GetNotifications(ctx context.Context, tx pgx.Tx, userID string, unreadOnly bool) ([]models.Notification, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNotifications", ctx, tx, userID, unreadOnly)
+	ret0, _ := ret[0].([]models.Notification)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetNotifications indicates an expected call of GetNotifications.
+func (mr *MocknotificationMockRecorder) GetNotifications(ctx, tx, userID, unreadOnly interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNotifications", reflect.TypeOf((*Mocknotification)(nil).GetNotifications), ctx, tx, userID, unreadOnly)
+}
+
+// MarkNotificationsRead mocks base method.
+func (m *Mocknotification) MarkNotificationsRead(ctx context.Context, tx pgx.Tx, userID string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkNotificationsRead", ctx, tx, userID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MarkNotificationsRead indicates an expected call of MarkNotificationsRead.
+func (mr *MocknotificationMockRecorder) MarkNotificationsRead(ctx, tx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkNotificationsRead", reflect.TypeOf((*Mocknotification)(nil).MarkNotificationsRead), ctx, tx, userID)
+}
@@ -0,0 +1,157 @@
+package wishlist
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+
+	"AvitoTask/internal/models"
+)
+
+type Usecase struct {
+	repo        wishlist
+	repoCatalog catalog
+	repoUser    user
+	repoNotify  notification
+}
+
+func NewUsecase(w wishlist, c catalog, u user, n notification) *Usecase {
+	return &Usecase{
+		repo:        w,
+		repoCatalog: c,
+		repoUser:    u,
+		repoNotify:  n,
+	}
+}
+
+// AddItem - добавляет позицию в список желаний; распроданные позиции добавлять можно,
+// чтобы узнать о поступлении
+func (u *Usecase) AddItem(ctx context.Context, userID, item string) (res []models.WishlistEntry, err error) {
+	tx, err := u.repo.BeginTx(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin tx: %w", err)
+	}
+
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback(ctx)
+		} else {
+			err = tx.Commit(ctx)
+		}
+	}()
+
+	catalogItem, err := u.repoCatalog.GetItemByName(ctx, tx, item)
+	if err != nil {
+		return nil, err
+	}
+
+	if catalogItem.Retired || catalogItem.Hidden {
+		err = models.ErrItemNotAvailable
+		return nil, err
+	}
+
+	if err = u.repo.AddToWishlist(ctx, tx, userID, catalogItem.ID); err != nil {
+		return nil, err
+	}
+
+	return u.wishlist(ctx, tx, userID)
+}
+
+func (u *Usecase) RemoveItem(ctx context.Context, userID, item string) (res []models.WishlistEntry, err error) {
+	tx, err := u.repo.BeginTx(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin tx: %w", err)
+	}
+
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback(ctx)
+		} else {
+			err = tx.Commit(ctx)
+		}
+	}()
+
+	catalogItem, err := u.repoCatalog.GetItemByName(ctx, tx, item)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = u.repo.RemoveFromWishlist(ctx, tx, userID, catalogItem.ID); err != nil {
+		return nil, err
+	}
+
+	return u.wishlist(ctx, tx, userID)
+}
+
+func (u *Usecase) GetWishlist(ctx context.Context, userID string) (res []models.WishlistEntry, err error) {
+	tx, err := u.repo.BeginTx(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin tx: %w", err)
+	}
+
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback(ctx)
+		} else {
+			err = tx.Commit(ctx)
+		}
+	}()
+
+	return u.wishlist(ctx, tx, userID)
+}
+
+// wishlist - список желаний с числом монет, которых не хватает при текущем балансе
+func (u *Usecase) wishlist(ctx context.Context, tx pgx.Tx, userID string) ([]models.WishlistEntry, error) {
+	coins, err := u.repoUser.GetUserCoins(ctx, tx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := u.repo.GetWishlist(ctx, tx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]models.WishlistEntry, 0, len(entries))
+	for _, e := range entries {
+		res = append(res, e.WithBalance(coins))
+	}
+
+	return res, nil
+}
+
+func (u *Usecase) GetNotifications(ctx context.Context, userID string, unreadOnly bool) (res []models.Notification, err error) {
+	tx, err := u.repo.BeginTx(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin tx: %w", err)
+	}
+
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback(ctx)
+		} else {
+			err = tx.Commit(ctx)
+		}
+	}()
+
+	return u.repoNotify.GetNotifications(ctx, tx, userID, unreadOnly)
+}
+
+// MarkRead - отмечает все уведомления пользователя прочитанными, возвращает их число
+func (u *Usecase) MarkRead(ctx context.Context, userID string) (n int64, err error) {
+	tx, err := u.repo.BeginTx(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin tx: %w", err)
+	}
+
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback(ctx)
+		} else {
+			err = tx.Commit(ctx)
+		}
+	}()
+
+	return u.repoNotify.MarkNotificationsRead(ctx, tx, userID)
+}
@@ -0,0 +1,85 @@
+package wishlist_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+
+	"AvitoTask/internal/models"
+	"AvitoTask/internal/usecase/wishlist"
+	"AvitoTask/internal/usecase/wishlist/mocks"
+)
+
+func TestAddItem_SoldOutAllowed(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockWishlist := mocks.NewMockwishlist(ctrl)
+	mockCatalog := mocks.NewMockcatalog(ctrl)
+	mockUser := mocks.NewMockuser(ctrl)
+	mockTx := mocks.NewMockTx(ctrl)
+
+	stock := int64(0)
+
+	mockWishlist.EXPECT().BeginTx(ctx).Return(mockTx, nil)
+	mockCatalog.EXPECT().GetItemByName(ctx, mockTx, "pink-hoody").Return(models.CatalogItem{ID: "item-1", Name: "pink-hoody", Price: 500, Stock: &stock}, nil)
+	mockWishlist.EXPECT().AddToWishlist(ctx, mockTx, "user123", "item-1").Return(nil)
+	mockUser.EXPECT().GetUserCoins(ctx, mockTx, "user123").Return(int64(120), nil)
+	mockWishlist.EXPECT().GetWishlist(ctx, mockTx, "user123").Return([]models.WishlistEntry{
+		{ItemID: "item-1", Item: "pink-hoody", Price: 500},
+	}, nil)
+	mockTx.EXPECT().Commit(ctx).Return(nil)
+
+	uc := wishlist.NewUsecase(mockWishlist, mockCatalog, mockUser, nil)
+	res, err := uc.AddItem(ctx, "user123", "pink-hoody")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(res) != 1 || res[0].MissingCoins != 380 {
+		t.Errorf("unexpected wishlist %+v", res)
+	}
+}
+
+func TestAddItem_Retired(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockWishlist := mocks.NewMockwishlist(ctrl)
+	mockCatalog := mocks.NewMockcatalog(ctrl)
+	mockTx := mocks.NewMockTx(ctrl)
+
+	mockWishlist.EXPECT().BeginTx(ctx).Return(mockTx, nil)
+	mockCatalog.EXPECT().GetItemByName(ctx, mockTx, "cup").Return(models.CatalogItem{ID: "item-2", Name: "cup", Retired: true}, nil)
+	mockTx.EXPECT().Rollback(ctx).Return(nil)
+
+	uc := wishlist.NewUsecase(mockWishlist, mockCatalog, nil, nil)
+	_, err := uc.AddItem(ctx, "user123", "cup")
+	if !errors.Is(err, models.ErrItemNotAvailable) {
+		t.Errorf("expected error %v, got %v", models.ErrItemNotAvailable, err)
+	}
+}
+
+func TestRemoveItem_NotInWishlist(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockWishlist := mocks.NewMockwishlist(ctrl)
+	mockCatalog := mocks.NewMockcatalog(ctrl)
+	mockTx := mocks.NewMockTx(ctrl)
+
+	mockWishlist.EXPECT().BeginTx(ctx).Return(mockTx, nil)
+	mockCatalog.EXPECT().GetItemByName(ctx, mockTx, "cup").Return(models.CatalogItem{ID: "item-2", Name: "cup"}, nil)
+	mockWishlist.EXPECT().RemoveFromWishlist(ctx, mockTx, "user123", "item-2").Return(models.ErrNotInWishlist)
+	mockTx.EXPECT().Rollback(ctx).Return(nil)
+
+	uc := wishlist.NewUsecase(mockWishlist, mockCatalog, nil, nil)
+	_, err := uc.RemoveItem(ctx, "user123", "cup")
+	if !errors.Is(err, models.ErrNotInWishlist) {
+		t.Errorf("expected error %v, got %v", models.ErrNotInWishlist, err)
+	}
+}
@@ -0,0 +1,61 @@
+package utils
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+
+	"AvitoTask/internal/models"
+)
+
+type idempotencyStore interface {
+	ClaimKey(ctx context.Context, tx pgx.Tx, k models.IdempotencyKey) (models.IdempotencyKey, error)
+	SaveResponse(ctx context.Context, tx pgx.Tx, k models.IdempotencyKey) error
+}
+
+// IdempotencyKeyFrom - ключ идемпотентности, который middleware положил в контекст запроса
+func IdempotencyKeyFrom(ctx context.Context) (models.IdempotencyKey, bool) {
+	k, ok := ctx.Value(models.IdempotencyKeyLocal).(models.IdempotencyKey)
+	return k, ok
+}
+
+// Idempotent - выполняет fn в транзакции tx не больше одного раза на ключ идемпотентности из ctx.
+// Ключ и ответ fn сохраняются в той же транзакции, так что при откате операции ключ освобождается.
+// Повтор запроса получает сохранённый ответ, а тот же ключ с другим запросом - ErrIdempotencyKeyReused.
+// Без ключа в контексте fn просто выполняется
+func Idempotent[T any](ctx context.Context, tx pgx.Tx, store idempotencyStore, fn func() (T, error)) (res T, err error) {
+	key, ok := IdempotencyKeyFrom(ctx)
+	if !ok {
+		return fn()
+	}
+
+	stored, err := store.ClaimKey(ctx, tx, key)
+	if err != nil {
+		return res, err
+	}
+
+	if stored.RequestHash != key.RequestHash {
+		return res, models.ErrIdempotencyKeyReused
+	}
+
+	if stored.Response != nil {
+		if err = json.Unmarshal(stored.Response, &res); err != nil {
+			return res, fmt.Errorf("failed to decode stored response for idempotency key %s: %w", key.Key, err)
+		}
+		return res, nil
+	}
+
+	res, err = fn()
+	if err != nil {
+		return res, err
+	}
+
+	key.Response, err = json.Marshal(res)
+	if err != nil {
+		return res, fmt.Errorf("failed to encode response for idempotency key %s: %w", key.Key, err)
+	}
+
+	return res, store.SaveResponse(ctx, tx, key)
+}
@@ -0,0 +1,56 @@
+package utils_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgconn"
+
+	"AvitoTask/internal/utils"
+)
+
+func TestRetryOnConflict_RetriesDeadlock(t *testing.T) {
+	calls := 0
+	err := utils.RetryOnConflict(context.Background(), 3, func() error {
+		calls++
+		if calls < 3 {
+			return fmt.Errorf("failed to debit coins: %w", &pgconn.PgError{Code: "40P01"})
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if calls != 3 {
+		t.Errorf("expected 3 calls, got %d", calls)
+	}
+}
+
+func TestRetryOnConflict_GivesUp(t *testing.T) {
+	calls := 0
+	conflict := &pgconn.PgError{Code: "40001"}
+	err := utils.RetryOnConflict(context.Background(), 2, func() error {
+		calls++
+		return conflict
+	})
+	if !errors.Is(err, conflict) {
+		t.Errorf("expected error %v, got %v", conflict, err)
+	}
+	if calls != 2 {
+		t.Errorf("expected 2 calls, got %d", calls)
+	}
+}
+
+func TestRetryOnConflict_OtherErrorNotRetried(t *testing.T) {
+	calls := 0
+	expected := errors.New("not enough coins")
+	err := utils.RetryOnConflict(context.Background(), 3, func() error {
+		calls++
+		return expected
+	})
+	if !errors.Is(err, expected) || calls != 1 {
+		t.Errorf("expected single call with %v, got %d calls and %v", expected, calls, err)
+	}
+}
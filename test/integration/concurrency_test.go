@@ -0,0 +1,403 @@
+package integration
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+const baseURL = "http://localhost:8080/api"
+
+type respInfo struct {
+	Coins     int64 `json:"coins"`
+	Inventory []struct {
+		Type     string `json:"type"`
+		Quantity int64  `json:"quantity"`
+	} `json:"inventory"`
+}
+
+type requestSendItem struct {
+	ToUser   string `json:"toUser"`
+	Item     string `json:"item"`
+	Quantity int64  `json:"quantity"`
+}
+
+var concurrentClient = http.Client{Timeout: time.Second * 30}
+
+func register(t *testing.T) (string, string) {
+	username := uuid.New().String()
+	data, err := json.Marshal(userAuthIn{
+		Username: username,
+		Password: "HardPass2007!",
+	})
+	require.NoError(t, err)
+
+	req, err := http.NewRequest("POST", baseURL+"/auth", bytes.NewReader(data))
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := concurrentClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var response RespRegister
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal(body, &response))
+
+	return username, response.Token
+}
+
+func coins(t *testing.T, token string) int64 {
+	return info(t, token).Coins
+}
+
+// owned - сколько предметов item в инвентаре пользователя
+func owned(t *testing.T, token, item string) int64 {
+	for _, inv := range info(t, token).Inventory {
+		if inv.Type == item {
+			return inv.Quantity
+		}
+	}
+
+	return 0
+}
+
+func info(t *testing.T, token string) respInfo {
+	req, err := http.NewRequest("GET", baseURL+"/info", nil)
+	require.NoError(t, err)
+	req.Header.Set("Authorization", "Bearer "+token)
+	resp, err := concurrentClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var res respInfo
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal(body, &res))
+
+	return res
+}
+
+// sendCoin - возвращает код ответа; вызывается из горутин, поэтому без require
+func sendCoin(token, toUser string, amount int64) (int, error) {
+	data, err := json.Marshal(requestSendCoin{ToUser: toUser, Amount: amount})
+	if err != nil {
+		return 0, err
+	}
+
+	req, err := http.NewRequest("POST", baseURL+"/sendCoin", bytes.NewReader(data))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+token)
+	resp, err := concurrentClient.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	return resp.StatusCode, nil
+}
+
+func sendItem(token, toUser, item string, quantity int64) (int, error) {
+	data, err := json.Marshal(requestSendItem{ToUser: toUser, Item: item, Quantity: quantity})
+	if err != nil {
+		return 0, err
+	}
+
+	req, err := http.NewRequest("POST", baseURL+"/sendItem", bytes.NewReader(data))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+token)
+	resp, err := concurrentClient.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	return resp.StatusCode, nil
+}
+
+func buy(token, item string) (int, error) {
+	req, err := http.NewRequest("GET", baseURL+"/buy/"+item, nil)
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	resp, err := concurrentClient.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	return resp.StatusCode, nil
+}
+
+func TestConcurrentSendCoin_PreservesTotal(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	const users = 4
+	const rounds = 25
+
+	names := make([]string, users)
+	tokens := make([]string, users)
+	for i := range names {
+		names[i], tokens[i] = register(t)
+	}
+
+	var total int64
+	for _, token := range tokens {
+		total += coins(t, token)
+	}
+
+	var wg sync.WaitGroup
+	errs := make(chan error, users*rounds*2)
+	for r := 0; r < rounds; r++ {
+		for i := 0; i < users; i++ {
+			wg.Add(2)
+			// по кольцу и навстречу, чтобы пары пользователей блокировались в разном порядке
+			go func(from, to int) {
+				defer wg.Done()
+				code, err := sendCoin(tokens[from], names[to], 7)
+				if err == nil && code != http.StatusOK && code != http.StatusBadRequest {
+					err = errUnexpectedStatus(code)
+				}
+				errs <- err
+			}(i, (i+1)%users)
+			go func(from, to int) {
+				defer wg.Done()
+				code, err := sendCoin(tokens[from], names[to], 3)
+				if err == nil && code != http.StatusOK && code != http.StatusBadRequest {
+					err = errUnexpectedStatus(code)
+				}
+				errs <- err
+			}(i, (i+users-1)%users)
+		}
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		require.NoError(t, err)
+	}
+
+	var after int64
+	for _, token := range tokens {
+		after += coins(t, token)
+	}
+	require.Equal(t, total, after)
+}
+
+func TestConcurrentSendCoin_NoOverdraw(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	_, sender := register(t)
+	receiver, receiverToken := register(t)
+
+	start := coins(t, sender)
+	received := coins(t, receiverToken)
+
+	const amount = 300
+	const attempts = 10
+
+	var ok atomic.Int64
+	var wg sync.WaitGroup
+	errs := make(chan error, attempts)
+	for i := 0; i < attempts; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			code, err := sendCoin(sender, receiver, amount)
+			if err == nil && code == http.StatusOK {
+				ok.Add(1)
+			} else if err == nil && code != http.StatusBadRequest {
+				err = errUnexpectedStatus(code)
+			}
+			errs <- err
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		require.NoError(t, err)
+	}
+
+	require.Equal(t, start/amount, ok.Load())
+	require.Equal(t, start-ok.Load()*amount, coins(t, sender))
+	require.Equal(t, received+ok.Load()*amount, coins(t, receiverToken))
+}
+
+func TestConcurrentBuyItem_ChargesEveryPurchase(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	_, token := register(t)
+	start := coins(t, token)
+
+	const price = 10
+	const attempts = 120
+
+	var ok atomic.Int64
+	var wg sync.WaitGroup
+	errs := make(chan error, attempts)
+	for i := 0; i < attempts; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			code, err := buy(token, "pen")
+			switch {
+			case err != nil:
+			case code == http.StatusOK:
+				ok.Add(1)
+			case code != http.StatusBadRequest && code != http.StatusConflict:
+				err = errUnexpectedStatus(code)
+			}
+			errs <- err
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		require.NoError(t, err)
+	}
+
+	require.LessOrEqual(t, ok.Load(), start/price)
+	require.Equal(t, start-ok.Load()*price, coins(t, token))
+	require.Equal(t, ok.Load(), owned(t, token, "pen"))
+}
+
+func TestConcurrentSendItem_SameNewRecipient(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	const senders = 8
+
+	tokens := make([]string, senders)
+	for i := range tokens {
+		_, tokens[i] = register(t)
+		code, err := buy(tokens[i], "pen")
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, code)
+	}
+	recipient, recipientToken := register(t)
+
+	var wg sync.WaitGroup
+	errs := make(chan error, senders)
+	for _, token := range tokens {
+		wg.Add(1)
+		go func(token string) {
+			defer wg.Done()
+			code, err := sendItem(token, recipient, "pen", 1)
+			if err == nil && code != http.StatusOK {
+				err = errUnexpectedStatus(code)
+			}
+			errs <- err
+		}(token)
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		require.NoError(t, err)
+	}
+	require.Equal(t, int64(senders), owned(t, recipientToken, "pen"))
+
+	// все подарки легли в одну строку инвентаря, поэтому их можно передать дальше одним переводом
+	third, thirdToken := register(t)
+	code, err := sendItem(recipientToken, third, "pen", senders)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, code)
+	require.Equal(t, int64(0), owned(t, recipientToken, "pen"))
+	require.Equal(t, int64(senders), owned(t, thirdToken, "pen"))
+}
+
+func TestConcurrentBuyAndSendItem_SameRecipient(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	const senders = 8
+	const buys = 8
+
+	tokens := make([]string, senders)
+	for i := range tokens {
+		_, tokens[i] = register(t)
+		code, err := buy(tokens[i], "pen")
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, code)
+	}
+	buyer, buyerToken := register(t)
+
+	var bought atomic.Int64
+	var wg sync.WaitGroup
+	errs := make(chan error, senders+buys)
+	for _, token := range tokens {
+		wg.Add(1)
+		go func(token string) {
+			defer wg.Done()
+			code, err := sendItem(token, buyer, "pen", 1)
+			if err == nil && code != http.StatusOK {
+				err = errUnexpectedStatus(code)
+			}
+			errs <- err
+		}(token)
+	}
+	for i := 0; i < buys; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			code, err := buy(buyerToken, "pen")
+			switch {
+			case err != nil:
+			case code == http.StatusOK:
+				bought.Add(1)
+			case code != http.StatusConflict:
+				err = errUnexpectedStatus(code)
+			}
+			errs <- err
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		require.NoError(t, err)
+	}
+
+	total := senders + bought.Load()
+	require.Equal(t, total, owned(t, buyerToken, "pen"))
+
+	// подарки и покупки легли в одну строку инвентаря
+	third, thirdToken := register(t)
+	code, err := sendItem(buyerToken, third, "pen", total)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, code)
+	require.Equal(t, total, owned(t, thirdToken, "pen"))
+}
+
+type errUnexpectedStatus int
+
+func (e errUnexpectedStatus) Error() string {
+	return "unexpected status " + http.StatusText(int(e))
+}
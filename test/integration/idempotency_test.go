@@ -0,0 +1,45 @@
+package integration
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func sendCoinWithKey(t *testing.T, token, key, toUser string, amount int64) int {
+	data, err := json.Marshal(requestSendCoin{ToUser: toUser, Amount: amount})
+	require.NoError(t, err)
+
+	req, err := http.NewRequest("POST", baseURL+"/sendCoin", bytes.NewReader(data))
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Idempotency-Key", key)
+	resp, err := concurrentClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	return resp.StatusCode
+}
+
+func TestSendCoin_IdempotencyKey(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	_, sender := register(t)
+	receiver, _ := register(t)
+	start := coins(t, sender)
+
+	key := uuid.New().String()
+	require.Equal(t, http.StatusOK, sendCoinWithKey(t, sender, key, receiver, 100))
+	require.Equal(t, http.StatusOK, sendCoinWithKey(t, sender, key, receiver, 100))
+	require.Equal(t, start-100, coins(t, sender))
+
+	require.Equal(t, http.StatusUnprocessableEntity, sendCoinWithKey(t, sender, key, receiver, 200))
+	require.Equal(t, start-100, coins(t, sender))
+}